@@ -6,9 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	path2 "path"
+	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
 	errors2 "github.com/pkg/errors"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/spf13/afero"
@@ -31,6 +38,15 @@ func Format(isDebug *bool) *cli.Command {
 				Usage: "fail the command if any of the assets need reformatting",
 				Value: false,
 			},
+			&cli.BoolFlag{
+				Name:  "sql",
+				Usage: "format the SQL queries of the assets as well, regardless of the `format.sql.enabled` setting",
+				Value: false,
+			},
+			&cli.StringFlag{
+				Name:  "config-file",
+				Usage: "the path to the .bruin.yml file",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			logger := makeLogger(*isDebug)
@@ -43,11 +59,26 @@ func Format(isDebug *bool) *cli.Command {
 			output := c.String("output")
 			checkLint := c.Bool("fail-if-changed")
 
+			configFilePath := c.String("config-file")
+			if configFilePath == "" {
+				repoRoot, err := git.FindRepoFromPath(repoOrAsset)
+				if err == nil {
+					configFilePath = path2.Join(repoRoot.Path, ".bruin.yml")
+				}
+			}
+
+			formatter, err := newSQLFormatter(afero.NewOsFs(), configFilePath, c.Bool("sql"), output)
+			if err != nil {
+				printErrorForOutput(output, err)
+				return cli.Exit("", 1)
+			}
+			defer formatter.Close()
+
 			if isPathReferencingAsset(repoOrAsset) {
 				if checkLint {
-					return checkChangesForSingleAsset(repoOrAsset, output)
+					return checkChangesForSingleAsset(repoOrAsset, output, formatter)
 				}
-				asset, err := formatAsset(repoOrAsset)
+				asset, err := formatAsset(repoOrAsset, formatter)
 				if err != nil {
 					if errors.Is(err, os.ErrNotExist) {
 						printErrorForOutput(output, fmt.Errorf("the given file path '%s' does not seem to exist, are you sure you used the right path?", repoOrAsset))
@@ -78,7 +109,7 @@ func Format(isDebug *bool) *cli.Command {
 			for _, assetPath := range assetPaths {
 				assetFinderPool.Go(func() {
 					if checkLint {
-						changed, err := shouldFileChange(assetPath)
+						changed, err := shouldFileChange(assetPath, formatter)
 						if err != nil {
 							logger.Debugf("failed to process path '%s': %v", assetPath, err)
 							errorList = append(errorList, errors2.Wrapf(err, "failed to check '%s'", assetPath))
@@ -99,6 +130,8 @@ func Format(isDebug *bool) *cli.Command {
 							return
 						}
 
+						formatter.formatQuery(asset)
+
 						err = asset.Persist(afero.NewOsFs())
 						if err != nil {
 							logger.Debugf("failed to persist asset '%s': %v", assetPath, err)
@@ -161,16 +194,18 @@ func Format(isDebug *bool) *cli.Command {
 	}
 }
 
-func formatAsset(path string) (*pipeline.Asset, error) {
+func formatAsset(path string, formatter *sqlFormatter) (*pipeline.Asset, error) {
 	asset, err := DefaultPipelineBuilder.CreateAssetFromFile(path, nil)
 	if err != nil {
 		return nil, errors2.Wrap(err, "failed to build the asset")
 	}
 
+	formatter.formatQuery(asset)
+
 	return asset, asset.Persist(afero.NewOsFs())
 }
 
-func shouldFileChange(path string) (bool, error) {
+func shouldFileChange(path string, formatter *sqlFormatter) (bool, error) {
 	fs := afero.NewOsFs()
 
 	// Read the original content from the file
@@ -186,6 +221,8 @@ func shouldFileChange(path string) (bool, error) {
 		return false, errors2.Wrap(err, "failed to build the asset")
 	}
 
+	formatter.formatQuery(asset)
+
 	// Generate the new content without persisting
 	newContent, err := asset.FormatContent()
 	if err != nil {
@@ -197,8 +234,8 @@ func shouldFileChange(path string) (bool, error) {
 	return string(normalizedOriginalContent) != string(normalizedNewContent), nil
 }
 
-func checkChangesForSingleAsset(repoOrAsset, output string) error {
-	changed, err := shouldFileChange(repoOrAsset)
+func checkChangesForSingleAsset(repoOrAsset, output string, formatter *sqlFormatter) error {
+	changed, err := shouldFileChange(repoOrAsset, formatter)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			printErrorForOutput(output, fmt.Errorf("the given file path '%s' does not seem to exist, are you sure you used the right path?", repoOrAsset))
@@ -222,3 +259,138 @@ func normalizeLineEndings(content []byte) []byte {
 	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
 	return bytes.ReplaceAll(content, []byte("\r"), []byte("\n"))
 }
+
+// sqlFormatter formats the queries of the SQL assets. The options set in `pipeline.yml` take precedence over the
+// ones in `.bruin.yml`, and the `--sql` flag enables the formatting regardless of both.
+type sqlFormatter struct {
+	fs     afero.Fs
+	forced bool
+	output string
+	global *pipeline.SQLFormatOptions
+
+	pipelineOptions map[string]*pipeline.SQLFormatOptions
+	optionsMutex    sync.Mutex
+
+	parser     *sqlparser.SQLParser
+	parserErr  error
+	parserOnce sync.Once
+}
+
+func newSQLFormatter(fs afero.Fs, configFilePath string, forced bool, output string) (*sqlFormatter, error) {
+	formatter := &sqlFormatter{
+		fs:              fs,
+		forced:          forced,
+		output:          output,
+		pipelineOptions: make(map[string]*pipeline.SQLFormatOptions),
+	}
+
+	if configFilePath == "" {
+		return formatter, nil
+	}
+
+	cm, err := config.LoadFromFile(fs, configFilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return formatter, nil
+		}
+		return nil, errors2.Wrapf(err, "failed to load the config file at '%s'", configFilePath)
+	}
+
+	if cm.Format != nil {
+		formatter.global = cm.Format.SQL
+	}
+
+	return formatter, nil
+}
+
+// formatQuery formats the query of the given asset in place. The queries that cannot be formatted, e.g. due to
+// unsupported Jinja blocks, are kept as they are with a warning.
+func (f *sqlFormatter) formatQuery(asset *pipeline.Asset) {
+	if f == nil || strings.ToLower(filepath.Ext(asset.ExecutableFile.Path)) != ".sql" {
+		return
+	}
+
+	dialect, err := sqlparser.AssetTypeToDialect(asset.Type)
+	if err != nil {
+		return
+	}
+
+	options, err := f.optionsForAsset(asset.ExecutableFile.Path)
+	if err != nil {
+		f.warn(asset, err)
+		return
+	}
+
+	if !f.forced && !options.IsEnabled() {
+		return
+	}
+
+	f.parserOnce.Do(func() {
+		f.parser, f.parserErr = sqlparser.NewSQLParser(true)
+	})
+	if f.parserErr != nil {
+		f.warn(asset, errors2.Wrap(f.parserErr, "failed to start the SQL parser"))
+		return
+	}
+
+	formatted, err := f.parser.FormatSQL(asset.ExecutableFile.Content, dialect, sqlparser.FormatOptions{
+		KeywordCase: options.KeywordCase,
+		Indent:      options.Indent,
+		LineWidth:   options.LineWidth,
+	})
+	if err != nil {
+		f.warn(asset, err)
+		return
+	}
+
+	asset.ExecutableFile.Content = formatted
+}
+
+func (f *sqlFormatter) optionsForAsset(assetPath string) (*pipeline.SQLFormatOptions, error) {
+	pipelineRoot, err := path.GetPipelineRootFromTask(assetPath, PipelineDefinitionFiles)
+	if err != nil {
+		return f.global.Merge(nil), nil //nolint:nilerr
+	}
+
+	f.optionsMutex.Lock()
+	defer f.optionsMutex.Unlock()
+
+	if options, ok := f.pipelineOptions[pipelineRoot]; ok {
+		return options, nil
+	}
+
+	options := f.global.Merge(nil)
+	for _, definitionFile := range PipelineDefinitionFiles {
+		definitionPath := filepath.Join(pipelineRoot, definitionFile)
+		if !path.FileExists(f.fs, definitionPath) {
+			continue
+		}
+
+		p, err := pipeline.PipelineFromPath(definitionPath, f.fs)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.Format != nil {
+			options = options.Merge(p.Format.SQL)
+		}
+		break
+	}
+
+	f.pipelineOptions[pipelineRoot] = options
+	return options, nil
+}
+
+func (f *sqlFormatter) warn(asset *pipeline.Asset, err error) {
+	if f.output == "json" {
+		return
+	}
+
+	warningPrinter.Printf("Skipping the SQL formatting of '%s', keeping the query as is: %v\n", asset.ExecutableFile.Path, err)
+}
+
+func (f *sqlFormatter) Close() {
+	if f.parser != nil {
+		_ = f.parser.Close()
+	}
+}
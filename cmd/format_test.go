@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,6 +22,7 @@ func TestCheckLint(t *testing.T) {
 	tests := []struct {
 		name          string
 		assetFilePath string
+		formatSQL     bool
 		expectError   bool
 		expectChange  bool
 	}{
@@ -36,6 +38,13 @@ func TestCheckLint(t *testing.T) {
 			expectError:   false,
 			expectChange:  true,
 		},
+		{
+			name:          "Valid Asset With Unformatted SQL",
+			assetFilePath: filepath.Join(testDatadir, "valid_asset.sql"),
+			formatSQL:     true,
+			expectError:   false,
+			expectChange:  true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -48,8 +57,15 @@ func TestCheckLint(t *testing.T) {
 			_, err := os.Stat(tc.assetFilePath)
 			require.NoError(t, err, "Test file does not exist")
 
+			var formatter *sqlFormatter
+			if tc.formatSQL {
+				formatter, err = newSQLFormatter(afero.NewOsFs(), "", true, "json")
+				require.NoError(t, err)
+				defer formatter.Close()
+			}
+
 			// Act: Run the check-lint functionality
-			changed, err := shouldFileChange(tc.assetFilePath)
+			changed, err := shouldFileChange(tc.assetFilePath, formatter)
 
 			// Assert: Check for expected results
 			if tc.expectError {
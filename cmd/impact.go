@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	lineagepackage "github.com/bruin-data/bruin/pkg/lineage"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+func Impact() *cli.Command {
+	return &cli.Command{
+		Name:      "impact",
+		Usage:     "list everything that depends on a column across all the pipelines in the repository",
+		ArgsUsage: "[asset name].[column name]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the output type, possible values are: plain, json",
+			},
+			&cli.StringFlag{
+				Name:  "path",
+				Usage: "the directory to look for pipelines in, defaults to the root of the git repository",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			r := ImpactCommand{
+				builder:        DefaultPipelineBuilder,
+				infoPrinter:    infoPrinter,
+				errorPrinter:   errorPrinter,
+				warningPrinter: warningPrinter,
+			}
+
+			return r.Run(c.Context, c.Args().Get(0), c.String("path"), c.String("output"))
+		},
+	}
+}
+
+// impactIssue is an asset whose column lineage could not be extracted, which means the impact might be incomplete.
+type impactIssue struct {
+	Asset       string `json:"asset"`
+	Description string `json:"description"`
+}
+
+type impactOutput struct {
+	*lineagepackage.Impact
+	Issues []impactIssue `json:"issues"`
+}
+
+type ImpactCommand struct {
+	builder        *pipeline.Builder
+	infoPrinter    printer
+	errorPrinter   printer
+	warningPrinter printer
+}
+
+func (r *ImpactCommand) Run(ctx context.Context, target, rootPath, output string) error {
+	separator := strings.LastIndex(target, ".")
+	if separator <= 0 || separator == len(target)-1 {
+		r.errorPrinter.Printf("Please give the column to analyze in the '<asset>.<column>' format: bruin impact raw.users.email\n")
+		return cli.Exit("", 1)
+	}
+	assetName, columnName := target[:separator], target[separator+1:]
+
+	if rootPath == "" {
+		rootPath = "."
+		if repo, err := git.FindRepoFromPath("."); err == nil {
+			rootPath = repo.Path
+		}
+	}
+
+	pipelines, err := r.buildPipelines(ctx, rootPath)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to build the pipelines: %v\n", err)
+		return cli.Exit("", 1)
+	}
+
+	issues, err := extractPipelinesColumnLineage(pipelines)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to extract the column lineage: %v\n", err)
+		return cli.Exit("", 1)
+	}
+
+	impact, err := lineagepackage.ColumnImpact(pipelines, assetName, columnName)
+	if err != nil {
+		r.errorPrinter.Printf("%v\n", err)
+		return cli.Exit("", 1)
+	}
+
+	if output == "json" {
+		jsonIssues := make([]impactIssue, 0, len(issues))
+		for _, issue := range issues {
+			jsonIssues = append(jsonIssues, impactIssue{Asset: issue.Task.Name, Description: issue.Description})
+		}
+
+		jsonVersion, err := json.Marshal(impactOutput{Impact: impact, Issues: jsonIssues})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the impact summary to json")
+		}
+
+		fmt.Println(string(jsonVersion))
+		return nil
+	}
+
+	r.printImpact(impact)
+	for _, issue := range issues {
+		r.warningPrinter.Printf("\nFailed to extract the column lineage of '%s', the impact might be incomplete: %s\n", issue.Task.Name, issue.Description)
+	}
+
+	return nil
+}
+
+func (r *ImpactCommand) buildPipelines(ctx context.Context, rootPath string) ([]*pipeline.Pipeline, error) {
+	pipelinePaths, err := path.GetPipelinePaths(rootPath, PipelineDefinitionFiles)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the pipelines in '%s'", rootPath)
+	}
+	if len(pipelinePaths) == 0 {
+		return nil, errors.Errorf("no pipelines found in '%s'", rootPath)
+	}
+
+	pipelines := make([]*pipeline.Pipeline, 0, len(pipelinePaths))
+	for _, pipelinePath := range pipelinePaths {
+		p, err := r.builder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build the pipeline in '%s'", pipelinePath)
+		}
+		pipelines = append(pipelines, p)
+	}
+
+	return pipelines, nil
+}
+
+// extractPipelinesColumnLineage fills the upstream columns of every asset in the given pipelines in place. The assets
+// of all the pipelines are looked up together so that the lineage can cross pipeline boundaries.
+func extractPipelinesColumnLineage(pipelines []*pipeline.Pipeline) ([]*lineagepackage.LineageIssue, error) {
+	parser, err := sqlparser.NewSQLParser(false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the sql parser")
+	}
+	defer parser.Close()
+
+	if err := parser.Start(); err != nil {
+		return nil, errors.Wrap(err, "failed to start the sql parser")
+	}
+
+	repoPipeline := &pipeline.Pipeline{Assets: make([]*pipeline.Asset, 0)}
+	for _, p := range pipelines {
+		repoPipeline.Assets = append(repoPipeline.Assets, p.Assets...)
+	}
+
+	extractor := lineagepackage.NewLineageExtractor(parser)
+	issues := make([]*lineagepackage.LineageIssue, 0)
+	processed := make(map[string]bool)
+	for _, asset := range repoPipeline.Assets {
+		if lineageErrors := extractor.ColumnLineage(repoPipeline, asset, processed); lineageErrors != nil {
+			issues = append(issues, lineageErrors.Issues...)
+		}
+	}
+
+	return issues, nil
+}
+
+func (r *ImpactCommand) printImpact(impact *lineagepackage.Impact) {
+	r.infoPrinter.Printf("\nImpact: '%s.%s'", impact.Asset, impact.Column)
+
+	r.printSection("Columns", "No columns depend on this column.", len(impact.Columns), func() {
+		for _, column := range impact.Columns {
+			r.infoPrinter.Printf("- %s.%s %s\n", column.Asset, column.Column, faint(fmt.Sprintf("(%s)", strings.Join(column.Path, " -> "))))
+		}
+	})
+
+	r.printSection("Checks", "No checks depend on this column.", len(impact.Checks), func() {
+		for _, check := range impact.Checks {
+			r.infoPrinter.Printf("- %s.%s: %s\n", check.Asset, check.Column, check.Name)
+		}
+	})
+
+	r.printSection("Custom Checks", "No custom checks refer to this column.", len(impact.CustomChecks), func() {
+		for _, check := range impact.CustomChecks {
+			r.infoPrinter.Printf("- %s: %s\n", check.Asset, check.Name)
+		}
+	})
+
+	r.printSection("Assets", "No assets depend on this column.", len(impact.Assets), func() {
+		for _, asset := range impact.Assets {
+			r.infoPrinter.Printf("- %s %s\n", asset.Asset, faint(fmt.Sprintf("(%s, %s)", asset.Pipeline, asset.File)))
+		}
+	})
+}
+
+func (r *ImpactCommand) printSection(title, absenceMessage string, count int, printItems func()) {
+	r.infoPrinter.Print("\n\n")
+	r.infoPrinter.Println(title)
+	r.infoPrinter.Println("========================")
+	if count == 0 {
+		r.infoPrinter.Println(absenceMessage)
+		return
+	}
+
+	printItems()
+	r.infoPrinter.Printf("\nTotal: %d\n", count)
+}
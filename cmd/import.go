@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bruin-data/bruin/pkg/dbt"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v2"
+)
+
+func Import() *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "Import projects from other tools as Bruin pipelines",
+		Subcommands: []*cli.Command{
+			ImportDbt(),
+		},
+	}
+}
+
+func ImportDbt() *cli.Command {
+	return &cli.Command{
+		Name:      "dbt",
+		Usage:     "Convert a dbt project into a Bruin pipeline, the parts that cannot be converted are listed in the summary",
+		ArgsUsage: "[path to the dbt project] [path to the new pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "asset-type",
+				Usage: "the type of the SQL assets, e.g. bq.sql, detected from the dbt profile by default",
+			},
+			&cli.StringFlag{
+				Name:  "schema",
+				Usage: "the schema the models are built in, read from the dbt profile by default",
+			},
+			&cli.StringFlag{
+				Name:    "profiles-dir",
+				Usage:   "the folder profiles.yml is read from",
+				EnvVars: []string{"DBT_PROFILES_DIR"},
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the output type, possible values are: plain, json",
+				Value:   "plain",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			output := c.String("output")
+
+			projectPath := c.Args().Get(0)
+			if projectPath == "" {
+				projectPath = "."
+			}
+			projectPath, err := filepath.Abs(projectPath)
+			if err != nil {
+				printErrorForOutput(output, fmt.Errorf("failed to resolve the path of the dbt project: %w", err))
+				return cli.Exit("", 1)
+			}
+
+			outputPath := c.Args().Get(1)
+			if outputPath == "" {
+				outputPath = projectPath + "-bruin"
+			}
+
+			summary, err := dbt.Import(afero.NewOsFs(), projectPath, outputPath, dbt.ImportOptions{
+				AssetType:   pipeline.AssetType(c.String("asset-type")),
+				Schema:      c.String("schema"),
+				ProfilesDir: c.String("profiles-dir"),
+			})
+			if err != nil {
+				printErrorForOutput(output, fmt.Errorf("failed to import the dbt project: %w", err))
+				return cli.Exit("", 1)
+			}
+
+			if output == "json" {
+				js, err := json.Marshal(summary)
+				if err != nil {
+					printErrorForOutput(output, err)
+					return cli.Exit("", 1)
+				}
+				fmt.Println(string(js))
+				return nil
+			}
+
+			printImportSummary(summary)
+			return nil
+		},
+	}
+}
+
+func printImportSummary(summary *dbt.Summary) {
+	successPrinter.Printf("Imported the dbt project '%s' into '%s'\n", summary.Pipeline, summary.Output)
+	infoPrinter.Printf("  %d models, %d seeds, %d sources, %d checks\n", summary.Models, summary.Seeds, summary.Sources, summary.Checks)
+
+	if len(summary.Issues) == 0 {
+		return
+	}
+
+	fmt.Println()
+	warningPrinter.Printf("%d items need attention before the pipeline can replace the dbt project:\n", len(summary.Issues))
+	lastPath := ""
+	for _, issue := range summary.Issues {
+		if issue.Path != lastPath {
+			fmt.Println()
+			infoPrinter.Printf("  %s\n", issue.Path)
+			lastPath = issue.Path
+		}
+		fmt.Printf("    - %s\n", issue.Message)
+	}
+}
@@ -309,6 +309,7 @@ func (r *ParseCommand) Run(ctx context.Context, assetPath string, lineage bool)
 		printErrorJSON(err)
 		return cli.Exit("", 1)
 	}
+	foundPipeline.SetUpstreamsFromReferences(asset)
 
 	type lineageIssueSummary struct {
 		Asset string `json:"name"`
@@ -457,10 +458,20 @@ func convertToBruinAsset(fs afero.Fs, filePath string) error {
 		bruinHeader = fmt.Sprintf("/* @bruin\nname: %s\ntype: bq.sql\n@bruin */\n\n", assetName)
 	case ".py":
 		bruinHeader = fmt.Sprintf("\"\"\" @bruin\nname: %s\n@bruin \"\"\"\n\n", assetName)
+	case ".sh", ".r":
+		bruinHeader = fmt.Sprintf("# @bruin\n# name: %s\n# @bruin\n\n", assetName)
 	default:
 		return nil // unsupported file types
 	}
-	newContent := bruinHeader + string(content)
+
+	// the shebang must stay in the first line of the scripts
+	shebang := ""
+	if strings.HasPrefix(string(content), "#!") {
+		firstLine, rest, _ := strings.Cut(string(content), "\n")
+		shebang = firstLine + "\n"
+		content = []byte(rest)
+	}
+	newContent := shebang + bruinHeader + string(content)
 	err = afero.WriteFile(fs, filePath, []byte(newContent), 0o644)
 	if err != nil {
 		printErrorJSON(errors2.Wrap(err, "failed to write file"))
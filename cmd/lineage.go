@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	lineagepackage "github.com/bruin-data/bruin/pkg/lineage"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 )
@@ -26,6 +29,10 @@ func Lineage() *cli.Command {
 				Aliases: []string{"o"},
 				Usage:   "the output type, possible values are: plain, json",
 			},
+			&cli.BoolFlag{
+				Name:  "columns",
+				Usage: "display the upstream columns of every column of the asset",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			r := LineageCommand{
@@ -34,7 +41,7 @@ func Lineage() *cli.Command {
 				errorPrinter: errorPrinter,
 			}
 
-			return r.Run(c.Context, c.Args().Get(0), c.Bool("full"), c.String("output"))
+			return r.Run(c.Context, c.Args().Get(0), c.Bool("full"), c.String("output"), c.Bool("columns"))
 		},
 	}
 }
@@ -51,7 +58,7 @@ type LineageCommand struct {
 	errorPrinter printer
 }
 
-func (r *LineageCommand) Run(ctx context.Context, assetPath string, fullLineage bool, output string) error {
+func (r *LineageCommand) Run(ctx context.Context, assetPath string, fullLineage bool, output string, columnLineage bool) error {
 	if assetPath == "" {
 		r.errorPrinter.Printf("Please give an asset path to get lineage of: bruin lineage <path to the asset definition>)\n")
 		return cli.Exit("", 1)
@@ -86,8 +93,17 @@ func (r *LineageCommand) Run(ctx context.Context, assetPath string, fullLineage
 		downstream = asset.GetFullDownstream()
 	}
 
+	var lineageIssues []*lineagepackage.LineageIssue
+	if columnLineage {
+		lineageIssues, err = extractColumnLineage(foundPipeline, asset)
+		if err != nil {
+			r.errorPrinter.Printf("Failed to extract the column lineage: %v\n", err)
+			return cli.Exit("", 1)
+		}
+	}
+
 	if output == "json" {
-		return r.printLineageJSON(asset, upstream, downstream)
+		return r.printLineageJSON(asset, upstream, downstream, columnLineage)
 	}
 
 	r.infoPrinter.Printf("\nLineage: '%s'", asset.Name)
@@ -101,11 +117,61 @@ func (r *LineageCommand) Run(ctx context.Context, assetPath string, fullLineage
 
 	r.printLineageSummary(foundPipeline, upstream, &externalDependencies, "Upstream Dependencies", "Asset has no upstream dependencies.")
 	r.printLineageSummary(foundPipeline, downstream, &[]pipeline.Upstream{}, "Downstream Dependencies", "Asset has no downstream dependencies.")
+	if columnLineage {
+		r.printColumnLineage(asset, lineageIssues)
+	}
 
 	return err
 }
 
-func (r *LineageCommand) printLineageJSON(asset *pipeline.Asset, upstream, downstream []*pipeline.Asset) error {
+// extractColumnLineage fills the upstream columns of the asset and its upstreams in place, returning the issues that
+// prevented some of the lineage from being extracted.
+func extractColumnLineage(foundPipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*lineagepackage.LineageIssue, error) {
+	parser, err := sqlparser.NewSQLParser(false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the sql parser")
+	}
+	defer parser.Close()
+
+	if err := parser.Start(); err != nil {
+		return nil, errors.Wrap(err, "failed to start the sql parser")
+	}
+
+	lineageErrors := lineagepackage.NewLineageExtractor(parser).ColumnLineage(foundPipeline, asset, make(map[string]bool))
+	if lineageErrors == nil {
+		return nil, nil
+	}
+
+	return lineageErrors.Issues, nil
+}
+
+func (r *LineageCommand) printColumnLineage(asset *pipeline.Asset, issues []*lineagepackage.LineageIssue) {
+	r.infoPrinter.Print("\n\n")
+	r.infoPrinter.Println("Column Lineage")
+	r.infoPrinter.Println("========================")
+	if len(asset.Columns) == 0 {
+		r.infoPrinter.Println("Asset has no columns.")
+	}
+
+	for _, column := range asset.Columns {
+		if len(column.Upstreams) == 0 {
+			r.infoPrinter.Printf("- %s %s\n", column.Name, faint("(no upstream columns)"))
+			continue
+		}
+
+		upstreams := make([]string, 0, len(column.Upstreams))
+		for _, upstream := range column.Upstreams {
+			upstreams = append(upstreams, upstream.Table+"."+upstream.Column)
+		}
+		r.infoPrinter.Printf("- %s <- %s\n", column.Name, strings.Join(upstreams, ", "))
+	}
+
+	for _, issue := range issues {
+		r.errorPrinter.Printf("\nFailed to extract the column lineage of '%s': %s\n", issue.Task.Name, issue.Description)
+	}
+}
+
+func (r *LineageCommand) printLineageJSON(asset *pipeline.Asset, upstream, downstream []*pipeline.Asset, columnLineage bool) error {
 	type dependencySummary struct {
 		Name           string                       `json:"name"`
 		Type           pipeline.AssetType           `json:"type,omitempty"`
@@ -114,11 +180,18 @@ func (r *LineageCommand) printLineageJSON(asset *pipeline.Asset, upstream, downs
 		External       *bool                        `json:"external,omitempty"`
 	}
 
+	type columnSummary struct {
+		Name      string                     `json:"name"`
+		Type      string                     `json:"type,omitempty"`
+		Upstreams []*pipeline.UpstreamColumn `json:"upstreams"`
+	}
+
 	type jsonSummary struct {
 		AssetName  string               `json:"name"`
 		Type       pipeline.AssetType   `json:"type"`
 		Upstream   []*dependencySummary `json:"upstreams"`
 		Downstream []*dependencySummary `json:"downstream"`
+		Columns    []*columnSummary     `json:"columns,omitempty"`
 	}
 
 	summary := jsonSummary{
@@ -166,6 +239,21 @@ func (r *LineageCommand) printLineageJSON(asset *pipeline.Asset, upstream, downs
 		}
 	}
 
+	if columnLineage {
+		summary.Columns = make([]*columnSummary, len(asset.Columns))
+		for i, column := range asset.Columns {
+			upstreams := column.Upstreams
+			if upstreams == nil {
+				upstreams = make([]*pipeline.UpstreamColumn, 0)
+			}
+			summary.Columns[i] = &columnSummary{
+				Name:      column.Name,
+				Type:      column.Type,
+				Upstreams: upstreams,
+			}
+		}
+	}
+
 	jsonVersion, err := json.Marshal(summary)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal the lineage summary to json")
@@ -45,6 +45,7 @@ func TestLineageCommand_Run(t *testing.T) {
 	type args struct {
 		assetPath string
 		full      bool
+		columns   bool
 	}
 
 	tests := []struct {
@@ -117,6 +118,34 @@ Total: 2
 Downstream Dependencies
 ========================
 Asset has no downstream dependencies.
+`,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "generate column lineage",
+			args: args{
+				assetPath: path.AbsPathForTests(t, "./testdata/lineage/assets/hello_bq.sql"),
+				columns:   true,
+			},
+			want: `
+Lineage: 'dashboard.hello_bq'
+
+Upstream Dependencies
+========================
+- hello_python (assets/nested/hello_python.py)
+- bigquery://project_id/dataset_id/table_id (EXTERNAL)
+
+Total: 2
+
+
+Downstream Dependencies
+========================
+Asset has no downstream dependencies.
+
+
+Column Lineage
+========================
+- one (no upstream columns)
 `,
 			wantErr: assert.NoError,
 		},
@@ -134,7 +163,7 @@ Asset has no downstream dependencies.
 				errorPrinter: mp,
 			}
 
-			res := r.Run(context.Background(), tt.args.assetPath, tt.args.full, "plain")
+			res := r.Run(context.Background(), tt.args.assetPath, tt.args.full, "plain", tt.args.columns)
 			tt.wantErr(t, res)
 			if tt.want != "" {
 				want := tt.want
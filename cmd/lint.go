@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -57,7 +58,7 @@ func Lint(isDebug *bool) *cli.Command {
 			&cli.StringFlag{
 				Name:    "output",
 				Aliases: []string{"o"},
-				Usage:   "the output type, possible values are: plain, json",
+				Usage:   "the output type, possible values are: plain, json, sarif, github",
 			},
 			&cli.BoolFlag{
 				Name:  "exclude-warnings",
@@ -76,16 +77,34 @@ func Lint(isDebug *bool) *cli.Command {
 				Name:  "var",
 				Usage: "override pipeline variables with custom values",
 			},
+			&cli.BoolFlag{
+				Name:  "fix",
+				Usage: "fix the issues that can be fixed automatically and persist the changed assets",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "used with --fix, print the changes as a diff instead of persisting them",
+			},
+			&cli.BoolFlag{
+				Name:  "schema-drift",
+				Usage: "compare the declared columns of the assets with the live tables in the warehouse",
+			},
 		},
 		Action: func(c *cli.Context) error {
-			// if the output is JSON then we intend to discard all the nicer pretty-print statements
-			// and only print the JSON output directly to the stdout
-			if c.String("output") == "json" {
+			// if the output is JSON or SARIF then we intend to discard all the nicer pretty-print statements
+			// and only print the machine-readable output directly to the stdout
+			output := strings.ToLower(strings.TrimSpace(c.String("output")))
+			if output == "json" || output == "sarif" {
 				color.Output = io.Discard
 			} else {
 				fmt.Println()
 			}
 
+			if c.Bool("dry-run") && !c.Bool("fix") {
+				printError(errors.New("the --dry-run flag can only be used together with --fix"), c.String("output"), "Invalid flags")
+				return cli.Exit("", 1)
+			}
+
 			if vars := c.StringSlice("var"); len(vars) > 0 {
 				DefaultPipelineBuilder.AddPipelineMutator(variableOverridesMutator(vars))
 			}
@@ -161,19 +180,35 @@ func Lint(isDebug *bool) *cli.Command {
 
 			rules = append(rules, queryValidatorRules(logger, cm, connectionManager)...)
 			rules = append(rules, lint.GetCustomCheckQueryDryRunRule(connectionManager))
+			if c.Bool("schema-drift") {
+				rules = append(rules, &lint.SchemaDriftRule{Connections: connectionManager, Logger: logger})
+			}
 
-			var result *lint.PipelineAnalysisResult
-			var errr error
-			if asset == "" {
-				linter := lint.NewLinter(path.GetPipelinePaths, DefaultPipelineBuilder, rules, logger)
-				logger.Debugf("running %d rules for pipeline validation", len(rules))
-				infoPrinter.Printf("Validating pipelines in '%s' for '%s' environment...\n", rootPath, cm.SelectedEnvironmentName)
-				result, errr = linter.Lint(rootPath, PipelineDefinitionFiles, c)
-			} else {
+			if asset != "" {
 				rules = lint.FilterRulesByLevel(rules, lint.LevelAsset)
+			}
+			linter := lint.NewLinterWithParser(path.GetPipelinePaths, DefaultPipelineBuilder, rules, logger, parser)
+			runLinter := func() (*lint.PipelineAnalysisResult, error) {
+				if asset == "" {
+					logger.Debugf("running %d rules for pipeline validation", len(rules))
+					infoPrinter.Printf("Validating pipelines in '%s' for '%s' environment...\n", rootPath, cm.SelectedEnvironmentName)
+					return linter.Lint(rootPath, PipelineDefinitionFiles, c)
+				}
+
 				logger.Debugf("running %d rules for asset-only validation", len(rules))
-				linter := lint.NewLinter(path.GetPipelinePaths, DefaultPipelineBuilder, rules, logger)
-				result, errr = linter.LintAsset(rootPath, PipelineDefinitionFiles, asset, c)
+				return linter.LintAsset(rootPath, PipelineDefinitionFiles, asset, c)
+			}
+
+			result, errr := runLinter()
+			if errr == nil && result != nil && c.Bool("fix") {
+				dryRun := c.Bool("dry-run")
+				var fixedCount int
+				fixedCount, errr = fixLintIssues(c.Context, result, dryRun)
+
+				// the issues are reported again after the fixes so that only the remaining ones are shown
+				if errr == nil && fixedCount > 0 && !dryRun {
+					result, errr = runLinter()
+				}
 			}
 
 			printer := lint.Printer{RootCheckPath: rootPath}
@@ -183,13 +218,24 @@ func Lint(isDebug *bool) *cli.Command {
 				return cli.Exit("", 1)
 			}
 
-			if strings.ToLower(strings.TrimSpace(c.String("output"))) == "json" {
+			switch output {
+			case "json":
 				err = printer.PrintJSON(result)
 				if err != nil {
 					printError(err, c.String("output"), "An error occurred")
 					return cli.Exit("", 1)
 				}
 				return nil
+			case "sarif":
+				err = printer.PrintSARIF(result)
+				if err != nil {
+					printError(err, c.String("output"), "An error occurred")
+					return cli.Exit("", 1)
+				}
+				return nil
+			case "github":
+				// the annotations are picked up by GitHub Actions, the regular summary is still printed for the logs
+				printer.PrintGitHubAnnotations(result)
 			}
 
 			err = reportLintErrors(result, err, printer, asset)
@@ -202,6 +248,46 @@ func Lint(isDebug *bool) *cli.Command {
 	}
 }
 
+// fixLintIssues applies the fixes of the rules to the assets, either persisting them or printing them as a diff.
+// The shared file system is used so that the assets are read with their fixes when they are validated again.
+func fixLintIssues(ctx context.Context, result *lint.PipelineAnalysisResult, dryRun bool) (int, error) {
+	fixResult, err := lint.Fix(ctx, fs, result)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, fixed := range fixResult.Fixed {
+		if dryRun {
+			diff, err := fixed.Diff()
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to generate the diff for the asset '%s'", fixed.Asset.Name)
+			}
+
+			fmt.Fprint(color.Output, diff)
+			continue
+		}
+
+		err = fixed.Asset.Persist(fs)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to persist the asset '%s'", fixed.Asset.Name)
+		}
+
+		successPrinter.Printf("Fixed the asset '%s' %s\n", fixed.Asset.Name, faint(fmt.Sprintf("(%s)", strings.Join(fixed.Rules, ", "))))
+	}
+
+	for path, err := range fixResult.Skipped {
+		warningPrinter.Printf("Skipped fixing '%s': %s\n", path, err)
+	}
+
+	if len(fixResult.Fixed) == 0 {
+		infoPrinter.Println("There are no issues that can be fixed automatically.")
+	} else {
+		fmt.Fprintln(color.Output)
+	}
+
+	return len(fixResult.Fixed), nil
+}
+
 func reportLintErrors(result *lint.PipelineAnalysisResult, err error, printer lint.Printer, asset string) error {
 	if err != nil {
 		errorPrinter.Println("\nAn error occurred while linting asset:")
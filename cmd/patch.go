@@ -4,8 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
 
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/connection"
+	"github.com/bruin-data/bruin/pkg/diff"
+	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/lint"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
@@ -187,6 +194,264 @@ func Patch() *cli.Command {
 					}
 				},
 			},
+			syncColumnsCommand(),
 		},
 	}
 }
+
+func syncColumnsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "sync-columns",
+		Usage:     "Updates the columns of the assets based on the schema of their tables in the warehouse. Accepts a path to an asset file or a pipeline directory.",
+		ArgsUsage: "[path to the asset or pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format. Possible values: plain, json",
+				Value:   "plain",
+			},
+			&cli.StringFlag{
+				Name:    "environment",
+				Aliases: []string{"e", "env"},
+				Usage:   "the environment to use",
+			},
+			&cli.StringFlag{
+				Name:    "config-file",
+				EnvVars: []string{"BRUIN_CONFIG_FILE"},
+				Usage:   "the path to the .bruin.yml file",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			inputPath := c.Args().First()
+			if inputPath == "" {
+				fmt.Println("Please provide a path to an asset or a pipeline.")
+				return nil
+			}
+
+			output := c.String("output")
+			ctx := context.Background()
+
+			pipelinePath := inputPath
+			if isPathReferencingAsset(inputPath) {
+				var err error
+				pipelinePath, err = path.GetPipelineRootFromTask(inputPath, PipelineDefinitionFiles)
+				if err != nil {
+					printErrorForOutput(output, fmt.Errorf("failed to find the pipeline this asset belongs to: '%s': %w", inputPath, err))
+					return cli.Exit("", 1)
+				}
+			}
+
+			foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+			if err != nil {
+				printErrorForOutput(output, fmt.Errorf("failed to build pipeline at '%s': %w", pipelinePath, err))
+				return cli.Exit("", 1)
+			}
+
+			assets := foundPipeline.Assets
+			if isPathReferencingAsset(inputPath) {
+				asset, err := DefaultPipelineBuilder.CreateAssetFromFile(inputPath, foundPipeline)
+				if err != nil {
+					printErrorForOutput(output, fmt.Errorf("failed to build asset from file '%s': %w", inputPath, err))
+					return cli.Exit("", 1)
+				}
+				if asset == nil {
+					printErrorForOutput(output, fmt.Errorf("the given file path doesn't seem to be a Bruin asset definition: '%s'", inputPath))
+					return cli.Exit("", 1)
+				}
+
+				asset, err = DefaultPipelineBuilder.MutateAsset(ctx, asset, foundPipeline)
+				if err != nil {
+					printErrorForOutput(output, fmt.Errorf("failed to mutate asset '%s': %w", asset.Name, err))
+					return cli.Exit("", 1)
+				}
+				assets = []*pipeline.Asset{asset}
+			}
+
+			configFilePath := c.String("config-file")
+			if configFilePath == "" {
+				repoRoot, err := git.FindRepoFromPath(pipelinePath)
+				if err != nil {
+					printErrorForOutput(output, fmt.Errorf("failed to find the git repository root: %w", err))
+					return cli.Exit("", 1)
+				}
+				configFilePath = filepath.Join(repoRoot.Path, ".bruin.yml")
+			}
+
+			cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
+			if err != nil {
+				printErrorForOutput(output, fmt.Errorf("failed to load the config file at '%s': %w", configFilePath, err))
+				return cli.Exit("", 1)
+			}
+
+			if env := c.String("environment"); env != "" {
+				if err := cm.SelectEnvironment(env); err != nil {
+					printErrorForOutput(output, fmt.Errorf("failed to use the environment '%s': %w", env, err))
+					return cli.Exit("", 1)
+				}
+			}
+
+			manager, errs := connection.NewManagerFromConfig(cm)
+			if len(errs) > 0 {
+				printErrorForOutput(output, fmt.Errorf("failed to create connection manager: %w", errs[0]))
+				return cli.Exit("", 1)
+			}
+
+			updated := make([]string, 0)
+			skipped := make([]string, 0)
+			failedAssets := make(map[string]error)
+			for _, asset := range assets {
+				if !hasWarehouseTable(asset) {
+					continue
+				}
+
+				supported, err := supportsSchemaFetching(foundPipeline, manager, asset)
+				if err != nil {
+					failedAssets[asset.Name] = err
+					continue
+				}
+				if !supported {
+					skipped = append(skipped, asset.Name)
+					continue
+				}
+
+				table, err := lint.FetchLiveSchema(ctx, manager, foundPipeline, asset)
+				if err != nil {
+					failedAssets[asset.Name] = err
+					continue
+				}
+
+				// the table is not created yet, there is nothing to sync
+				if len(table.Columns) == 0 {
+					continue
+				}
+
+				if !syncAssetColumns(asset, table) {
+					continue
+				}
+
+				if err := asset.Persist(afero.NewOsFs()); err != nil {
+					failedAssets[asset.Name] = fmt.Errorf("failed to persist asset '%s': %w", asset.Name, err)
+					continue
+				}
+				updated = append(updated, asset.Name)
+			}
+
+			if output == "json" {
+				failed := make(map[string]string, len(failedAssets))
+				for name, err := range failedAssets {
+					failed[name] = err.Error()
+				}
+				status := "success"
+				if len(failedAssets) > 0 {
+					status = "failed"
+				}
+
+				jsonResp, err := json.Marshal(map[string]interface{}{
+					"status":         status,
+					"updated_assets": updated,
+					"skipped_assets": skipped,
+					"failed_assets":  failed,
+				})
+				if err != nil {
+					printErrorForOutput(output, fmt.Errorf("failed to marshal json: %w", err))
+					return cli.Exit("", 1)
+				}
+				fmt.Println(string(jsonResp))
+			} else {
+				for _, name := range updated {
+					successPrinter.Printf("Synced the columns of asset '%s'\n", name)
+				}
+				for _, name := range skipped {
+					infoPrinter.Printf("Skipped asset '%s', reading the table schemas is not supported for its platform\n", name)
+				}
+				if len(updated) == 0 && len(failedAssets) == 0 && len(skipped) == 0 {
+					infoPrinter.Println("The columns of the assets are already in sync with the warehouse.")
+				}
+				for name, err := range failedAssets {
+					errorPrinter.Printf("- '%s': %s\n", name, err)
+				}
+			}
+
+			if len(failedAssets) > 0 {
+				return cli.Exit("", 1)
+			}
+
+			return nil
+		},
+	}
+}
+
+// hasWarehouseTable returns true if the asset is backed by a table whose schema can be read from the warehouse.
+func hasWarehouseTable(asset *pipeline.Asset) bool {
+	if asset.Materialization.Type != pipeline.MaterializationTypeNone {
+		return true
+	}
+
+	assetType := string(asset.Type)
+	return strings.HasSuffix(assetType, ".seed") || strings.HasSuffix(assetType, ".source")
+}
+
+// supportsSchemaFetching returns true if the connection of the asset can read the schema of its table.
+func supportsSchemaFetching(p *pipeline.Pipeline, manager *connection.Manager, asset *pipeline.Asset) (bool, error) {
+	connectionName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return false, err
+	}
+
+	conn, err := manager.GetConnection(connectionName)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := conn.(diff.TableSchemaFetcher)
+	return ok, nil
+}
+
+// syncAssetColumns updates the declared columns of the asset to match the given table. The existing columns keep
+// their descriptions, checks and the rest of their attributes with the type taken from the table, the columns that do
+// not exist in the table are removed, and the new ones are appended. It returns true if the columns changed.
+func syncAssetColumns(asset *pipeline.Asset, table *diff.Table) bool {
+	liveColumns := make(map[string]*diff.Column, len(table.Columns))
+	for _, col := range table.Columns {
+		liveColumns[strings.ToLower(col.Name)] = col
+	}
+
+	changed := false
+	columns := make([]pipeline.Column, 0, len(table.Columns))
+	declared := make(map[string]bool, len(asset.Columns))
+	for _, col := range asset.Columns {
+		live, ok := liveColumns[strings.ToLower(col.Name)]
+		if !ok {
+			changed = true
+			continue
+		}
+
+		declared[strings.ToLower(col.Name)] = true
+		if col.Type != live.Type {
+			col.Type = live.Type
+			changed = true
+		}
+		columns = append(columns, col)
+	}
+
+	for _, live := range table.Columns {
+		if declared[strings.ToLower(live.Name)] {
+			continue
+		}
+
+		column := pipeline.Column{
+			Name: live.Name,
+			Type: live.Type,
+		}
+		if !live.Nullable {
+			notNullable := false
+			column.Nullable = pipeline.DefaultTrueBool{Value: &notNullable}
+		}
+		columns = append(columns, column)
+		changed = true
+	}
+
+	asset.Columns = columns
+	return changed
+}
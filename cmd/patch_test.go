@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/diff"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncAssetColumns(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Columns: []pipeline.Column{
+			{Name: "id", Type: "integer", Description: "the identifier", PrimaryKey: true, Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+			{Name: "Name", Type: "VARCHAR"},
+			{Name: "deleted_at", Type: "TIMESTAMP"},
+		},
+	}
+	table := &diff.Table{
+		Columns: []*diff.Column{
+			{Name: "country", Type: "VARCHAR", Nullable: false},
+			{Name: "name", Type: "VARCHAR", Nullable: true},
+			{Name: "id", Type: "BIGINT", Nullable: false},
+			{Name: "created_at", Type: "TIMESTAMP", Nullable: true},
+		},
+	}
+
+	require.True(t, syncAssetColumns(asset, table))
+	require.Len(t, asset.Columns, 4)
+
+	assert.Equal(t, "id", asset.Columns[0].Name)
+	assert.Equal(t, "BIGINT", asset.Columns[0].Type)
+	assert.Equal(t, "the identifier", asset.Columns[0].Description)
+	assert.True(t, asset.Columns[0].PrimaryKey)
+	assert.Len(t, asset.Columns[0].Checks, 1)
+
+	assert.Equal(t, "Name", asset.Columns[1].Name)
+
+	assert.Equal(t, "country", asset.Columns[2].Name)
+	assert.False(t, asset.Columns[2].Nullable.Bool())
+	assert.Equal(t, "created_at", asset.Columns[3].Name)
+	assert.Nil(t, asset.Columns[3].Nullable.Value)
+
+	// syncing again does not change anything
+	assert.False(t, syncAssetColumns(asset, table))
+}
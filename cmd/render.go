@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,12 +22,17 @@ import (
 	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/jinja"
 	"github.com/bruin-data/bruin/pkg/mssql"
+	"github.com/bruin-data/bruin/pkg/mysql"
+	"github.com/bruin-data/bruin/pkg/oracle"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/postgres"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/snowflake"
+	"github.com/bruin-data/bruin/pkg/spanner"
+	"github.com/bruin-data/bruin/pkg/sqlite"
 	"github.com/bruin-data/bruin/pkg/synapse"
+	"github.com/bruin-data/bruin/pkg/trino"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/urfave/cli/v2"
@@ -127,43 +133,49 @@ func Render() *cli.Command {
 				return cli.Exit("", 1)
 			}
 
-			pipelineDefinitionFullPath, err := getPipelineDefinitionFullPath(pipelinePath)
+			absInputPath, err := filepath.Abs(inputPath)
 			if err != nil {
-				printError(err, c.String("output"), "Failed to locate a valid pipeline definition file")
+				printError(err, c.String("output"), "Failed to get the absolute path of the asset:")
 				return cli.Exit("", 1)
 			}
 
-			pl, err := pipeline.PipelineFromPath(pipelineDefinitionFullPath, fs)
-			if err != nil {
-				printError(err, c.String("output"), "Failed to read the pipeline definition file:")
-				return cli.Exit("", 1)
-			}
-
-			pl, err = DefaultPipelineBuilder.MutatePipeline(c.Context, pl)
+			// the whole pipeline is needed for the SQL helpers that look up the other assets, e.g. `star`, the other
+			// assets that cannot be built must not prevent rendering this one though
+			assetErrors := make(map[string]error)
+			skippedPaths := make([]string, 0)
+			pl, err := DefaultPipelineBuilder.CreatePipelineFromPath(c.Context, pipelinePath, pipeline.WithMutate(), pipeline.WithSkippedAssetErrors(func(assetPath string, err error) {
+				if absAssetPath, absErr := filepath.Abs(assetPath); absErr == nil {
+					assetPath = absAssetPath
+				}
+				assetErrors[assetPath] = err
+				skippedPaths = append(skippedPaths, assetPath)
+			}))
 			if err != nil {
-				printError(err, c.String("output"), "Failed to mutate the pipeline:")
+				printError(err, c.String("output"), "Failed to build the pipeline:")
 				return cli.Exit("", 1)
 			}
 
-			asset, err := DefaultPipelineBuilder.CreateAssetFromFile(inputPath, pl)
-			if err != nil {
+			if err, ok := assetErrors[absInputPath]; ok {
 				printError(err, c.String("output"), "Failed to read the asset definition file:")
 				return cli.Exit("", 1)
 			}
 
-			asset, err = DefaultPipelineBuilder.MutateAsset(c.Context, asset, pl)
-			if err != nil {
-				printError(errors.New("failed to mutate the asset"), c.String("output"), "Failed to mutate the asset:")
-				return cli.Exit("", 1)
-			}
-
+			asset := pl.GetAssetByPath(inputPath)
 			if asset == nil {
 				printError(errors.New("no asset found"), c.String("output"), "Failed to read the asset definition file:")
 				return cli.Exit("", 1)
 			}
 
+			if c.String("output") != "json" {
+				for _, assetPath := range skippedPaths {
+					warningPrinter.Printf("Skipping the asset '%s' that cannot be built, the helpers cannot refer to it: %v\n", assetPath, assetErrors[assetPath])
+				}
+			}
+
+			// Athena needs the results location and Trino needs the table format of the connection to render the queries
 			resultsLocation := "s3://{destination-bucket}"
-			if asset.Type == pipeline.AssetTypeAthenaQuery {
+			trinoTableFormat := trino.TableFormatIceberg
+			if asset.Type == pipeline.AssetTypeAthenaQuery || asset.Type == pipeline.AssetTypeTrinoQuery {
 				connName, err := pl.GetConnectionNameForAsset(asset)
 				if err != nil {
 					printError(err, c.String("output"), "Failed to get the connection name for the asset:")
@@ -192,12 +204,19 @@ func Render() *cli.Command {
 						break
 					}
 				}
+
+				for _, conn := range cm.SelectedEnvironment.Connections.Trino {
+					if conn.Name == connName && conn.TableFormat != "" {
+						trinoTableFormat = conn.TableFormat
+						break
+					}
+				}
 			}
 
 			r := RenderCommand{
 				extractor: &query.WholeFileExtractor{
 					Fs:       fs,
-					Renderer: jinja.NewRendererWithStartEndDates(&startDate, &endDate, pl.Name, "your-run-id", pl.Variables.Value()),
+					Renderer: jinja.NewRendererWithStartEndDates(&startDate, &endDate, pl.Name, "your-run-id", pl.Variables.Value()).CloneForAsset(c.Context, pl, asset),
 				},
 				materializers: map[pipeline.AssetType]queryMaterializer{
 					pipeline.AssetTypeBigqueryQuery:   bigquery.NewMaterializer(fullRefresh),
@@ -210,6 +229,11 @@ func Render() *cli.Command {
 					pipeline.AssetTypeAthenaQuery:     athena.NewRenderer(fullRefresh, resultsLocation),
 					pipeline.AssetTypeDuckDBQuery:     duck.NewMaterializer(fullRefresh),
 					pipeline.AssetTypeClickHouse:      clickhouse.NewRenderer(fullRefresh),
+					pipeline.AssetTypeMySQLQuery:      mysql.NewMaterializer(fullRefresh),
+					pipeline.AssetTypeOracleQuery:     oracle.NewMaterializer(fullRefresh),
+					pipeline.AssetTypeSQLiteQuery:     sqlite.NewMaterializer(fullRefresh),
+					pipeline.AssetTypeSpannerQuery:    spanner.NewRenderer(fullRefresh),
+					pipeline.AssetTypeTrinoQuery:      trino.NewRenderer(fullRefresh, trinoTableFormat),
 				},
 				builder: DefaultPipelineBuilder,
 				writer:  os.Stdout,
@@ -274,7 +298,7 @@ func (r *RenderCommand) Run(pl *pipeline.Pipeline, task *pipeline.Asset, modifie
 		}
 
 		qq.Query = materialized
-		if task.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval {
+		if task.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval || task.Materialization.Strategy == pipeline.MaterializationStrategyMicrobatch {
 			var rextractedQueries []*query.Query
 
 			rextractedQueries, err = r.extractor.ExtractQueriesFromString(materialized)
@@ -364,7 +388,7 @@ func getPipelineDefinitionFullPath(pipelinePath string) (string, error) {
 func modifyExtractor(ctx ModifierInfo, p *pipeline.Pipeline, t *pipeline.Asset) queryExtractor {
 	newStartDate := pipeline.ModifyDate(ctx.StartDate, t.IntervalModifiers.Start)
 	newEnddate := pipeline.ModifyDate(ctx.EndDate, t.IntervalModifiers.End)
-	newRenderer := jinja.NewRendererWithStartEndDates(&newStartDate, &newEnddate, p.Name, "your-run-id", p.Variables.Value())
+	newRenderer := jinja.NewRendererWithStartEndDates(&newStartDate, &newEnddate, p.Name, "your-run-id", p.Variables.Value()).CloneForAsset(context.Background(), p, t)
 
 	return &query.WholeFileExtractor{
 		Renderer: newRenderer,
@@ -33,16 +33,22 @@ import (
 	"github.com/bruin-data/bruin/pkg/lint"
 	"github.com/bruin-data/bruin/pkg/logger"
 	"github.com/bruin-data/bruin/pkg/mssql"
+	"github.com/bruin-data/bruin/pkg/mysql"
+	"github.com/bruin-data/bruin/pkg/oracle"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/postgres"
 	"github.com/bruin-data/bruin/pkg/python"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/bruin-data/bruin/pkg/script"
 	"github.com/bruin-data/bruin/pkg/snowflake"
+	"github.com/bruin-data/bruin/pkg/spanner"
+	"github.com/bruin-data/bruin/pkg/sqlite"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/bruin-data/bruin/pkg/synapse"
 	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/bruin-data/bruin/pkg/trino"
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
@@ -57,6 +63,13 @@ type PipelineInfo struct {
 	Pipeline           *pipeline.Pipeline
 	RunningForAnAsset  bool
 	RunDownstreamTasks bool
+	// Pipelines are the pipelines that run together when the given path is a folder that contains multiple
+	// pipelines, in which case Pipeline only groups their assets together and is not run itself.
+	Pipelines []*pipeline.Pipeline
+}
+
+func (p *PipelineInfo) RunningForRepository() bool {
+	return len(p.Pipelines) > 0
 }
 
 var (
@@ -158,6 +171,10 @@ func Run(isDebug *bool) *cli.Command {
 				DefaultText: "'main', 'checks', 'push-metadata'",
 				Usage:       "limit the types of tasks to run. By default it will run main and checks, while push-metadata is optional if defined in the pipeline definition",
 			},
+			&cli.BoolFlag{
+				Name:  "spark-local",
+				Usage: "run the EMR Serverless assets with a local spark-submit instead of the EMR Serverless application, skipping their quality checks",
+			},
 			&cli.BoolFlag{
 				Name:  "exp-use-winget-for-uv",
 				Usage: "use powershell to manage and install uv on windows, on non-windows systems this has no effect.",
@@ -225,6 +242,7 @@ func Run(isDebug *bool) *cli.Command {
 				ConfigFilePath:         c.String("config-file"),
 				SensorMode:             c.String("sensor-mode"),
 				ApplyIntervalModifiers: c.Bool("apply-interval-modifiers"),
+				SparkLocal:             c.Bool("spark-local"),
 			}
 
 			var startDate, endDate time.Time
@@ -232,7 +250,8 @@ func Run(isDebug *bool) *cli.Command {
 			var err error
 			startDate, endDate, inputPath, err := ValidateRunConfig(runConfig, c.Args().Get(0), logger)
 			if err != nil {
-				return err
+				errorPrinter.Printf("%v\n", err)
+				return cli.Exit("", 1)
 			}
 			repoRoot, err := git.FindRepoFromPath(inputPath)
 			if err != nil {
@@ -271,7 +290,8 @@ func Run(isDebug *bool) *cli.Command {
 
 			pipelineInfo, err := GetPipeline(c.Context, inputPath, runConfig, logger)
 			if err != nil {
-				return err
+				errorPrinter.Printf("%v\n", err)
+				return cli.Exit("", 1)
 			}
 
 			var task *pipeline.Asset
@@ -299,7 +319,11 @@ func Run(isDebug *bool) *cli.Command {
 			}
 			executionStartLog := "Starting execution..."
 			if !c.Bool("minimal-logs") {
-				infoPrinter.Printf("Analyzed the pipeline '%s' with %d assets.\n", pipelineInfo.Pipeline.Name, len(pipelineInfo.Pipeline.Assets))
+				if pipelineInfo.RunningForRepository() {
+					infoPrinter.Printf("Analyzed %d pipelines in '%s' with %d assets.\n", len(pipelineInfo.Pipelines), pipelineInfo.Pipeline.Name, len(pipelineInfo.Pipeline.Assets))
+				} else {
+					infoPrinter.Printf("Analyzed the pipeline '%s' with %d assets.\n", pipelineInfo.Pipeline.Name, len(pipelineInfo.Pipeline.Assets))
+				}
 
 				if pipelineInfo.RunningForAnAsset {
 					infoPrinter.Printf("Running only the asset '%s'\n", task.Name)
@@ -313,12 +337,21 @@ func Run(isDebug *bool) *cli.Command {
 			}
 			shouldValidate := !pipelineInfo.RunningForAnAsset && !c.Bool("no-validation")
 			if shouldValidate {
-				if err := CheckLint(pipelineInfo.Pipeline, inputPath, logger, nil, connectionManager); err != nil {
-					return err
+				pipelinesToLint := []*pipeline.Pipeline{pipelineInfo.Pipeline}
+				if pipelineInfo.RunningForRepository() {
+					pipelinesToLint = pipelineInfo.Pipelines
+				}
+				if err := checkLintForPipelines(pipelinesToLint, inputPath, logger, nil); err != nil {
+					errorPrinter.Printf("%v\n", err)
+					return cli.Exit("", 1)
 				}
 			}
 
 			statePath := filepath.Join(repoRoot.Path, "logs/runs", pipelineInfo.Pipeline.Name)
+			if pipelineInfo.RunningForRepository() {
+				// the combined runs are kept apart from the runs of a pipeline that may have the same name as the folder
+				statePath = filepath.Join(repoRoot.Path, "logs/runs", "_repository", pipelineInfo.Pipeline.Name)
+			}
 			err = git.EnsureGivenPatternIsInGitignore(afero.NewOsFs(), repoRoot.Path, "logs/runs")
 			if err != nil {
 				errorPrinter.Printf("Failed to add the run state folder to .gitignore: %v\n", err)
@@ -339,7 +372,7 @@ func Run(isDebug *bool) *cli.Command {
 				pipelineState, err = ReadState(afero.NewOsFs(), statePath, filter)
 				if err != nil {
 					errorPrinter.Printf("Failed to restore state: %v\n", err)
-					return err
+					return cli.Exit("", 1)
 				}
 
 				runConfig = &pipelineState.Parameters
@@ -389,9 +422,21 @@ func Run(isDebug *bool) *cli.Command {
 
 			if filter.PushMetaData {
 				foundPipeline.MetadataPush.Global = true
+				for _, p := range pipelineInfo.Pipelines {
+					p.MetadataPush.Global = true
+				}
 			}
 
-			s := scheduler.NewScheduler(logger, foundPipeline, runID)
+			var s *scheduler.Scheduler
+			if pipelineInfo.RunningForRepository() {
+				s, err = scheduler.NewSchedulerForPipelines(logger, pipelineInfo.Pipelines, runID)
+				if err != nil {
+					errorPrinter.Printf("Failed to combine the pipelines: %v\n", err)
+					return cli.Exit("", 1)
+				}
+			} else {
+				s = scheduler.NewScheduler(logger, foundPipeline, runID)
+			}
 
 			if c.Bool("continue") {
 				if err := s.RestoreState(pipelineState); err != nil {
@@ -438,11 +483,14 @@ func Run(isDebug *bool) *cli.Command {
 				}()
 			}
 
-			mainExecutors, err := SetupExecutors(s, cm, connectionManager, startDate, endDate, foundPipeline.Name, runID, runConfig.FullRefresh, runConfig.UsePip, runConfig.SensorMode, parser)
+			mainExecutors, err := SetupExecutors(s, cm, connectionManager, startDate, endDate, foundPipeline.Name, runID, runConfig.FullRefresh, runConfig.UsePip, runConfig.SparkLocal, runConfig.SensorMode, parser)
 			if err != nil {
 				errorPrinter.Println(err.Error())
 				return cli.Exit("", 1)
 			}
+			if runConfig.SparkLocal && (s.WillRunTaskOfType(pipeline.AssetTypeEMRServerlessSpark) || s.WillRunTaskOfType(pipeline.AssetTypeEMRServerlessPyspark)) {
+				warningPrinter.Println("The quality checks of the EMR Serverless assets are skipped with --spark-local since they run on Athena.")
+			}
 			formatOpts := executor.FormattingOptions{
 				DoNotLogTimestamp: c.Bool("no-timestamp"),
 				NoColor:           c.Bool("no-color"),
@@ -472,6 +520,11 @@ func Run(isDebug *bool) *cli.Command {
 
 			ex.Start(exeCtx, s.WorkQueue, s.Results)
 
+			// the progress is saved as the tasks finish so that it can be followed, and continued from, before the run ends
+			s.OnTaskFinished(func() {
+				_ = s.SavePipelineState(afero.NewOsFs(), runConfig, runID, statePath)
+			})
+
 			start := time.Now()
 			results := s.Run(runCtx)
 			duration := time.Since(start)
@@ -525,6 +578,30 @@ func GetPipeline(ctx context.Context, inputPath string, runConfig *scheduler.Run
 	var err error
 	runDownstreamTasks := false
 
+	if !runningForAnAsset {
+		pipelines, err := getPipelinesInFolder(ctx, inputPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(pipelines) > 0 {
+			absPath, err := filepath.Abs(inputPath)
+			if err != nil {
+				return nil, err
+			}
+
+			combined := &pipeline.Pipeline{Name: filepath.Base(absPath)}
+			for _, p := range pipelines {
+				combined.Assets = append(combined.Assets, p.Assets...)
+			}
+
+			return &PipelineInfo{
+				Pipeline:  combined,
+				Pipelines: pipelines,
+			}, nil
+		}
+	}
+
 	if runningForAnAsset {
 		pipelinePath, err = path.GetPipelineRootFromTask(inputPath, PipelineDefinitionFiles)
 		if err != nil {
@@ -585,6 +662,38 @@ func GetPipeline(ctx context.Context, inputPath string, runConfig *scheduler.Run
 	}, nil
 }
 
+// getPipelinesInFolder builds the pipelines under the given folder if the folder is not within a pipeline, e.g. the
+// root of a repository, so that they can run together. It returns nil if the path is not a folder, such as the
+// definition file of a pipeline, or if the folder is a pipeline or is inside one.
+func getPipelinesInFolder(ctx context.Context, folder string) ([]*pipeline.Pipeline, error) {
+	if info, err := os.Stat(folder); err != nil || !info.IsDir() {
+		return nil, nil
+	}
+
+	if _, err := path.GetPipelineRootFromTask(folder, PipelineDefinitionFiles); err == nil {
+		return nil, nil
+	}
+
+	pipelinePaths, err := path.GetPipelinePaths(folder, PipelineDefinitionFiles)
+	if err != nil {
+		errorPrinter.Printf("Failed to find the pipelines in '%s': %v\n", folder, err)
+		return nil, err
+	}
+
+	pipelines := make([]*pipeline.Pipeline, 0, len(pipelinePaths))
+	for _, pipelinePath := range pipelinePaths {
+		p, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+		if err != nil {
+			errorPrinter.Printf("Failed to build the pipeline in '%s': %v\n", pipelinePath, err)
+			return nil, err
+		}
+
+		pipelines = append(pipelines, p)
+	}
+
+	return pipelines, nil
+}
+
 func ParseDate(startDateStr, endDateStr string, logger logger.Logger) (time.Time, time.Time, error) {
 	startDate, err := date.ParseTime(startDateStr)
 	logger.Debug("given start date: ", startDate)
@@ -623,6 +732,10 @@ func ValidateRunConfig(runConfig *scheduler.RunConfig, inputPath string, logger
 }
 
 func CheckLint(foundPipeline *pipeline.Pipeline, pipelinePath string, logger logger.Logger, parser *sqlparser.SQLParser, connectionManager *connection.Manager) error {
+	return checkLintForPipelines([]*pipeline.Pipeline{foundPipeline}, pipelinePath, logger, parser)
+}
+
+func checkLintForPipelines(pipelines []*pipeline.Pipeline, pipelinePath string, logger logger.Logger, parser *sqlparser.SQLParser) error {
 	rules, err := lint.GetRules(fs, &git.RepoFinder{}, true, parser, true)
 	if err != nil {
 		errorPrinter.Printf("An error occurred while linting the pipelines: %v\n", err)
@@ -631,8 +744,8 @@ func CheckLint(foundPipeline *pipeline.Pipeline, pipelinePath string, logger log
 
 	rules = lint.FilterRulesBySpeed(rules, true)
 
-	linter := lint.NewLinter(path.GetPipelinePaths, DefaultPipelineBuilder, rules, logger)
-	res, err := linter.LintPipelines([]*pipeline.Pipeline{foundPipeline})
+	linter := lint.NewLinterWithParser(path.GetPipelinePaths, DefaultPipelineBuilder, rules, logger, parser)
+	res, err := linter.LintPipelines(pipelines)
 	err = reportLintErrors(res, err, lint.Printer{RootCheckPath: pipelinePath}, "")
 	if err != nil {
 		return err
@@ -714,6 +827,7 @@ func SetupExecutors(
 	runID string,
 	fullRefresh bool,
 	usePipForPython bool,
+	sparkLocal bool,
 	sensorMode string,
 	parser *sqlparser.SQLParser,
 ) (map[pipeline.AssetType]executor.Config, error) {
@@ -733,12 +847,24 @@ func SetupExecutors(
 	jinjaVariables := jinja.PythonEnvVariables(&startDate, &endDate, pipelineName, runID, fullRefresh)
 	if s.WillRunTaskOfType(pipeline.AssetTypePython) {
 		if usePipForPython {
-			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeMain] = python.NewLocalOperator(config, jinjaVariables)
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeMain] = python.NewLocalOperator(config, conn, jinjaVariables)
 		} else {
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeMain] = python.NewLocalOperatorWithUv(config, conn, jinjaVariables)
 		}
 	}
 
+	if s.WillRunTaskOfType(pipeline.AssetTypeNotebook) {
+		mainExecutors[pipeline.AssetTypeNotebook][scheduler.TaskInstanceTypeMain] = python.NewNotebookOperator(config, conn, jinjaVariables)
+	}
+
+	if s.WillRunTaskOfType(pipeline.AssetTypeShell) {
+		mainExecutors[pipeline.AssetTypeShell][scheduler.TaskInstanceTypeMain] = script.NewShellOperator(config, jinjaVariables)
+	}
+
+	if s.WillRunTaskOfType(pipeline.AssetTypeR) {
+		mainExecutors[pipeline.AssetTypeR][scheduler.TaskInstanceTypeMain] = script.NewROperator(config, jinjaVariables)
+	}
+
 	wholeFileExtractor := &query.WholeFileExtractor{
 		Fs:       fs,
 		Renderer: renderer,
@@ -1012,6 +1138,131 @@ func SetupExecutors(
 		}
 	}
 
+	//nolint:dupl
+	if s.WillRunTaskOfType(pipeline.AssetTypeMySQLQuery) || estimateCustomCheckType == pipeline.AssetTypeMySQLQuery ||
+		s.WillRunTaskOfType(pipeline.AssetTypeMySQLSeed) || s.WillRunTaskOfType(pipeline.AssetTypeMySQLQuerySensor) {
+		mySQLOperator := mysql.NewBasicOperator(conn, wholeFileExtractor, mysql.NewMaterializer(fullRefresh))
+		mySQLCheckRunner := mysql.NewColumnCheckOperator(conn)
+		mySQLQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
+
+		mainExecutors[pipeline.AssetTypeMySQLQuery][scheduler.TaskInstanceTypeMain] = mySQLOperator
+		mainExecutors[pipeline.AssetTypeMySQLQuery][scheduler.TaskInstanceTypeColumnCheck] = mySQLCheckRunner
+		mainExecutors[pipeline.AssetTypeMySQLQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeMySQLSeed][scheduler.TaskInstanceTypeMain] = seedOperator
+		mainExecutors[pipeline.AssetTypeMySQLSeed][scheduler.TaskInstanceTypeColumnCheck] = mySQLCheckRunner
+		mainExecutors[pipeline.AssetTypeMySQLSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeMySQLQuerySensor][scheduler.TaskInstanceTypeMain] = mySQLQuerySensor
+		mainExecutors[pipeline.AssetTypeMySQLQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = mySQLCheckRunner
+		mainExecutors[pipeline.AssetTypeMySQLQuerySensor][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		if estimateCustomCheckType == pipeline.AssetTypeMySQLQuery {
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = mySQLCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		}
+	}
+
+	//nolint:dupl
+	if s.WillRunTaskOfType(pipeline.AssetTypeOracleQuery) || estimateCustomCheckType == pipeline.AssetTypeOracleQuery ||
+		s.WillRunTaskOfType(pipeline.AssetTypeOracleSeed) || s.WillRunTaskOfType(pipeline.AssetTypeOracleQuerySensor) {
+		oracleOperator := oracle.NewBasicOperator(conn, wholeFileExtractor, oracle.NewMaterializer(fullRefresh))
+		oracleCheckRunner := oracle.NewColumnCheckOperator(conn)
+		oracleQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
+
+		mainExecutors[pipeline.AssetTypeOracleQuery][scheduler.TaskInstanceTypeMain] = oracleOperator
+		mainExecutors[pipeline.AssetTypeOracleQuery][scheduler.TaskInstanceTypeColumnCheck] = oracleCheckRunner
+		mainExecutors[pipeline.AssetTypeOracleQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeOracleSeed][scheduler.TaskInstanceTypeMain] = seedOperator
+		mainExecutors[pipeline.AssetTypeOracleSeed][scheduler.TaskInstanceTypeColumnCheck] = oracleCheckRunner
+		mainExecutors[pipeline.AssetTypeOracleSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeOracleQuerySensor][scheduler.TaskInstanceTypeMain] = oracleQuerySensor
+		mainExecutors[pipeline.AssetTypeOracleQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = oracleCheckRunner
+		mainExecutors[pipeline.AssetTypeOracleQuerySensor][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		if estimateCustomCheckType == pipeline.AssetTypeOracleQuery {
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = oracleCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		}
+	}
+
+	//nolint:dupl
+	if s.WillRunTaskOfType(pipeline.AssetTypeSQLiteQuery) || estimateCustomCheckType == pipeline.AssetTypeSQLiteQuery ||
+		s.WillRunTaskOfType(pipeline.AssetTypeSQLiteSeed) || s.WillRunTaskOfType(pipeline.AssetTypeSQLiteQuerySensor) {
+		sqliteOperator := sqlite.NewBasicOperator(conn, wholeFileExtractor, sqlite.NewMaterializer(fullRefresh))
+		sqliteCheckRunner := sqlite.NewColumnCheckOperator(conn)
+		sqliteQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
+
+		mainExecutors[pipeline.AssetTypeSQLiteQuery][scheduler.TaskInstanceTypeMain] = sqliteOperator
+		mainExecutors[pipeline.AssetTypeSQLiteQuery][scheduler.TaskInstanceTypeColumnCheck] = sqliteCheckRunner
+		mainExecutors[pipeline.AssetTypeSQLiteQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeSQLiteSeed][scheduler.TaskInstanceTypeMain] = seedOperator
+		mainExecutors[pipeline.AssetTypeSQLiteSeed][scheduler.TaskInstanceTypeColumnCheck] = sqliteCheckRunner
+		mainExecutors[pipeline.AssetTypeSQLiteSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeSQLiteQuerySensor][scheduler.TaskInstanceTypeMain] = sqliteQuerySensor
+		mainExecutors[pipeline.AssetTypeSQLiteQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = sqliteCheckRunner
+		mainExecutors[pipeline.AssetTypeSQLiteQuerySensor][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		if estimateCustomCheckType == pipeline.AssetTypeSQLiteQuery {
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = sqliteCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		}
+	}
+
+	//nolint:dupl
+	if s.WillRunTaskOfType(pipeline.AssetTypeSpannerQuery) || estimateCustomCheckType == pipeline.AssetTypeSpannerQuery ||
+		s.WillRunTaskOfType(pipeline.AssetTypeSpannerSeed) || s.WillRunTaskOfType(pipeline.AssetTypeSpannerQuerySensor) {
+		spannerOperator := spanner.NewBasicOperator(conn, wholeFileExtractor, spanner.NewMaterializer(fullRefresh))
+		spannerCheckRunner := spanner.NewColumnCheckOperator(conn)
+		spannerQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
+
+		mainExecutors[pipeline.AssetTypeSpannerQuery][scheduler.TaskInstanceTypeMain] = spannerOperator
+		mainExecutors[pipeline.AssetTypeSpannerQuery][scheduler.TaskInstanceTypeColumnCheck] = spannerCheckRunner
+		mainExecutors[pipeline.AssetTypeSpannerQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeSpannerSeed][scheduler.TaskInstanceTypeMain] = seedOperator
+		mainExecutors[pipeline.AssetTypeSpannerSeed][scheduler.TaskInstanceTypeColumnCheck] = spannerCheckRunner
+		mainExecutors[pipeline.AssetTypeSpannerSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeSpannerQuerySensor][scheduler.TaskInstanceTypeMain] = spannerQuerySensor
+		mainExecutors[pipeline.AssetTypeSpannerQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = spannerCheckRunner
+		mainExecutors[pipeline.AssetTypeSpannerQuerySensor][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		if estimateCustomCheckType == pipeline.AssetTypeSpannerQuery {
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = spannerCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		}
+	}
+
+	//nolint:dupl
+	if s.WillRunTaskOfType(pipeline.AssetTypeTrinoQuery) || estimateCustomCheckType == pipeline.AssetTypeTrinoQuery ||
+		s.WillRunTaskOfType(pipeline.AssetTypeTrinoSeed) || s.WillRunTaskOfType(pipeline.AssetTypeTrinoQuerySensor) {
+		trinoOperator := trino.NewBasicOperator(conn, wholeFileExtractor, trino.NewMaterializer(fullRefresh))
+		trinoCheckRunner := trino.NewColumnCheckOperator(conn)
+		trinoQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
+
+		mainExecutors[pipeline.AssetTypeTrinoQuery][scheduler.TaskInstanceTypeMain] = trinoOperator
+		mainExecutors[pipeline.AssetTypeTrinoQuery][scheduler.TaskInstanceTypeColumnCheck] = trinoCheckRunner
+		mainExecutors[pipeline.AssetTypeTrinoQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeTrinoSeed][scheduler.TaskInstanceTypeMain] = seedOperator
+		mainExecutors[pipeline.AssetTypeTrinoSeed][scheduler.TaskInstanceTypeColumnCheck] = trinoCheckRunner
+		mainExecutors[pipeline.AssetTypeTrinoSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeTrinoQuerySensor][scheduler.TaskInstanceTypeMain] = trinoQuerySensor
+		mainExecutors[pipeline.AssetTypeTrinoQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = trinoCheckRunner
+		mainExecutors[pipeline.AssetTypeTrinoQuerySensor][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		if estimateCustomCheckType == pipeline.AssetTypeTrinoQuery {
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = trinoCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		}
+	}
+
 	emrServerlessAssetTypes := []pipeline.AssetType{
 		pipeline.AssetTypeEMRServerlessSpark,
 		pipeline.AssetTypeEMRServerlessPyspark,
@@ -1028,6 +1279,23 @@ func SetupExecutors(
 			mainExecutors[typ][scheduler.TaskInstanceTypeMain] = emrServerlessOperator
 			mainExecutors[typ][scheduler.TaskInstanceTypeColumnCheck] = emrCheckRunner
 			mainExecutors[typ][scheduler.TaskInstanceTypeCustomCheck] = emrCustomCheckRunner
+			if sparkLocal {
+				// the checks run on Athena, which the local runs do not need, therefore they are skipped
+				mainExecutors[typ][scheduler.TaskInstanceTypeMain] = emr_serverless.NewLocalOperator(jinjaVariables, renderer, &python.UvChecker{})
+				mainExecutors[typ][scheduler.TaskInstanceTypeColumnCheck] = executor.NoOpOperator{}
+				mainExecutors[typ][scheduler.TaskInstanceTypeCustomCheck] = executor.NoOpOperator{}
+			}
+		}
+	}
+
+	databricksJobAssetTypes := []pipeline.AssetType{
+		pipeline.AssetTypeDatabricksNotebook,
+		pipeline.AssetTypeDatabricksPyspark,
+	}
+
+	for _, typ := range databricksJobAssetTypes {
+		if s.WillRunTaskOfType(typ) {
+			mainExecutors[typ][scheduler.TaskInstanceTypeMain] = databricks.NewJobOperator(conn, jinjaVariables, renderer)
 		}
 	}
 
@@ -1435,3 +1435,42 @@ func TestReadState(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPipelinesInFolder_SinglePipeline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{
+			name: "pipeline definition file",
+			path: filepath.Join("testdata", "simple-pipeline", "pipeline.yml"),
+		},
+		{
+			name: "pipeline folder",
+			path: filepath.Join("testdata", "simple-pipeline"),
+		},
+		{
+			name: "folder inside a pipeline",
+			path: filepath.Join("testdata", "simple-pipeline", "assets"),
+		},
+		{
+			name: "asset file",
+			path: filepath.Join("testdata", "simple-pipeline", "assets", "nested1.sql"),
+		},
+		{
+			name: "missing path",
+			path: filepath.Join("testdata", "missing"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pipelines, err := getPipelinesInFolder(context.Background(), tt.path)
+			require.NoError(t, err)
+			assert.Nil(t, pipelines)
+		})
+	}
+}
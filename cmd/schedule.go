@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	path2 "path"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/daemon"
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v2"
+)
+
+func Schedule() *cli.Command {
+	return &cli.Command{
+		Name:      "schedule",
+		Usage:     "run the pipelines of a repository on their schedules until stopped",
+		ArgsUsage: "[path to the repository]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "environment",
+				Aliases: []string{"e", "env"},
+				Usage:   "the environment to use for the runs",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "do not ask for confirmation if the environment is a production environment",
+			},
+			&cli.StringFlag{
+				Name:    "config-file",
+				EnvVars: []string{"BRUIN_CONFIG_FILE"},
+				Usage:   "the path to the .bruin.yml file",
+			},
+			&cli.IntFlag{
+				Name:  "max-concurrent-runs",
+				Usage: "the maximum number of pipelines that run at the same time",
+				Value: 4,
+			},
+			&cli.IntFlag{
+				Name:  "workers",
+				Usage: "number of workers to run the tasks of each pipeline in parallel",
+				Value: 16,
+			},
+			&cli.DurationFlag{
+				Name:  "poll-interval",
+				Usage: "how often the pipelines are checked for due runs",
+				Value: time.Minute,
+			},
+			&cli.DurationFlag{
+				Name:  "retry-delay",
+				Usage: "how long a failed interval waits before it is run again, doubling with every failure up to an hour",
+				Value: 5 * time.Minute,
+			},
+			&cli.DurationFlag{
+				Name:  "grace-period",
+				Usage: "how long the active runs are given to stop on shutdown before they are killed",
+				Value: 5 * time.Minute,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+			inputPath, err := filepath.Abs(inputPath)
+			if err != nil {
+				errorPrinter.Printf("Failed to resolve the path '%s': %v\n", inputPath, err)
+				return cli.Exit("", 1)
+			}
+
+			repoRoot, err := git.FindRepoFromPath(inputPath)
+			if err != nil {
+				errorPrinter.Printf("Failed to find the git repository root: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			configFilePath := c.String("config-file")
+			if configFilePath == "" {
+				configFilePath = path2.Join(repoRoot.Path, ".bruin.yml")
+			}
+			cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
+			if err != nil {
+				errorPrinter.Printf("Failed to load the config file at '%s': %v\n", configFilePath, err)
+				return cli.Exit("", 1)
+			}
+
+			// the production environments are confirmed once, the runs cannot prompt
+			environment := c.String("environment")
+			if err := switchEnvironment(environment, c.Bool("force"), cm, os.Stdin); err != nil {
+				return err
+			}
+
+			runArgs := []string{"--config-file", configFilePath, "--workers", strconv.Itoa(c.Int("workers"))}
+			if environment != "" {
+				runArgs = append(runArgs, "--environment", environment, "--force")
+			}
+
+			executable, err := os.Executable()
+			if err != nil {
+				errorPrinter.Printf("Failed to find the bruin executable: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			err = git.EnsureGivenPatternIsInGitignore(afero.NewOsFs(), repoRoot.Path, "logs/schedule")
+			if err != nil {
+				errorPrinter.Printf("Failed to add the schedule state folder to .gitignore: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			d := daemon.New(
+				afero.NewOsFs(),
+				inputPath,
+				&daemon.CommandRunner{
+					Executable:  executable,
+					Args:        runArgs,
+					Output:      os.Stdout,
+					GracePeriod: c.Duration("grace-period"),
+				},
+				path.GetPipelinePaths,
+				os.Stdout,
+				daemon.Options{
+					StateDir:                filepath.Join(repoRoot.Path, LogsFolder, "schedule"),
+					MaxConcurrentRuns:       c.Int("max-concurrent-runs"),
+					PollInterval:            c.Duration("poll-interval"),
+					RetryDelay:              c.Duration("retry-delay"),
+					PipelineDefinitionFiles: PipelineDefinitionFiles,
+				},
+			)
+
+			ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+
+			return d.Run(ctx)
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
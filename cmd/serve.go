@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	path2 "path"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/server"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v2"
+)
+
+func Serve() *cli.Command {
+	return &cli.Command{
+		Name:      "serve",
+		Usage:     "serve a local HTTP API to trigger and follow the runs of the pipelines in a repository",
+		ArgsUsage: "[path to the repository]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "address",
+				Usage: "the address the API listens on",
+				Value: "127.0.0.1:8080",
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				EnvVars: []string{"BRUIN_SERVER_TOKEN"},
+				Usage:   "the token the requests authenticate with, a random one is generated if not given",
+			},
+			&cli.StringFlag{
+				Name:    "config-file",
+				EnvVars: []string{"BRUIN_CONFIG_FILE"},
+				Usage:   "the path to the .bruin.yml file",
+			},
+			&cli.IntFlag{
+				Name:  "max-concurrent-runs",
+				Usage: "the maximum number of runs that can be active at the same time",
+				Value: 4,
+			},
+			&cli.DurationFlag{
+				Name:  "grace-period",
+				Usage: "how long the cancelled runs are given to stop before they are killed",
+				Value: 5 * time.Minute,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+			inputPath, err := filepath.Abs(inputPath)
+			if err != nil {
+				errorPrinter.Printf("Failed to resolve the path '%s': %v\n", inputPath, err)
+				return cli.Exit("", 1)
+			}
+
+			repoRoot, err := git.FindRepoFromPath(inputPath)
+			if err != nil {
+				errorPrinter.Printf("Failed to find the git repository root: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			configFilePath := c.String("config-file")
+			if configFilePath == "" {
+				configFilePath = path2.Join(repoRoot.Path, ".bruin.yml")
+			}
+			if _, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath); err != nil {
+				errorPrinter.Printf("Failed to load the config file at '%s': %v\n", configFilePath, err)
+				return cli.Exit("", 1)
+			}
+
+			token := c.String("token")
+			if token == "" {
+				token, err = server.GenerateToken()
+				if err != nil {
+					errorPrinter.Printf("Failed to generate a token: %v\n", err)
+					return cli.Exit("", 1)
+				}
+				infoPrinter.Printf("No token is given, the requests need to authenticate with the token '%s'\n", token)
+			}
+
+			executable, err := os.Executable()
+			if err != nil {
+				errorPrinter.Printf("Failed to find the bruin executable: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			err = git.EnsureGivenPatternIsInGitignore(afero.NewOsFs(), repoRoot.Path, "logs/runs")
+			if err != nil {
+				errorPrinter.Printf("Failed to add the run state folder to .gitignore: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			s := server.New(
+				afero.NewOsFs(),
+				inputPath,
+				&server.CommandRunner{
+					Executable:  executable,
+					Args:        []string{"--config-file", configFilePath},
+					GracePeriod: c.Duration("grace-period"),
+				},
+				path.GetPipelinePaths,
+				func(ctx context.Context, pipelinePath string) (*pipeline.Pipeline, error) {
+					return DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+				},
+				os.Stdout,
+				server.Options{
+					Token:                   token,
+					MaxConcurrentRuns:       c.Int("max-concurrent-runs"),
+					StateDir:                filepath.Join(repoRoot.Path, LogsFolder, "runs"),
+					PipelineDefinitionFiles: PipelineDefinitionFiles,
+				},
+			)
+
+			httpServer := &http.Server{
+				Addr:              c.String("address"),
+				Handler:           s.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer cancel()
+
+			serveErr := make(chan error, 1)
+			go func() {
+				serveErr <- httpServer.ListenAndServe()
+			}()
+			infoPrinter.Printf("Serving the pipelines in '%s' on http://%s\n", inputPath, httpServer.Addr)
+
+			select {
+			case err := <-serveErr:
+				errorPrinter.Printf("Failed to serve the API: %v\n", err)
+				return cli.Exit("", 1)
+			case <-ctx.Done():
+			}
+
+			infoPrinter.Println("Shutting down, waiting for the active runs to stop...")
+			// the runs are stopped first so that the log streams following them can finish
+			s.Close()
+
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer shutdownCancel()
+			if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errorPrinter.Printf("Failed to shut down the API: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			return nil
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
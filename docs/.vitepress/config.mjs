@@ -135,11 +135,16 @@ export default withMermaid({
                     {text: "Databricks", link: "/platforms/databricks"},
                     {text: "DuckDB", link: "/platforms/duckdb"},
                     {text: "Google BigQuery", link: "/platforms/bigquery"},
+                    {text: "Google Cloud Spanner", link: "/platforms/spanner"},
                     {text: "Microsoft SQL Server", link: "/platforms/mssql"},
+                    {text: "MySQL", link: "/platforms/mysql"},
+                    {text: "Oracle", link: "/platforms/oracle"},
                     {text: "Postgres", link: "/platforms/postgres"},
                     {text: "Redshift", link: "/platforms/redshift"},
                     {text: "Snowflake", link: "/platforms/snowflake"},
+                    {text: "SQLite", link: "/platforms/sqlite"},
                     {text: "Synapse", link: "/platforms/synapse"},
+                    {text: "Trino", link: "/platforms/trino"},
                     {text: "AWS EMR Serverless", link: "/platforms/emr_serverless"},
                 ],
             },
@@ -154,6 +159,7 @@ export default withMermaid({
                             {text: "Seed", link: "/assets/seed"},
                             {text: "Ingestr", link: "/assets/ingestr"},
                             {text: "Python", link: "/assets/python"},
+                            {text: "Shell, R & Notebooks", link: "/assets/scripts"},
                             {text: "Sensor", link: "/assets/sensor"},
                         ]
                     },
@@ -164,7 +170,10 @@ export default withMermaid({
                     {
                         text: " Jinja Templating",
                         link: "/assets/templating/templating",
-                        items: [{text: "Filters", link: "/assets/templating/filters"}],
+                        items: [
+                            {text: "Filters", link: "/assets/templating/filters"},
+                            {text: "Macros", link: "/assets/templating/macros"},
+                        ],
                     },
                 ],
             },
@@ -251,8 +260,13 @@ export default withMermaid({
                     {text: "Format", link: "/commands/format"},
                     {text: "Init", link: "/commands/init"},
                     {text: "Lineage", link: "/commands/lineage"},
+                    {text: "Impact", link: "/commands/impact"},
+                    {text: "Import", link: "/commands/import"},
+                    {text: "Patch", link: "/commands/patch"},
                     {text: "Render", link: "/commands/render"},
                     {text: "Run", link: "/commands/run"},
+                    {text: "Schedule", link: "/commands/schedule"},
+                    {text: "Serve", link: "/commands/serve"},
                     {text: "Query", link: "/commands/query"},
                     {text: "Validate", link: "/commands/validate"},
                 ],
@@ -393,6 +393,95 @@ func getWorkflow(binary string, currentFolder string, tempdir string) []e2e.Work
 				},
 			},
 		},
+		{
+			Name: "Python incremental materialization",
+			Steps: []e2e.Task{
+				{
+					Name:    "create the tables",
+					Command: binary,
+					Args:    []string{"run", "--full-refresh", "--start-date", "2025-01-01", "--end-date", "2025-01-01 23:59:59", "--env", "env-run-python-materialization", filepath.Join(currentFolder, "test-pipelines/python-incremental-materialization")},
+					Env:     []string{},
+
+					Expected: e2e.Output{
+						ExitCode: 0,
+					},
+					Asserts: []func(*e2e.Task) error{
+						e2e.AssertByExitCode,
+					},
+				},
+				{
+					Name:    "load the next day",
+					Command: binary,
+					Args:    []string{"run", "--start-date", "2025-01-02", "--end-date", "2025-01-02 23:59:59", "--env", "env-run-python-materialization", filepath.Join(currentFolder, "test-pipelines/python-incremental-materialization")},
+					Env:     []string{},
+
+					Expected: e2e.Output{
+						ExitCode: 0,
+					},
+					Asserts: []func(*e2e.Task) error{
+						e2e.AssertByExitCode,
+					},
+				},
+				{
+					Name:    "load the next day again",
+					Command: binary,
+					Args:    []string{"run", "--start-date", "2025-01-02", "--end-date", "2025-01-02 23:59:59", "--env", "env-run-python-materialization", filepath.Join(currentFolder, "test-pipelines/python-incremental-materialization")},
+					Env:     []string{},
+
+					Expected: e2e.Output{
+						ExitCode: 0,
+					},
+					Asserts: []func(*e2e.Task) error{
+						e2e.AssertByExitCode,
+					},
+				},
+				{
+					Name:    "query the merged table",
+					Command: binary,
+					Args:    []string{"query", "--env", "env-run-python-materialization", "--connection", "duckdb-run-python-materialization", "--query", `SELECT string_agg(id || ':' || status, ',' ORDER BY id) AS result FROM incremental.orders`, "--output", "json"},
+					Env:     []string{},
+
+					Expected: e2e.Output{
+						ExitCode: 0,
+						Output:   `{"columns":[{"name":"result","type":"VARCHAR"}],"rows":[["1:pending,2:shipped,3:pending"]],"connectionName":"duckdb-run-python-materialization","query":"SELECT string_agg(id || ':' || status, ',' ORDER BY id) AS result FROM incremental.orders"}`,
+					},
+					Asserts: []func(*e2e.Task) error{
+						e2e.AssertByExitCode,
+						e2e.AssertByOutputJSON,
+					},
+				},
+				{
+					Name:    "query the appended table",
+					Command: binary,
+					Args:    []string{"query", "--env", "env-run-python-materialization", "--connection", "duckdb-run-python-materialization", "--query", `SELECT string_agg(name, ',' ORDER BY name) AS result FROM incremental.events`, "--output", "json"},
+					Env:     []string{},
+
+					Expected: e2e.Output{
+						ExitCode: 0,
+						Output:   `{"columns":[{"name":"result","type":"VARCHAR"}],"rows":[["event_2025-01-01,event_2025-01-02,event_2025-01-02"]],"connectionName":"duckdb-run-python-materialization","query":"SELECT string_agg(name, ',' ORDER BY name) AS result FROM incremental.events"}`,
+					},
+					Asserts: []func(*e2e.Task) error{
+						e2e.AssertByExitCode,
+						e2e.AssertByOutputJSON,
+					},
+				},
+				{
+					Name:    "query the time interval table",
+					Command: binary,
+					Args:    []string{"query", "--env", "env-run-python-materialization", "--connection", "duckdb-run-python-materialization", "--query", `SELECT string_agg(strftime(dt, '%Y-%m-%d') || ':' || product || ':' || amount, ',' ORDER BY dt, product) AS result FROM incremental.daily_sales`, "--output", "json"},
+					Env:     []string{},
+
+					Expected: e2e.Output{
+						ExitCode: 0,
+						Output:   `{"columns":[{"name":"result","type":"VARCHAR"}],"rows":[["2025-01-01:a:10,2025-01-01:b:20,2025-01-02:a:15"]],"connectionName":"duckdb-run-python-materialization","query":"SELECT string_agg(strftime(dt, '%Y-%m-%d') || ':' || product || ':' || amount, ',' ORDER BY dt, product) AS result FROM incremental.daily_sales"}`,
+					},
+					Asserts: []func(*e2e.Task) error{
+						e2e.AssertByExitCode,
+						e2e.AssertByOutputJSON,
+					},
+				},
+			},
+		},
 		{
 			Name: "Run pipeline with nameless asset",
 			Steps: []e2e.Task{
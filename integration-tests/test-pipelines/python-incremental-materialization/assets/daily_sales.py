@@ -0,0 +1,24 @@
+""" @bruin
+
+name: incremental.daily_sales
+connection: duckdb-run-python-materialization
+
+materialization:
+    type: table
+    strategy: time_interval
+    incremental_key: dt
+    time_granularity: timestamp
+
+@bruin """
+
+import os
+
+import pandas as pd
+
+
+def materialize():
+    dt = pd.Timestamp(os.environ["BRUIN_START_DATE"], tz="UTC")
+    if os.environ["BRUIN_START_DATE"] == "2025-01-01":
+        return pd.DataFrame({"dt": [dt, dt], "product": ["a", "b"], "amount": [10, 20]})
+
+    return pd.DataFrame({"dt": [dt], "product": ["a"], "amount": [15]})
@@ -0,0 +1,16 @@
+""" @bruin
+
+name: incremental.events
+connection: duckdb-run-python-materialization
+
+materialization:
+    type: table
+    strategy: append
+
+@bruin """
+
+import os
+
+
+def materialize():
+    return [{"name": "event_" + os.environ["BRUIN_START_DATE"]}]
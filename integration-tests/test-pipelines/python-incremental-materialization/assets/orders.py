@@ -0,0 +1,28 @@
+""" @bruin
+
+name: incremental.orders
+connection: duckdb-run-python-materialization
+
+materialization:
+    type: table
+    strategy: merge
+
+columns:
+    - name: id
+      type: integer
+      primary_key: true
+    - name: status
+      type: string
+
+@bruin """
+
+import os
+
+import pandas as pd
+
+
+def materialize():
+    if os.environ["BRUIN_START_DATE"] == "2025-01-01":
+        return pd.DataFrame({"id": [1, 2], "status": ["pending", "pending"]})
+
+    return pd.DataFrame({"id": [2, 3], "status": ["shipped", "pending"]})
@@ -64,6 +64,7 @@ func main() {
 			cmd.Run(&isDebug),
 			cmd.Render(),
 			cmd.Lineage(),
+			cmd.Impact(),
 			cmd.CleanCmd(),
 			cmd.Format(&isDebug),
 			cmd.Docs(),
@@ -74,6 +75,9 @@ func main() {
 			cmd.Query(),
 			cmd.Patch(),
 			cmd.DataDiffCmd(),
+			cmd.Import(),
+			cmd.Schedule(),
+			cmd.Serve(),
 			versionCommand,
 		},
 		DisableSliceFlagSeparator: true,
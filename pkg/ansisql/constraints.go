@@ -0,0 +1,23 @@
+package ansisql
+
+import (
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// ConstraintName builds a deterministic constraint name out of the table name and the given columns,
+// e.g. `fk_schema_table_user_id`, so that the same asset always produces the same DDL.
+func ConstraintName(prefix, tableName string, columns ...string) string {
+	parts := append([]string{prefix, strings.ReplaceAll(tableName, ".", "_")}, columns...)
+	return strings.ToLower(strings.Join(parts, "_"))
+}
+
+// ColumnNames returns the names of the columns defined in the asset, in the order of their definition.
+func ColumnNames(asset *pipeline.Asset) []string {
+	names := make([]string, 0, len(asset.Columns))
+	for _, col := range asset.Columns {
+		names = append(names, col.Name)
+	}
+	return names
+}
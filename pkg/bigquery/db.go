@@ -500,6 +500,64 @@ func (d *Client) GetTableSummary(ctx context.Context, tableName string) (*diff.T
 		}
 	}
 
+	table, err := d.GetTableSchema(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, col := range table.Columns {
+		columnName, dataType, normalizedType := col.Name, col.Type, col.NormalizedType
+
+		// Debug: log type mapping for troubleshooting
+		if normalizedType == diff.CommonTypeUnknown {
+			fmt.Printf("Warning: Unknown type mapping for BigQuery type '%s' in column '%s'\n", dataType, columnName)
+		}
+
+		// Collect statistics for this column
+		var stats diff.ColumnStatistics
+		switch normalizedType {
+		case diff.CommonTypeNumeric:
+			stats, err = d.fetchNumericalStats(ctx, tableName, columnName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch numerical stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
+			}
+		case diff.CommonTypeString:
+			stats, err = d.fetchStringStats(ctx, tableName, columnName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch string stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
+			}
+		case diff.CommonTypeBoolean:
+			stats, err = d.fetchBooleanStats(ctx, tableName, columnName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch boolean stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
+			}
+		case diff.CommonTypeDateTime:
+			stats, err = d.fetchDateTimeStats(ctx, tableName, columnName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch datetime stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
+			}
+		case diff.CommonTypeJSON:
+			stats, err = d.fetchJSONStats(ctx, tableName, columnName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch JSON stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
+			}
+		case diff.CommonTypeBinary, diff.CommonTypeUnknown:
+			fmt.Printf("Warning: Using unknown statistics for column '%s' with BigQuery type '%s'\n", columnName, dataType)
+			stats = &diff.UnknownStatistics{}
+		}
+
+		col.Stats = stats
+	}
+
+	return &diff.TableSummaryResult{
+		RowCount: rowCount,
+		Table:    table,
+	}, nil
+}
+
+// GetTableSchema returns the columns of the table from INFORMATION_SCHEMA, without running any query on the table
+// itself.
+func (d *Client) GetTableSchema(ctx context.Context, tableName string) (*diff.Table, error) {
 	// Get table schema using INFORMATION_SCHEMA
 	tableComponents := strings.Split(tableName, ".")
 	var schemaQuery string
@@ -561,66 +619,19 @@ func (d *Client) GetTableSummary(ctx context.Context, tableName string) (*diff.T
 
 		isPartitioning, _ := row[3].(string)
 
-		nullable := strings.ToLower(isNullableStr) == "yes"
-		normalizedType := d.typeMapper.MapType(dataType)
-
-		// Debug: log type mapping for troubleshooting
-		if normalizedType == diff.CommonTypeUnknown {
-			fmt.Printf("Warning: Unknown type mapping for BigQuery type '%s' in column '%s'\n", dataType, columnName)
-		}
-
-		// Collect statistics for this column
-		var stats diff.ColumnStatistics
-		switch normalizedType {
-		case diff.CommonTypeNumeric:
-			stats, err = d.fetchNumericalStats(ctx, tableName, columnName)
-			if err != nil {
-				return nil, fmt.Errorf("failed to fetch numerical stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
-			}
-		case diff.CommonTypeString:
-			stats, err = d.fetchStringStats(ctx, tableName, columnName)
-			if err != nil {
-				return nil, fmt.Errorf("failed to fetch string stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
-			}
-		case diff.CommonTypeBoolean:
-			stats, err = d.fetchBooleanStats(ctx, tableName, columnName)
-			if err != nil {
-				return nil, fmt.Errorf("failed to fetch boolean stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
-			}
-		case diff.CommonTypeDateTime:
-			stats, err = d.fetchDateTimeStats(ctx, tableName, columnName)
-			if err != nil {
-				return nil, fmt.Errorf("failed to fetch datetime stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
-			}
-		case diff.CommonTypeJSON:
-			stats, err = d.fetchJSONStats(ctx, tableName, columnName)
-			if err != nil {
-				return nil, fmt.Errorf("failed to fetch JSON stats for column '%s' (BigQuery type: %s, normalized: %s): %w", columnName, dataType, normalizedType, err)
-			}
-		case diff.CommonTypeBinary, diff.CommonTypeUnknown:
-			fmt.Printf("Warning: Using unknown statistics for column '%s' with BigQuery type '%s'\n", columnName, dataType)
-			stats = &diff.UnknownStatistics{}
-		}
-
 		columns = append(columns, &diff.Column{
 			Name:           columnName,
 			Type:           dataType,
-			NormalizedType: normalizedType,
-			Nullable:       nullable,
+			NormalizedType: d.typeMapper.MapType(dataType),
+			Nullable:       strings.ToLower(isNullableStr) == "yes",
 			PrimaryKey:     false,                   // BigQuery doesn't have traditional primary keys
 			Unique:         isPartitioning == "YES", // Use partitioning as a proxy for uniqueness
-			Stats:          stats,
 		})
 	}
 
-	dbTable := &diff.Table{
+	return &diff.Table{
 		Name:    tableName,
 		Columns: columns,
-	}
-
-	return &diff.TableSummaryResult{
-		RowCount: rowCount,
-		Table:    dbTable,
 	}, nil
 }
 
@@ -23,6 +23,7 @@ var matMap = pipeline.AssetMaterializationMap{
 		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
 		pipeline.MaterializationStrategyMerge:         mergeMaterializer,
 		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
 		pipeline.MaterializationStrategyDDL:           BuildDDLQuery,
 	},
 }
@@ -145,7 +146,27 @@ func buildCreateReplaceQuery(asset *pipeline.Asset, query string) (string, error
 		clusterByClause = "CLUSTER BY " + strings.Join(mat.ClusterBy, ", ")
 	}
 
-	return fmt.Sprintf("CREATE OR REPLACE TABLE %s %s %s AS\n%s", asset.Name, partitionClause, clusterByClause, query), nil
+	tableElements := ""
+	if asset.HasColumnConstraints() {
+		columnDefs, err := buildTableElements(asset)
+		if err != nil {
+			return "", err
+		}
+		tableElements = fmt.Sprintf("(\n  %s\n) ", strings.Join(columnDefs, ",\n  "))
+	}
+
+	createQuery := fmt.Sprintf("CREATE OR REPLACE TABLE %s %s%s %s AS\n%s", asset.Name, tableElements, partitionClause, clusterByClause, query)
+
+	// the primary key is part of the table elements above if there are any, otherwise it is added to the created table
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if tableElements != "" || len(primaryKeys) == 0 {
+		return createQuery, nil
+	}
+
+	return fmt.Sprintf(
+		"%s;\nALTER TABLE %s ADD PRIMARY KEY (%s) NOT ENFORCED;",
+		strings.TrimSuffix(strings.TrimSpace(createQuery), ";"), asset.Name, strings.Join(primaryKeys, ", "),
+	), nil
 }
 
 func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error) {
@@ -184,18 +205,54 @@ func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error)
 }
 
 func BuildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
+	columnDefs, err := buildTableElements(asset)
+	if err != nil {
+		return "", err
+	}
+
+	q := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n  %s\n)",
+		asset.Name,
+		strings.Join(columnDefs, ",\n  "),
+	)
+
+	if asset.Materialization.PartitionBy != "" {
+		q += "\nPARTITION BY " + asset.Materialization.PartitionBy
+	}
+	if len(asset.Materialization.ClusterBy) > 0 {
+		q += "\nCLUSTER BY " + strings.Join(asset.Materialization.ClusterBy, ", ")
+	}
+
+	return q, nil
+}
+
+// buildTableElements returns the column definitions of the asset together with the table constraints. BigQuery does not
+// enforce primary and foreign keys, they are only used as hints by the query optimizer.
+func buildTableElements(asset *pipeline.Asset) ([]string, error) {
 	columnDefs := make([]string, 0, len(asset.Columns))
 	primaryKeys := []string{}
+	foreignKeys := []string{}
 
 	for _, col := range asset.Columns {
-		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if col.CheckConstraint != "" {
+			return nil, fmt.Errorf("check constraints are not supported in BigQuery, column '%s' has one", col.Name)
+		}
 
+		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if !col.Nullable.Bool() {
+			def += " NOT NULL"
+		}
+		if col.Default != "" {
+			def += " DEFAULT " + col.Default
+		}
 		if col.Description != "" {
 			def += fmt.Sprintf(` OPTIONS(description=%q)`, col.Description)
 		}
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, col.Name)
 		}
+		if col.ForeignKey != nil {
+			foreignKeys = append(foreignKeys, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(%s) NOT ENFORCED", col.Name, col.ForeignKey.Table, col.ForeignKey.Column))
+		}
 		columnDefs = append(columnDefs, def)
 	}
 
@@ -204,17 +261,5 @@ func BuildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 		columnDefs = append(columnDefs, primaryKeyClause)
 	}
 
-	q := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n  %s\n)",
-		asset.Name,
-		strings.Join(columnDefs, ",\n  "),
-	)
-
-	if asset.Materialization.PartitionBy != "" {
-		q += "\nPARTITION BY " + asset.Materialization.PartitionBy
-	}
-	if len(asset.Materialization.ClusterBy) > 0 {
-		q += "\nCLUSTER BY " + strings.Join(asset.Materialization.ClusterBy, ", ")
-	}
-
-	return q, nil
+	return append(columnDefs, foreignKeys...), nil
 }
@@ -100,6 +100,21 @@ func TestMaterializer_Render(t *testing.T) {
 			query: "SELECT 1",
 			want:  "CREATE OR REPLACE TABLE my.asset PARTITION BY dt CLUSTER BY event_type, event_name AS\nSELECT 1",
 		},
+		{
+			name: "materialize to a table, the primary key is added after creating the table",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyCreateReplace,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+				},
+			},
+			query: "SELECT 1 as id",
+			want:  "CREATE OR REPLACE TABLE my.asset   AS\nSELECT 1 as id;\nALTER TABLE my.asset ADD PRIMARY KEY \\(id\\) NOT ENFORCED;",
+		},
 		{
 			name: "materialize to a table with append",
 			task: &pipeline.Asset{
@@ -374,6 +389,7 @@ func TestMaterializer_Render(t *testing.T) {
 
 func TestBuildDDLQuery(t *testing.T) {
 	t.Parallel()
+	falseValue := false
 	tests := []struct {
 		name    string
 		asset   *pipeline.Asset
@@ -470,6 +486,31 @@ func TestBuildDDLQuery(t *testing.T) {
 			},
 			want: "CREATE TABLE IF NOT EXISTS my_table_with_multiple_pks (\n  id INT64,\n  category STRING,\n  name STRING OPTIONS(description=\"The name of the person\"),\n  PRIMARY KEY (id, category) NOT ENFORCED\n)",
 		},
+		{
+			name: "table with constraints and defaults",
+			asset: &pipeline.Asset{
+				Name: "my.orders",
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &falseValue}},
+					{Name: "status", Type: "STRING", Default: "'new'"},
+					{Name: "user_id", Type: "INT64", ForeignKey: &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}},
+				},
+				Materialization: pipeline.Materialization{
+					Type: pipeline.MaterializationTypeTable,
+				},
+			},
+			want: "CREATE TABLE IF NOT EXISTS my.orders (\n  id INT64 NOT NULL,\n  status STRING DEFAULT 'new',\n  user_id INT64,\n  PRIMARY KEY (id) NOT ENFORCED,\n  FOREIGN KEY (user_id) REFERENCES my.users(id) NOT ENFORCED\n)",
+		},
+		{
+			name: "check constraints are not supported",
+			asset: &pipeline.Asset{
+				Name: "my.orders",
+				Columns: []pipeline.Column{
+					{Name: "amount", Type: "FLOAT64", CheckConstraint: "amount > 0"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -67,7 +67,7 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 		return err
 	}
 	q.Query = materialized
-	if t.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval {
+	if t.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval || t.Materialization.Strategy == pipeline.MaterializationStrategyMicrobatch {
 		renderedQueries, err := extractor.ExtractQueriesFromString(materialized)
 		if err != nil {
 			return errors.Wrap(err, "cannot re-extract/render materialized query for time_interval strategy")
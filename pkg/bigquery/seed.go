@@ -0,0 +1,72 @@
+package bigquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/pkg/errors"
+)
+
+// seedFieldTypes maps the seed types to the column types dlt would create in BigQuery.
+var seedFieldTypes = map[string]bigquery.FieldType{
+	seed.TypeText:      bigquery.StringFieldType,
+	seed.TypeBigint:    bigquery.IntegerFieldType,
+	seed.TypeDouble:    bigquery.FloatFieldType,
+	seed.TypeBinary:    bigquery.BytesFieldType,
+	seed.TypeBool:      bigquery.BooleanFieldType,
+	seed.TypeDate:      bigquery.DateFieldType,
+	seed.TypeTimestamp: bigquery.TimestampFieldType,
+	seed.TypeTime:      bigquery.TimeFieldType,
+}
+
+func seedSchema(data *seed.Data) bigquery.Schema {
+	schema := make(bigquery.Schema, len(data.Columns))
+	for i, col := range data.Columns {
+		schema[i] = &bigquery.FieldSchema{Name: col.Name, Type: seedFieldTypes[col.Type]}
+	}
+
+	return schema
+}
+
+// LoadSeed replaces the table with the seed data through a load job, the values are re-encoded in the formats
+// BigQuery expects, binaries are base64 encoded.
+func (d *Client) LoadSeed(ctx context.Context, asset *pipeline.Asset, data *seed.Data) error {
+	table, err := d.getTableRef(asset.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := d.CreateDataSetIfNotExist(asset, ctx); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := data.WriteCSV(&buf, base64.StdEncoding.EncodeToString); err != nil {
+		return errors.Wrap(err, "failed to encode the seed data")
+	}
+
+	source := bigquery.NewReaderSource(&buf)
+	source.SourceFormat = bigquery.CSV
+	source.SkipLeadingRows = 1
+	source.Schema = seedSchema(data)
+
+	loader := table.LoaderFrom(source)
+	loader.CreateDisposition = bigquery.CreateIfNeeded
+	loader.WriteDisposition = bigquery.WriteTruncate
+
+	job, err := loader.Run(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return formatError(err)
+	}
+
+	return formatError(status.Err())
+}
@@ -0,0 +1,28 @@
+package bigquery
+
+import (
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeedSchema(t *testing.T) {
+	t.Parallel()
+
+	data := &seed.Data{
+		Columns: []seed.Column{
+			{Name: "id", Type: seed.TypeBigint},
+			{Name: "payload", Type: seed.TypeBinary},
+			{Name: "name", Type: seed.TypeText},
+		},
+	}
+
+	expected := bigquery.Schema{
+		{Name: "id", Type: bigquery.IntegerFieldType},
+		{Name: "payload", Type: bigquery.BytesFieldType},
+		{Name: "name", Type: bigquery.StringFieldType},
+	}
+	assert.Equal(t, expected, seedSchema(data))
+}
@@ -63,7 +63,7 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 		return err
 	}
 
-	if t.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval {
+	if t.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval || t.Materialization.Strategy == pipeline.MaterializationStrategyMicrobatch {
 		materializedQueries, err = extractor.ReextractQueriesFromSlice(materializedQueries)
 		if err != nil {
 			return err
@@ -785,3 +785,18 @@ type AttioConnection struct {
 func (c AttioConnection) GetName() string {
 	return c.Name
 }
+
+type TrinoConnection struct {
+	Name        string `yaml:"name,omitempty" json:"name" mapstructure:"name"`
+	Username    string `yaml:"username,omitempty" json:"username" mapstructure:"username"`
+	Password    string `yaml:"password,omitempty" json:"password,omitempty" mapstructure:"password"`
+	Host        string `yaml:"host,omitempty" json:"host" mapstructure:"host"`
+	Port        int    `yaml:"port,omitempty" json:"port" mapstructure:"port" jsonschema:"default=8080"`
+	Catalog     string `yaml:"catalog,omitempty" json:"catalog" mapstructure:"catalog"`
+	Schema      string `yaml:"schema,omitempty" json:"schema,omitempty" mapstructure:"schema"`
+	TableFormat string `yaml:"table_format,omitempty" json:"table_format,omitempty" mapstructure:"table_format" jsonschema:"enum=iceberg,enum=hive,default=iceberg"`
+}
+
+func (c TrinoConnection) GetName() string {
+	return c.Name
+}
@@ -78,6 +78,7 @@ type Connections struct {
 	Spanner             []SpannerConnection             `yaml:"spanner,omitempty" json:"spanner,omitempty" mapstructure:"spanner"`
 	Smartsheet          []SmartsheetConnection          `yaml:"smartsheet,omitempty" json:"smartsheet,omitempty" mapstructure:"smartsheet"`
 	Attio               []AttioConnection               `yaml:"attio,omitempty" json:"attio,omitempty" mapstructure:"attio"`
+	Trino               []TrinoConnection               `yaml:"trino,omitempty" json:"trino,omitempty" mapstructure:"trino"`
 	byKey               map[string]any
 	typeNameMap         map[string]string
 }
@@ -175,10 +176,11 @@ type Config struct {
 	fs   afero.Fs
 	path string
 
-	DefaultEnvironmentName  string                 `yaml:"default_environment" json:"default_environment_name" mapstructure:"default_environment_name"`
-	SelectedEnvironmentName string                 `yaml:"-" json:"selected_environment_name" mapstructure:"selected_environment_name"`
-	SelectedEnvironment     *Environment           `yaml:"-" json:"selected_environment" mapstructure:"selected_environment"`
-	Environments            map[string]Environment `yaml:"environments" json:"environments" mapstructure:"environments"`
+	DefaultEnvironmentName  string                  `yaml:"default_environment" json:"default_environment_name" mapstructure:"default_environment_name"`
+	SelectedEnvironmentName string                  `yaml:"-" json:"selected_environment_name" mapstructure:"selected_environment_name"`
+	SelectedEnvironment     *Environment            `yaml:"-" json:"selected_environment" mapstructure:"selected_environment"`
+	Environments            map[string]Environment  `yaml:"environments" json:"environments" mapstructure:"environments"`
+	Format                  *pipeline.FormatOptions `yaml:"format,omitempty" json:"format,omitempty" mapstructure:"format,omitempty"`
 }
 
 func (c *Config) CanRunTaskInstances(p *pipeline.Pipeline, tasks []scheduler.TaskInstance) error {
@@ -214,6 +216,16 @@ func (c *Config) GetSecretByKey(key string) (string, error) {
 	return c.SelectedEnvironment.GetSecretByKey(key)
 }
 
+// GetConnectionType returns the type of the connection with the given name in the selected environment, e.g.
+// `postgres`, or an empty string if there's no such connection.
+func (c *Config) GetConnectionType(name string) string {
+	if c.SelectedEnvironment == nil || c.SelectedEnvironment.Connections == nil {
+		return ""
+	}
+
+	return c.SelectedEnvironment.Connections.ConnectionsSummaryList()[name]
+}
+
 func (c *Config) Persist() error {
 	return c.PersistToFs(c.fs)
 }
@@ -815,6 +827,13 @@ func (c *Config) AddConnection(environmentName, name, connType string, creds map
 		}
 		conn.Name = name
 		env.Connections.Attio = append(env.Connections.Attio, conn)
+	case "trino":
+		var conn TrinoConnection
+		if err := mapstructure.Decode(creds, &conn); err != nil {
+			return fmt.Errorf("failed to decode credentials: %w", err)
+		}
+		conn.Name = name
+		env.Connections.Trino = append(env.Connections.Trino, conn)
 	default:
 		return fmt.Errorf("unsupported connection type: %s", connType)
 	}
@@ -960,6 +979,8 @@ func (c *Config) DeleteConnection(environmentName, connectionName string) error
 		env.Connections.Smartsheet = removeConnection(env.Connections.Smartsheet, connectionName)
 	case "attio":
 		env.Connections.Attio = removeConnection(env.Connections.Attio, connectionName)
+	case "trino":
+		env.Connections.Trino = removeConnection(env.Connections.Trino, connectionName)
 	default:
 		return fmt.Errorf("unsupported connection type: %s", connType)
 	}
@@ -1069,6 +1090,7 @@ func (c *Connections) MergeFrom(source *Connections) error {
 	mergeConnectionList(&c.Smartsheet, source.Smartsheet)
 
 	mergeConnectionList(&c.Attio, source.Attio)
+	mergeConnectionList(&c.Trino, source.Trino)
 	c.buildConnectionKeyMap()
 	return nil
 }
@@ -502,6 +502,18 @@ func TestLoadFromFile(t *testing.T) {
 					APIKey: "api-key-123",
 				},
 			},
+			Trino: []TrinoConnection{
+				{
+					Name:        "trino-1",
+					Username:    "trino-user",
+					Password:    "trino-password",
+					Host:        "trino.example.com",
+					Port:        443,
+					Catalog:     "lakehouse",
+					Schema:      "analytics",
+					TableFormat: "hive",
+				},
+			},
 		},
 	}
 
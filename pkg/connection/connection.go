@@ -61,6 +61,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/sqlite"
 	"github.com/bruin-data/bruin/pkg/stripe"
 	"github.com/bruin-data/bruin/pkg/tiktokads"
+	"github.com/bruin-data/bruin/pkg/trino"
 	"github.com/bruin-data/bruin/pkg/zendesk"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/conc"
@@ -121,6 +122,7 @@ type Manager struct {
 	Solidgate       map[string]*solidgate.Client
 	Smartsheet      map[string]*smartsheet.Client
 	Attio           map[string]*attio.Client
+	Trino           map[string]*trino.DB
 	mutex           sync.Mutex
 }
 
@@ -443,6 +445,12 @@ func (m *Manager) GetConnection(name string) (interface{}, error) {
 	}
 	availableConnectionNames = append(availableConnectionNames, maps.Keys(m.Attio)...)
 
+	connTrino, err := m.GetTrinoConnectionWithoutDefault(name)
+	if err == nil {
+		return connTrino, nil
+	}
+	availableConnectionNames = append(availableConnectionNames, maps.Keys(m.Trino)...)
+
 	return nil, errors.Errorf("connection '%s' not found, available connection names are: %v", name, availableConnectionNames)
 }
 
@@ -637,7 +645,7 @@ func (m *Manager) GetMongoConnectionWithoutDefault(name string) (*mongo.DB, erro
 	return db, nil
 }
 
-func (m *Manager) GetMySQLConnection(name string) (*mysql.Client, error) {
+func (m *Manager) GetMySQLConnection(name string) (mysql.DB, error) {
 	db, err := m.GetMySQLConnectionWithoutDefault(name)
 	if err == nil {
 		return db, nil
@@ -646,7 +654,7 @@ func (m *Manager) GetMySQLConnection(name string) (*mysql.Client, error) {
 	return m.GetMySQLConnectionWithoutDefault("mysql-default")
 }
 
-func (m *Manager) GetMySQLConnectionWithoutDefault(name string) (*mysql.Client, error) {
+func (m *Manager) GetMySQLConnectionWithoutDefault(name string) (mysql.DB, error) {
 	if m.Mysql == nil {
 		return nil, errors.New("no mysql connections found")
 	}
@@ -747,7 +755,29 @@ func (m *Manager) GetKlaviyoConnectionWithoutDefault(name string) (*klaviyo.Clie
 	return db, nil
 }
 
-func (m *Manager) GetSpannerConnection(name string) (*spanner.Client, error) {
+func (m *Manager) GetTrinoConnection(name string) (trino.Client, error) {
+	db, err := m.GetTrinoConnectionWithoutDefault(name)
+	if err == nil {
+		return db, nil
+	}
+
+	return m.GetTrinoConnectionWithoutDefault("trino-default")
+}
+
+func (m *Manager) GetTrinoConnectionWithoutDefault(name string) (trino.Client, error) {
+	if m.Trino == nil {
+		return nil, errors.New("no Trino connections found")
+	}
+
+	db, ok := m.Trino[name]
+	if !ok {
+		return nil, errors.Errorf("Trino connection not found for '%s'", name)
+	}
+
+	return db, nil
+}
+
+func (m *Manager) GetSpannerConnection(name string) (spanner.DB, error) {
 	db, err := m.GetSpannerConnectionWithoutDefault(name)
 	if err == nil {
 		return db, nil
@@ -756,7 +786,7 @@ func (m *Manager) GetSpannerConnection(name string) (*spanner.Client, error) {
 	return m.GetSpannerConnectionWithoutDefault("spanner-default")
 }
 
-func (m *Manager) GetSpannerConnectionWithoutDefault(name string) (*spanner.Client, error) {
+func (m *Manager) GetSpannerConnectionWithoutDefault(name string) (spanner.DB, error) {
 	if m.Spanner == nil {
 		return nil, errors.New("no spanner connections found")
 	}
@@ -1430,7 +1460,7 @@ func (m *Manager) GetSalesforceConnectionWithoutDefault(name string) (*salesforc
 	return db, nil
 }
 
-func (m *Manager) GetSQLiteConnection(name string) (*sqlite.Client, error) {
+func (m *Manager) GetSQLiteConnection(name string) (sqlite.DB, error) {
 	db, err := m.GetSQLiteConnectionWithoutDefault(name)
 	if err == nil {
 		return db, nil
@@ -1438,7 +1468,7 @@ func (m *Manager) GetSQLiteConnection(name string) (*sqlite.Client, error) {
 	return m.GetSQLiteConnectionWithoutDefault("sqlite-default")
 }
 
-func (m *Manager) GetSQLiteConnectionWithoutDefault(name string) (*sqlite.Client, error) {
+func (m *Manager) GetSQLiteConnectionWithoutDefault(name string) (sqlite.DB, error) {
 	if m.SQLite == nil {
 		return nil, errors.New("no sqlite connections found")
 	}
@@ -1449,7 +1479,7 @@ func (m *Manager) GetSQLiteConnectionWithoutDefault(name string) (*sqlite.Client
 	return db, nil
 }
 
-func (m *Manager) GetOracleConnection(name string) (*oracle.Client, error) {
+func (m *Manager) GetOracleConnection(name string) (oracle.DB, error) {
 	db, err := m.GetOracleConnectionWithoutDefault(name)
 	if err == nil {
 		return db, nil
@@ -1457,7 +1487,7 @@ func (m *Manager) GetOracleConnection(name string) (*oracle.Client, error) {
 	return m.GetOracleConnectionWithoutDefault("oracle-default")
 }
 
-func (m *Manager) GetOracleConnectionWithoutDefault(name string) (*oracle.Client, error) {
+func (m *Manager) GetOracleConnectionWithoutDefault(name string) (oracle.DB, error) {
 	if m.Oracle == nil {
 		return nil, errors.New("no oracle connections found")
 	}
@@ -2758,6 +2788,26 @@ func (m *Manager) AddAttioConnectionFromConfig(connection *config.AttioConnectio
 	return nil
 }
 
+func (m *Manager) AddTrinoConnectionFromConfig(connection *config.TrinoConnection) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.Trino == nil {
+		m.Trino = make(map[string]*trino.DB)
+	}
+
+	m.Trino[connection.Name] = trino.NewDB(&trino.Config{
+		Username:    connection.Username,
+		Password:    connection.Password,
+		Host:        connection.Host,
+		Port:        connection.Port,
+		Catalog:     connection.Catalog,
+		Schema:      connection.Schema,
+		TableFormat: connection.TableFormat,
+	})
+
+	return nil
+}
+
 func (m *Manager) AddElasticsearchConnectionFromConfig(connection *config.ElasticsearchConnection) error {
 	m.mutex.Lock()
 	if m.Elasticsearch == nil {
@@ -2926,6 +2976,7 @@ func NewManagerFromConfig(cm *config.Config) (*Manager, []error) {
 	processConnections(cm.SelectedEnvironment.Connections.Solidgate, connectionManager.AddSolidgateConnectionFromConfig, &wg, &errList, &mu)
 	processConnections(cm.SelectedEnvironment.Connections.Smartsheet, connectionManager.AddSmartsheetConnectionFromConfig, &wg, &errList, &mu)
 	processConnections(cm.SelectedEnvironment.Connections.Attio, connectionManager.AddAttioConnectionFromConfig, &wg, &errList, &mu)
+	processConnections(cm.SelectedEnvironment.Connections.Trino, connectionManager.AddTrinoConnectionFromConfig, &wg, &errList, &mu)
 	wg.Wait()
 	return connectionManager, errList
 }
@@ -0,0 +1,353 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/date"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/afero"
+)
+
+const (
+	logDateFormat = "2006-01-02 15:04:05"
+
+	defaultRetryDelay = 5 * time.Minute
+	maxRetryDelay     = time.Hour
+)
+
+// Job is a scheduled run of a pipeline for a single interval.
+type Job struct {
+	Pipeline string
+	Path     string
+	Interval Interval
+	RunID    string
+}
+
+type Runner interface {
+	Run(ctx context.Context, job *Job) error
+}
+
+type pipelineFinder func(root string, pipelineDefinitionFile []string) ([]string, error)
+
+type Options struct {
+	// StateDir is the folder the state of the scheduled pipelines is persisted in.
+	StateDir string
+	// MaxConcurrentRuns is the maximum number of pipelines that run at the same time.
+	MaxConcurrentRuns int
+	// PollInterval is how often the pipelines are checked for due intervals.
+	PollInterval time.Duration
+	// RetryDelay is how long a failed interval waits before it is run again, the delay doubles with every consecutive
+	// failure up to an hour.
+	RetryDelay time.Duration
+	// PipelineDefinitionFiles are the file names the pipelines are discovered with.
+	PipelineDefinitionFiles []string
+}
+
+// scheduledPipeline is a pipeline that has a cron schedule.
+type scheduledPipeline struct {
+	name      string
+	path      string
+	schedule  cron.Schedule
+	startDate time.Time
+	catchup   bool
+}
+
+// Daemon runs the pipelines of a repository on their schedules. Each pipeline runs a single interval at a time, in
+// order, while the different pipelines run concurrently up to the configured limit.
+type Daemon struct {
+	fs            afero.Fs
+	root          string
+	runner        Runner
+	findPipelines pipelineFinder
+	state         *stateStore
+	logger        *log.Logger
+	opts          Options
+	now           func() time.Time
+
+	mu       sync.Mutex
+	running  map[string]bool
+	warned   map[string]string
+	wg       sync.WaitGroup
+	finished chan struct{}
+}
+
+func New(fs afero.Fs, root string, runner Runner, findPipelines pipelineFinder, output io.Writer, opts Options) *Daemon {
+	if opts.MaxConcurrentRuns < 1 {
+		opts.MaxConcurrentRuns = 1
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Minute
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultRetryDelay
+	}
+
+	return &Daemon{
+		fs:            fs,
+		root:          root,
+		runner:        runner,
+		findPipelines: findPipelines,
+		state:         &stateStore{fs: fs, dir: opts.StateDir},
+		logger:        log.New(output, "", log.LstdFlags|log.LUTC),
+		opts:          opts,
+		now:           func() time.Time { return time.Now().UTC() },
+		running:       map[string]bool{},
+		warned:        map[string]string{},
+		finished:      make(chan struct{}, 1),
+	}
+}
+
+// Run schedules the pipelines until the context is cancelled, then waits for the active runs to stop.
+func (d *Daemon) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	d.logger.Printf("scheduling the pipelines in '%s', checking for due runs every %s", d.root, d.opts.PollInterval)
+	for {
+		if err := d.schedule(ctx); err != nil {
+			d.logger.Printf("failed to schedule the pipelines: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Printf("shutting down, waiting for %d active runs to stop", d.activeRuns())
+			d.wg.Wait()
+			return nil
+		case <-ticker.C:
+		case <-d.finished:
+			// a finished run frees a slot and may have more intervals to catch up on
+		}
+	}
+}
+
+func (d *Daemon) activeRuns() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.running)
+}
+
+// schedule starts the runs of the pipelines that have a due interval, the pipelines are discovered on every call so
+// that the changes in the repository are picked up without a restart.
+func (d *Daemon) schedule(ctx context.Context) error {
+	pipelines, err := d.discover()
+	if err != nil {
+		return err
+	}
+
+	now := d.now()
+	for _, p := range pipelines {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		d.mu.Lock()
+		busy := d.running[p.name]
+		full := len(d.running) >= d.opts.MaxConcurrentRuns
+		d.mu.Unlock()
+		if busy {
+			continue
+		}
+		if full {
+			return nil
+		}
+
+		state, err := d.state.read(p.name)
+		if err != nil {
+			d.logger.Printf("%v", err)
+			continue
+		}
+
+		var lastEnd time.Time
+		if state != nil {
+			if state.LastStatus == StatusFailed && now.Before(state.RetryAt) {
+				continue
+			}
+			lastEnd = state.LastInterval.End
+		}
+
+		interval, due := nextInterval(p.schedule, p.startDate, lastEnd, now, p.catchup)
+		if !due {
+			continue
+		}
+
+		d.start(ctx, &Job{
+			Pipeline: p.name,
+			Path:     p.path,
+			Interval: interval,
+			RunID:    "scheduled_" + interval.Start.Format("2006_01_02_15_04_05"),
+		}, state)
+	}
+
+	return nil
+}
+
+// start runs the interval in the background, previous is the state of the pipeline before the run, if there's one.
+func (d *Daemon) start(ctx context.Context, job *Job, previous *PipelineState) {
+	d.mu.Lock()
+	d.running[job.Pipeline] = true
+	d.mu.Unlock()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer func() {
+			d.mu.Lock()
+			delete(d.running, job.Pipeline)
+			d.mu.Unlock()
+
+			select {
+			case d.finished <- struct{}{}:
+			default:
+			}
+		}()
+
+		d.logger.Printf("starting '%s' for %s - %s", job.Pipeline, job.Interval.Start.Format(logDateFormat), job.Interval.End.Format(logDateFormat))
+		err := d.runner.Run(ctx, job)
+		if ctx.Err() != nil {
+			// the interval is not recorded, it is run again once the daemon is restarted
+			d.logger.Printf("the run of '%s' was interrupted", job.Pipeline)
+			return
+		}
+
+		state := &PipelineState{
+			Pipeline:     job.Pipeline,
+			Path:         job.Path,
+			LastInterval: job.Interval,
+			LastRunID:    job.RunID,
+			LastStatus:   StatusSucceeded,
+			FinishedAt:   d.now(),
+		}
+		if err != nil {
+			// the last interval stays at the last successful one, so that the failed interval is run again after a delay
+			state.LastInterval = Interval{}
+			state.FailedAttempts = 1
+			if previous != nil {
+				state.LastInterval = previous.LastInterval
+				if previous.LastStatus == StatusFailed {
+					state.FailedAttempts = previous.FailedAttempts + 1
+				}
+			}
+			state.LastStatus = StatusFailed
+			state.LastError = err.Error()
+			state.RetryAt = state.FinishedAt.Add(d.retryDelay(state.FailedAttempts))
+			d.logger.Printf("'%s' failed for %s - %s, retrying at %s: %v", job.Pipeline, job.Interval.Start.Format(logDateFormat), job.Interval.End.Format(logDateFormat), state.RetryAt.Format(logDateFormat), err)
+		} else {
+			d.logger.Printf("'%s' succeeded for %s - %s", job.Pipeline, job.Interval.Start.Format(logDateFormat), job.Interval.End.Format(logDateFormat))
+		}
+
+		if err := d.state.write(state); err != nil {
+			d.logger.Printf("%v", err)
+		}
+	}()
+}
+
+// retryDelay returns how long to wait before running a failed interval again, doubling with every failed attempt.
+func (d *Daemon) retryDelay(failedAttempts int) time.Duration {
+	delay := d.opts.RetryDelay
+	for range failedAttempts - 1 {
+		if delay >= maxRetryDelay {
+			break
+		}
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+// discover finds the pipelines in the repository that have a cron schedule, sorted by name.
+func (d *Daemon) discover() ([]*scheduledPipeline, error) {
+	paths, err := d.findPipelines(d.root, d.opts.PipelineDefinitionFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]string{}
+	pipelines := make([]*scheduledPipeline, 0, len(paths))
+	for _, pipelinePath := range paths {
+		p, err := d.readPipeline(pipelinePath)
+		if err != nil {
+			d.warnOnce(pipelinePath, fmt.Sprintf("skipping the pipeline in '%s': %v", pipelinePath, err))
+			continue
+		}
+		if p == nil {
+			continue
+		}
+
+		if other, ok := seen[p.name]; ok {
+			d.warnOnce(pipelinePath, fmt.Sprintf("skipping the pipeline in '%s': the name '%s' is already used by '%s'", pipelinePath, p.name, other))
+			continue
+		}
+		delete(d.warned, pipelinePath)
+		seen[p.name] = pipelinePath
+		pipelines = append(pipelines, p)
+	}
+
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].name < pipelines[j].name
+	})
+
+	return pipelines, nil
+}
+
+// warnOnce logs the problems of the pipelines once, rather than on every poll until they are fixed.
+func (d *Daemon) warnOnce(pipelinePath, message string) {
+	if d.warned[pipelinePath] == message {
+		return
+	}
+
+	d.warned[pipelinePath] = message
+	d.logger.Print(message)
+}
+
+// readPipeline reads the schedule of the pipeline, it returns nil if the pipeline is not scheduled.
+func (d *Daemon) readPipeline(pipelinePath string) (*scheduledPipeline, error) {
+	var definition *pipeline.Pipeline
+	var err error
+	for _, file := range d.opts.PipelineDefinitionFiles {
+		definitionPath := filepath.Join(pipelinePath, file)
+		if exists, _ := afero.Exists(d.fs, definitionPath); !exists {
+			continue
+		}
+
+		definition, err = pipeline.PipelineFromPath(definitionPath, d.fs)
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	if definition == nil {
+		return nil, fmt.Errorf("no pipeline definition found")
+	}
+
+	schedule, err := definition.Schedule.CronSchedule()
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule '%s': %w", definition.Schedule, err)
+	}
+	if schedule == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	p := &scheduledPipeline{
+		name:     definition.Name,
+		path:     pipelinePath,
+		schedule: schedule,
+		catchup:  definition.Catchup,
+	}
+	if definition.StartDate != "" {
+		p.startDate, err = date.ParseTime(definition.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date '%s': %w", definition.StartDate, err)
+		}
+	}
+
+	return p, nil
+}
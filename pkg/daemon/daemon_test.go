@@ -0,0 +1,236 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingRunner struct {
+	mu      sync.Mutex
+	jobs    []Job
+	fail    map[string]bool
+	release chan struct{}
+}
+
+func (r *recordingRunner) Run(ctx context.Context, job *Job) error {
+	r.mu.Lock()
+	r.jobs = append(r.jobs, *job)
+	r.mu.Unlock()
+
+	if r.release != nil {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if r.fail[job.Pipeline] {
+		return errors.New("exit status 1")
+	}
+
+	return nil
+}
+
+func (r *recordingRunner) started() []Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Job{}, r.jobs...)
+}
+
+func newTestDaemon(t *testing.T, runner Runner, pipelines map[string]string, maxConcurrentRuns int) (*Daemon, afero.Fs) {
+	fs := afero.NewMemMapFs()
+	paths := make([]string, 0, len(pipelines))
+	for pipelinePath, definition := range pipelines {
+		require.NoError(t, afero.WriteFile(fs, pipelinePath+"/pipeline.yml", []byte(definition), 0o644))
+		paths = append(paths, pipelinePath)
+	}
+
+	finder := func(root string, files []string) ([]string, error) {
+		return paths, nil
+	}
+
+	d := New(fs, "/repo", runner, finder, &bytes.Buffer{}, Options{
+		StateDir:                "/repo/logs/schedule",
+		MaxConcurrentRuns:       maxConcurrentRuns,
+		PollInterval:            time.Hour,
+		PipelineDefinitionFiles: []string{"pipeline.yml"},
+	})
+	d.now = func() time.Time { return day(4).Add(time.Hour) }
+
+	return d, fs
+}
+
+func TestDaemon_schedule(t *testing.T) {
+	t.Parallel()
+
+	runner := &recordingRunner{fail: map[string]bool{"failing": true}}
+	d, fs := newTestDaemon(t, runner, map[string]string{
+		"/repo/daily":     "name: daily\nschedule: daily\nstart_date: \"2024-01-01\"\ncatchup: true\n",
+		"/repo/failing":   "name: failing\nschedule: \"0 0 * * *\"\n",
+		"/repo/manual":    "name: manual\n",
+		"/repo/invalid":   "name: invalid\nschedule: every day\n",
+		"/repo/streaming": "name: streaming\nschedule: continuous\n",
+	}, 4)
+
+	// the intervals of the catchup are run one by one
+	for range 5 {
+		require.NoError(t, d.schedule(context.Background()))
+		d.wg.Wait()
+	}
+
+	var daily []Interval
+	var failing []Interval
+	for _, job := range runner.started() {
+		switch job.Pipeline {
+		case "daily":
+			daily = append(daily, job.Interval)
+			assert.Equal(t, "/repo/daily", job.Path)
+		case "failing":
+			failing = append(failing, job.Interval)
+		default:
+			t.Errorf("unexpected run of '%s'", job.Pipeline)
+		}
+	}
+
+	assert.Equal(t, []Interval{
+		{Start: day(1), End: day(2)},
+		{Start: day(2), End: day(3)},
+		{Start: day(3), End: day(4)},
+	}, daily)
+	// the failed interval waits for the retry delay before it is run again
+	assert.Equal(t, []Interval{{Start: day(3), End: day(4)}}, failing)
+
+	store := &stateStore{fs: fs, dir: "/repo/logs/schedule"}
+	state, err := store.read("daily")
+	require.NoError(t, err)
+	assert.Equal(t, Interval{Start: day(3), End: day(4)}, state.LastInterval)
+	assert.Equal(t, StatusSucceeded, state.LastStatus)
+	assert.Equal(t, "scheduled_2024_01_03_00_00_00", state.LastRunID)
+
+	state, err = store.read("failing")
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, state.LastStatus)
+	assert.Equal(t, "exit status 1", state.LastError)
+	assert.Equal(t, Interval{}, state.LastInterval)
+	assert.Equal(t, 1, state.FailedAttempts)
+	assert.Equal(t, day(4).Add(time.Hour+5*time.Minute), state.RetryAt)
+
+	state, err = store.read("manual")
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestDaemon_schedule_RetriesFailedIntervals(t *testing.T) {
+	t.Parallel()
+
+	runner := &recordingRunner{fail: map[string]bool{"daily": true}}
+	d, fs := newTestDaemon(t, runner, map[string]string{
+		"/repo/daily": "name: daily\nschedule: daily\nstart_date: \"2024-01-02\"\ncatchup: true\n",
+	}, 1)
+
+	now := day(4).Add(time.Hour)
+	d.now = func() time.Time { return now }
+	run := func() {
+		require.NoError(t, d.schedule(context.Background()))
+		d.wg.Wait()
+	}
+
+	store := &stateStore{fs: fs, dir: "/repo/logs/schedule"}
+	run()
+	run()
+	assert.Len(t, runner.started(), 1, "the failed interval is not retried before the delay")
+
+	// the delay doubles with every failure
+	now = now.Add(5 * time.Minute)
+	run()
+	state, err := store.read("daily")
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.FailedAttempts)
+	assert.Equal(t, now.Add(10*time.Minute), state.RetryAt)
+
+	runner.mu.Lock()
+	runner.fail = nil
+	runner.mu.Unlock()
+
+	now = now.Add(10 * time.Minute)
+	run()
+	run()
+
+	intervals := make([]Interval, 0)
+	for _, job := range runner.started() {
+		intervals = append(intervals, job.Interval)
+	}
+	assert.Equal(t, []Interval{
+		{Start: day(2), End: day(3)},
+		{Start: day(2), End: day(3)},
+		{Start: day(2), End: day(3)},
+		{Start: day(3), End: day(4)},
+	}, intervals)
+
+	state, err = store.read("daily")
+	require.NoError(t, err)
+	assert.Equal(t, StatusSucceeded, state.LastStatus)
+	assert.Equal(t, Interval{Start: day(3), End: day(4)}, state.LastInterval)
+	assert.Zero(t, state.FailedAttempts)
+}
+
+func TestDaemon_retryDelay(t *testing.T) {
+	t.Parallel()
+
+	d := New(afero.NewMemMapFs(), "/repo", nil, nil, &bytes.Buffer{}, Options{RetryDelay: 10 * time.Minute})
+	assert.Equal(t, 10*time.Minute, d.retryDelay(1))
+	assert.Equal(t, 20*time.Minute, d.retryDelay(2))
+	assert.Equal(t, 40*time.Minute, d.retryDelay(3))
+	assert.Equal(t, time.Hour, d.retryDelay(4))
+	assert.Equal(t, time.Hour, d.retryDelay(100))
+}
+
+func TestDaemon_Run_ConcurrencyAndShutdown(t *testing.T) {
+	t.Parallel()
+
+	runner := &recordingRunner{release: make(chan struct{})}
+	d, fs := newTestDaemon(t, runner, map[string]string{
+		"/repo/a": "name: a\nschedule: daily\n",
+		"/repo/b": "name: b\nschedule: daily\n",
+		"/repo/c": "name: c\nschedule: daily\n",
+	}, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- d.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool { return len(runner.started()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, 2, d.activeRuns())
+
+	// a finished run lets the next pipeline start
+	runner.release <- struct{}{}
+	require.Eventually(t, func() bool { return len(runner.started()) == 3 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, 0, d.activeRuns())
+
+	// the interrupted runs are not recorded so that they run again after a restart
+	store := &stateStore{fs: fs, dir: "/repo/logs/schedule"}
+	recorded := 0
+	for _, name := range []string{"a", "b", "c"} {
+		state, err := store.read(name)
+		require.NoError(t, err)
+		if state != nil {
+			recorded++
+		}
+	}
+	assert.Equal(t, 1, recorded)
+}
@@ -0,0 +1,86 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Interval is the data interval a scheduled run processes, the run is due once the end of the interval has passed.
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// previousTickLookback are the windows searched for the previous tick of a schedule, in increasing order so that
+// frequent schedules are resolved quickly.
+var previousTickLookback = []time.Duration{
+	time.Hour,
+	24 * time.Hour,
+	8 * 24 * time.Hour,
+	32 * 24 * time.Hour,
+	367 * 24 * time.Hour,
+	5 * 367 * 24 * time.Hour,
+}
+
+// previousTick returns the latest tick of the schedule that is strictly before the given time, or the zero time if
+// there's none in the last five years.
+func previousTick(schedule cron.Schedule, t time.Time) time.Time {
+	for _, lookback := range previousTickLookback {
+		tick := schedule.Next(t.Add(-lookback))
+		if !tick.Before(t) {
+			continue
+		}
+
+		for {
+			next := schedule.Next(tick)
+			if !next.Before(t) {
+				return tick
+			}
+			tick = next
+		}
+	}
+
+	return time.Time{}
+}
+
+// nextInterval returns the next interval the pipeline needs to run for, and whether it is due at the given time.
+//
+// The intervals follow the ticks of the schedule: the first one starts at the start date of the pipeline, or at the
+// previous interval if the pipeline has neither a start date nor a previous run. When catchup is disabled the missed
+// intervals are skipped and only the latest complete one is run.
+func nextInterval(schedule cron.Schedule, startDate, lastEnd, now time.Time, catchup bool) (Interval, bool) {
+	var start time.Time
+	switch {
+	case !lastEnd.IsZero():
+		start = lastEnd
+	case !startDate.IsZero():
+		start = schedule.Next(startDate.Add(-time.Nanosecond))
+	default:
+		end := previousTick(schedule, now.Add(time.Nanosecond))
+		if end.IsZero() {
+			return Interval{}, false
+		}
+		start = previousTick(schedule, end)
+		if start.IsZero() {
+			return Interval{}, false
+		}
+	}
+
+	interval := Interval{Start: start, End: schedule.Next(start)}
+	if interval.End.IsZero() || interval.End.After(now) {
+		return interval, false
+	}
+
+	if !catchup {
+		for {
+			next := schedule.Next(interval.End)
+			if next.IsZero() || next.After(now) {
+				break
+			}
+			interval = Interval{Start: interval.End, End: next}
+		}
+	}
+
+	return interval, true
+}
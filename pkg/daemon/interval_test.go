@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, expression string) cron.Schedule {
+	schedule, err := cron.ParseStandard(expression)
+	require.NoError(t, err)
+
+	return schedule
+}
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPreviousTick(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, day(3), previousTick(mustParse(t, "@daily"), day(3).Add(time.Hour)))
+	assert.Equal(t, day(2), previousTick(mustParse(t, "@daily"), day(3)))
+	assert.Equal(t, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), previousTick(mustParse(t, "@monthly"), day(1)))
+	assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), previousTick(mustParse(t, "@yearly"), day(1)))
+	assert.Equal(t, day(3).Add(-5*time.Minute), previousTick(mustParse(t, "*/5 * * * *"), day(3)))
+}
+
+func TestNextInterval(t *testing.T) {
+	t.Parallel()
+
+	daily := mustParse(t, "@daily")
+
+	tests := []struct {
+		name      string
+		startDate time.Time
+		lastEnd   time.Time
+		now       time.Time
+		catchup   bool
+		want      Interval
+		wantDue   bool
+	}{
+		{
+			name:    "the latest complete interval is run without a start date or a previous run",
+			now:     day(5).Add(3 * time.Hour),
+			want:    Interval{Start: day(4), End: day(5)},
+			wantDue: true,
+		},
+		{
+			name:      "the first interval starts at the start date with catchup",
+			startDate: day(1),
+			now:       day(5).Add(3 * time.Hour),
+			catchup:   true,
+			want:      Interval{Start: day(1), End: day(2)},
+			wantDue:   true,
+		},
+		{
+			name:      "a start date in the middle of an interval is aligned to the next tick",
+			startDate: day(1).Add(time.Hour),
+			now:       day(5),
+			catchup:   true,
+			want:      Interval{Start: day(2), End: day(3)},
+			wantDue:   true,
+		},
+		{
+			name:      "the missed intervals are skipped without catchup",
+			startDate: day(1),
+			now:       day(5).Add(3 * time.Hour),
+			want:      Interval{Start: day(4), End: day(5)},
+			wantDue:   true,
+		},
+		{
+			name:    "the interval after the previous run is picked with catchup",
+			lastEnd: day(3),
+			now:     day(5).Add(3 * time.Hour),
+			catchup: true,
+			want:    Interval{Start: day(3), End: day(4)},
+			wantDue: true,
+		},
+		{
+			name:    "the interval is not due until it ends",
+			lastEnd: day(5),
+			now:     day(5).Add(3 * time.Hour),
+			want:    Interval{Start: day(5), End: day(6)},
+			wantDue: false,
+		},
+		{
+			name:      "the start date in the future is not due",
+			startDate: day(10),
+			now:       day(5),
+			catchup:   true,
+			want:      Interval{Start: day(10), End: day(11)},
+			wantDue:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, due := nextInterval(daily, tt.startDate, tt.lastEnd, tt.now, tt.catchup)
+			assert.Equal(t, tt.wantDue, due)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/runner"
+)
+
+const runDateFormat = "2006-01-02 15:04:05.000000"
+
+// CommandRunner runs the scheduled intervals with `bruin run`, see runner.NewCommand.
+type CommandRunner struct {
+	// Executable is the path to the bruin binary.
+	Executable string
+	// Args are the flags passed to every run, e.g. the environment.
+	Args []string
+	// Output receives the logs of the runs, prefixed with the name of the pipeline.
+	Output io.Writer
+	// GracePeriod is how long a run is given to stop after it is interrupted before it is killed.
+	GracePeriod time.Duration
+
+	mu sync.Mutex
+}
+
+func (r *CommandRunner) Run(ctx context.Context, job *Job) error {
+	args := []string{
+		"--start-date", job.Interval.Start.Format(runDateFormat),
+		// the end date of the runs is inclusive, the interval ends right before the next tick
+		"--end-date", job.Interval.End.Add(-time.Microsecond).Format(runDateFormat),
+	}
+	args = append(args, r.Args...)
+	args = append(args, job.Path)
+
+	cmd := runner.NewCommand(ctx, r.Executable, args, job.RunID, r.GracePeriod)
+	cmd.Dir = job.Path
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, pipe := range []io.Reader{stdout, stderr} {
+		wg.Add(1)
+		go func(pipe io.Reader) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(pipe)
+			scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				r.mu.Lock()
+				_, _ = fmt.Fprintf(r.Output, "%s | %s\n", job.Pipeline, scanner.Text())
+				r.mu.Unlock()
+			}
+		}(pipe)
+	}
+	wg.Wait()
+
+	return cmd.Wait()
+}
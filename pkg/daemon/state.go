@@ -0,0 +1,67 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// PipelineState is the last scheduled run of a pipeline. It is persisted after every run so that the daemon picks up
+// from the next interval after a restart. LastInterval is the last interval that succeeded, while the other fields
+// describe the last run, which is a failed attempt of the next interval if LastStatus is failed.
+type PipelineState struct {
+	Pipeline       string    `json:"pipeline"`
+	Path           string    `json:"path"`
+	LastInterval   Interval  `json:"last_interval"`
+	LastRunID      string    `json:"last_run_id"`
+	LastStatus     string    `json:"last_status"`
+	LastError      string    `json:"last_error,omitempty"`
+	FinishedAt     time.Time `json:"finished_at"`
+	FailedAttempts int       `json:"failed_attempts,omitempty"`
+	RetryAt        time.Time `json:"retry_at"`
+}
+
+type stateStore struct {
+	fs  afero.Fs
+	dir string
+}
+
+func (s *stateStore) path(pipelineName string) string {
+	return filepath.Join(s.dir, unsafeFileNameChars.ReplaceAllString(pipelineName, "_")+".json")
+}
+
+// read returns the state of the pipeline, or nil if it has never been run by the daemon.
+func (s *stateStore) read(pipelineName string) (*PipelineState, error) {
+	state := &PipelineState{}
+	err := helpers.ReadJSONToFile(s.fs, s.path(pipelineName), state)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the schedule state of the pipeline '%s'", pipelineName)
+	}
+
+	return state, nil
+}
+
+// write replaces the state of the pipeline, the file is renamed into place so that a crash cannot leave a partial state.
+func (s *stateStore) write(state *PipelineState) error {
+	target := s.path(state.Pipeline)
+	if err := helpers.WriteJSONToFile(s.fs, state, target+".tmp"); err != nil {
+		return errors.Wrapf(err, "failed to write the schedule state of the pipeline '%s'", state.Pipeline)
+	}
+
+	return errors.Wrapf(s.fs.Rename(target+".tmp", target), "failed to write the schedule state of the pipeline '%s'", state.Pipeline)
+}
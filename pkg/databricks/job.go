@@ -0,0 +1,289 @@
+package databricks
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/emr_serverless"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultJobWorkspace = "/Shared/bruin"
+	jobTaskKey          = "bruin"
+	jobEnvironmentKey   = "default"
+
+	// launcherName is the script that sets up the environment variables before running the entrypoint of the
+	// pyspark assets, since the one-time runs have no way of setting them.
+	launcherName = "bruin_launcher.py"
+)
+
+//go:embed launcher.py
+var launcherScript []byte
+
+// JobRunParams are the parameters of the one-time runs submitted for the notebook and pyspark assets.
+type JobRunParams struct {
+	ClusterID string
+	Workspace string
+	Args      []string
+	Timeout   time.Duration
+}
+
+func parseJobParams(params map[string]string) *JobRunParams {
+	jobParams := JobRunParams{
+		ClusterID: params["cluster_id"],
+		Workspace: strings.TrimSuffix(params["workspace"], "/"),
+	}
+	if jobParams.Workspace == "" {
+		jobParams.Workspace = defaultJobWorkspace
+	}
+
+	if params["timeout"] != "" {
+		t, err := time.ParseDuration(params["timeout"])
+		if err == nil {
+			jobParams.Timeout = t
+		}
+	}
+	if params["args"] != "" {
+		for _, arg := range strings.Split(strings.TrimSpace(params["args"]), " ") {
+			arg = strings.TrimSpace(arg)
+			if arg != "" {
+				jobParams.Args = append(jobParams.Args, arg)
+			}
+		}
+	}
+
+	return &jobParams
+}
+
+type Job struct {
+	logger   *log.Logger
+	client   *JobsClient
+	asset    *pipeline.Asset
+	pipeline *pipeline.Pipeline
+	params   *JobRunParams
+	poll     *emr_serverless.PollTimer
+	env      map[string]string
+}
+
+// prepareWorkspace uploads the entrypoint of the asset to a workspace folder that is unique to the run, and returns
+// the folder along with the task that runs the entrypoint.
+func (job Job) prepareWorkspace(ctx context.Context) (string, *RunTask, error) {
+	jobID, err := uuid.NewV7()
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating job ID: %w", err)
+	}
+	dir := path.Join(job.params.Workspace, job.pipeline.Name, jobID.String())
+
+	if err := job.client.Mkdirs(ctx, dir); err != nil {
+		return "", nil, fmt.Errorf("error creating workspace folder %q: %w", dir, err)
+	}
+
+	scriptPath := job.asset.ExecutableFile.Path
+	content, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return dir, nil, fmt.Errorf("error reading file %q: %w", scriptPath, err)
+	}
+
+	task := &RunTask{TaskKey: jobTaskKey, ExistingClusterID: job.params.ClusterID}
+	fileName := filepath.Base(scriptPath)
+	if job.asset.Type == pipeline.AssetTypeDatabricksNotebook {
+		format := WorkspaceImportFormatSource
+		if filepath.Ext(fileName) == ".ipynb" {
+			format = WorkspaceImportFormatJupyter
+		}
+
+		// notebooks are stored without their extension in the workspace
+		notebookPath := path.Join(dir, strings.TrimSuffix(fileName, filepath.Ext(fileName)))
+		if err := job.client.Import(ctx, notebookPath, format, content); err != nil {
+			return dir, nil, fmt.Errorf("error uploading notebook %q: %w", notebookPath, err)
+		}
+
+		task.NotebookTask = &NotebookTask{
+			NotebookPath:   notebookPath,
+			BaseParameters: job.env,
+			Source:         "WORKSPACE",
+		}
+		return dir, task, nil
+	}
+
+	entrypointPath := path.Join(dir, fileName)
+	if err := job.client.Import(ctx, entrypointPath, WorkspaceImportFormatAuto, content); err != nil {
+		return dir, nil, fmt.Errorf("error uploading entrypoint %q: %w", entrypointPath, err)
+	}
+
+	launcherPath := path.Join(dir, launcherName)
+	if err := job.client.Import(ctx, launcherPath, WorkspaceImportFormatAuto, launcherScript); err != nil {
+		return dir, nil, fmt.Errorf("error uploading launcher %q: %w", launcherPath, err)
+	}
+
+	env, err := json.Marshal(job.env)
+	if err != nil {
+		return dir, nil, fmt.Errorf("error marshalling environment variables: %w", err)
+	}
+
+	// the workspace files are available under /Workspace in the file system of the cluster
+	parameters := []string{"/Workspace" + entrypointPath, string(env)}
+	task.SparkPythonTask = &SparkPythonTask{
+		PythonFile: launcherPath,
+		Parameters: append(parameters, job.params.Args...),
+		Source:     "WORKSPACE",
+	}
+
+	return dir, task, nil
+}
+
+func (job Job) deleteWorkspace(dir string) {
+	// todo: timeout for cleanup
+	_ = job.client.Delete(context.Background(), dir)
+}
+
+func (job Job) buildSubmitRequest(task *RunTask) *SubmitRunRequest {
+	req := &SubmitRunRequest{
+		RunName:        job.asset.Name,
+		TimeoutSeconds: int64(job.params.Timeout.Seconds()),
+		Tasks:          []RunTask{*task},
+	}
+
+	// without a cluster the run uses serverless compute, which needs an environment for the Python tasks
+	if task.ExistingClusterID == "" && task.SparkPythonTask != nil {
+		req.Tasks[0].EnvironmentKey = jobEnvironmentKey
+		req.Environments = []RunEnvironment{
+			{EnvironmentKey: jobEnvironmentKey, Spec: map[string]any{"client": "1"}},
+		}
+	}
+
+	return req
+}
+
+func (job Job) Run(ctx context.Context) (err error) {
+	dir, task, err := job.prepareWorkspace(ctx)
+	if dir != "" {
+		defer job.deleteWorkspace(dir)
+	}
+	if err != nil {
+		return fmt.Errorf("error preparing workspace: %w", err)
+	}
+
+	runID, err := job.client.SubmitRun(ctx, job.buildSubmitRequest(task))
+	if err != nil {
+		return fmt.Errorf("error submitting job run: %w", err)
+	}
+	job.logger.Printf("created job run: %d", runID)
+
+	finished := false
+	defer func() {
+		if err != nil && !finished {
+			// todo: timeout for cancellation
+			job.logger.Printf("error detected. cancelling job run.")
+			_ = job.client.CancelRun(context.Background(), runID)
+		}
+	}()
+
+	var (
+		previousState = "unknown"
+		logs          = &runLogConsumer{client: job.client, printed: map[int64]int{}}
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(job.poll.Duration()):
+			run, err := job.client.GetRun(ctx, runID)
+			if err != nil {
+				return fmt.Errorf("error checking job run status: %w", err)
+			}
+
+			if previousState != run.State.LifeCycleState {
+				job.logger.Printf("%d | %s | %s", runID, run.State.LifeCycleState, run.State.StateMessage)
+				if previousState == "unknown" && run.RunPageURL != "" {
+					job.logger.Printf("%d | %s", runID, run.RunPageURL)
+				}
+				previousState = run.State.LifeCycleState
+				job.poll.Reset()
+			} else {
+				// the runs take a while, there's no need to check them as often once they settle
+				job.poll.Increase()
+			}
+
+			for _, line := range logs.Next(ctx, run) {
+				job.logger.Print(line)
+			}
+
+			if !run.State.IsTerminal() {
+				continue
+			}
+
+			finished = true
+			if run.State.ResultState != "SUCCESS" {
+				message := run.State.StateMessage
+				if taskError := logs.Error(); taskError != "" {
+					message = taskError
+				}
+				return fmt.Errorf("job run %d finished with state %s: %s", runID, run.State.ResultState, message)
+			}
+
+			return nil
+		}
+	}
+}
+
+// runLogConsumer prints the driver logs of the tasks as they become available, only the lines that have not been
+// printed yet are returned.
+type runLogConsumer struct {
+	client    *JobsClient
+	printed   map[int64]int
+	lastError string
+}
+
+func (c *runLogConsumer) Next(ctx context.Context, run *Run) []string {
+	lines := make([]string, 0)
+	for _, task := range run.Tasks {
+		switch task.State.LifeCycleState {
+		case "", "PENDING", "QUEUED", "BLOCKED":
+			continue
+		}
+
+		output, err := c.client.GetRunOutput(ctx, task.RunID)
+		if err != nil {
+			continue
+		}
+
+		if output.Error != "" {
+			c.lastError = output.Error
+		}
+
+		logs := output.Logs
+		if output.ErrorTrace != "" && task.State.IsTerminal() {
+			logs = strings.TrimRight(logs, "\n") + "\n" + output.ErrorTrace
+		}
+		if len(logs) <= c.printed[task.RunID] {
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimRight(logs[c.printed[task.RunID]:], "\n"), "\n") {
+			lines = append(lines, fmt.Sprintf("%s | %s", task.TaskKey, line))
+		}
+		c.printed[task.RunID] = len(logs)
+
+		if task.State.IsTerminal() && output.NotebookOutput.Result != "" {
+			lines = append(lines, fmt.Sprintf("%s | notebook result: %s", task.TaskKey, output.NotebookOutput.Result))
+		}
+	}
+
+	return lines
+}
+
+// Error returns the error message of the last failed task.
+func (c *runLogConsumer) Error() string {
+	return c.lastError
+}
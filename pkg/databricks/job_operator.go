@@ -0,0 +1,92 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/emr_serverless"
+	"github.com/bruin-data/bruin/pkg/env"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+)
+
+type jobsClientProvider interface {
+	JobsClient() *JobsClient
+}
+
+// JobOperator runs the notebook and pyspark assets as one-time runs on Databricks.
+type JobOperator struct {
+	connection connectionFetcher
+	env        map[string]string
+	renderer   jinja.RendererInterface
+}
+
+func NewJobOperator(conn connectionFetcher, env map[string]string, renderer jinja.RendererInterface) *JobOperator {
+	return &JobOperator{
+		connection: conn,
+		env:        env,
+		renderer:   renderer,
+	}
+}
+
+func (op *JobOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	var output io.Writer = os.Stdout
+	if w, ok := ctx.Value(executor.KeyPrinter).(io.Writer); ok {
+		output = w
+	}
+	logger := log.New(output, "", 0)
+
+	renderer := op.renderer.CloneForAsset(ctx, ti.GetPipeline(), ti.GetAsset())
+	asset, err := renderer.RenderAsset(ti.GetAsset())
+	if err != nil {
+		return fmt.Errorf("error rendering asset: %w", err)
+	}
+
+	connID, err := ti.GetPipeline().GetConnectionNameForAsset(asset)
+	if err != nil {
+		return fmt.Errorf("error looking up connection name: %w", err)
+	}
+	conn, err := op.connection.GetDatabricksConnection(connID)
+	if err != nil {
+		return fmt.Errorf("error fetching connection: %w", err)
+	}
+	provider, ok := conn.(jobsClientProvider)
+	if !ok {
+		return fmt.Errorf("connection %q does not support running jobs", connID)
+	}
+
+	env, err := env.SetupVariables(ctx, ti.GetPipeline(), asset, cloneEnv(op.env))
+	if err != nil {
+		return fmt.Errorf("error setting up environment variables: %w", err)
+	}
+
+	job := Job{
+		logger:   logger,
+		client:   provider.JobsClient(),
+		asset:    asset,
+		pipeline: ti.GetPipeline(),
+		params:   parseJobParams(asset.Parameters),
+		poll: &emr_serverless.PollTimer{
+			BaseDuration: time.Second,
+
+			// maximum backoff: 32 seconds
+			MaxRetry: 5,
+		},
+		env: env,
+	}
+
+	return job.Run(ctx)
+}
+
+func cloneEnv(env map[string]string) map[string]string {
+	clone := make(map[string]string, len(env))
+	for k, v := range env {
+		clone[k] = v
+	}
+	return clone
+}
@@ -0,0 +1,292 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/emr_serverless"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockJobsAPI is an in-memory implementation of the parts of the Jobs and Workspace APIs used by the jobs.
+type mockJobsAPI struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	files     map[string]map[string]any
+	deleted   []string
+	submitted *SubmitRunRequest
+	cancelled bool
+
+	// states are returned by the consecutive runs/get calls, the last one is repeated
+	states []RunState
+	polls  int
+	logs   []string
+	error  string
+}
+
+func (m *mockJobsAPI) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/2.0/workspace/mkdirs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/api/2.0/workspace/import", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(m.t, json.NewDecoder(r.Body).Decode(&body))
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.files[body["path"].(string)] = body
+	})
+	mux.HandleFunc("/api/2.0/workspace/delete", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(m.t, json.NewDecoder(r.Body).Decode(&body))
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.deleted = append(m.deleted, body["path"].(string))
+	})
+	mux.HandleFunc("/api/2.1/jobs/runs/submit", func(w http.ResponseWriter, r *http.Request) {
+		var req SubmitRunRequest
+		require.NoError(m.t, json.NewDecoder(r.Body).Decode(&req))
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.submitted = &req
+		_, _ = w.Write([]byte(`{"run_id": 42}`))
+	})
+	mux.HandleFunc("/api/2.1/jobs/runs/get", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(m.t, "42", r.URL.Query().Get("run_id"))
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		state := m.states[min(m.polls, len(m.states)-1)]
+		m.polls++
+
+		run := map[string]any{
+			"run_id":       42,
+			"run_page_url": "https://example.cloud.databricks.com/#job/1/run/42",
+			"state":        state,
+			"tasks": []map[string]any{
+				{"run_id": 43, "task_key": jobTaskKey, "state": state},
+			},
+		}
+		require.NoError(m.t, json.NewEncoder(w).Encode(run))
+	})
+	mux.HandleFunc("/api/2.1/jobs/runs/get-output", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(m.t, "43", r.URL.Query().Get("run_id"))
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		output := map[string]any{
+			"logs":  strings.Join(m.logs[:min(m.polls, len(m.logs))], ""),
+			"error": m.error,
+		}
+		require.NoError(m.t, json.NewEncoder(w).Encode(output))
+	})
+	mux.HandleFunc("/api/2.1/jobs/runs/cancel", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.cancelled = true
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(m.t, "Bearer token", r.Header.Get("Authorization"))
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func newTestJob(t *testing.T, api *mockJobsAPI, asset *pipeline.Asset, params map[string]string) (*Job, *bytes.Buffer) {
+	server := httptest.NewServer(api.handler())
+	t.Cleanup(server.Close)
+
+	output := &bytes.Buffer{}
+	return &Job{
+		logger:   log.New(output, "", 0),
+		client:   NewJobsClient(&Config{Host: server.URL, Token: "token"}),
+		asset:    asset,
+		pipeline: &pipeline.Pipeline{Name: "spark"},
+		params:   parseJobParams(params),
+		poll:     &emr_serverless.PollTimer{BaseDuration: time.Millisecond, MaxRetry: 1},
+		env:      map[string]string{"BRUIN_START_DATE": "2024-01-01"},
+	}, output
+}
+
+func writeAssetFile(t *testing.T, name, content string) *pipeline.Asset {
+	assetPath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(assetPath, []byte(content), 0o600))
+
+	return &pipeline.Asset{
+		Name:           "spark.asset",
+		ExecutableFile: pipeline.ExecutableFile{Path: assetPath},
+	}
+}
+
+func TestJob_Run_Notebook(t *testing.T) {
+	t.Parallel()
+
+	api := &mockJobsAPI{
+		t:     t,
+		files: map[string]map[string]any{},
+		states: []RunState{
+			{LifeCycleState: "PENDING"},
+			{LifeCycleState: "RUNNING"},
+			{LifeCycleState: "RUNNING"},
+			{LifeCycleState: "TERMINATED", ResultState: "SUCCESS"},
+		},
+		logs: []string{"", "", "first line\n", "second line\n"},
+	}
+
+	asset := writeAssetFile(t, "report.ipynb", `{"cells": []}`)
+	asset.Type = pipeline.AssetTypeDatabricksNotebook
+	job, output := newTestJob(t, api, asset, map[string]string{"cluster_id": "cluster-1", "timeout": "1h"})
+
+	require.NoError(t, job.Run(context.Background()))
+
+	require.NotNil(t, api.submitted)
+	assert.Equal(t, "spark.asset", api.submitted.RunName)
+	assert.Equal(t, int64(3600), api.submitted.TimeoutSeconds)
+	assert.Empty(t, api.submitted.Environments)
+	require.Len(t, api.submitted.Tasks, 1)
+
+	task := api.submitted.Tasks[0]
+	assert.Equal(t, "cluster-1", task.ExistingClusterID)
+	require.NotNil(t, task.NotebookTask)
+	assert.Equal(t, map[string]string{"BRUIN_START_DATE": "2024-01-01"}, task.NotebookTask.BaseParameters)
+
+	dir := filepath.Dir(task.NotebookTask.NotebookPath)
+	assert.True(t, strings.HasPrefix(dir, "/Shared/bruin/spark/"))
+	assert.Equal(t, "report", filepath.Base(task.NotebookTask.NotebookPath))
+
+	notebook := api.files[task.NotebookTask.NotebookPath]
+	require.NotNil(t, notebook)
+	assert.Equal(t, "JUPYTER", notebook["format"])
+	content, err := base64.StdEncoding.DecodeString(notebook["content"].(string))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"cells": []}`, string(content))
+
+	assert.Equal(t, []string{dir}, api.deleted)
+	assert.False(t, api.cancelled)
+
+	logs := output.String()
+	assert.Contains(t, logs, "created job run: 42")
+	assert.Contains(t, logs, "42 | RUNNING")
+	assert.Contains(t, logs, "bruin | first line\n")
+	assert.Contains(t, logs, "bruin | second line\n")
+	assert.Equal(t, 1, strings.Count(logs, "first line"))
+}
+
+func TestJob_Run_PysparkFailure(t *testing.T) {
+	t.Parallel()
+
+	api := &mockJobsAPI{
+		t:     t,
+		files: map[string]map[string]any{},
+		states: []RunState{
+			{LifeCycleState: "RUNNING"},
+			{LifeCycleState: "TERMINATED", ResultState: "FAILED", StateMessage: "Workload failed"},
+		},
+		logs:  []string{"starting\n"},
+		error: "ZeroDivisionError: division by zero",
+	}
+
+	asset := writeAssetFile(t, "main.py", "print(1 / 0)\n")
+	asset.Type = pipeline.AssetTypeDatabricksPyspark
+	job, _ := newTestJob(t, api, asset, map[string]string{"args": "--env  prod", "workspace": "/Users/jobs/"})
+
+	err := job.Run(context.Background())
+	require.ErrorContains(t, err, "job run 42 finished with state FAILED: ZeroDivisionError: division by zero")
+
+	require.NotNil(t, api.submitted)
+	assert.Equal(t, []RunEnvironment{
+		{EnvironmentKey: jobEnvironmentKey, Spec: map[string]any{"client": "1"}},
+	}, api.submitted.Environments)
+
+	task := api.submitted.Tasks[0]
+	assert.Empty(t, task.ExistingClusterID)
+	assert.Equal(t, jobEnvironmentKey, task.EnvironmentKey)
+	require.NotNil(t, task.SparkPythonTask)
+
+	dir := filepath.Dir(task.SparkPythonTask.PythonFile)
+	assert.True(t, strings.HasPrefix(dir, "/Users/jobs/spark/"))
+	assert.Equal(t, launcherName, filepath.Base(task.SparkPythonTask.PythonFile))
+	assert.Equal(t, []string{
+		"/Workspace" + dir + "/main.py",
+		`{"BRUIN_START_DATE":"2024-01-01"}`,
+		"--env",
+		"prod",
+	}, task.SparkPythonTask.Parameters)
+
+	assert.Contains(t, api.files, dir+"/main.py")
+	assert.Contains(t, api.files, dir+"/"+launcherName)
+	assert.Equal(t, []string{dir}, api.deleted)
+
+	// the run is over, there's nothing to cancel
+	assert.False(t, api.cancelled)
+}
+
+func TestJob_Run_CancelledContext(t *testing.T) {
+	t.Parallel()
+
+	api := &mockJobsAPI{
+		t:      t,
+		files:  map[string]map[string]any{},
+		states: []RunState{{LifeCycleState: "RUNNING"}},
+		logs:   []string{},
+	}
+
+	asset := writeAssetFile(t, "main.py", "print('hi')\n")
+	asset.Type = pipeline.AssetTypeDatabricksPyspark
+	job, output := newTestJob(t, api, asset, map[string]string{"cluster_id": "cluster-1"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for {
+			api.mu.Lock()
+			polls := api.polls
+			api.mu.Unlock()
+			if polls > 0 {
+				cancel()
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	err := job.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	assert.True(t, api.cancelled)
+	assert.Len(t, api.deleted, 1)
+	assert.Contains(t, output.String(), "error detected. cancelling job run.")
+}
+
+func TestJobsClient_APIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = io.WriteString(w, `{"error_code": "PERMISSION_DENIED", "message": "no access"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewJobsClient(&Config{Host: server.URL, Token: "token"})
+	err := client.Mkdirs(context.Background(), "/Shared/bruin")
+	require.EqualError(t, err, "/api/2.0/workspace/mkdirs failed with status 403: PERMISSION_DENIED: no access")
+}
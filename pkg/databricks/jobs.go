@@ -0,0 +1,230 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JobsClient is a minimal client for the Databricks Jobs and Workspace APIs, it is used to submit one-time runs.
+type JobsClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewJobsClient(c *Config) *JobsClient {
+	baseURL := strings.TrimSuffix(c.Host, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "https://" + baseURL
+	}
+
+	return &JobsClient{
+		baseURL:    baseURL,
+		token:      c.Token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// JobsClient returns a client for the Jobs API of the workspace the connection belongs to.
+func (db *DB) JobsClient() *JobsClient {
+	return NewJobsClient(db.config)
+}
+
+type apiError struct {
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func (c *JobsClient) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the request")
+		}
+		reqBody = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the request")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call %s", path)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the response of %s", path)
+	}
+
+	if resp.StatusCode >= 300 {
+		var apiErr apiError
+		if err := json.Unmarshal(content, &apiErr); err == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s failed with status %d: %s: %s", path, resp.StatusCode, apiErr.ErrorCode, apiErr.Message)
+		}
+		return fmt.Errorf("%s failed with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(content)))
+	}
+
+	if result == nil || len(content) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(content, result); err != nil {
+		return errors.Wrapf(err, "failed to parse the response of %s", path)
+	}
+
+	return nil
+}
+
+// WorkspaceImportFormat is the format of the files imported to the workspace.
+type WorkspaceImportFormat string
+
+const (
+	WorkspaceImportFormatAuto    WorkspaceImportFormat = "AUTO"
+	WorkspaceImportFormatSource  WorkspaceImportFormat = "SOURCE"
+	WorkspaceImportFormatJupyter WorkspaceImportFormat = "JUPYTER"
+)
+
+func (c *JobsClient) Mkdirs(ctx context.Context, path string) error {
+	return c.do(ctx, http.MethodPost, "/api/2.0/workspace/mkdirs", map[string]any{"path": path}, nil)
+}
+
+// Import uploads the content to the given workspace path, overwriting the existing object.
+func (c *JobsClient) Import(ctx context.Context, path string, format WorkspaceImportFormat, content []byte) error {
+	body := map[string]any{
+		"path":      path,
+		"format":    format,
+		"content":   base64.StdEncoding.EncodeToString(content),
+		"overwrite": true,
+	}
+	if format == WorkspaceImportFormatSource {
+		body["language"] = "PYTHON"
+	}
+
+	return c.do(ctx, http.MethodPost, "/api/2.0/workspace/import", body, nil)
+}
+
+func (c *JobsClient) Delete(ctx context.Context, path string) error {
+	return c.do(ctx, http.MethodPost, "/api/2.0/workspace/delete", map[string]any{"path": path, "recursive": true}, nil)
+}
+
+type NotebookTask struct {
+	NotebookPath   string            `json:"notebook_path"`
+	BaseParameters map[string]string `json:"base_parameters,omitempty"`
+	Source         string            `json:"source,omitempty"`
+}
+
+type SparkPythonTask struct {
+	PythonFile string   `json:"python_file"`
+	Parameters []string `json:"parameters,omitempty"`
+	Source     string   `json:"source,omitempty"`
+}
+
+type RunTask struct {
+	TaskKey           string           `json:"task_key"`
+	ExistingClusterID string           `json:"existing_cluster_id,omitempty"`
+	EnvironmentKey    string           `json:"environment_key,omitempty"`
+	NotebookTask      *NotebookTask    `json:"notebook_task,omitempty"`
+	SparkPythonTask   *SparkPythonTask `json:"spark_python_task,omitempty"`
+}
+
+type RunEnvironment struct {
+	EnvironmentKey string         `json:"environment_key"`
+	Spec           map[string]any `json:"spec"`
+}
+
+type SubmitRunRequest struct {
+	RunName        string           `json:"run_name"`
+	TimeoutSeconds int64            `json:"timeout_seconds,omitempty"`
+	Tasks          []RunTask        `json:"tasks"`
+	Environments   []RunEnvironment `json:"environments,omitempty"`
+}
+
+func (c *JobsClient) SubmitRun(ctx context.Context, req *SubmitRunRequest) (int64, error) {
+	var resp struct {
+		RunID int64 `json:"run_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/2.1/jobs/runs/submit", req, &resp); err != nil {
+		return 0, err
+	}
+
+	return resp.RunID, nil
+}
+
+type RunState struct {
+	LifeCycleState string `json:"life_cycle_state"`
+	ResultState    string `json:"result_state"`
+	StateMessage   string `json:"state_message"`
+}
+
+// IsTerminal checks if the run is over, successfully or not.
+func (s RunState) IsTerminal() bool {
+	switch s.LifeCycleState {
+	case "TERMINATED", "SKIPPED", "INTERNAL_ERROR":
+		return true
+	default:
+		return false
+	}
+}
+
+type Run struct {
+	RunID      int64    `json:"run_id"`
+	RunPageURL string   `json:"run_page_url"`
+	State      RunState `json:"state"`
+	Tasks      []struct {
+		RunID   int64    `json:"run_id"`
+		TaskKey string   `json:"task_key"`
+		State   RunState `json:"state"`
+	} `json:"tasks"`
+}
+
+func (c *JobsClient) GetRun(ctx context.Context, runID int64) (*Run, error) {
+	query := url.Values{"run_id": []string{strconv.FormatInt(runID, 10)}}
+	var run Run
+	if err := c.do(ctx, http.MethodGet, "/api/2.1/jobs/runs/get?"+query.Encode(), nil, &run); err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+type RunOutput struct {
+	Logs           string `json:"logs"`
+	LogsTruncated  bool   `json:"logs_truncated"`
+	Error          string `json:"error"`
+	ErrorTrace     string `json:"error_trace"`
+	NotebookOutput struct {
+		Result string `json:"result"`
+	} `json:"notebook_output"`
+}
+
+// GetRunOutput returns the output of a task run, the driver logs are only available for the Python tasks.
+func (c *JobsClient) GetRunOutput(ctx context.Context, taskRunID int64) (*RunOutput, error) {
+	query := url.Values{"run_id": []string{strconv.FormatInt(taskRunID, 10)}}
+	var output RunOutput
+	if err := c.do(ctx, http.MethodGet, "/api/2.1/jobs/runs/get-output?"+query.Encode(), nil, &output); err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+func (c *JobsClient) CancelRun(ctx context.Context, runID int64) error {
+	return c.do(ctx, http.MethodPost, "/api/2.1/jobs/runs/cancel", map[string]any{"run_id": runID}, nil)
+}
@@ -0,0 +1,16 @@
+# Sets up the environment of the bruin asset and runs its entrypoint, the one-time runs cannot set environment
+# variables for the Python tasks, therefore they are passed as the arguments of this script.
+#
+# usage: bruin_launcher.py <entrypoint> <environment variables as JSON> [args...]
+import json
+import os
+import runpy
+import sys
+
+entrypoint = sys.argv[1]
+os.environ.update(json.loads(sys.argv[2]))
+
+sys.path.insert(0, os.path.dirname(entrypoint))
+sys.argv = [entrypoint] + sys.argv[3:]
+
+runpy.run_path(entrypoint, run_name="__main__")
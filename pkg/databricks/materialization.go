@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/helpers"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 )
@@ -28,6 +29,7 @@ var matMap = AssetMaterializationMap{
 		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
 		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
 		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
 		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
 	},
 }
@@ -127,11 +129,59 @@ func buildCreateReplaceQuery(task *pipeline.Asset, query string) ([]string, erro
 
 	query = strings.TrimSuffix(query, ";")
 
-	return []string{
+	queries := []string{
 		fmt.Sprintf(`CREATE TABLE %s AS %s;`, tempTableName, query),
 		fmt.Sprintf(`DROP TABLE IF EXISTS %s;`, task.Name),
 		fmt.Sprintf(`ALTER TABLE %s RENAME TO %s;`, tempTableName, task.Name),
-	}, nil
+	}
+
+	return append(queries, buildConstraintQueries(task)...), nil
+}
+
+// buildConstraintQueries adds the column constraints and defaults to a table that is created via "CREATE TABLE AS",
+// which does not accept them in the column list.
+func buildConstraintQueries(asset *pipeline.Asset) []string {
+	queries := make([]string, 0)
+	defaults := make([]string, 0)
+	for _, col := range asset.Columns {
+		// primary key columns must not be nullable in Databricks
+		if !col.Nullable.Bool() || col.PrimaryKey {
+			queries = append(queries, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", asset.Name, col.Name))
+		}
+		if col.Default != "" {
+			defaults = append(defaults, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", asset.Name, col.Name, col.Default))
+		}
+	}
+
+	if len(defaults) > 0 {
+		queries = append(queries, fmt.Sprintf("ALTER TABLE %s SET TBLPROPERTIES ('delta.feature.allowColumnDefaults' = 'supported');", asset.Name))
+		queries = append(queries, defaults...)
+	}
+
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) > 0 {
+		queries = append(queries, fmt.Sprintf(
+			"ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s);",
+			asset.Name, ansisql.ConstraintName("pk", asset.Name), strings.Join(primaryKeys, ", "),
+		))
+	}
+
+	for _, col := range asset.Columns {
+		if col.ForeignKey != nil {
+			queries = append(queries, fmt.Sprintf(
+				"ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s);",
+				asset.Name, ansisql.ConstraintName("fk", asset.Name, col.Name), col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
+			))
+		}
+		if col.CheckConstraint != "" {
+			queries = append(queries, fmt.Sprintf(
+				"ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);",
+				asset.Name, ansisql.ConstraintName("chk", asset.Name, col.Name), col.CheckConstraint,
+			))
+		}
+	}
+
+	return queries
 }
 
 func buildTimeIntervalQuery(asset *pipeline.Asset, query string) ([]string, error) {
@@ -169,18 +219,49 @@ func buildTimeIntervalQuery(asset *pipeline.Asset, query string) ([]string, erro
 
 func buildDDLQuery(asset *pipeline.Asset, query string) ([]string, error) {
 	columnDefs := make([]string, 0, len(asset.Columns))
+	constraints := make([]string, 0)
+	checkConstraints := make([]string, 0)
+	hasDefaults := false
 
 	for _, col := range asset.Columns {
 		def := fmt.Sprintf("%s %s", col.Name, col.Type)
-		if col.PrimaryKey {
-			def += " PRIMARY KEY"
+		// primary key columns must not be nullable in Databricks
+		if !col.Nullable.Bool() || col.PrimaryKey {
+			def += " NOT NULL"
+		}
+		if col.Default != "" {
+			def += " DEFAULT " + col.Default
+			hasDefaults = true
 		}
 		if col.Description != "" {
 			def += fmt.Sprintf(" COMMENT '%s'", col.Description)
 		}
+		if col.ForeignKey != nil {
+			constraints = append(constraints, fmt.Sprintf(
+				"CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+				ansisql.ConstraintName("fk", asset.Name, col.Name), col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
+			))
+		}
+		if col.CheckConstraint != "" {
+			// check constraints can only be added to existing tables, dropping them first keeps the query re-runnable
+			name := ansisql.ConstraintName("chk", asset.Name, col.Name)
+			checkConstraints = append(checkConstraints,
+				fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s", asset.Name, name),
+				fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s)", asset.Name, name, col.CheckConstraint),
+			)
+		}
 		columnDefs = append(columnDefs, def)
 	}
 
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) > 0 {
+		constraints = append([]string{fmt.Sprintf(
+			"CONSTRAINT %s PRIMARY KEY (%s)",
+			ansisql.ConstraintName("pk", asset.Name), strings.Join(primaryKeys, ", "),
+		)}, constraints...)
+	}
+	columnDefs = append(columnDefs, constraints...)
+
 	partitionBy := ""
 	if asset.Materialization.PartitionBy != "" {
 		partitionBy = fmt.Sprintf("\nPARTITIONED BY (%s)", asset.Materialization.PartitionBy)
@@ -191,15 +272,22 @@ func buildDDLQuery(asset *pipeline.Asset, query string) ([]string, error) {
 		clusterByClause = "\nCLUSTER BY (" + strings.Join(asset.Materialization.ClusterBy, ", ") + ")"
 	}
 
+	tableProperties := ""
+	if hasDefaults {
+		tableProperties = "\nTBLPROPERTIES ('delta.feature.allowColumnDefaults' = 'supported')"
+	}
+
 	ddl := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n"+
 		"%s\n"+
 		")%s"+
+		"%s"+
 		"%s",
 		asset.Name,
 		strings.Join(columnDefs, ",\n"),
 		partitionBy,
 		clusterByClause,
+		tableProperties,
 	)
 
-	return []string{ddl}, nil
+	return append([]string{ddl}, checkConstraints...), nil
 }
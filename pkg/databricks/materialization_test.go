@@ -9,6 +9,7 @@ import (
 
 func TestMaterializer_Render(t *testing.T) {
 	t.Parallel()
+	falseValue := false
 	tests := []struct {
 		name        string
 		task        *pipeline.Asset
@@ -66,6 +67,34 @@ func TestMaterializer_Render(t *testing.T) {
 				"ALTER TABLE my\\.__bruin_tmp_.+ RENAME TO my\\.asset;",
 			},
 		},
+		{
+			name: "materialize to a table, the constraints are added after creating the table",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyCreateReplace,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+					{Name: "status", Default: "'new'"},
+					{Name: "user_id", ForeignKey: &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}},
+					{Name: "amount", CheckConstraint: "amount > 0"},
+				},
+			},
+			query: "SELECT 1",
+			want: []string{
+				"CREATE TABLE my\\.__bruin_tmp_.+ AS SELECT 1;",
+				"DROP TABLE IF EXISTS my\\.asset;",
+				"ALTER TABLE my\\.__bruin_tmp_.+ RENAME TO my\\.asset;",
+				"ALTER TABLE my\\.asset ALTER COLUMN id SET NOT NULL;",
+				"ALTER TABLE my\\.asset SET TBLPROPERTIES \\('delta\\.feature\\.allowColumnDefaults' = 'supported'\\);",
+				"ALTER TABLE my\\.asset ALTER COLUMN status SET DEFAULT 'new';",
+				"ALTER TABLE my\\.asset ADD CONSTRAINT pk_my_asset PRIMARY KEY \\(id\\);",
+				"ALTER TABLE my\\.asset ADD CONSTRAINT fk_my_asset_user_id FOREIGN KEY \\(user_id\\) REFERENCES my\\.users \\(id\\);",
+				"ALTER TABLE my\\.asset ADD CONSTRAINT chk_my_asset_amount CHECK \\(amount > 0\\);",
+			},
+		},
 		{
 			name: "materialize to a table with cluster, single field to cluster",
 			task: &pipeline.Asset{
@@ -312,7 +341,8 @@ func TestMaterializer_Render(t *testing.T) {
 			want: []string{
 				"CREATE TABLE IF NOT EXISTS two_col_table \\(\n" +
 					"id INT64,\n" +
-					"name STRING PRIMARY KEY COMMENT \\'The name of the person\\'\n" +
+					"name STRING NOT NULL COMMENT \\'The name of the person\\',\n" +
+					"CONSTRAINT pk_two_col_table PRIMARY KEY \\(name\\)\n" +
 					"\\)",
 			},
 		},
@@ -354,12 +384,40 @@ func TestMaterializer_Render(t *testing.T) {
 			},
 			want: []string{
 				"CREATE TABLE IF NOT EXISTS my_partitioned_table \\(\n" +
-					"id INT64 PRIMARY KEY,\n" +
-					"timestamp TIMESTAMP COMMENT 'Event timestamp'\n" +
+					"id INT64 NOT NULL,\n" +
+					"timestamp TIMESTAMP COMMENT 'Event timestamp',\n" +
+					"CONSTRAINT pk_my_partitioned_table PRIMARY KEY \\(id\\)\n" +
 					"\\)" +
 					"\nPARTITIONED BY \\(timestamp\\)",
 			},
 		},
+		{
+			name: "table with constraints and defaults",
+			task: &pipeline.Asset{
+				Name: "my.constrained_table",
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64", PrimaryKey: true},
+					{Name: "user_id", Type: "INT64", Nullable: pipeline.DefaultTrueBool{Value: &falseValue}, ForeignKey: &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}},
+					{Name: "amount", Type: "DOUBLE", Default: "0", CheckConstraint: "amount >= 0"},
+				},
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+			},
+			want: []string{
+				"CREATE TABLE IF NOT EXISTS my.constrained_table \\(\n" +
+					"id INT64 NOT NULL,\n" +
+					"user_id INT64 NOT NULL,\n" +
+					"amount DOUBLE DEFAULT 0,\n" +
+					"CONSTRAINT pk_my_constrained_table PRIMARY KEY \\(id\\),\n" +
+					"CONSTRAINT fk_my_constrained_table_user_id FOREIGN KEY \\(user_id\\) REFERENCES my.users \\(id\\)\n" +
+					"\\)" +
+					"\nTBLPROPERTIES \\('delta.feature.allowColumnDefaults' = 'supported'\\)",
+				"ALTER TABLE my.constrained_table DROP CONSTRAINT IF EXISTS chk_my_constrained_table_amount",
+				"ALTER TABLE my.constrained_table ADD CONSTRAINT chk_my_constrained_table_amount CHECK \\(amount >= 0\\)",
+			},
+		},
 		{
 			name: "table with composite partitioning key",
 			task: &pipeline.Asset{
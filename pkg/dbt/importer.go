@@ -0,0 +1,870 @@
+package dbt
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// ImportOptions configure how the dbt project is converted.
+type ImportOptions struct {
+	// AssetType is the type of the SQL assets, it is detected from the dbt profile if it's empty.
+	AssetType pipeline.AssetType
+	// Schema is the schema the models are built in, it is read from the dbt profile if it's empty.
+	Schema string
+	// ProfilesDir is the folder `profiles.yml` is read from.
+	ProfilesDir string
+}
+
+// Issue is a part of the dbt project that could not be converted as is.
+type Issue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Summary is the migration summary of an imported dbt project.
+type Summary struct {
+	Pipeline string  `json:"pipeline"`
+	Output   string  `json:"output"`
+	Models   int     `json:"models"`
+	Seeds    int     `json:"seeds"`
+	Sources  int     `json:"sources"`
+	Checks   int     `json:"checks"`
+	Issues   []Issue `json:"issues"`
+}
+
+// model is a dbt model along with the properties that are used to convert it.
+type model struct {
+	name   string
+	path   string
+	folder string
+	query  string
+	config nodeConfig
+	spec   *nodeSpec
+	asset  string
+}
+
+type seed struct {
+	name   string
+	path   string
+	folder string
+	config nodeConfig
+	spec   *nodeSpec
+	asset  string
+}
+
+type sourceTable struct {
+	source      string
+	asset       string
+	description string
+	spec        sourceTableSpec
+}
+
+type importer struct {
+	fs          afero.Fs
+	projectPath string
+	outputPath  string
+	project     *Project
+	assetType   pipeline.AssetType
+	seedType    pipeline.AssetType
+	schema      string
+
+	models       []*model
+	seeds        []*seed
+	modelSpecs   map[string]*nodeSpec
+	seedSpecs    map[string]*nodeSpec
+	sources      map[string]string
+	sourceTables []*sourceTable
+	refs         map[string]string
+	variables    map[string]any
+	summary      *Summary
+}
+
+// Import converts the dbt project in the given path into a Bruin pipeline in the output path.
+func Import(fs afero.Fs, projectPath, outputPath string, opts ImportOptions) (*Summary, error) {
+	project, err := readProject(fs, projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	assetType, schema := opts.AssetType, opts.Schema
+	if assetType == "" || schema == "" {
+		t := readTarget(fs, profileDirs(projectPath, opts.ProfilesDir), project.Profile)
+		if t != nil && assetType == "" {
+			types, ok := adapterAssetTypes[t.Type]
+			if !ok {
+				return nil, fmt.Errorf("the dbt adapter '%s' is not supported, please set the asset type explicitly", t.Type)
+			}
+			assetType = types[0]
+		}
+		if t != nil && schema == "" {
+			schema = t.Schema
+		}
+	}
+	if assetType == "" {
+		return nil, fmt.Errorf("the profile '%s' could not be found, please set the asset type explicitly", project.Profile)
+	}
+	if schema == "" {
+		return nil, errors.New("the target schema could not be read from the dbt profile, please set the schema explicitly")
+	}
+
+	if err := ensureEmptyDir(fs, outputPath); err != nil {
+		return nil, err
+	}
+
+	im := &importer{
+		fs:          fs,
+		projectPath: projectPath,
+		outputPath:  outputPath,
+		project:     project,
+		assetType:   assetType,
+		seedType:    seedTypeFor(assetType),
+		schema:      schema,
+		modelSpecs:  map[string]*nodeSpec{},
+		seedSpecs:   map[string]*nodeSpec{},
+		sources:     map[string]string{},
+		refs:        map[string]string{},
+		variables:   project.variables(),
+		summary:     &Summary{Pipeline: project.Name, Output: outputPath},
+	}
+
+	steps := []func() error{
+		im.readSchemaFiles,
+		im.readModels,
+		im.readSeeds,
+		im.writeSources,
+		im.writeModels,
+		im.writeSeeds,
+		im.reportUnsupportedFolders,
+		im.writePipeline,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(im.summary.Issues, func(i, j int) bool {
+		return im.summary.Issues[i].Path < im.summary.Issues[j].Path
+	})
+
+	return im.summary, nil
+}
+
+func ensureEmptyDir(fs afero.Fs, path string) error {
+	exists, err := afero.DirExists(fs, path)
+	if err != nil || !exists {
+		return err
+	}
+
+	empty, err := afero.IsEmpty(fs, path)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return fmt.Errorf("the output folder '%s' is not empty", path)
+	}
+
+	return nil
+}
+
+func (im *importer) report(path, format string, args ...any) {
+	im.summary.Issues = append(im.summary.Issues, Issue{Path: filepath.ToSlash(path), Message: fmt.Sprintf(format, args...)})
+}
+
+// walk calls the given function for the files with the given extensions under the project folders, in a stable order.
+func (im *importer) walk(folders []string, extensions []string, fn func(root, relPath string) error) error {
+	for _, folder := range folders {
+		root := filepath.Join(im.projectPath, folder)
+		if exists, _ := afero.DirExists(im.fs, root); !exists {
+			continue
+		}
+
+		err := afero.Walk(im.fs, root, func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			for _, ext := range extensions {
+				if strings.HasSuffix(path, ext) {
+					rel, err := filepath.Rel(root, path)
+					if err != nil {
+						return err
+					}
+					return fn(folder, rel)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the '%s' folder", folder)
+		}
+	}
+
+	return nil
+}
+
+func (im *importer) readSchemaFiles() error {
+	folders := append(append([]string{}, im.project.ModelPaths...), im.project.SeedPaths...)
+	return im.walk(folders, []string{".yml", ".yaml"}, func(root, relPath string) error {
+		path := filepath.Join(root, relPath)
+		content, err := afero.ReadFile(im.fs, filepath.Join(im.projectPath, path))
+		if err != nil {
+			return err
+		}
+
+		var file schemaFile
+		if err := yaml.Unmarshal(content, &file); err != nil {
+			im.report(path, "the file could not be parsed: %s", err)
+			return nil
+		}
+
+		for i := range file.Models {
+			im.modelSpecs[file.Models[i].Name] = &file.Models[i]
+		}
+		for i := range file.Seeds {
+			im.seedSpecs[file.Seeds[i].Name] = &file.Seeds[i]
+		}
+		for _, source := range file.Sources {
+			im.readSource(path, source)
+		}
+
+		return nil
+	})
+}
+
+// readSource registers the tables of the source, so that the models can depend on them.
+func (im *importer) readSource(path string, source sourceSpec) {
+	schema := source.Schema
+	if schema == "" {
+		schema = source.Name
+	}
+
+	for _, table := range source.Tables {
+		identifier := table.Identifier
+		if identifier == "" {
+			identifier = table.Name
+		}
+
+		description := table.Description
+		if description == "" {
+			description = source.Description
+		}
+
+		im.sources[source.Name+"."+table.Name] = schema + "." + identifier
+		im.sourceTables = append(im.sourceTables, &sourceTable{
+			source:      source.Name,
+			asset:       schema + "." + identifier,
+			description: strings.TrimSpace(description),
+			spec:        table,
+		})
+
+		if len(table.Tests) > 0 || len(table.DataTests) > 0 || hasColumnTests(table.Columns) {
+			im.report(path, "the tests of the source table '%s.%s' are not converted, sources are not built by the pipeline", source.Name, table.Name)
+		}
+	}
+}
+
+func hasColumnTests(columns []columnSpec) bool {
+	for _, column := range columns {
+		if len(column.tests()) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeSources writes the source tables as empty assets, they document the tables and let the models depend on them.
+func (im *importer) writeSources() error {
+	for _, table := range im.sourceTables {
+		asset := &pipeline.Asset{
+			Name:        table.asset,
+			Type:        pipeline.AssetTypeEmpty,
+			Description: table.description,
+			ExecutableFile: pipeline.ExecutableFile{
+				Path: filepath.Join(im.outputPath, "assets", "sources", table.source, table.spec.Name+".asset.yml"),
+			},
+		}
+		for _, column := range table.spec.Columns {
+			asset.Columns = append(asset.Columns, pipeline.Column{
+				Name:        column.Name,
+				Type:        column.DataType,
+				Description: strings.TrimSpace(column.Description),
+			})
+		}
+
+		if err := im.persist(asset); err != nil {
+			return err
+		}
+		im.summary.Sources++
+	}
+
+	return nil
+}
+
+func (im *importer) readModels() error {
+	return im.walk(im.project.ModelPaths, []string{".sql", ".py"}, func(root, relPath string) error {
+		path := filepath.Join(root, relPath)
+		if strings.HasSuffix(relPath, ".py") {
+			im.report(path, "Python models are not converted, they can be rewritten as Python assets")
+			return nil
+		}
+
+		content, err := afero.ReadFile(im.fs, filepath.Join(im.projectPath, path))
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimSuffix(filepath.Base(relPath), ".sql")
+		folder := filepath.Dir(relPath)
+		query, inFileConfig, issues := extractConfig(string(content))
+		for _, issue := range issues {
+			im.report(path, "%s", issue)
+		}
+
+		config := configForPath(im.project.Models, im.project.Name, splitFolders(folder))
+		spec := im.modelSpecs[name]
+		if spec != nil {
+			config = config.merge(spec.Config)
+		}
+		config = config.merge(inFileConfig)
+
+		if !config.enabled() {
+			im.report(path, "the model is disabled, it is not converted")
+			return nil
+		}
+
+		m := &model{name: name, path: path, folder: folder, query: query, config: config, spec: spec}
+		m.asset = im.assetName(name, config)
+		im.refs[name] = m.asset
+		im.models = append(im.models, m)
+
+		return nil
+	})
+}
+
+func (im *importer) readSeeds() error {
+	return im.walk(im.project.SeedPaths, []string{".csv"}, func(root, relPath string) error {
+		name := strings.TrimSuffix(filepath.Base(relPath), ".csv")
+		folder := filepath.Dir(relPath)
+
+		config := configForPath(im.project.Seeds, im.project.Name, splitFolders(folder))
+		spec := im.seedSpecs[name]
+		if spec != nil {
+			config = config.merge(spec.Config)
+		}
+		if !config.enabled() {
+			im.report(filepath.Join(root, relPath), "the seed is disabled, it is not converted")
+			return nil
+		}
+
+		s := &seed{name: name, path: filepath.Join(root, relPath), folder: folder, config: config, spec: spec}
+		s.asset = im.assetName(name, config)
+		im.refs[name] = s.asset
+		im.seeds = append(im.seeds, s)
+
+		return nil
+	})
+}
+
+func splitFolders(folder string) []string {
+	if folder == "." || folder == "" {
+		return nil
+	}
+
+	return strings.Split(filepath.ToSlash(folder), "/")
+}
+
+// assetName builds the name of the table the node is materialized as, custom schemas are appended to the target schema
+// the same way dbt does by default.
+func (im *importer) assetName(name string, config nodeConfig) string {
+	schema := im.schema
+	if custom := config.string("schema"); custom != "" {
+		schema = im.schema + "_" + custom
+	}
+	if alias := config.string("alias"); alias != "" {
+		name = alias
+	}
+
+	return schema + "." + name
+}
+
+func (im *importer) resolveRef(name string) (string, bool) {
+	asset, ok := im.refs[name]
+	return asset, ok
+}
+
+func (im *importer) resolveSource(source, table string) (string, bool) {
+	asset, ok := im.sources[source+"."+table]
+	return asset, ok
+}
+
+func (im *importer) writeModels() error {
+	rewriter := &sqlRewriter{resolveRef: im.resolveRef, resolveSource: im.resolveSource}
+	for _, m := range im.models {
+		converted := rewriter.rewrite(m.query)
+		for _, issue := range converted.Issues {
+			im.report(m.path, "%s", issue)
+		}
+		im.collectVariables(m.path, converted.Variables)
+
+		asset := &pipeline.Asset{
+			Name: m.asset,
+			Type: im.assetType,
+			ExecutableFile: pipeline.ExecutableFile{
+				Path:    filepath.Join(im.outputPath, "assets", m.folder, m.name+".sql"),
+				Content: converted.Query,
+			},
+		}
+		for _, dependency := range converted.Dependencies {
+			asset.Upstreams = append(asset.Upstreams, pipeline.Upstream{Type: "asset", Value: dependency, Mode: pipeline.UpstreamModeFull})
+		}
+
+		var primaryKeys []string
+		asset.Materialization, primaryKeys = im.materialization(m.path, m.config)
+		im.describe(asset, m.path, m.config, m.spec)
+		if asset.Materialization.Strategy == pipeline.MaterializationStrategyMerge {
+			im.setMergeColumns(asset, m.path, primaryKeys)
+		}
+		im.reportConfig(m.path, m.config)
+
+		if err := im.persist(asset); err != nil {
+			return err
+		}
+		im.summary.Models++
+	}
+
+	return nil
+}
+
+func (im *importer) writeSeeds() error {
+	for _, s := range im.seeds {
+		if im.seedType == "" {
+			im.report(s.path, "the platform of the '%s' assets has no seed assets, the seed is not converted", im.assetType)
+			continue
+		}
+
+		content, err := afero.ReadFile(im.fs, filepath.Join(im.projectPath, s.path))
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the seed '%s'", s.path)
+		}
+
+		folder := filepath.Join(im.outputPath, "assets", "seeds", s.folder)
+		if err := im.fs.MkdirAll(folder, 0o755); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(im.fs, filepath.Join(folder, s.name+".csv"), content, 0o644); err != nil {
+			return err
+		}
+
+		asset := &pipeline.Asset{
+			Name:       s.asset,
+			Type:       im.seedType,
+			Parameters: pipeline.EmptyStringMap{"path": s.name + ".csv"},
+			ExecutableFile: pipeline.ExecutableFile{
+				Path: filepath.Join(folder, s.name+".asset.yml"),
+			},
+		}
+		im.describe(asset, s.path, s.config, s.spec)
+		if _, ok := s.config["column_types"]; ok {
+			im.report(s.path, "the column types of the seed are not converted, the types are inferred from the file")
+		}
+
+		if err := im.persist(asset); err != nil {
+			return err
+		}
+		im.summary.Seeds++
+	}
+
+	return nil
+}
+
+// describe sets the documentation, the ownership and the columns of the node on the asset.
+func (im *importer) describe(asset *pipeline.Asset, path string, config nodeConfig, spec *nodeSpec) {
+	asset.Tags = config.strings("tags")
+
+	meta, _ := config["meta"].(map[string]any)
+	if spec != nil && spec.Meta != nil {
+		meta = spec.Meta
+	}
+	if owner, ok := meta["owner"]; ok {
+		asset.Owner = fmt.Sprint(owner)
+	}
+
+	if spec == nil {
+		return
+	}
+
+	asset.Description = strings.TrimSpace(spec.Description)
+	for _, column := range spec.Columns {
+		asset.Columns = append(asset.Columns, im.column(asset, path, column))
+	}
+
+	for _, raw := range spec.tests() {
+		test, err := parseTest(raw)
+		if err != nil {
+			im.report(path, "%s", err)
+			continue
+		}
+		im.report(path, "the model-level test '%s' is not converted, it can be added as a custom check", test.Name)
+	}
+}
+
+func (im *importer) column(asset *pipeline.Asset, path string, spec columnSpec) pipeline.Column {
+	column := pipeline.Column{
+		Name:        spec.Name,
+		Type:        spec.DataType,
+		Description: strings.TrimSpace(spec.Description),
+	}
+
+	for _, raw := range spec.tests() {
+		test, err := parseTest(raw)
+		if err != nil {
+			im.report(path, "%s", err)
+			continue
+		}
+
+		if test.Where != "" {
+			im.report(path, "the '%s' test of the column '%s' is not converted, checks cannot be filtered with 'where'", test.Name, spec.Name)
+			continue
+		}
+
+		var blocking *bool
+		if test.Severity == "warn" {
+			blocking = new(bool)
+		}
+
+		switch strings.TrimPrefix(test.Name, "dbt_utils.") {
+		case "not_null", "unique":
+			column.Checks = append(column.Checks, pipeline.NewColumnCheck(asset.Name, spec.Name, test.Name, pipeline.ColumnCheckValue{}, blocking, ""))
+		case "accepted_values":
+			value, ok := acceptedValues(test.Args["values"])
+			if !ok {
+				im.report(path, "the values of the 'accepted_values' test of the column '%s' could not be read", spec.Name)
+				continue
+			}
+			column.Checks = append(column.Checks, pipeline.NewColumnCheck(asset.Name, spec.Name, "accepted_values", value, blocking, ""))
+		case "relationships":
+			check, err := im.relationshipCheck(spec.Name, test)
+			if err != nil {
+				im.report(path, "the 'relationships' test of the column '%s' is not converted: %s", spec.Name, err)
+				continue
+			}
+			check.Blocking = pipeline.DefaultTrueBool{Value: blocking}
+			asset.CustomChecks = append(asset.CustomChecks, *check)
+			im.summary.Checks++
+			continue
+		default:
+			im.report(path, "the '%s' test of the column '%s' is not converted, it can be added as a custom check", test.Name, spec.Name)
+			continue
+		}
+		im.summary.Checks++
+	}
+
+	return column
+}
+
+func acceptedValues(raw any) (pipeline.ColumnCheckValue, bool) {
+	values, ok := raw.([]any)
+	if !ok || len(values) == 0 {
+		return pipeline.ColumnCheckValue{}, false
+	}
+
+	ints := make([]int, 0, len(values))
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		if i, ok := value.(int); ok {
+			ints = append(ints, i)
+		}
+		strs = append(strs, fmt.Sprint(value))
+	}
+
+	if len(ints) == len(values) {
+		return pipeline.ColumnCheckValue{IntArray: &ints}, true
+	}
+
+	return pipeline.ColumnCheckValue{StringArray: &strs}, true
+}
+
+// relationshipCheck converts the `relationships` test to a custom check that counts the rows without a parent.
+func (im *importer) relationshipCheck(columnName string, test *genericTest) (*pipeline.CustomCheck, error) {
+	to := fmt.Sprint(test.Args["to"])
+	field := fmt.Sprint(test.Args["field"])
+	if test.Args["to"] == nil || test.Args["field"] == nil {
+		return nil, errors.New("'to' and 'field' are required")
+	}
+
+	rewriter := &sqlRewriter{resolveRef: im.resolveRef, resolveSource: im.resolveSource}
+	parent := rewriter.rewrite("{{ " + to + " }}")
+	if len(parent.Dependencies) != 1 || len(parent.Issues) > 0 {
+		return nil, fmt.Errorf("the parent '%s' could not be resolved", to)
+	}
+
+	query := fmt.Sprintf(`SELECT count(*)
+FROM {{ this }} AS child
+LEFT JOIN %s AS parent ON child.%s = parent.%s
+WHERE child.%s IS NOT NULL AND parent.%s IS NULL`, parent.Query, columnName, field, columnName, field)
+
+	return &pipeline.CustomCheck{
+		Name:        fmt.Sprintf("%s_references_%s", columnName, parent.Dependencies[0]),
+		Description: fmt.Sprintf("every %s has a matching %s in %s", columnName, field, parent.Dependencies[0]),
+		Value:       0,
+		Query:       query,
+	}, nil
+}
+
+// setMergeColumns marks the unique key of the model as the primary key, dbt updates every column on merge while Bruin
+// only updates and inserts the columns in the asset definition.
+func (im *importer) setMergeColumns(asset *pipeline.Asset, path string, primaryKeys []string) {
+	isKey := make(map[string]bool, len(primaryKeys))
+	for _, key := range primaryKeys {
+		isKey[strings.ToLower(key)] = true
+	}
+
+	found := make(map[string]bool, len(primaryKeys))
+	for i := range asset.Columns {
+		name := strings.ToLower(asset.Columns[i].Name)
+		if isKey[name] {
+			asset.Columns[i].PrimaryKey = true
+			found[name] = true
+			continue
+		}
+		asset.Columns[i].UpdateOnMerge = true
+	}
+
+	for _, key := range primaryKeys {
+		if !found[strings.ToLower(key)] {
+			asset.Columns = append(asset.Columns, pipeline.Column{Name: key, PrimaryKey: true})
+		}
+	}
+
+	if len(asset.Columns) == len(primaryKeys) {
+		im.report(path, "the merge strategy only writes the columns of the asset, list the columns of the model in the asset definition")
+	}
+}
+
+// materialization maps the dbt materialization of the model, it returns the primary key columns used by the merge
+// strategy along with it.
+func (im *importer) materialization(path string, config nodeConfig) (pipeline.Materialization, []string) {
+	materialized := config.string("materialized")
+	if materialized == "" {
+		materialized = "view"
+	}
+
+	var m pipeline.Materialization
+	var primaryKeys []string
+	switch materialized {
+	case "view":
+		m.Type = pipeline.MaterializationTypeView
+	case "table":
+		m.Type = pipeline.MaterializationTypeTable
+	case "ephemeral":
+		m.Type = pipeline.MaterializationTypeView
+		im.report(path, "ephemeral models are converted to views")
+	case "incremental":
+		m.Type = pipeline.MaterializationTypeTable
+		primaryKeys = im.incrementalStrategy(path, config, &m)
+	default:
+		m.Type = pipeline.MaterializationTypeTable
+		im.report(path, "the '%s' materialization is not supported, the model is converted to a table", materialized)
+	}
+
+	if m.Type == pipeline.MaterializationTypeTable {
+		switch partition := config["partition_by"].(type) {
+		case string:
+			m.PartitionBy = partition
+		case map[string]any:
+			m.PartitionBy = fmt.Sprint(partition["field"])
+			if granularity, ok := partition["granularity"]; ok && granularity != "day" {
+				im.report(path, "the '%s' granularity of the partition is not converted", granularity)
+			}
+		}
+		m.ClusterBy = config.strings("cluster_by")
+	}
+
+	return m, primaryKeys
+}
+
+func (im *importer) incrementalStrategy(path string, config nodeConfig, m *pipeline.Materialization) []string {
+	uniqueKeys := config.strings("unique_key")
+	strategy := config.string("incremental_strategy")
+
+	switch strategy {
+	case "merge", "":
+		if len(uniqueKeys) == 0 {
+			// dbt appends the new rows when there's no unique key
+			m.Strategy = pipeline.MaterializationStrategyAppend
+			return nil
+		}
+		m.Strategy = pipeline.MaterializationStrategyMerge
+		return uniqueKeys
+	case "append":
+		m.Strategy = pipeline.MaterializationStrategyAppend
+	case "delete+insert":
+		m.Strategy = pipeline.MaterializationStrategyDeleteInsert
+		if len(uniqueKeys) == 0 {
+			im.report(path, "the delete+insert strategy requires a unique key, set the incremental_key of the asset")
+			return nil
+		}
+		m.IncrementalKey = uniqueKeys[0]
+		if len(uniqueKeys) > 1 {
+			im.report(path, "delete+insert uses a single incremental key, only '%s' is used", uniqueKeys[0])
+		}
+	case "insert_overwrite":
+		m.Strategy = pipeline.MaterializationStrategyDeleteInsert
+		switch partition := config["partition_by"].(type) {
+		case string:
+			m.IncrementalKey = partition
+		case map[string]any:
+			m.IncrementalKey = fmt.Sprint(partition["field"])
+		default:
+			im.report(path, "the insert_overwrite strategy requires a partition, set the incremental_key of the asset")
+		}
+	case "microbatch":
+		m.Strategy = pipeline.MaterializationStrategyMicrobatch
+		m.IncrementalKey = config.string("event_time")
+		m.TimeGranularity = pipeline.MaterializationTimeGranularityTimestamp
+		if m.IncrementalKey == "" {
+			im.report(path, "the microbatch strategy requires an event_time, set the incremental_key of the asset")
+		}
+
+		switch batchSize := config.string("batch_size"); batchSize {
+		case "hour", "day", "month":
+			m.BatchSize = pipeline.MaterializationBatchSize(batchSize)
+		default:
+			m.BatchSize = pipeline.MaterializationBatchSizeDay
+			im.report(path, "the '%s' batch size is not supported, the batches are daily", batchSize)
+		}
+		if lookback, err := strconv.Atoi(config.string("lookback")); err == nil {
+			m.Lookback = lookback
+		}
+	default:
+		m.Strategy = pipeline.MaterializationStrategyAppend
+		im.report(path, "the '%s' incremental strategy is not supported, the model is converted to append", strategy)
+	}
+
+	return nil
+}
+
+// reportConfig reports the configs of the model that have no equivalent in the asset definitions.
+func (im *importer) reportConfig(path string, config nodeConfig) {
+	for _, key := range []string{"pre-hook", "post-hook"} {
+		if hooks := config.strings(key); len(hooks) > 0 {
+			im.report(path, "the %s is not converted, it can be added to the query or as a separate asset", key)
+		}
+	}
+	if database := config.string("database"); database != "" {
+		im.report(path, "the custom database '%s' is not converted", database)
+	}
+}
+
+// collectVariables adds the variables used by the models to the pipeline, along with their defaults.
+func (im *importer) collectVariables(path string, variables map[string]string) {
+	for name, rawDefault := range variables {
+		if _, ok := im.variables[name]; ok {
+			continue
+		}
+
+		if rawDefault == "" {
+			im.report(path, "the variable '%s' has no default value, add it to pipeline.yml", name)
+			continue
+		}
+
+		parsed, err := parseConfigCall("default=" + rawDefault)
+		if err != nil {
+			im.report(path, "the default value of the variable '%s' could not be parsed", name)
+			continue
+		}
+		im.variables[name] = parsed["default"]
+	}
+}
+
+func (im *importer) reportUnsupportedFolders() error {
+	folders := []struct {
+		paths   []string
+		message string
+	}{
+		{im.project.SnapshotPaths, "snapshots are not converted, they can be rewritten as assets with the merge strategy"},
+		{im.project.MacroPaths, "macros are not converted, the models using them are listed in the summary"},
+		{im.project.TestPaths, "singular tests are not converted, they can be added as custom checks"},
+		{im.project.AnalysisPaths, "analyses are not converted"},
+	}
+
+	for _, folder := range folders {
+		err := im.walk(folder.paths, []string{".sql"}, func(root, relPath string) error {
+			im.report(filepath.Join(root, relPath), "%s", folder.message)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (im *importer) writePipeline() error {
+	definition := struct {
+		Name      string                    `yaml:"name"`
+		Variables map[string]map[string]any `yaml:"variables,omitempty"`
+	}{
+		Name: im.project.Name,
+	}
+
+	if len(im.variables) > 0 {
+		definition.Variables = make(map[string]map[string]any, len(im.variables))
+		for name, value := range im.variables {
+			definition.Variables[name] = map[string]any{"type": jsonSchemaType(value), "default": value}
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(definition); err != nil {
+		return errors.Wrap(err, "failed to generate pipeline.yml")
+	}
+
+	if err := im.fs.MkdirAll(im.outputPath, 0o755); err != nil {
+		return err
+	}
+
+	return afero.WriteFile(im.fs, filepath.Join(im.outputPath, "pipeline.yml"), buf.Bytes(), 0o644)
+}
+
+func jsonSchemaType(value any) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case int, int64:
+		return "integer"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+func (im *importer) persist(asset *pipeline.Asset) error {
+	if err := im.fs.MkdirAll(filepath.Dir(asset.ExecutableFile.Path), 0o755); err != nil {
+		return err
+	}
+
+	return errors.Wrapf(asset.Persist(im.fs), "failed to write the asset '%s'", asset.Name)
+}
@@ -0,0 +1,154 @@
+package dbt
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	projectPath, err := filepath.Abs("testdata/jaffle_shop")
+	require.NoError(t, err)
+
+	fs := afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(afero.NewOsFs()), afero.NewMemMapFs())
+	summary, err := Import(fs, projectPath, "/out", ImportOptions{ProfilesDir: projectPath})
+	require.NoError(t, err)
+
+	assert.Equal(t, "jaffle_shop", summary.Pipeline)
+	assert.Equal(t, 4, summary.Models)
+	assert.Equal(t, 1, summary.Seeds)
+	assert.Equal(t, 2, summary.Sources)
+	assert.Equal(t, 4, summary.Checks)
+	assert.Equal(t, []Issue{
+		{Path: "macros/cents_to_dollars.sql", Message: "macros are not converted, the models using them are listed in the summary"},
+		{Path: "models/marts/legacy.sql", Message: "the model is disabled, it is not converted"},
+		{Path: "models/marts/orders.sql", Message: "the is_incremental() block was removed, filter the query with the dates of the run instead, e.g. {{ start_datetime }}"},
+		{Path: "models/marts/orders.sql", Message: "the merge strategy only writes the columns of the asset, list the columns of the model in the asset definition"},
+		{Path: "models/staging/schema.yml", Message: "the tests of the source table 'raw.orders' are not converted, sources are not built by the pipeline"},
+		{Path: "models/staging/stg_customers.sql", Message: "cents_to_dollars() is not available, it is likely a macro that needs to be inlined"},
+		{Path: "models/staging/stg_customers.sql", Message: "the 'not_null' test of the column 'customer_id' is not converted, checks cannot be filtered with 'where'"},
+		{Path: "models/staging/stg_orders.sql", Message: "the 'dbt_expectations.expect_column_to_exist' test of the column 'customer_id' is not converted, it can be added as a custom check"},
+		{Path: "snapshots/customers_snapshot.sql", Message: "snapshots are not converted, they can be rewritten as assets with the merge strategy"},
+		{Path: "tests/assert_positive_amounts.sql", Message: "singular tests are not converted, they can be added as custom checks"},
+	}, summary.Issues)
+
+	pipelineDefinition, err := afero.ReadFile(fs, "/out/pipeline.yml")
+	require.NoError(t, err)
+	assert.Equal(t, `name: jaffle_shop
+variables:
+  currency:
+    default: USD
+    type: string
+  start_date:
+    default: "2018-01-01"
+    type: string
+`, string(pipelineDefinition))
+
+	orders, err := afero.ReadFile(fs, "/out/assets/marts/orders.sql")
+	require.NoError(t, err)
+	assert.Equal(t, `/* @bruin
+
+name: analytics_marts.orders
+type: pg.sql
+tags:
+  - marts
+
+materialization:
+  type: table
+  strategy: merge
+  cluster_by:
+    - customer_id
+
+depends:
+  - analytics.stg_orders
+  - analytics.payments
+
+columns:
+  - name: order_id
+    primary_key: true
+
+@bruin */
+
+select
+    o.order_id,
+    o.customer_id,
+    o.order_date,
+    p.amount
+from {{ ref('analytics.stg_orders') }} as o
+left join {{ ref('analytics.payments') }} as p on p.order_id = o.order_id
+
+`, string(orders))
+
+	seed, err := afero.ReadFile(fs, "/out/assets/seeds/payments.asset.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "name: analytics.payments\ntype: pg.seed\n\nparameters:\n  path: payments.csv\n", string(seed))
+	assert.FileExists(t, filepath.Join(projectPath, "seeds/payments.csv"))
+	exists, err := afero.Exists(fs, "/out/assets/seeds/payments.csv")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	source, err := afero.ReadFile(fs, "/out/assets/sources/raw/customers.asset.yml")
+	require.NoError(t, err)
+	assert.Equal(t, "name: raw_data.customers\ntype: empty\ndescription: The customers of the shop.\n", string(source))
+
+	// the generated assets are parsed back to make sure they are valid
+	builder := pipeline.NewBuilder(pipeline.BuilderConfig{
+		PipelineFileName:   []string{"pipeline.yml"},
+		TasksDirectoryName: "assets",
+		TasksFileSuffixes:  []string{"asset.yml"},
+	}, pipeline.CreateTaskFromYamlDefinition(fs), pipeline.CreateTaskFromFileComments(fs), fs, nil)
+
+	stgOrders, err := builder.CreateAssetFromFile("/out/assets/staging/stg_orders.sql", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "analytics.stg_orders", stgOrders.Name)
+	require.Len(t, stgOrders.Upstreams, 1)
+	assert.Equal(t, "raw_data.raw_orders", stgOrders.Upstreams[0].Value)
+	require.Len(t, stgOrders.Columns, 3)
+	assert.Equal(t, "integer", stgOrders.Columns[0].Type)
+	assert.Equal(t, []string{"unique", "not_null"}, []string{stgOrders.Columns[0].Checks[0].Name, stgOrders.Columns[0].Checks[1].Name})
+	assert.Equal(t, []string{"placed", "shipped", "completed", "returned"}, *stgOrders.Columns[1].Checks[0].Value.StringArray)
+	assert.False(t, stgOrders.Columns[1].Checks[0].Blocking.Bool())
+	require.Len(t, stgOrders.CustomChecks, 1)
+	assert.Contains(t, stgOrders.CustomChecks[0].Query, "LEFT JOIN {{ ref('analytics.stg_customers') }} AS parent ON child.customer_id = parent.customer_id")
+
+	customers, err := builder.CreateAssetFromFile("/out/assets/marts/customers.sql", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "analytics_marts.dim_customers", customers.Name)
+	assert.Equal(t, pipeline.MaterializationTypeTable, customers.Materialization.Type)
+	assert.Contains(t, customers.ExecutableFile.Content, "'{{ var.currency }}' as currency")
+}
+
+func TestImport_Errors(t *testing.T) {
+	t.Parallel()
+
+	projectPath, err := filepath.Abs("testdata/jaffle_shop")
+	require.NoError(t, err)
+
+	t.Run("unknown profiles require the asset type", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(afero.NewOsFs()), afero.NewMemMapFs())
+		_, err := Import(fs, projectPath, "/out", ImportOptions{ProfilesDir: t.TempDir()})
+		require.EqualError(t, err, "the profile 'jaffle_shop' could not be found, please set the asset type explicitly")
+
+		_, err = Import(fs, projectPath, "/out", ImportOptions{ProfilesDir: t.TempDir(), AssetType: pipeline.AssetTypeBigqueryQuery})
+		require.EqualError(t, err, "the target schema could not be read from the dbt profile, please set the schema explicitly")
+	})
+
+	t.Run("the output folder must be empty", func(t *testing.T) {
+		t.Parallel()
+
+		fs := afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(afero.NewOsFs()), afero.NewMemMapFs())
+		require.NoError(t, fs.MkdirAll("/out", 0o755))
+		require.NoError(t, afero.WriteFile(fs, "/out/pipeline.yml", []byte("name: existing\n"), 0o644))
+
+		_, err := Import(fs, projectPath, "/out", ImportOptions{ProfilesDir: projectPath})
+		require.EqualError(t, err, "the output folder '/out' is not empty")
+	})
+}
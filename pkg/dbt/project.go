@@ -0,0 +1,377 @@
+package dbt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// Project is the subset of `dbt_project.yml` the importer uses.
+type Project struct {
+	Name          string         `yaml:"name"`
+	Profile       string         `yaml:"profile"`
+	ModelPaths    []string       `yaml:"model-paths"`
+	SourcePaths   []string       `yaml:"source-paths"`
+	SeedPaths     []string       `yaml:"seed-paths"`
+	DataPaths     []string       `yaml:"data-paths"`
+	SnapshotPaths []string       `yaml:"snapshot-paths"`
+	MacroPaths    []string       `yaml:"macro-paths"`
+	TestPaths     []string       `yaml:"test-paths"`
+	AnalysisPaths []string       `yaml:"analysis-paths"`
+	Vars          map[string]any `yaml:"vars"`
+	Models        map[string]any `yaml:"models"`
+	Seeds         map[string]any `yaml:"seeds"`
+}
+
+func readProject(fs afero.Fs, projectPath string) (*Project, error) {
+	content, err := afero.ReadFile(fs, filepath.Join(projectPath, "dbt_project.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dbt_project.yml: %w", err)
+	}
+
+	var project Project
+	if err := yaml.Unmarshal(content, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse dbt_project.yml: %w", err)
+	}
+	if project.Name == "" {
+		return nil, fmt.Errorf("dbt_project.yml does not have a name")
+	}
+
+	// the paths were renamed in dbt 1.0, the old names are still accepted
+	project.ModelPaths = firstNonEmpty(project.ModelPaths, project.SourcePaths, []string{"models"})
+	project.SeedPaths = firstNonEmpty(project.SeedPaths, project.DataPaths, []string{"seeds"})
+	project.SnapshotPaths = firstNonEmpty(project.SnapshotPaths, []string{"snapshots"})
+	project.MacroPaths = firstNonEmpty(project.MacroPaths, []string{"macros"})
+	project.TestPaths = firstNonEmpty(project.TestPaths, []string{"tests"})
+	project.AnalysisPaths = firstNonEmpty(project.AnalysisPaths, []string{"analyses"})
+
+	return &project, nil
+}
+
+// variables returns the variables of the project, the ones scoped to the project itself are flattened.
+func (p *Project) variables() map[string]any {
+	vars := make(map[string]any, len(p.Vars))
+	for key, value := range p.Vars {
+		if scoped, ok := value.(map[string]any); ok && key == p.Name {
+			for k, v := range scoped {
+				vars[k] = v
+			}
+			continue
+		}
+		vars[key] = value
+	}
+
+	return vars
+}
+
+func firstNonEmpty(values ...[]string) []string {
+	for _, v := range values {
+		if len(v) > 0 {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// nodeConfig is the config of a model or a seed, with the `+` prefixes of `dbt_project.yml` removed.
+type nodeConfig map[string]any
+
+// configKeys are the configs that can be set without the `+` prefix in `dbt_project.yml`, any other key is a folder.
+var configKeys = map[string]bool{
+	"materialized": true, "schema": true, "alias": true, "database": true, "tags": true, "enabled": true,
+	"unique_key": true, "incremental_strategy": true, "partition_by": true, "cluster_by": true, "meta": true,
+	"event_time": true, "batch_size": true, "lookback": true, "pre-hook": true, "post-hook": true,
+	"docs": true, "persist_docs": true, "grants": true, "on_schema_change": true, "full_refresh": true,
+	"contract": true, "column_types": true, "quote_columns": true, "delimiter": true,
+}
+
+func (c nodeConfig) merge(other map[string]any) nodeConfig {
+	merged := make(nodeConfig, len(c)+len(other))
+	for key, value := range c {
+		merged[key] = value
+	}
+	for key, value := range other {
+		merged[strings.TrimPrefix(key, "+")] = value
+	}
+
+	return merged
+}
+
+func (c nodeConfig) string(key string) string {
+	value, ok := c[key]
+	if !ok || value == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+// strings returns the config either given as a single value or as a list.
+func (c nodeConfig) strings(key string) []string {
+	switch value := c[key].(type) {
+	case nil:
+		return nil
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			values = append(values, fmt.Sprint(v))
+		}
+		return values
+	default:
+		return []string{fmt.Sprint(value)}
+	}
+}
+
+func (c nodeConfig) enabled() bool {
+	value, ok := c["enabled"].(bool)
+	return !ok || value
+}
+
+// configForPath resolves the configs in the `models` or `seeds` tree of `dbt_project.yml` that apply to the node in
+// the given folder, the configs of the nested folders override the ones of their parents.
+func configForPath(tree map[string]any, projectName string, folders []string) nodeConfig {
+	config := nodeConfig{}
+	config = config.merge(configsOf(tree))
+
+	node, ok := tree[projectName].(map[string]any)
+	if !ok {
+		return config
+	}
+	config = config.merge(configsOf(node))
+
+	for _, folder := range folders {
+		node, ok = node[folder].(map[string]any)
+		if !ok {
+			break
+		}
+		config = config.merge(configsOf(node))
+	}
+
+	return config
+}
+
+func configsOf(node map[string]any) map[string]any {
+	configs := make(map[string]any)
+	for key, value := range node {
+		if strings.HasPrefix(key, "+") {
+			configs[key] = value
+			continue
+		}
+
+		if _, isFolder := value.(map[string]any); configKeys[key] && (!isFolder || key == "meta" || key == "partition_by") {
+			configs[key] = value
+		}
+	}
+
+	return configs
+}
+
+// schemaFile is a YAML file with the properties of the models, seeds and sources.
+type schemaFile struct {
+	Models  []nodeSpec   `yaml:"models"`
+	Seeds   []nodeSpec   `yaml:"seeds"`
+	Sources []sourceSpec `yaml:"sources"`
+}
+
+type nodeSpec struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Config      map[string]any `yaml:"config"`
+	Meta        map[string]any `yaml:"meta"`
+	Columns     []columnSpec   `yaml:"columns"`
+	Tests       []any          `yaml:"tests"`
+	DataTests   []any          `yaml:"data_tests"`
+}
+
+func (n nodeSpec) tests() []any {
+	return append(append([]any{}, n.Tests...), n.DataTests...)
+}
+
+type columnSpec struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	DataType    string `yaml:"data_type"`
+	Tests       []any  `yaml:"tests"`
+	DataTests   []any  `yaml:"data_tests"`
+}
+
+func (c columnSpec) tests() []any {
+	return append(append([]any{}, c.Tests...), c.DataTests...)
+}
+
+type sourceSpec struct {
+	Name        string            `yaml:"name"`
+	Schema      string            `yaml:"schema"`
+	Description string            `yaml:"description"`
+	Tables      []sourceTableSpec `yaml:"tables"`
+}
+
+type sourceTableSpec struct {
+	Name        string       `yaml:"name"`
+	Identifier  string       `yaml:"identifier"`
+	Description string       `yaml:"description"`
+	Columns     []columnSpec `yaml:"columns"`
+	Tests       []any        `yaml:"tests"`
+	DataTests   []any        `yaml:"data_tests"`
+}
+
+// genericTest is a test in the properties of a model, e.g. `not_null` or `accepted_values: {values: [a, b]}`.
+type genericTest struct {
+	Name     string
+	Args     map[string]any
+	Severity string
+	Where    string
+}
+
+func parseTest(raw any) (*genericTest, error) {
+	switch test := raw.(type) {
+	case string:
+		return &genericTest{Name: test, Args: map[string]any{}}, nil
+	case map[string]any:
+		if len(test) != 1 {
+			return nil, fmt.Errorf("unsupported test definition: %v", test)
+		}
+
+		for name, value := range test {
+			parsed := &genericTest{Name: name, Args: map[string]any{}}
+			args, _ := value.(map[string]any)
+			for key, arg := range args {
+				switch key {
+				case "config":
+					if config, ok := arg.(map[string]any); ok {
+						parsed.Severity = strings.ToLower(fmt.Sprint(config["severity"]))
+						if where, ok := config["where"]; ok {
+							parsed.Where = fmt.Sprint(where)
+						}
+					}
+				case "arguments":
+					// dbt 1.10 moved the test arguments under their own key
+					if nested, ok := arg.(map[string]any); ok {
+						for k, v := range nested {
+							parsed.Args[k] = v
+						}
+					}
+				case "severity":
+					parsed.Severity = strings.ToLower(fmt.Sprint(arg))
+				case "where":
+					parsed.Where = fmt.Sprint(arg)
+				default:
+					parsed.Args[key] = arg
+				}
+			}
+
+			return parsed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported test definition: %v", raw)
+}
+
+// target is the output of the dbt profile the project uses.
+type target struct {
+	Type   string
+	Schema string
+}
+
+// adapterAssetTypes maps the dbt adapters to the asset types of the models and the seeds.
+var adapterAssetTypes = map[string][2]pipeline.AssetType{
+	"bigquery":   {pipeline.AssetTypeBigqueryQuery, pipeline.AssetTypeBigquerySeed},
+	"snowflake":  {pipeline.AssetTypeSnowflakeQuery, pipeline.AssetTypeSnowflakeSeed},
+	"postgres":   {pipeline.AssetTypePostgresQuery, pipeline.AssetTypePostgresSeed},
+	"redshift":   {pipeline.AssetTypeRedshiftQuery, pipeline.AssetTypeRedshiftSeed},
+	"databricks": {pipeline.AssetTypeDatabricksQuery, pipeline.AssetTypeDatabricksSeed},
+	"duckdb":     {pipeline.AssetTypeDuckDBQuery, pipeline.AssetTypeDuckDBSeed},
+	"trino":      {pipeline.AssetTypeTrinoQuery, pipeline.AssetTypeTrinoSeed},
+	"athena":     {pipeline.AssetTypeAthenaQuery, pipeline.AssetTypeAthenaSeed},
+	"clickhouse": {pipeline.AssetTypeClickHouse, pipeline.AssetTypeClickHouseSeed},
+	"sqlserver":  {pipeline.AssetTypeMsSQLQuery, pipeline.AssetTypeMsSQLSeed},
+	"synapse":    {pipeline.AssetTypeSynapseQuery, pipeline.AssetTypeSynapseSeed},
+	"mysql":      {pipeline.AssetTypeMySQLQuery, pipeline.AssetTypeMySQLSeed},
+	"oracle":     {pipeline.AssetTypeOracleQuery, pipeline.AssetTypeOracleSeed},
+	"sqlite":     {pipeline.AssetTypeSQLiteQuery, pipeline.AssetTypeSQLiteSeed},
+}
+
+// seedTypeFor returns the seed asset type of the platform the given asset type belongs to.
+func seedTypeFor(assetType pipeline.AssetType) pipeline.AssetType {
+	for _, types := range adapterAssetTypes {
+		if types[0] == assetType {
+			return types[1]
+		}
+	}
+
+	return ""
+}
+
+// profileDirs returns the folders `profiles.yml` is looked up in, in the same order dbt does.
+func profileDirs(projectPath, profilesDir string) []string {
+	if profilesDir != "" {
+		return []string{profilesDir}
+	}
+
+	dirs := []string{projectPath}
+	if env := os.Getenv("DBT_PROFILES_DIR"); env != "" {
+		dirs = append([]string{env}, dirs...)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".dbt"))
+	}
+
+	return dirs
+}
+
+// readTarget reads the default target of the profile, it returns nil if the profile cannot be found.
+func readTarget(fs afero.Fs, dirs []string, profileName string) *target {
+	for _, dir := range dirs {
+		content, err := afero.ReadFile(fs, filepath.Join(dir, "profiles.yml"))
+		if err != nil {
+			continue
+		}
+
+		var profiles map[string]struct {
+			Target  string                    `yaml:"target"`
+			Outputs map[string]map[string]any `yaml:"outputs"`
+		}
+		if err := yaml.Unmarshal(content, &profiles); err != nil {
+			continue
+		}
+
+		profile, ok := profiles[profileName]
+		if !ok {
+			continue
+		}
+
+		targetName := profile.Target
+		if targetName == "" && len(profile.Outputs) > 0 {
+			names := make([]string, 0, len(profile.Outputs))
+			for name := range profile.Outputs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			targetName = names[0]
+		}
+
+		output, ok := profile.Outputs[targetName]
+		if !ok {
+			continue
+		}
+
+		t := &target{Type: fmt.Sprint(output["type"])}
+		for _, key := range []string{"schema", "dataset"} {
+			if schema, ok := output[key].(string); ok && !strings.Contains(schema, "{{") {
+				t.Schema = schema
+				break
+			}
+		}
+
+		return t
+	}
+
+	return nil
+}
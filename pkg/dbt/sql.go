@@ -0,0 +1,281 @@
+package dbt
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	configBlockRegex = regexp.MustCompile(`(?s)\{\{-?\s*config\((.*?)\)\s*-?\}\}[ \t]*\n?`)
+	refCallRegex     = regexp.MustCompile(`\bref\(\s*['"]([^'"]+)['"]\s*(?:,\s*['"]([^'"]+)['"]\s*)?(?:,\s*v(?:ersion)?\s*=\s*[^)]*)?\)`)
+	sourceCallRegex  = regexp.MustCompile(`\bsource\(\s*['"]([^'"]+)['"]\s*,\s*['"]([^'"]+)['"]\s*\)`)
+	varCallRegex     = regexp.MustCompile(`\bvar\(\s*['"]([^'"]+)['"]\s*(?:,\s*([^)]*?)\s*)?\)`)
+	surrogateKeyCall = regexp.MustCompile(`\bdbt_utils\.(?:generate_)?surrogate_key\(`)
+	jinjaBlockRegex  = regexp.MustCompile(`(?s)\{\{.*?\}\}|\{%.*?%\}`)
+	jinjaTagRegex    = regexp.MustCompile(`(?s)\{%-?\s*(\w+)(.*?)-?%\}`)
+	callRegex        = regexp.MustCompile(`(?:^|[^\w.|])([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)?)\s*\(`)
+	targetRegex      = regexp.MustCompile(`\btarget\.\w+`)
+)
+
+// supportedFunctions are the functions that are available in the Jinja context of the SQL assets.
+var supportedFunctions = map[string]bool{
+	"ref": true, "source": true, "var": true, "surrogate_key": true, "star": true, "date_spine": true,
+	"union_relations": true, "pivot": true, "range": true, "dict": true, "lipsum": true, "cycler": true,
+	"joiner": true, "namespace": true, "caller": true,
+}
+
+// dbtNamespaces are the objects and packages that are only available in dbt, the calls to their functions cannot
+// be converted.
+var dbtNamespaces = map[string]bool{
+	"dbt": true, "dbt_utils": true, "adapter": true, "api": true, "modules": true, "exceptions": true,
+	"dbt_date": true, "dbt_expectations": true, "audit_helper": true, "codegen": true, "fivetran_utils": true,
+}
+
+// modelSQL is a model after its Jinja is converted to the Bruin equivalents.
+type modelSQL struct {
+	Query        string
+	Dependencies []string
+	// Variables are the variables used in the model along with the default given to `var()`, if any.
+	Variables map[string]string
+	Issues    []string
+}
+
+type sqlRewriter struct {
+	resolveRef    func(name string) (string, bool)
+	resolveSource func(source, table string) (string, bool)
+}
+
+// extractConfig removes the `{{ config(...) }}` blocks from the model and returns the configs they set.
+func extractConfig(query string) (string, map[string]any, []string) {
+	config := map[string]any{}
+	var issues []string
+	query = configBlockRegex.ReplaceAllStringFunc(query, func(block string) string {
+		parsed, err := parseConfigCall(configBlockRegex.FindStringSubmatch(block)[1])
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("the config block could not be parsed: %s", err))
+			return ""
+		}
+		for key, value := range parsed {
+			config[key] = value
+		}
+		return ""
+	})
+
+	return query, config, issues
+}
+
+// rewrite converts the Jinja of the model to the Bruin equivalents, the config blocks must be extracted beforehand.
+func (r *sqlRewriter) rewrite(query string) *modelSQL {
+	result := &modelSQL{Variables: map[string]string{}}
+
+	query, removed := removeIncrementalBlocks(query)
+	if removed {
+		result.Issues = append(result.Issues, "the is_incremental() block was removed, filter the query with the dates of the run instead, e.g. {{ start_datetime }}")
+	}
+
+	seen := map[string]bool{}
+	addDependency := func(name string) {
+		if !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			result.Dependencies = append(result.Dependencies, name)
+		}
+	}
+
+	query = refCallRegex.ReplaceAllStringFunc(query, func(call string) string {
+		match := refCallRegex.FindStringSubmatch(call)
+		model := match[1]
+		if match[2] != "" {
+			// ref('package', 'model')
+			model = match[2]
+		}
+
+		name, ok := r.resolveRef(model)
+		if !ok {
+			result.Issues = append(result.Issues, fmt.Sprintf("ref('%s') refers to an unknown model", model))
+			return call
+		}
+
+		addDependency(name)
+		return fmt.Sprintf("ref('%s')", name)
+	})
+
+	query = sourceCallRegex.ReplaceAllStringFunc(query, func(call string) string {
+		match := sourceCallRegex.FindStringSubmatch(call)
+		name, ok := r.resolveSource(match[1], match[2])
+		if !ok {
+			result.Issues = append(result.Issues, fmt.Sprintf("source('%s', '%s') refers to an unknown source", match[1], match[2]))
+			return call
+		}
+
+		addDependency(name)
+		return fmt.Sprintf("source('%s')", name)
+	})
+
+	query = varCallRegex.ReplaceAllStringFunc(query, func(call string) string {
+		match := varCallRegex.FindStringSubmatch(call)
+		if _, ok := result.Variables[match[1]]; !ok || match[2] != "" {
+			result.Variables[match[1]] = match[2]
+		}
+
+		return "var." + match[1]
+	})
+
+	query = surrogateKeyCall.ReplaceAllString(query, "surrogate_key(")
+
+	result.Issues = append(result.Issues, unsupportedJinja(query)...)
+	result.Query = strings.TrimLeft(query, "\n")
+
+	return result
+}
+
+// unsupportedJinja lists the Jinja constructs that are specific to dbt and have no equivalent in Bruin.
+func unsupportedJinja(query string) []string {
+	found := map[string]bool{}
+	for _, block := range jinjaBlockRegex.FindAllString(query, -1) {
+		if tag := jinjaTagRegex.FindStringSubmatch(block); tag != nil {
+			switch tag[1] {
+			case "macro", "call", "materialization", "snapshot", "test":
+				found[fmt.Sprintf("the '%s' tag is not supported", tag[1])] = true
+			}
+		}
+
+		for _, match := range callRegex.FindAllStringSubmatch(block, -1) {
+			name := match[1]
+			namespace, _, qualified := strings.Cut(name, ".")
+			switch {
+			case qualified && dbtNamespaces[namespace]:
+				found[fmt.Sprintf("%s() is specific to dbt", name)] = true
+			case !qualified && !supportedFunctions[name]:
+				found[fmt.Sprintf("%s() is not available, it is likely a macro that needs to be inlined", name)] = true
+			}
+		}
+
+		for _, match := range targetRegex.FindAllString(block, -1) {
+			found[fmt.Sprintf("%s is specific to dbt", match)] = true
+		}
+	}
+
+	issues := make([]string, 0, len(found))
+	for issue := range found {
+		issues = append(issues, issue)
+	}
+	sort.Strings(issues)
+
+	return issues
+}
+
+// removeIncrementalBlocks removes the `{% if is_incremental() %}` blocks, keeping their `else` branch if there's one.
+// Bruin runs the same query for every run, the incremental behavior is configured with the materialization instead.
+func removeIncrementalBlocks(query string) (string, bool) {
+	removed := false
+	for {
+		next, ok := removeFirstIncrementalBlock(query)
+		if !ok {
+			return query, removed
+		}
+		query, removed = next, true
+	}
+}
+
+func removeFirstIncrementalBlock(query string) (string, bool) {
+	tags := jinjaTagRegex.FindAllStringSubmatchIndex(query, -1)
+	for i, tag := range tags {
+		if query[tag[2]:tag[3]] != "if" || strings.TrimSpace(query[tag[4]:tag[5]]) != "is_incremental()" {
+			continue
+		}
+
+		depth, elseStart := 0, -1
+		for _, inner := range tags[i+1:] {
+			switch query[inner[2]:inner[3]] {
+			case "if":
+				depth++
+			case "else":
+				if depth == 0 {
+					elseStart = inner[1]
+				}
+			case "endif":
+				if depth > 0 {
+					depth--
+					continue
+				}
+
+				elseContent := ""
+				if elseStart != -1 {
+					elseContent = query[elseStart:inner[0]]
+				}
+				return query[:tag[0]] + elseContent + query[inner[1]:], true
+			}
+		}
+
+		// the block is not closed, the query is left as is
+		return query, false
+	}
+
+	return query, false
+}
+
+// parseConfigCall parses the keyword arguments of a `config()` call, the values are Python literals which are mostly
+// valid YAML flow values.
+func parseConfigCall(args string) (map[string]any, error) {
+	config := map[string]any{}
+	for _, arg := range splitTopLevel(args) {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("unexpected argument '%s'", strings.TrimSpace(arg))
+		}
+
+		value = strings.TrimSpace(value)
+		switch value {
+		case "True":
+			value = "true"
+		case "False":
+			value = "false"
+		case "None":
+			value = "null"
+		}
+
+		var parsed any
+		if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+			return nil, fmt.Errorf("unsupported value for '%s': %s", strings.TrimSpace(key), value)
+		}
+		config[strings.TrimSpace(key)] = parsed
+	}
+
+	return config, nil
+}
+
+// splitTopLevel splits the arguments of a call by the commas that are not in a string, a list or a dict.
+func splitTopLevel(args string) []string {
+	var (
+		parts []string
+		depth int
+		quote rune
+		start int
+	)
+	for i, c := range args {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '{' || c == '(':
+			depth++
+		case c == ']' || c == '}' || c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, args[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(args[start:]) != "" {
+		parts = append(parts, args[start:])
+	}
+
+	return parts
+}
@@ -0,0 +1,161 @@
+package dbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractConfig(t *testing.T) {
+	t.Parallel()
+
+	query, config, issues := extractConfig(`{{
+  config(
+    materialized='incremental',
+    unique_key=['id', "day"],
+    partition_by={'field': 'day', 'data_type': 'date'},
+    full_refresh=False,
+  )
+}}
+select 1`)
+
+	assert.Equal(t, "select 1", query)
+	assert.Empty(t, issues)
+	assert.Equal(t, map[string]any{
+		"materialized": "incremental",
+		"unique_key":   []any{"id", "day"},
+		"partition_by": map[string]any{"field": "day", "data_type": "date"},
+		"full_refresh": false,
+	}, config)
+
+	_, _, issues = extractConfig("{{ config(materialized) }}select 1")
+	assert.Equal(t, []string{"the config block could not be parsed: unexpected argument 'materialized'"}, issues)
+}
+
+func TestSQLRewriter_rewrite(t *testing.T) {
+	t.Parallel()
+
+	rewriter := &sqlRewriter{
+		resolveRef: func(name string) (string, bool) {
+			if name == "missing" {
+				return "", false
+			}
+			return "analytics." + name, true
+		},
+		resolveSource: func(source, table string) (string, bool) {
+			return "raw." + table, true
+		},
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		want         string
+		dependencies []string
+		variables    map[string]string
+		issues       []string
+	}{
+		{
+			name:         "refs and sources are resolved to the asset names",
+			query:        "select * from {{ ref('orders') }} join {{ ref(\"pkg\", \"users\") }} join {{ source('app', 'events') }} join {{ ref('orders') }}",
+			want:         "select * from {{ ref('analytics.orders') }} join {{ ref('analytics.users') }} join {{ source('raw.events') }} join {{ ref('analytics.orders') }}",
+			dependencies: []string{"analytics.orders", "analytics.users", "raw.events"},
+		},
+		{
+			name:      "variables and the supported helpers are converted",
+			query:     "select {{ dbt_utils.generate_surrogate_key(['a', 'b']) }} where d > '{{ var('start', '2024-01-01') }}' and e = {{ var('limit') }}",
+			want:      "select {{ surrogate_key(['a', 'b']) }} where d > '{{ var.start }}' and e = {{ var.limit }}",
+			variables: map[string]string{"start": "'2024-01-01'", "limit": ""},
+		},
+		{
+			name: "incremental blocks are removed, keeping the else branch",
+			query: `select * from t
+{% if is_incremental() %}
+where d > (select max(d) from {{ this }})
+{% else %}
+where d > '2020-01-01'
+{% endif %}`,
+			want:   "select * from t\n\nwhere d > '2020-01-01'\n",
+			issues: []string{"the is_incremental() block was removed, filter the query with the dates of the run instead, e.g. {{ start_datetime }}"},
+		},
+		{
+			name:  "dbt specific constructs are reported",
+			query: "select {{ money('x') }}, {{ dbt_utils.pivot('y', []) }}, '{{ target.name }}' from {{ ref('missing') }}",
+			want:  "select {{ money('x') }}, {{ dbt_utils.pivot('y', []) }}, '{{ target.name }}' from {{ ref('missing') }}",
+			issues: []string{
+				"ref('missing') refers to an unknown model",
+				"dbt_utils.pivot() is specific to dbt",
+				"money() is not available, it is likely a macro that needs to be inlined",
+				"target.name is specific to dbt",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := rewriter.rewrite(tt.query)
+			assert.Equal(t, tt.want, got.Query)
+			assert.Equal(t, tt.dependencies, got.Dependencies)
+			assert.Equal(t, tt.issues, got.Issues)
+			if tt.variables != nil {
+				assert.Equal(t, tt.variables, got.Variables)
+			}
+		})
+	}
+}
+
+func TestParseTest(t *testing.T) {
+	t.Parallel()
+
+	test, err := parseTest("not_null")
+	require.NoError(t, err)
+	assert.Equal(t, &genericTest{Name: "not_null", Args: map[string]any{}}, test)
+
+	test, err = parseTest(map[string]any{
+		"accepted_values": map[string]any{
+			"arguments": map[string]any{"values": []any{1, 2}},
+			"config":    map[string]any{"severity": "WARN", "where": "id > 0"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &genericTest{
+		Name:     "accepted_values",
+		Args:     map[string]any{"values": []any{1, 2}},
+		Severity: "warn",
+		Where:    "id > 0",
+	}, test)
+
+	_, err = parseTest(map[string]any{"a": nil, "b": nil})
+	require.Error(t, err)
+}
+
+func TestConfigForPath(t *testing.T) {
+	t.Parallel()
+
+	tree := map[string]any{
+		"+tags": []any{"all"},
+		"jaffle_shop": map[string]any{
+			"+materialized": "view",
+			"marts": map[string]any{
+				"materialized": "table",
+				"meta":         map[string]any{"owner": "data"},
+				"finance": map[string]any{
+					"+schema": "finance",
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, nodeConfig{
+		"tags":         []any{"all"},
+		"materialized": "table",
+		"meta":         map[string]any{"owner": "data"},
+		"schema":       "finance",
+	}, configForPath(tree, "jaffle_shop", []string{"marts", "finance"}))
+	assert.Equal(t, nodeConfig{
+		"tags":         []any{"all"},
+		"materialized": "view",
+	}, configForPath(tree, "jaffle_shop", []string{"staging"}))
+}
@@ -0,0 +1,9 @@
+{{ config(alias='dim_customers', enabled=True) }}
+
+select
+    c.customer_id,
+    count(o.order_id) as number_of_orders,
+    '{{ var("currency", "USD") }}' as currency
+from {{ ref('stg_customers') }} as c
+left join {{ ref('orders') }} as o on o.customer_id = c.customer_id
+group by 1
@@ -0,0 +1,18 @@
+{{
+    config(
+        materialized='incremental',
+        unique_key='order_id',
+        cluster_by=['customer_id'],
+    )
+}}
+
+select
+    o.order_id,
+    o.customer_id,
+    o.order_date,
+    p.amount
+from {{ ref('stg_orders') }} as o
+left join {{ ref('payments') }} as p on p.order_id = o.order_id
+{% if is_incremental() %}
+where o.order_date > (select max(order_date) from {{ this }})
+{% endif %}
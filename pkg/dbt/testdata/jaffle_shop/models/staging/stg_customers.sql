@@ -0,0 +1,6 @@
+select
+    id as customer_id,
+    first_name,
+    last_name,
+    {{ cents_to_dollars('balance') }} as balance
+from {{ source('raw', 'customers') }}
@@ -0,0 +1,3 @@
+{% snapshot customers_snapshot %}
+select * from {{ source('raw', 'customers') }}
+{% endsnapshot %}
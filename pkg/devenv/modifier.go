@@ -113,9 +113,17 @@ func (d *DevEnvQueryModifier) Modify(ctx context.Context, p *pipeline.Pipeline,
 		renameMapping[originalAssetName] = assetName
 	}
 
+	// the relations referred to via `ref()` and `source()` are already resolved for the environment during rendering,
+	// only the tables that are written as plain names are guessed from the tables that exist in the database.
+	referencedTables := make(map[string]bool)
+	for _, name := range a.ReferencedAssets() {
+		referencedTables[strings.ToLower(name)] = true
+		referencedTables[strings.ToLower(env.SchemaPrefix+name)] = true
+	}
+
 	for _, tableReference := range usedTables {
 		parts := strings.Split(tableReference, ".")
-		if len(parts) != 2 {
+		if len(parts) != 2 || referencedTables[strings.ToLower(tableReference)] {
 			continue
 		}
 		schema := parts[0]
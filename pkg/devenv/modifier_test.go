@@ -72,6 +72,7 @@ func TestDevEnvQueryModifier_Modify(t *testing.T) {
 	}
 	tests := []struct {
 		name        string
+		asset       *pipeline.Asset
 		selectedEnv *config.Environment
 		setupFields func(f *fields)
 		inputQuery  string
@@ -209,6 +210,41 @@ func TestDevEnvQueryModifier_Modify(t *testing.T) {
 				).Return("select * from dev_schema1.table1 t1 join schema2.table1 t2 using (someid)", nil)
 			},
 		},
+		{
+			name: "the tables referred to via source() are not renamed, the rest are",
+			asset: &pipeline.Asset{
+				Name: "schema1.table2",
+				Type: pipeline.AssetTypePostgresQuery,
+				ExecutableFile: pipeline.ExecutableFile{
+					Path:    "assets/table2.sql",
+					Content: "select * from {{ source('schema2.table1') }} s join schema1.table1 t using (someid)",
+				},
+			},
+			selectedEnv: &config.Environment{SchemaPrefix: "dev_"},
+			inputQuery:  "select * from schema2.table1 s join schema1.table1 t using (someid)",
+			outputQuery: "select * from schema2.table1 s join dev_schema1.table1 t using (someid)",
+			setupFields: func(f *fields) {
+				c := new(mockConnectionInstance)
+				c.On("GetDatabaseSummary", mock.Anything).Return(&ansisql.DBDatabase{
+					Name: "db1",
+					Schemas: []*ansisql.DBSchema{
+						{Name: "dev_schema1", Tables: []*ansisql.DBTable{{Name: "table1"}}},
+						{Name: "dev_schema2", Tables: []*ansisql.DBTable{{Name: "table1"}}},
+					},
+				}, nil)
+				f.Conn.On("GetConnection", "postgres-default").Return(c, nil)
+
+				f.Parser.On("UsedTables", "select * from schema2.table1 s join schema1.table1 t using (someid)", "postgres").
+					Return([]string{"schema2.table1", "schema1.table1"}, nil)
+
+				f.Parser.On(
+					"RenameTables",
+					"select * from schema2.table1 s join schema1.table1 t using (someid)",
+					"postgres",
+					map[string]string{"schema1.table1": "dev_schema1.table1"},
+				).Return("select * from schema2.table1 s join dev_schema1.table1 t using (someid)", nil)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -231,7 +267,12 @@ func TestDevEnvQueryModifier_Modify(t *testing.T) {
 
 			ctx := context.WithValue(context.Background(), config.EnvironmentContextKey, tt.selectedEnv)
 
-			got, err := d.Modify(ctx, p, a, &query.Query{Query: tt.inputQuery})
+			asset := a
+			if tt.asset != nil {
+				asset = tt.asset
+			}
+
+			got, err := d.Modify(ctx, p, asset, &query.Query{Query: tt.inputQuery})
 			if tt.error != "" && (err == nil || tt.error != err.Error()) {
 				t.Errorf("Modify() error = %v, wantErr %v", err, tt.error)
 				return
@@ -8,3 +8,9 @@ import (
 type TableSummarizer interface {
 	GetTableSummary(ctx context.Context, tableName string) (*TableSummaryResult, error)
 }
+
+// TableSchemaFetcher defines an interface for connections that can return the columns of a table without
+// computing any statistics on its data.
+type TableSchemaFetcher interface {
+	GetTableSchema(ctx context.Context, tableName string) (*Table, error)
+}
@@ -244,6 +244,47 @@ func NewBigQueryTypeMapper() *DatabaseTypeMapper {
 	return mapper
 }
 
+// NewTrinoTypeMapper provides Trino-specific type mapping.
+func NewTrinoTypeMapper() *DatabaseTypeMapper {
+	mapper := NewDatabaseTypeMapper()
+
+	// Numeric types in Trino (base types only, prefix matching handles parametrized versions)
+	mapper.AddNumericTypes(
+		"tinyint", "smallint", "integer", "int", "bigint",
+		"real", "double", "decimal",
+	)
+
+	// String types in Trino
+	mapper.AddStringTypes(
+		"varchar", "char", "uuid",
+	)
+
+	// Boolean types in Trino
+	mapper.AddBooleanTypes(
+		"boolean",
+	)
+
+	// DateTime types in Trino, `timestamp(6) with time zone` is matched through its base type
+	mapper.AddDateTimeTypes(
+		"date", "time", "timestamp",
+	)
+
+	// Binary types in Trino
+	mapper.AddBinaryTypes(
+		"varbinary",
+	)
+
+	// JSON types in Trino
+	mapper.AddJSONTypes(
+		"json",
+	)
+
+	// Note: Trino ARRAY, MAP, ROW and interval types are not commonly comparable
+	// across databases, so they'll be mapped to CommonTypeUnknown by default
+
+	return mapper
+}
+
 type Table struct {
 	Name    string
 	Columns []*Column
@@ -256,6 +256,57 @@ func TestBigQueryTypeMapper(t *testing.T) {
 	}
 }
 
+func TestTrinoTypeMapper(t *testing.T) {
+	t.Parallel()
+	mapper := NewTrinoTypeMapper()
+
+	tests := []struct {
+		inputType      string
+		expectedResult CommonDataType
+	}{
+		// Trino numeric types
+		{"integer", CommonTypeNumeric},
+		{"bigint", CommonTypeNumeric},
+		{"double", CommonTypeNumeric},
+		{"real", CommonTypeNumeric},
+		{"decimal(38,9)", CommonTypeNumeric},
+
+		// Trino string types
+		{"varchar", CommonTypeString},
+		{"varchar(255)", CommonTypeString},
+		{"char(3)", CommonTypeString},
+		{"uuid", CommonTypeString},
+
+		// Trino boolean types
+		{"boolean", CommonTypeBoolean},
+
+		// Trino datetime types
+		{"date", CommonTypeDateTime},
+		{"timestamp(6)", CommonTypeDateTime},
+		{"timestamp(3) with time zone", CommonTypeDateTime},
+		{"time(3)", CommonTypeDateTime},
+
+		// Trino binary and JSON types
+		{"varbinary", CommonTypeBinary},
+		{"json", CommonTypeJSON},
+
+		// Trino specific unknown types
+		{"array(varchar)", CommonTypeUnknown},
+		{"map(varchar, integer)", CommonTypeUnknown},
+		{"row(name varchar, age integer)", CommonTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run("Trino_"+tt.inputType, func(t *testing.T) {
+			t.Parallel()
+			result := mapper.MapType(tt.inputType)
+			if result != tt.expectedResult {
+				t.Errorf("Trino mapper: MapType(%q) = %v, want %v", tt.inputType, result, tt.expectedResult)
+			}
+		})
+	}
+}
+
 func TestDatabaseTypeMapper_IsNumeric(t *testing.T) {
 	t.Parallel()
 	mapper := createTestMapper()
@@ -187,84 +187,89 @@ func (c *Client) GetTableSummary(ctx context.Context, tableName string) (*diff.T
 		return nil, fmt.Errorf("error after iterating rows for count query on table '%s': %w", tableName, err)
 	}
 
-	// Get table schema using PRAGMA table_info
-	schemaQuery := fmt.Sprintf("PRAGMA table_info('%s')", tableName)
-	schemaRows, err := c.connection.QueryContext(ctx, schemaQuery)
+	table, err := c.GetTableSchema(ctx, tableName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute PRAGMA table_info for table '%s': %w", tableName, err)
+		return nil, err
 	}
-	defer schemaRows.Close() // Defer close for schemaRows
-
-	var columns []*diff.Column
-	for schemaRows.Next() {
-		var (
-			cid       int
-			name      string
-			colType   string
-			notNull   bool
-			dfltValue sql.NullString
-			pk        bool
-		)
-
-		if err := schemaRows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
-			return nil, fmt.Errorf("failed to scan PRAGMA table_info result for table '%s': %w", tableName, err)
-		}
-
-		normalizedType := c.typeMapper.MapType(colType)
 
-		var stats diff.ColumnStatistics
-		switch normalizedType {
+	for _, col := range table.Columns {
+		switch col.NormalizedType {
 		case diff.CommonTypeNumeric:
-			stats, err = c.fetchNumericalStats(ctx, tableName, name)
+			col.Stats, err = c.fetchNumericalStats(ctx, tableName, col.Name)
 			if err != nil {
-				return nil, fmt.Errorf("failed to fetch numerical stats for column '%s': %w", name, err)
+				return nil, fmt.Errorf("failed to fetch numerical stats for column '%s': %w", col.Name, err)
 			}
 		case diff.CommonTypeString:
-			stats, err = c.fetchStringStats(ctx, tableName, name)
+			col.Stats, err = c.fetchStringStats(ctx, tableName, col.Name)
 			if err != nil {
-				return nil, fmt.Errorf("failed to fetch string stats for column '%s': %w", name, err)
+				return nil, fmt.Errorf("failed to fetch string stats for column '%s': %w", col.Name, err)
 			}
 		case diff.CommonTypeBoolean:
-			stats, err = c.fetchBooleanStats(ctx, tableName, name)
+			col.Stats, err = c.fetchBooleanStats(ctx, tableName, col.Name)
 			if err != nil {
-				return nil, fmt.Errorf("failed to fetch boolean stats for column '%s': %w", name, err)
+				return nil, fmt.Errorf("failed to fetch boolean stats for column '%s': %w", col.Name, err)
 			}
 		case diff.CommonTypeDateTime:
-			stats, err = c.fetchDateTimeStats(ctx, tableName, name)
+			col.Stats, err = c.fetchDateTimeStats(ctx, tableName, col.Name)
 			if err != nil {
-				return nil, fmt.Errorf("failed to fetch datetime stats for column '%s': %w", name, err)
+				return nil, fmt.Errorf("failed to fetch datetime stats for column '%s': %w", col.Name, err)
 			}
 		case diff.CommonTypeJSON:
-			stats, err = c.fetchJSONStats(ctx, tableName, name)
+			col.Stats, err = c.fetchJSONStats(ctx, tableName, col.Name)
 			if err != nil {
-				return nil, fmt.Errorf("failed to fetch JSON stats for column '%s': %w", name, err)
+				return nil, fmt.Errorf("failed to fetch JSON stats for column '%s': %w", col.Name, err)
 			}
 		case diff.CommonTypeBinary, diff.CommonTypeUnknown:
-			stats = &diff.UnknownStatistics{}
+			col.Stats = &diff.UnknownStatistics{}
+		}
+	}
+
+	return &diff.TableSummaryResult{
+		RowCount: rowCount,
+		Table:    table,
+	}, nil
+}
+
+func (c *Client) GetTableSchema(ctx context.Context, tableName string) (*diff.Table, error) {
+	// Get table schema using PRAGMA table_info
+	schemaQuery := fmt.Sprintf("PRAGMA table_info('%s')", tableName)
+	schemaRows, err := c.connection.QueryContext(ctx, schemaQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute PRAGMA table_info for table '%s': %w", tableName, err)
+	}
+	defer schemaRows.Close()
+
+	columns := make([]*diff.Column, 0)
+	for schemaRows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   bool
+			dfltValue sql.NullString
+			pk        bool
+		)
+
+		if err := schemaRows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return nil, fmt.Errorf("failed to scan PRAGMA table_info result for table '%s': %w", tableName, err)
 		}
 
 		columns = append(columns, &diff.Column{
 			Name:           name,
 			Type:           colType,
-			NormalizedType: normalizedType,
+			NormalizedType: c.typeMapper.MapType(colType),
 			Nullable:       !notNull,
 			PrimaryKey:     pk,
 			Unique:         pk,
-			Stats:          stats,
 		})
 	}
 	if err = schemaRows.Err(); err != nil {
 		return nil, fmt.Errorf("error after iterating PRAGMA table_info results for table '%s': %w", tableName, err)
 	}
 
-	dbTable := &diff.Table{
+	return &diff.Table{
 		Name:    tableName,
 		Columns: columns,
-	}
-
-	return &diff.TableSummaryResult{
-		RowCount: rowCount,
-		Table:    dbTable,
 	}, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/helpers"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/pkg/errors"
@@ -30,6 +31,7 @@ var matMap = pipeline.AssetMaterializationMap{
 		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
 		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
 		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
 		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
 	},
 }
@@ -102,11 +104,44 @@ func buildMergeQuery(asset *pipeline.Asset, query string) (string, error) {
 
 func buildCreateReplaceQuery(task *pipeline.Asset, query string) (string, error) {
 	query = strings.TrimSuffix(query, ";")
+	if task.HasColumnConstraints() {
+		return buildCreateReplaceWithConstraintsQuery(task, query)
+	}
+
+	// DuckDB cannot add a primary key to an existing table, a unique index on the non-null columns enforces the same
+	primaryKeyQuery := ""
+	if primaryKeys := task.ColumnNamesWithPrimaryKey(); len(primaryKeys) > 0 {
+		for _, column := range primaryKeys {
+			primaryKeyQuery += fmt.Sprintf("\nALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", task.Name, column)
+		}
+		primaryKeyQuery += fmt.Sprintf(
+			"\nCREATE UNIQUE INDEX %s ON %s (%s);",
+			ansisql.ConstraintName("pk", task.Name), task.Name, strings.Join(primaryKeys, ", "),
+		)
+	}
+
 	return fmt.Sprintf(
 		`BEGIN TRANSACTION;
 DROP TABLE IF EXISTS %s; 
-CREATE TABLE %s AS %s;
-COMMIT;`, task.Name, task.Name, query), nil
+CREATE TABLE %s AS %s;%s
+COMMIT;`, task.Name, task.Name, query, primaryKeyQuery), nil
+}
+
+// buildCreateReplaceWithConstraintsQuery creates the table from the column definitions first and inserts the query results
+// afterward, since the constraints and defaults cannot be defined with a "CREATE TABLE AS" statement.
+func buildCreateReplaceWithConstraintsQuery(task *pipeline.Asset, query string) (string, error) {
+	ddl, err := buildDDLQuery(task, query)
+	if err != nil {
+		return "", err
+	}
+
+	columns := strings.Join(ansisql.ColumnNames(task), ", ")
+	return fmt.Sprintf(
+		`BEGIN TRANSACTION;
+DROP TABLE IF EXISTS %s;
+%s;
+INSERT INTO %s (%s) SELECT %s FROM (%s) AS __bruin_source;
+COMMIT;`, task.Name, strings.TrimSuffix(ddl, ";"), task.Name, columns, columns, query), nil
 }
 
 func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error) {
@@ -148,14 +183,27 @@ func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error)
 func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 	columnDefs := make([]string, 0, len(asset.Columns))
 	primaryKeys := []string{}
+	foreignKeys := []string{}
 	columnComments := []string{}
 
 	for _, col := range asset.Columns {
 		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if !col.Nullable.Bool() {
+			def += " NOT NULL"
+		}
+		if col.Default != "" {
+			def += " DEFAULT " + col.Default
+		}
+		if col.CheckConstraint != "" {
+			def += fmt.Sprintf(" CHECK (%s)", col.CheckConstraint)
+		}
 
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, col.Name)
 		}
+		if col.ForeignKey != nil {
+			foreignKeys = append(foreignKeys, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", col.Name, col.ForeignKey.Table, col.ForeignKey.Column))
+		}
 
 		columnDefs = append(columnDefs, def)
 
@@ -169,6 +217,7 @@ func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 		primaryKeyClause := fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", "))
 		columnDefs = append(columnDefs, primaryKeyClause)
 	}
+	columnDefs = append(columnDefs, foreignKeys...)
 
 	createTableStmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n  %s\n)", asset.Name, strings.Join(columnDefs, ",\n  "))
 
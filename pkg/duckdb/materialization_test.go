@@ -64,6 +64,26 @@ COMMIT;`,
 DROP TABLE IF EXISTS my.asset; 
 CREATE TABLE my.asset AS SELECT 1;
 COMMIT;`,
+		},
+		{
+			name: "materialize to a table, the primary key is added after creating the table",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyCreateReplace,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+				},
+			},
+			query: "SELECT 1 as id",
+			want: `^BEGIN TRANSACTION;
+DROP TABLE IF EXISTS my.asset; 
+CREATE TABLE my.asset AS SELECT 1 as id;
+ALTER TABLE my.asset ALTER COLUMN id SET NOT NULL;
+CREATE UNIQUE INDEX pk_my_asset ON my.asset \(id\);
+COMMIT;$`,
 		},
 		{
 			name: "materialize to a table with append",
@@ -274,6 +294,7 @@ COMMIT;`,
 
 func TestBuildDDLQuery(t *testing.T) {
 	t.Parallel()
+	falseValue := false
 	tests := []struct {
 		name    string
 		asset   *pipeline.Asset
@@ -327,6 +348,18 @@ func TestBuildDDLQuery(t *testing.T) {
 				"COMMENT ON COLUMN my_table_with_comments.id IS 'Identifier for the record';\n" +
 				"COMMENT ON COLUMN my_table_with_comments.name IS 'Name of the person';",
 		},
+		{
+			name: "table with constraints and defaults",
+			asset: &pipeline.Asset{
+				Name: "my.orders",
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &falseValue}},
+					{Name: "amount", Type: "DOUBLE", Default: "0", CheckConstraint: "amount >= 0"},
+					{Name: "user_id", Type: "INT64", ForeignKey: &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}},
+				},
+			},
+			want: "CREATE TABLE IF NOT EXISTS my.orders (\n  id INT64 NOT NULL,\n  amount DOUBLE DEFAULT 0 CHECK (amount >= 0),\n  user_id INT64,\n  PRIMARY KEY (id),\n  FOREIGN KEY (user_id) REFERENCES my.users (id)\n)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,80 @@
+package duck
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/pkg/errors"
+)
+
+// seedColumnTypes maps the seed types to the column types dlt would create in DuckDB.
+var seedColumnTypes = map[string]string{
+	seed.TypeText:      "VARCHAR",
+	seed.TypeBigint:    "BIGINT",
+	seed.TypeDouble:    "DOUBLE",
+	seed.TypeBinary:    "BLOB",
+	seed.TypeBool:      "BOOLEAN",
+	seed.TypeDate:      "DATE",
+	seed.TypeTimestamp: "TIMESTAMP WITH TIME ZONE",
+	seed.TypeTime:      "TIME",
+}
+
+// LoadSeed replaces the table with the seed data using `read_csv`. The parsed values are written to a temporary file
+// in canonical formats first, so that DuckDB reads them with the explicit column types instead of sniffing them.
+func (c *Client) LoadSeed(ctx context.Context, asset *pipeline.Asset, data *seed.Data) error {
+	if err := c.CreateSchemaIfNotExist(ctx, asset); err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "bruin-seed-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a temporary directory for the seed file")
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "seed.csv")
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the seed file")
+	}
+
+	err = data.WriteCSV(file, escapeBlob)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write the seed file")
+	}
+
+	return c.RunQueryWithoutResult(ctx, &query.Query{Query: buildSeedQuery(asset.Name, path, data)})
+}
+
+func buildSeedQuery(tableName, path string, data *seed.Data) string {
+	columns := make([]string, len(data.Columns))
+	for i, col := range data.Columns {
+		columns[i] = fmt.Sprintf("'%s': '%s'", col.Name, seedColumnTypes[col.Type])
+	}
+
+	return fmt.Sprintf(
+		"CREATE OR REPLACE TABLE %s AS SELECT * FROM read_csv('%s', header = true, auto_detect = false, delim = ',', quote = '\"', escape = '\"', columns = {%s})",
+		tableName,
+		strings.ReplaceAll(filepath.ToSlash(path), "'", "''"),
+		strings.Join(columns, ", "),
+	)
+}
+
+// escapeBlob encodes every byte as `\xNN`, which DuckDB decodes back when casting the text into a BLOB.
+func escapeBlob(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		fmt.Fprintf(&sb, "\\x%02X", c)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+//go:build !bruin_no_duckdb
+
+package duck
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSeedQuery(t *testing.T) {
+	t.Parallel()
+
+	data := &seed.Data{
+		Columns: []seed.Column{
+			{Name: "id", Type: seed.TypeBigint},
+			{Name: "name", Type: seed.TypeText},
+		},
+	}
+
+	got := buildSeedQuery("raw.people", "/tmp/it's/seed.csv", data)
+	expected := "CREATE OR REPLACE TABLE raw.people AS SELECT * FROM read_csv('/tmp/it''s/seed.csv', header = true, auto_detect = false, delim = ',', quote = '\"', escape = '\"', columns = {'id': 'BIGINT', 'name': 'VARCHAR'})"
+	assert.Equal(t, expected, got)
+}
+
+func TestClient_LoadSeed(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(Config{Path: filepath.Join(t.TempDir(), "seed.db")})
+	require.NoError(t, err)
+
+	data := &seed.Data{
+		Columns: []seed.Column{
+			{Name: "id", Type: seed.TypeBigint},
+			{Name: "is_active", Type: seed.TypeBool},
+			{Name: "signup_date", Type: seed.TypeDate},
+			{Name: "payload", Type: seed.TypeBinary},
+			{Name: "name", Type: seed.TypeText},
+		},
+		Rows: [][]any{
+			{int64(1), true, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), []byte("a,b"), "doe, john"},
+			{int64(2), nil, nil, nil, nil},
+		},
+	}
+
+	asset := &pipeline.Asset{Name: "raw.people"}
+	require.NoError(t, client.LoadSeed(context.Background(), asset, data))
+	// loading twice replaces the table instead of appending to it
+	require.NoError(t, client.LoadSeed(context.Background(), asset, data))
+
+	result, err := client.Select(context.Background(), &query.Query{
+		Query: "SELECT id, is_active, CAST(signup_date AS VARCHAR), CAST(payload AS VARCHAR), name FROM raw.people ORDER BY id",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{
+		{int64(1), true, "2024-01-02", "a,b", "doe, john"},
+		{int64(2), nil, nil, nil, nil},
+	}, result)
+}
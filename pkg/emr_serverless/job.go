@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
@@ -83,12 +84,7 @@ func (job Job) buildJobRunConfig() *emrserverless.StartJobRunInput {
 		},
 	}
 
-	submitParams := job.params.Config
-	for key, val := range job.env {
-		submitParams += fmt.Sprintf(` --conf spark.executorEnv.%s=%s`, key, val)
-		submitParams += fmt.Sprintf(` --conf spark.emr-serverless.driverEnv.%s=%s`, key, val)
-	}
-
+	submitParams := sparkSubmitParameters(job.params.Config, job.env)
 	if submitParams != "" {
 		driver.Value.SparkSubmitParameters = &submitParams
 	}
@@ -114,6 +110,24 @@ func (job Job) buildJobRunConfig() *emrserverless.StartJobRunInput {
 	return cfg
 }
 
+// sparkSubmitParameters appends the configuration that injects the environment variables into the driver and the
+// executors to the user supplied spark-submit parameters.
+func sparkSubmitParameters(config string, env map[string]string) string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	submitParams := config
+	for _, key := range keys {
+		submitParams += fmt.Sprintf(` --conf spark.executorEnv.%s=%s`, key, env[key])
+		submitParams += fmt.Sprintf(` --conf spark.emr-serverless.driverEnv.%s=%s`, key, env[key])
+	}
+
+	return submitParams
+}
+
 type workspace struct {
 	Root       *url.URL
 	Entrypoint string
@@ -158,25 +172,9 @@ func (job Job) prepareWorkspace(ctx context.Context) (*workspace, error) {
 	defer os.Remove(fd.Name())
 	defer fd.Close()
 
-	zipper := zip.NewWriter(fd)
-	defer zipper.Close()
-
-	pipelineRoot, err := path.GetPipelineRootFromTask(scriptPath, []string{"pipeline.yaml", "pipeline.yml"})
+	err = packageContext(fd, scriptPath)
 	if err != nil {
-		return nil, fmt.Errorf("error finding pipeline root: %w", err)
-	}
-
-	err = packageContextWithPrefix(
-		zipper,
-		os.DirFS(pipelineRoot),
-		filepath.Base(pipelineRoot),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error packaging files: %w", err)
-	}
-	err = zipper.Close()
-	if err != nil {
-		return nil, fmt.Errorf("error closing zip writer: %w", err)
+		return nil, err
 	}
 	_, err = fd.Seek(0, 0)
 	if err != nil {
@@ -201,6 +199,33 @@ func (job Job) prepareWorkspace(ctx context.Context) (*workspace, error) {
 	}, nil
 }
 
+// packageContext writes the pipeline the script belongs to as a zip archive, so that the modules of the pipeline
+// can be imported by the script.
+func packageContext(w io.Writer, scriptPath string) error {
+	pipelineRoot, err := path.GetPipelineRootFromTask(scriptPath, []string{"pipeline.yaml", "pipeline.yml"})
+	if err != nil {
+		return fmt.Errorf("error finding pipeline root: %w", err)
+	}
+
+	zipper := zip.NewWriter(w)
+	defer zipper.Close()
+
+	err = packageContextWithPrefix(
+		zipper,
+		os.DirFS(pipelineRoot),
+		filepath.Base(pipelineRoot),
+	)
+	if err != nil {
+		return fmt.Errorf("error packaging files: %w", err)
+	}
+	err = zipper.Close()
+	if err != nil {
+		return fmt.Errorf("error closing zip writer: %w", err)
+	}
+
+	return nil
+}
+
 func (job Job) deleteWorkspace(ws *workspace) {
 	// todo(turtledev)
 	//   * pagination
@@ -0,0 +1,238 @@
+package emr_serverless //nolint
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/env"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/google/shlex"
+)
+
+const (
+	localSparkMaster = "local[*]"
+
+	// localSparkPythonVersion is the Python version used to install pyspark when there's no local spark-submit.
+	localSparkPythonVersion = "3.11"
+)
+
+type uvInstaller interface {
+	EnsureUvInstalled(ctx context.Context) (string, error)
+}
+
+// LocalOperator runs the EMR Serverless assets with a local Spark installation, so that the jobs can be developed
+// without an EMR Serverless application. It uses the spark-submit in PATH if there's one, otherwise pyspark is
+// installed in an isolated environment with uv.
+type LocalOperator struct {
+	env         map[string]string
+	renderer    jinja.RendererInterface
+	uvInstaller uvInstaller
+	lookPath    func(file string) (string, error)
+}
+
+func NewLocalOperator(env map[string]string, renderer jinja.RendererInterface, uv uvInstaller) *LocalOperator {
+	return &LocalOperator{
+		env:         env,
+		renderer:    renderer,
+		uvInstaller: uv,
+		lookPath:    exec.LookPath,
+	}
+}
+
+func (op *LocalOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	output := ctx.Value(executor.KeyPrinter).(io.Writer)
+	renderer := op.renderer.CloneForAsset(ctx, ti.GetPipeline(), ti.GetAsset())
+	asset, err := renderer.RenderAsset(ti.GetAsset())
+	if err != nil {
+		return fmt.Errorf("error rendering asset: %w", err)
+	}
+
+	env, err := env.SetupVariables(ctx, ti.GetPipeline(), asset, cloneEnv(op.env))
+	if err != nil {
+		return fmt.Errorf("error setting up environment variables: %w", err)
+	}
+
+	submitCommand, err := op.sparkSubmitCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	job := LocalJob{
+		logger:        log.New(output, "", 0),
+		asset:         asset,
+		pipeline:      ti.GetPipeline(),
+		params:        parseParams(&Client{}, asset.Parameters),
+		env:           env,
+		submitCommand: submitCommand,
+		runner:        &execRunner{output: output},
+	}
+
+	return job.Run(ctx)
+}
+
+// sparkSubmitCommand returns the command that runs spark-submit, along with its leading arguments.
+func (op *LocalOperator) sparkSubmitCommand(ctx context.Context) ([]string, error) {
+	if sparkSubmit, err := op.lookPath("spark-submit"); err == nil {
+		return []string{sparkSubmit}, nil
+	}
+
+	uv, err := op.uvInstaller.EnsureUvInstalled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("spark-submit is not installed and uv could not be set up: %w", err)
+	}
+
+	return []string{uv, "run", "--no-project", "--python", localSparkPythonVersion, "--with", "pyspark", "spark-submit"}, nil
+}
+
+type localCommand struct {
+	Name string
+	Args []string
+	Env  []string
+	Dir  string
+}
+
+type commandRunner interface {
+	Run(ctx context.Context, cmd *localCommand) error
+}
+
+type LocalJob struct {
+	logger        *log.Logger
+	asset         *pipeline.Asset
+	pipeline      *pipeline.Pipeline
+	params        *JobRunParams
+	env           map[string]string
+	submitCommand []string
+	runner        commandRunner
+}
+
+func (job LocalJob) Run(ctx context.Context) error {
+	if job.params.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.params.Timeout)
+		defer cancel()
+	}
+
+	pipelineRoot, err := path.GetPipelineRootFromTask(job.asset.ExecutableFile.Path, []string{"pipeline.yaml", "pipeline.yml"})
+	if err != nil {
+		return fmt.Errorf("error finding pipeline root: %w", err)
+	}
+
+	args := append([]string{}, job.submitCommand[1:]...)
+	args = append(args, "--master", localSparkMaster)
+	// the settings are split the way a shell would, so that the quoted values with spaces are kept as a single argument
+	config, err := shlex.Split(job.params.Config)
+	if err != nil {
+		return fmt.Errorf("error parsing the spark-submit settings in 'config': %w", err)
+	}
+	args = append(args, config...)
+
+	entrypoint := job.params.Entrypoint
+	if job.asset.Type == pipeline.AssetTypeEMRServerlessPyspark {
+		archive, err := job.packageLocalContext()
+		if archive != "" {
+			defer os.Remove(archive)
+		}
+		if err != nil {
+			return fmt.Errorf("error preparing workspace: %w", err)
+		}
+
+		entrypoint = job.asset.ExecutableFile.Path
+		args = append(args, "--conf", "spark.submit.pyFiles="+archive)
+	} else if !strings.Contains(entrypoint, "://") && !filepath.IsAbs(entrypoint) {
+		// local entrypoints are relative to the asset definition, the same way seed files are
+		entrypoint = filepath.Join(filepath.Dir(job.asset.ExecutableFile.Path), entrypoint)
+	}
+
+	args = append(args, entrypoint)
+	args = append(args, job.params.Args...)
+
+	// the driver and the executors run in the local process, the variables are set for it directly rather than through
+	// the `spark.executorEnv.*` and `spark.emr-serverless.driverEnv.*` settings
+	processEnv := os.Environ()
+	for key, val := range job.env {
+		processEnv = append(processEnv, fmt.Sprintf("%s=%s", key, val))
+	}
+
+	job.logger.Printf("running %s with local spark-submit", entrypoint)
+	err = job.runner.Run(ctx, &localCommand{
+		Name: job.submitCommand[0],
+		Args: args,
+		Env:  processEnv,
+		Dir:  pipelineRoot,
+	})
+	if err != nil {
+		return fmt.Errorf("error running spark-submit: %w", err)
+	}
+
+	return nil
+}
+
+// packageLocalContext packages the pipeline the same way it is packaged for the EMR Serverless runs, and returns the
+// path to the archive.
+func (job LocalJob) packageLocalContext() (string, error) {
+	fd, err := os.CreateTemp("", "bruin-spark-context-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary file %w", err)
+	}
+	defer fd.Close()
+
+	return fd.Name(), packageContext(fd, job.asset.ExecutableFile.Path)
+}
+
+type execRunner struct {
+	output io.Writer
+}
+
+func (r *execRunner) Run(ctx context.Context, command *localCommand) error {
+	cmd := exec.CommandContext(ctx, command.Name, command.Args...) //nolint:gosec
+	cmd.Env = command.Env
+	cmd.Dir = command.Dir
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// the output of spark-submit is consumed fully before waiting for the process, otherwise the pipes are closed
+	// before the last lines are read
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, pipe := range []io.Reader{stdout, stderr} {
+		wg.Add(1)
+		go func(pipe io.Reader) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(pipe)
+			scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				mu.Lock()
+				_, _ = fmt.Fprintln(r.output, scanner.Text())
+				mu.Unlock()
+			}
+		}(pipe)
+	}
+	wg.Wait()
+
+	return cmd.Wait()
+}
@@ -0,0 +1,236 @@
+package emr_serverless //nolint
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingRunner struct {
+	command *localCommand
+	files   []string
+}
+
+func (r *recordingRunner) Run(ctx context.Context, cmd *localCommand) error {
+	r.command = cmd
+
+	// the context archive is removed once the job is over, its contents are checked while it still exists
+	for _, arg := range cmd.Args {
+		archive, found := strings.CutPrefix(arg, "spark.submit.pyFiles=")
+		if !found {
+			continue
+		}
+
+		zr, err := zip.OpenReader(archive)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+
+		for _, f := range zr.File {
+			r.files = append(r.files, f.Name)
+		}
+	}
+
+	return nil
+}
+
+func createLocalPipeline(t *testing.T) string {
+	root := filepath.Join(t.TempDir(), "acme")
+	files := map[string]string{
+		"pipeline.yml":        "name: acme\n",
+		"assets/main.py":      "from acme.lib import util\n",
+		"assets/job.yml":      "name: job\n",
+		"assets/src/job.py":   "print('hi')\n",
+		"lib/util.py":         "def util(): pass\n",
+		"lib/more/helpers.py": "",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+	}
+
+	return root
+}
+
+func TestLocalJob_Run(t *testing.T) {
+	t.Parallel()
+
+	root := createLocalPipeline(t)
+
+	t.Run("pyspark assets are run with the packaged pipeline", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &recordingRunner{}
+		job := LocalJob{
+			logger: log.New(&bytes.Buffer{}, "", 0),
+			asset: &pipeline.Asset{
+				Name:           "main",
+				Type:           pipeline.AssetTypeEMRServerlessPyspark,
+				ExecutableFile: pipeline.ExecutableFile{Path: filepath.Join(root, "assets/main.py")},
+			},
+			pipeline: &pipeline.Pipeline{Name: "acme"},
+			params: parseParams(&Client{}, map[string]string{
+				"config": "--conf spark.executor.cores=1",
+				"args":   "--day  2024-01-01",
+			}),
+			env:           map[string]string{"BRUIN_START_DATE": "2024-01-01", "BRUIN_VARS": `{"region": "eu west"}`},
+			submitCommand: []string{"spark-submit"},
+			runner:        runner,
+		}
+
+		require.NoError(t, job.Run(context.Background()))
+		require.NotNil(t, runner.command)
+
+		cmd := runner.command
+		assert.Equal(t, "spark-submit", cmd.Name)
+		assert.Equal(t, root, cmd.Dir)
+		assert.Contains(t, cmd.Env, "BRUIN_START_DATE=2024-01-01")
+		assert.Contains(t, cmd.Env, `BRUIN_VARS={"region": "eu west"}`)
+
+		archiveConf := slices.IndexFunc(cmd.Args, func(arg string) bool {
+			return strings.HasPrefix(arg, "spark.submit.pyFiles=")
+		})
+		require.NotEqual(t, -1, archiveConf)
+		archive := strings.TrimPrefix(cmd.Args[archiveConf], "spark.submit.pyFiles=")
+		assert.NoFileExists(t, archive)
+
+		assert.Equal(t, []string{
+			"--master", "local[*]",
+			"--conf", "spark.executor.cores=1",
+			"--conf", "spark.submit.pyFiles=" + archive,
+			filepath.Join(root, "assets/main.py"),
+			"--day", "2024-01-01",
+		}, cmd.Args)
+
+		assert.Contains(t, runner.files, "acme/lib/util.py")
+		assert.Contains(t, runner.files, "acme/lib/more/__init__.py")
+	})
+
+	t.Run("local spark entrypoints are relative to the asset", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &recordingRunner{}
+		job := LocalJob{
+			logger: log.New(&bytes.Buffer{}, "", 0),
+			asset: &pipeline.Asset{
+				Name:           "job",
+				Type:           pipeline.AssetTypeEMRServerlessSpark,
+				ExecutableFile: pipeline.ExecutableFile{Path: filepath.Join(root, "assets/job.yml")},
+			},
+			pipeline:      &pipeline.Pipeline{Name: "acme"},
+			params:        parseParams(&Client{}, map[string]string{"entrypoint": "src/job.py"}),
+			env:           map[string]string{},
+			submitCommand: []string{"uv", "run", "spark-submit"},
+			runner:        runner,
+		}
+
+		require.NoError(t, job.Run(context.Background()))
+		assert.Equal(t, "uv", runner.command.Name)
+		assert.Equal(t, []string{
+			"run", "spark-submit",
+			"--master", "local[*]",
+			filepath.Join(root, "assets/src/job.py"),
+		}, runner.command.Args)
+		assert.Empty(t, runner.files)
+	})
+
+	t.Run("quoted settings are kept as a single argument", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &recordingRunner{}
+		job := LocalJob{
+			logger: log.New(&bytes.Buffer{}, "", 0),
+			asset: &pipeline.Asset{
+				Name:           "job",
+				Type:           pipeline.AssetTypeEMRServerlessSpark,
+				ExecutableFile: pipeline.ExecutableFile{Path: filepath.Join(root, "assets/job.yml")},
+			},
+			pipeline: &pipeline.Pipeline{Name: "acme"},
+			params: parseParams(&Client{}, map[string]string{
+				"entrypoint": "s3://acme/job.py",
+				"config":     `--conf "spark.driver.extraJavaOptions=-Dlog.level=INFO -Dregion=eu" --conf 'spark.app.name=daily job'`,
+			}),
+			env:           map[string]string{},
+			submitCommand: []string{"spark-submit"},
+			runner:        runner,
+		}
+
+		require.NoError(t, job.Run(context.Background()))
+		assert.Equal(t, []string{
+			"--master", "local[*]",
+			"--conf", "spark.driver.extraJavaOptions=-Dlog.level=INFO -Dregion=eu",
+			"--conf", "spark.app.name=daily job",
+			"s3://acme/job.py",
+		}, runner.command.Args)
+	})
+
+	t.Run("unterminated quotes in the settings fail the run", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &recordingRunner{}
+		job := LocalJob{
+			logger: log.New(&bytes.Buffer{}, "", 0),
+			asset: &pipeline.Asset{
+				Name:           "job",
+				Type:           pipeline.AssetTypeEMRServerlessSpark,
+				ExecutableFile: pipeline.ExecutableFile{Path: filepath.Join(root, "assets/job.yml")},
+			},
+			pipeline: &pipeline.Pipeline{Name: "acme"},
+			params: parseParams(&Client{}, map[string]string{
+				"entrypoint": "s3://acme/job.py",
+				"config":     `--conf "spark.app.name=daily job`,
+			}),
+			env:           map[string]string{},
+			submitCommand: []string{"spark-submit"},
+			runner:        runner,
+		}
+
+		require.ErrorContains(t, job.Run(context.Background()), "error parsing the spark-submit settings in 'config'")
+		assert.Nil(t, runner.command)
+	})
+}
+
+type staticUvInstaller struct {
+	path string
+	err  error
+}
+
+func (s *staticUvInstaller) EnsureUvInstalled(ctx context.Context) (string, error) {
+	return s.path, s.err
+}
+
+func TestLocalOperator_sparkSubmitCommand(t *testing.T) {
+	t.Parallel()
+
+	notFound := func(string) (string, error) { return "", errors.New("not found") }
+
+	op := &LocalOperator{
+		uvInstaller: &staticUvInstaller{path: "/home/.bruin/uv"},
+		lookPath:    func(string) (string, error) { return "/opt/spark/bin/spark-submit", nil },
+	}
+	cmd, err := op.sparkSubmitCommand(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/opt/spark/bin/spark-submit"}, cmd)
+
+	op.lookPath = notFound
+	cmd, err = op.sparkSubmitCommand(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/home/.bruin/uv", "run", "--no-project", "--python", "3.11", "--with", "pyspark", "spark-submit"}, cmd)
+
+	op.uvInstaller = &staticUvInstaller{err: errors.New("no network")}
+	_, err = op.sparkSubmitCommand(context.Background())
+	require.ErrorContains(t, err, "spark-submit is not installed")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/bruin-data/bruin/pkg/jinja"
@@ -13,12 +14,20 @@ import (
 
 // SetupVariables prepares the environment variables for a pipeline run.
 // It is meant for use in python operators.
+// The given environment is not modified, since the operators share it across the assets of different pipelines.
 func SetupVariables(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset, env map[string]string) (map[string]string, error) {
-	env, err := envMutateIntervals(ctx, t, env)
+	env, err := envMutateIntervals(ctx, t, maps.Clone(env))
 	if err != nil {
 		return nil, err
 	}
 
+	if env == nil {
+		env = make(map[string]string)
+	}
+	if p.Name != "" {
+		env["BRUIN_PIPELINE"] = p.Name
+	}
+
 	env, err = envInjectVariables(env, p.Variables.Value())
 	if err != nil {
 		return nil, err
@@ -27,6 +36,28 @@ func SetupVariables(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset
 	return env, nil
 }
 
+type secretFinder interface {
+	GetSecretByKey(key string) (string, error)
+}
+
+// InjectSecrets adds the values of the secrets of the asset to the environment variables under their injected keys.
+func InjectSecrets(env map[string]string, secrets []pipeline.SecretMapping, finder secretFinder) error {
+	for _, mapping := range secrets {
+		val, err := finder.GetSecretByKey(mapping.SecretKey)
+		if err != nil {
+			return fmt.Errorf("there's no secret with the name '%s', make sure you are referring to the right secret and the secret is defined correctly in your .bruin.yml file.: %w", mapping.SecretKey, err)
+		}
+
+		if val == "" {
+			return fmt.Errorf("there's no secret with the name '%s', make sure you are referring to the right secret and the secret is defined correctly in your .bruin.yml file.", mapping.SecretKey)
+		}
+
+		env[mapping.InjectedKey] = val
+	}
+
+	return nil
+}
+
 func envMutateIntervals(ctx context.Context, t *pipeline.Asset, env map[string]string) (map[string]string, error) {
 	if val := ctx.Value(pipeline.RunConfigApplyIntervalModifiers); val != nil {
 		if applyModifiers, ok := val.(bool); !ok || !applyModifiers {
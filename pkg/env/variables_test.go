@@ -2,6 +2,8 @@ package env_test
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -223,3 +225,73 @@ func TestSetupVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupVariables_DoesNotShareStateAcrossPipelines(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.WithValue(context.Background(), pipeline.RunConfigApplyIntervalModifiers, false)
+	shared := map[string]string{"BRUIN_PIPELINE": "combined"}
+
+	withVars := &pipeline.Pipeline{
+		Name: "first",
+		Variables: pipeline.Variables{
+			"env": map[string]any{"type": "string", "default": "dev"},
+		},
+	}
+	first, err := env.SetupVariables(ctx, withVars, &pipeline.Asset{}, shared)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	second, err := env.SetupVariables(ctx, &pipeline.Pipeline{Name: "second"}, &pipeline.Asset{}, shared)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if first["BRUIN_PIPELINE"] != "first" || first["BRUIN_VARS"] != `{"env":"dev"}` {
+		t.Errorf("unexpected env for the first pipeline: %+v", first)
+	}
+	if second["BRUIN_PIPELINE"] != "second" {
+		t.Errorf("expected the name of the second pipeline, got '%s'", second["BRUIN_PIPELINE"])
+	}
+	if _, ok := second["BRUIN_VARS"]; ok {
+		t.Errorf("the variables of the first pipeline leaked into the second: %+v", second)
+	}
+	if len(shared) != 1 || shared["BRUIN_PIPELINE"] != "combined" {
+		t.Errorf("the shared env was modified: %+v", shared)
+	}
+}
+
+type secrets map[string]string
+
+func (s secrets) GetSecretByKey(key string) (string, error) {
+	val, ok := s[key]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return val, nil
+}
+
+func TestInjectSecrets(t *testing.T) {
+	t.Parallel()
+
+	finder := secrets{"api": "token", "empty": ""}
+
+	vars := map[string]string{"BRUIN_ASSET": "asset"}
+	if err := env.InjectSecrets(vars, []pipeline.SecretMapping{{SecretKey: "api", InjectedKey: "API_KEY"}}, finder); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if vars["API_KEY"] != "token" || vars["BRUIN_ASSET"] != "asset" {
+		t.Errorf("unexpected env: %+v", vars)
+	}
+
+	err := env.InjectSecrets(vars, []pipeline.SecretMapping{{SecretKey: "missing", InjectedKey: "MISSING"}}, finder)
+	if err == nil || !strings.Contains(err.Error(), "there's no secret with the name 'missing'") || !strings.Contains(err.Error(), "secret not found") {
+		t.Errorf("unexpected error for a missing secret: %v", err)
+	}
+
+	err = env.InjectSecrets(vars, []pipeline.SecretMapping{{SecretKey: "empty", InjectedKey: "EMPTY"}}, finder)
+	if err == nil || !strings.Contains(err.Error(), "there's no secret with the name 'empty'") {
+		t.Errorf("unexpected error for an empty secret: %v", err)
+	}
+}
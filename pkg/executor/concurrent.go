@@ -118,6 +118,10 @@ func (w worker) run(ctx context.Context, taskChannel <-chan scheduler.TaskInstan
 
 		executionCtx := context.WithValue(ctx, KeyPrinter, printer)
 		executionCtx = context.WithValue(executionCtx, ContextLogger, w.logger)
+		if p := task.GetPipeline(); p != nil {
+			// the tasks of a run may belong to different pipelines, each of them is rendered with its own name
+			executionCtx = context.WithValue(executionCtx, pipeline.RunConfigPipelineName, p.Name)
+		}
 		err := w.executor.RunSingleTask(executionCtx, task)
 
 		duration := time.Since(start)
@@ -0,0 +1,130 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+)
+
+// MicrobatchOperator runs the wrapped operator once per batch of the run interval for assets that use the
+// `microbatch` materialization strategy. Every batch gets its own start and end dates in the context, which means the
+// rendered queries only replace the data for that specific batch. Full refreshes run the whole interval at once, since
+// every batch would recreate the table otherwise.
+type MicrobatchOperator struct {
+	operator Operator
+}
+
+func NewMicrobatchOperator(operator Operator) *MicrobatchOperator {
+	return &MicrobatchOperator{operator: operator}
+}
+
+func (o *MicrobatchOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	asset := ti.GetAsset()
+	if asset.Materialization.Strategy != pipeline.MaterializationStrategyMicrobatch {
+		return o.operator.Run(ctx, ti)
+	}
+
+	// the full refresh recreates the table, which means only the last batch would be kept if they ran one by one
+	if fullRefresh, ok := ctx.Value(pipeline.RunConfigFullRefresh).(bool); ok && fullRefresh {
+		return o.operator.Run(ctx, ti)
+	}
+
+	startDate, ok := ctx.Value(pipeline.RunConfigStartDate).(time.Time)
+	if !ok {
+		return errors.New("microbatch strategy requires a start date for the run")
+	}
+
+	endDate, ok := ctx.Value(pipeline.RunConfigEndDate).(time.Time)
+	if !ok {
+		return errors.New("microbatch strategy requires an end date for the run")
+	}
+
+	// the interval of the run is kept as given, the retry hint must not include the shifts applied below
+	runStartDate, runEndDate := startDate, endDate
+
+	applyModifiers, ok := ctx.Value(pipeline.RunConfigApplyIntervalModifiers).(bool)
+	applyModifiers = ok && applyModifiers
+	if applyModifiers {
+		startDate = pipeline.ModifyDate(startDate, asset.IntervalModifiers.Start)
+		endDate = pipeline.ModifyDate(endDate, asset.IntervalModifiers.End)
+	}
+
+	batches, err := pipeline.SplitIntoBatches(startDate, endDate, asset.Materialization.BatchSize, asset.Materialization.Lookback)
+	if err != nil {
+		return errors.Wrap(err, "failed to split the run interval into batches")
+	}
+
+	printer, printerExists := ctx.Value(KeyPrinter).(io.Writer)
+
+	failedBatches := make([]pipeline.Batch, 0)
+	for i, batch := range batches {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if printerExists {
+			fmt.Fprintf(printer, "Running batch %d/%d: %s\n", i+1, len(batches), batch)
+		}
+
+		// the modifiers are already applied to the whole interval, they must not shift the individual batches again
+		batchCtx := context.WithValue(ctx, pipeline.RunConfigStartDate, batch.Start)
+		batchCtx = context.WithValue(batchCtx, pipeline.RunConfigEndDate, batch.End)
+		batchCtx = context.WithValue(batchCtx, pipeline.RunConfigApplyIntervalModifiers, false)
+
+		err := o.operator.Run(batchCtx, ti)
+		if err != nil {
+			if printerExists {
+				fmt.Fprintf(printer, "Batch %s failed: %v\n", batch, err)
+			}
+
+			failedBatches = append(failedBatches, batch)
+		}
+	}
+
+	if len(failedBatches) == 0 {
+		return nil
+	}
+
+	// the lookback and the interval modifiers shift the dates given to the run, which means the dates of a batch
+	// would be shifted again if they were given as they are. The only exact retry is the original interval then.
+	if asset.Materialization.Lookback > 0 || applyModifiers {
+		failed := make([]string, 0, len(failedBatches))
+		for _, batch := range failedBatches {
+			failed = append(failed, batch.String())
+		}
+
+		retryFlags := dateFlags(runStartDate, runEndDate)
+		if applyModifiers {
+			retryFlags += " --apply-interval-modifiers"
+		}
+
+		return fmt.Errorf(
+			"%d out of %d batches failed: %s\nthe lookback or the interval modifiers of the asset shift the batches, you can retry them by running the asset again with the same interval:\n  %s",
+			len(failedBatches), len(batches), strings.Join(failed, ", "), retryFlags,
+		)
+	}
+
+	retries := make([]string, 0, len(failedBatches))
+	for _, batch := range failedBatches {
+		retries = append(retries, dateFlags(batch.Start, batch.End))
+	}
+
+	return fmt.Errorf(
+		"%d out of %d batches failed, you can retry each of them individually with the following flags:\n  %s",
+		len(failedBatches), len(batches), strings.Join(retries, "\n  "),
+	)
+}
+
+func dateFlags(start, end time.Time) string {
+	return fmt.Sprintf(
+		"--start-date %s --end-date %s",
+		start.Format("2006-01-02T15:04:05.000000"),
+		end.Format("2006-01-02T15:04:05.000000"),
+	)
+}
@@ -0,0 +1,144 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMicrobatchOperator_Run(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "task1",
+		Type: "test",
+		Materialization: pipeline.Materialization{
+			Type:      pipeline.MaterializationTypeTable,
+			Strategy:  pipeline.MaterializationStrategyMicrobatch,
+			BatchSize: pipeline.MaterializationBatchSizeDay,
+		},
+	}
+	instance := &scheduler.AssetInstance{
+		Asset: asset,
+	}
+
+	runCtx := context.WithValue(context.Background(), pipeline.RunConfigStartDate, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	runCtx = context.WithValue(runCtx, pipeline.RunConfigEndDate, time.Date(2024, 1, 3, 23, 59, 59, 999999999, time.UTC))
+
+	t.Run("each batch runs with its own interval", func(t *testing.T) {
+		t.Parallel()
+
+		var starts []time.Time
+		op := new(mockOperator)
+		op.On("Run", mock.Anything, instance).
+			Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				starts = append(starts, ctx.Value(pipeline.RunConfigStartDate).(time.Time))
+			}).
+			Return(nil)
+
+		l := Sequential{
+			TaskTypeMap: map[pipeline.AssetType]Config{
+				"test": {
+					scheduler.TaskInstanceTypeMain: op,
+				},
+			},
+		}
+
+		err := l.RunSingleTask(runCtx, instance)
+
+		require.NoError(t, err)
+		op.AssertNumberOfCalls(t, "Run", 3)
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		}, starts)
+	})
+
+	t.Run("failed batches do not stop the others", func(t *testing.T) {
+		t.Parallel()
+
+		op := new(mockOperator)
+		op.On("Run", mock.Anything, instance).
+			Return(errors.New("some error occurred")).Once()
+		op.On("Run", mock.Anything, instance).
+			Return(nil)
+
+		err := NewMicrobatchOperator(op).Run(runCtx, instance)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 out of 3 batches failed")
+		assert.Contains(t, err.Error(), "--start-date 2024-01-01T00:00:00.000000 --end-date 2024-01-01T23:59:59.999999")
+		op.AssertNumberOfCalls(t, "Run", 3)
+	})
+
+	t.Run("shifted batches are retried with the original interval", func(t *testing.T) {
+		t.Parallel()
+
+		shiftedInstance := &scheduler.AssetInstance{
+			Asset: &pipeline.Asset{
+				Name: "task1",
+				Type: "test",
+				Materialization: pipeline.Materialization{
+					Type:      pipeline.MaterializationTypeTable,
+					Strategy:  pipeline.MaterializationStrategyMicrobatch,
+					BatchSize: pipeline.MaterializationBatchSizeDay,
+					Lookback:  1,
+				},
+				IntervalModifiers: pipeline.IntervalModifiers{
+					Start: pipeline.TimeModifier{Days: -1},
+				},
+			},
+		}
+
+		op := new(mockOperator)
+		op.On("Run", mock.Anything, shiftedInstance).
+			Return(errors.New("some error occurred")).Once()
+		op.On("Run", mock.Anything, shiftedInstance).
+			Return(nil)
+
+		err := NewMicrobatchOperator(op).Run(context.WithValue(runCtx, pipeline.RunConfigApplyIntervalModifiers, true), shiftedInstance)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 out of 5 batches failed: 2023-12-30T00:00:00Z - 2023-12-30T23:59:59Z")
+		assert.Contains(t, err.Error(), "--start-date 2024-01-01T00:00:00.000000 --end-date 2024-01-03T23:59:59.999999 --apply-interval-modifiers")
+		op.AssertNumberOfCalls(t, "Run", 5)
+	})
+
+	t.Run("full refresh runs the whole interval at once", func(t *testing.T) {
+		t.Parallel()
+
+		op := new(mockOperator)
+		op.On("Run", mock.Anything, instance).
+			Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ctx.Value(pipeline.RunConfigStartDate))
+				assert.Equal(t, time.Date(2024, 1, 3, 23, 59, 59, 999999999, time.UTC), ctx.Value(pipeline.RunConfigEndDate))
+			}).
+			Return(nil)
+
+		err := NewMicrobatchOperator(op).Run(context.WithValue(runCtx, pipeline.RunConfigFullRefresh, true), instance)
+
+		require.NoError(t, err)
+		op.AssertNumberOfCalls(t, "Run", 1)
+	})
+
+	t.Run("missing dates are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		op := new(mockOperator)
+
+		err := NewMicrobatchOperator(op).Run(context.Background(), instance)
+
+		require.Error(t, err)
+		op.AssertExpectations(t)
+	})
+}
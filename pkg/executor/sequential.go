@@ -34,5 +34,9 @@ func (s Sequential) RunSingleTask(ctx context.Context, instance scheduler.TaskIn
 		return errors.New("there is no executor configured for the asset class: " + instance.GetType().String())
 	}
 
+	if instance.GetType() == scheduler.TaskInstanceTypeMain && task.Materialization.Strategy == pipeline.MaterializationStrategyMicrobatch {
+		executor = NewMicrobatchOperator(executor)
+	}
+
 	return executor.Run(ctx, instance)
 }
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ChangedFiles returns the absolute paths of the files in the repository that differ from the given base ref, including
+// the uncommitted changes and the untracked files that are not ignored. If the base ref is empty, the changes are
+// compared against the point the current branch forked from the default branch, see MergeBaseWithDefaultBranch.
+func ChangedFiles(repoPath, baseRef string) ([]string, error) {
+	if baseRef == "" {
+		baseRef = MergeBaseWithDefaultBranch(repoPath)
+	}
+
+	changed, err := runGitListFiles(repoPath, "diff", "--name-only", baseRef)
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := runGitListFiles(repoPath, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(changed)+len(untracked))
+	for _, file := range append(changed, untracked...) {
+		files = append(files, filepath.Join(repoPath, filepath.FromSlash(file)))
+	}
+
+	return files, nil
+}
+
+// MergeBaseWithDefaultBranch returns the commit the current branch forked from the default branch, which is the branch
+// the remote HEAD points to, or the local `main` or `master` branch. It returns HEAD if there is no default branch, so
+// that only the uncommitted changes are taken into account.
+func MergeBaseWithDefaultBranch(repoPath string) string {
+	branch := defaultBranch(repoPath)
+	if branch == "" {
+		return "HEAD"
+	}
+
+	mergeBase, err := runGitListFiles(repoPath, "merge-base", "HEAD", branch)
+	if err != nil || len(mergeBase) == 0 {
+		return "HEAD"
+	}
+
+	return mergeBase[0]
+}
+
+func defaultBranch(repoPath string) string {
+	remoteHead, err := runGitListFiles(repoPath, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if err == nil && len(remoteHead) > 0 {
+		return remoteHead[0]
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		if _, err := runGitListFiles(repoPath, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+			return branch
+		}
+	}
+
+	return ""
+}
+
+func runGitListFiles(path string, args ...string) ([]string, error) {
+	var (
+		stdout = new(bytes.Buffer)
+		stderr = new(bytes.Buffer)
+	)
+	command := exec.Command("git", args...)
+	command.Dir = path
+	command.Stdout = stdout
+	command.Stderr = stderr
+	err := command.Run()
+	if err != nil {
+		return nil, parseGitError(err, stderr.String())
+	}
+
+	files := make([]string, 0)
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+
+	return files, nil
+}
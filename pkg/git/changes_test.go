@@ -0,0 +1,66 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	runGit := func(args ...string) {
+		command := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		command.Dir = repo
+		out, err := command.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	writeFile := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, name), []byte(content), 0o600))
+	}
+
+	runGit("init", "-q")
+	writeFile("assets/unchanged.sql", "select 1")
+	writeFile("assets/modified.sql", "select 1")
+	writeFile(".gitignore", "logs/\n")
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+
+	writeFile("assets/modified.sql", "select 2")
+	writeFile("assets/new.sql", "select 3")
+	writeFile("logs/run.log", "ignored")
+
+	files, err := ChangedFiles(repo, "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(repo, "assets", "modified.sql"),
+		filepath.Join(repo, "assets", "new.sql"),
+	}, files)
+
+	// the changes committed on a branch are compared against the default branch
+	runGit("branch", "-M", "main")
+	runGit("checkout", "-q", "-b", "feature")
+	runGit("add", "assets/modified.sql")
+	runGit("commit", "-q", "-m", "modify")
+
+	files, err = ChangedFiles(repo, "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(repo, "assets", "modified.sql"),
+		filepath.Join(repo, "assets", "new.sql"),
+	}, files)
+
+	// a given base ref takes precedence
+	files, err = ChangedFiles(repo, "HEAD")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(repo, "assets", "new.sql"),
+	}, files)
+}
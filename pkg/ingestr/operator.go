@@ -12,6 +12,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/python"
 	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/bruin-data/bruin/pkg/seed"
 	"github.com/pkg/errors"
 )
 
@@ -178,7 +179,8 @@ func (o *SeedOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 		return errors.New("source connection not configured")
 	}
 
-	sourceURI := "csv://" + filepath.Join(filepath.Dir(asset.ExecutableFile.Path), sourceConnectionPath)
+	seedPath := filepath.Join(filepath.Dir(asset.ExecutableFile.Path), sourceConnectionPath)
+	sourceURI := "csv://" + seedPath
 
 	destConnectionName, err := ti.GetPipeline().GetConnectionNameForAsset(asset)
 	if err != nil {
@@ -190,6 +192,13 @@ func (o *SeedOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 		return fmt.Errorf("destination connection %s not found", destConnectionName)
 	}
 
+	if loader, ok := destConnection.(seed.Loader); ok && asset.Parameters["loader"] != "ingestr" {
+		err = loadSeedNatively(ctx, loader, asset, seedPath)
+		if !errors.Is(err, seed.ErrNotSupported) {
+			return err
+		}
+	}
+
 	destURI, err := destConnection.(pipelineConnection).GetIngestrURI()
 	if err != nil {
 		return errors.New("could not get the source uri")
@@ -230,3 +239,13 @@ func (o *SeedOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 
 	return o.runner.RunIngestr(ctx, cmdArgs, extraPackages, repo)
 }
+
+// loadSeedNatively loads the seed file through the bulk APIs of the destination, without going through ingestr.
+func loadSeedNatively(ctx context.Context, loader seed.Loader, asset *pipeline.Asset, path string) error {
+	data, err := seed.ReadCSV(path, asset.Columns)
+	if err != nil {
+		return err
+	}
+
+	return loader.LoadSeed(ctx, asset, data)
+}
@@ -2,6 +2,9 @@ package ingestr
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/jinja"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/bruin-data/bruin/pkg/seed"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -515,3 +519,112 @@ func TestBasicOperator_ConvertSeedTaskInstanceToIngestrCommand(t *testing.T) {
 		})
 	}
 }
+
+type mockSeedLoader struct {
+	mockConnection
+}
+
+func (m *mockSeedLoader) LoadSeed(ctx context.Context, asset *pipeline.Asset, data *seed.Data) error {
+	res := m.Called(asset, data)
+	return res.Error(0)
+}
+
+type seedLoaderFetcher struct {
+	loader *mockSeedLoader
+}
+
+func (s seedLoaderFetcher) GetConnection(name string) (interface{}, error) {
+	return s.loader, nil
+}
+
+func TestSeedOperator_LoadsNatively(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "seed.csv"), []byte("ID,name\n1,john\n"), 0o600))
+
+	expectedData := &seed.Data{
+		Path:    filepath.Join(dir, "seed.csv"),
+		Columns: []seed.Column{{Name: "id", Type: seed.TypeBigint}, {Name: "name", Type: seed.TypeText}},
+		Rows:    [][]any{{int64(1), "john"}},
+	}
+	ingestrArgs := []string{"ingest", "--source-uri", "csv://" + filepath.Join(dir, "seed.csv"), "--source-table", "seed.raw", "--dest-uri", "postgresql://uri-here", "--dest-table", "asset-name", "--yes", "--progress", "log", "--columns", "id:bigint"}
+
+	tests := []struct {
+		name        string
+		loader      string
+		loadErr     error
+		wantNative  bool
+		wantIngestr bool
+		wantErr     string
+	}{
+		{
+			name:       "the seed is loaded natively when the connection supports it",
+			wantNative: true,
+		},
+		{
+			name:        "unsupported connections fall back to ingestr",
+			loadErr:     seed.ErrNotSupported,
+			wantNative:  true,
+			wantIngestr: true,
+		},
+		{
+			name:       "native loading errors are returned",
+			loadErr:    errors.New("load failed"),
+			wantNative: true,
+			wantErr:    "load failed",
+		},
+		{
+			name:        "the ingestr loader can be forced",
+			loader:      "ingestr",
+			wantIngestr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			asset := &pipeline.Asset{
+				Name:           "asset-name",
+				Connection:     "pg",
+				Parameters:     map[string]string{"path": "seed.csv"},
+				Columns:        []pipeline.Column{{Name: "id", Type: "integer"}},
+				ExecutableFile: pipeline.ExecutableFile{Path: filepath.Join(dir, "asset.yml")},
+			}
+			if tt.loader != "" {
+				asset.Parameters["loader"] = tt.loader
+			}
+
+			loader := new(mockSeedLoader)
+			if tt.wantIngestr {
+				loader.On("GetIngestrURI").Return("postgresql://uri-here", nil)
+			}
+			if tt.wantNative {
+				loader.On("LoadSeed", mock.Anything, expectedData).Return(tt.loadErr)
+			}
+
+			runner := new(mockRunner)
+			if tt.wantIngestr {
+				runner.On("RunIngestr", mock.Anything, ingestrArgs, []string(nil), repo).Return(nil)
+			}
+
+			o := &SeedOperator{
+				conn:     seedLoaderFetcher{loader: loader},
+				finder:   new(mockFinder),
+				runner:   runner,
+				renderer: jinja.NewRendererWithYesterday("ingestr-test", "ingestr-test"),
+			}
+
+			err := o.Run(context.Background(), &scheduler.AssetInstance{Pipeline: &pipeline.Pipeline{}, Asset: asset})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			loader.AssertExpectations(t)
+			runner.AssertExpectations(t)
+		})
+	}
+}
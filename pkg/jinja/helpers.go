@@ -0,0 +1,395 @@
+package jinja
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/date"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/nikolalohinski/gonja/v2/exec"
+	"github.com/pkg/errors"
+)
+
+const maxDateSpineRows = 100000
+
+// helperContext is what the SQL helpers know about the asset being rendered, both the pipeline and the asset can be nil
+// when the renderer is not bound to an asset.
+type helperContext struct {
+	pipeline     *pipeline.Pipeline
+	asset        *pipeline.Asset
+	schemaPrefix string
+}
+
+func newHelperContext(ctx context.Context, pipe *pipeline.Pipeline, asset *pipeline.Asset) *helperContext {
+	hc := &helperContext{pipeline: pipe, asset: asset}
+	if env, ok := ctx.Value(config.EnvironmentContextKey).(*config.Environment); ok && env != nil {
+		hc.schemaPrefix = env.SchemaPrefix
+	}
+
+	return hc
+}
+
+type sqlHelper func(hc *helperContext, params *exec.VarArgs) (string, error)
+
+// sqlHelpers is the registry of the Go-implemented functions that generate common SQL snippets, they are available
+// in every render next to the macros.
+var sqlHelpers = map[string]sqlHelper{
+	"date_spine":      dateSpine,
+	"union_relations": unionRelations,
+	"pivot":           pivot,
+	"star":            star,
+	"surrogate_key":   surrogateKey,
+	"ref":             ref,
+	"source":          source,
+}
+
+func (hc *helperContext) functions() map[string]any {
+	functions := make(map[string]any, len(sqlHelpers))
+	for name, helper := range sqlHelpers {
+		functions[name] = func(params *exec.VarArgs) *exec.Value {
+			out, err := helper(hc, params)
+			if err != nil {
+				return exec.AsValue(errors.Wrapf(err, "failed to run '%s'", name))
+			}
+			return exec.AsSafeValue(out)
+		}
+	}
+
+	return functions
+}
+
+func (hc *helperContext) dialect() string {
+	if hc.asset == nil {
+		return ""
+	}
+
+	switch hc.asset.Type { //nolint:exhaustive
+	case pipeline.AssetTypeBigqueryQuery, pipeline.AssetTypeBigqueryQuerySensor, pipeline.AssetTypeBigqueryTableSensor:
+		return "bigquery"
+	case pipeline.AssetTypeMsSQLQuery, pipeline.AssetTypeSynapseQuery:
+		return "tsql"
+	}
+
+	return ""
+}
+
+// relation returns the name of the given asset in the environment that is being rendered, the schema prefix of
+// the developer environments is applied the same way it is applied to the asset names.
+func (hc *helperContext) relation(name string) string {
+	if hc.schemaPrefix == "" {
+		return name
+	}
+
+	parts := strings.Split(name, ".")
+	if len(parts) != 2 {
+		return name
+	}
+
+	return hc.schemaPrefix + parts[0] + "." + parts[1]
+}
+
+// findAsset looks for the asset either by its name or by its name in the environment, since the asset names are
+// prefixed in the developer environments.
+func (hc *helperContext) findAsset(name string) *pipeline.Asset {
+	if hc.pipeline == nil {
+		return nil
+	}
+
+	relation := hc.relation(name)
+	for _, candidate := range hc.pipeline.Assets {
+		if strings.EqualFold(candidate.Name, name) || strings.EqualFold(candidate.Name, relation) {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+// argument returns the parameter either by its position or by its keyword.
+func argument(params *exec.VarArgs, position int, name string) (*exec.Value, bool) {
+	if value, ok := params.KwArgs[name]; ok {
+		return value, true
+	}
+	if position >= 0 && position < len(params.Args) {
+		return params.Args[position], true
+	}
+
+	return nil, false
+}
+
+func stringArgument(params *exec.VarArgs, position int, name, defaultValue string) string {
+	value, ok := argument(params, position, name)
+	if !ok || value.IsNil() {
+		return defaultValue
+	}
+
+	return value.String()
+}
+
+func listArgument(params *exec.VarArgs, position int, name string) ([]string, error) {
+	value, ok := argument(params, position, name)
+	if !ok || value.IsNil() {
+		return nil, nil
+	}
+	if value.IsString() {
+		return []string{value.String()}, nil
+	}
+	if !value.IsList() {
+		return nil, errors.Errorf("'%s' must be a list, '%s' given", name, value.String())
+	}
+
+	items := make([]string, 0, value.Len())
+	value.Iterate(func(_, _ int, item, _ *exec.Value) bool {
+		items = append(items, item.String())
+		return true
+	}, func() {})
+
+	return items, nil
+}
+
+func quoteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// dateSpine generates a query that returns a row for every datepart between the start date (inclusive) and the end
+// date (exclusive), e.g. `{{ date_spine('day', start_date, end_date) }}`.
+func dateSpine(_ *helperContext, params *exec.VarArgs) (string, error) {
+	datepart := strings.ToLower(stringArgument(params, 0, "datepart", ""))
+	startInput := stringArgument(params, 1, "start_date", "")
+	endInput := stringArgument(params, 2, "end_date", "")
+
+	start, err := date.ParseTime(startInput)
+	if err != nil {
+		return "", errors.Errorf("invalid start date '%s'", startInput)
+	}
+	end, err := date.ParseTime(endInput)
+	if err != nil {
+		return "", errors.Errorf("invalid end date '%s'", endInput)
+	}
+
+	var next func(time.Time) time.Time
+	castType, format := "date", "2006-01-02"
+	switch datepart {
+	case "minute":
+		next = func(t time.Time) time.Time { return t.Add(time.Minute) }
+		castType, format = "timestamp", "2006-01-02 15:04:05"
+	case "hour":
+		next = func(t time.Time) time.Time { return t.Add(time.Hour) }
+		castType, format = "timestamp", "2006-01-02 15:04:05"
+	case "day":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case "week":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case "month":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	case "quarter":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 3, 0) }
+	case "year":
+		next = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+	default:
+		return "", errors.Errorf("unsupported datepart '%s', it must be one of minute, hour, day, week, month, quarter or year", datepart)
+	}
+
+	rows := make([]string, 0)
+	for current := start; current.Before(end); current = next(current) {
+		if len(rows) == maxDateSpineRows {
+			return "", errors.Errorf("the date spine cannot have more than %d rows", maxDateSpineRows)
+		}
+		rows = append(rows, fmt.Sprintf("select cast('%s' as %s) as date_%s", current.Format(format), castType, datepart))
+	}
+	if len(rows) == 0 {
+		return "", errors.Errorf("the start date '%s' must be before the end date '%s'", startInput, endInput)
+	}
+
+	return strings.Join(rows, "\nunion all\n"), nil
+}
+
+// unionRelations generates a query that unions the given tables, adding a column with the name of the table every row
+// comes from, e.g. `{{ union_relations(['raw.orders_eu', 'raw.orders_us'], columns=['id', 'amount']) }}`.
+func unionRelations(_ *helperContext, params *exec.VarArgs) (string, error) {
+	relations, err := listArgument(params, 0, "relations")
+	if err != nil {
+		return "", err
+	}
+	if len(relations) == 0 {
+		return "", errors.New("at least one relation must be given")
+	}
+
+	columns, err := listArgument(params, 1, "columns")
+	if err != nil {
+		return "", err
+	}
+	selected := "*"
+	if len(columns) > 0 {
+		selected = strings.Join(columns, ", ")
+	}
+
+	sourceColumn := stringArgument(params, 2, "source_column_name", "_source_relation")
+
+	queries := make([]string, 0, len(relations))
+	for _, relation := range relations {
+		selection := selected
+		if sourceColumn != "" {
+			selection += fmt.Sprintf(", %s as %s", quoteString(relation), sourceColumn)
+		}
+		queries = append(queries, fmt.Sprintf("select %s from %s", selection, relation))
+	}
+
+	return strings.Join(queries, "\nunion all\n"), nil
+}
+
+var nonIdentifierCharacters = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// pivot generates an aggregated column for every given value of a column, e.g.
+// `{{ pivot('status', ['paid', 'refunded'], then_value='amount') }}`.
+func pivot(_ *helperContext, params *exec.VarArgs) (string, error) {
+	column := stringArgument(params, 0, "column", "")
+	if column == "" {
+		return "", errors.New("the column to pivot must be given")
+	}
+
+	values, err := listArgument(params, 1, "values")
+	if err != nil {
+		return "", err
+	}
+	if len(values) == 0 {
+		return "", errors.New("at least one value must be given")
+	}
+
+	agg := stringArgument(params, 2, "agg", "sum")
+	thenValue := stringArgument(params, 3, "then_value", "1")
+	elseValue := stringArgument(params, 4, "else_value", "0")
+	prefix := stringArgument(params, 5, "prefix", "")
+	suffix := stringArgument(params, 6, "suffix", "")
+
+	columns := make([]string, 0, len(values))
+	for _, value := range values {
+		alias := nonIdentifierCharacters.ReplaceAllString(prefix+value+suffix, "_")
+		columns = append(columns, fmt.Sprintf("%s(case when %s = %s then %s else %s end) as %s", agg, column, quoteString(value), thenValue, elseValue, alias))
+	}
+
+	return strings.Join(columns, ",\n"), nil
+}
+
+// star lists the columns of an asset in the pipeline except the given ones, e.g.
+// `{{ star('raw.users', except=['password']) }}`.
+func star(hc *helperContext, params *exec.VarArgs) (string, error) {
+	relation := stringArgument(params, 0, "relation", "")
+	if relation == "" {
+		return "", errors.New("the asset to select the columns from must be given")
+	}
+	if hc.pipeline == nil {
+		return "", errors.New("the columns can only be listed when rendering an asset in a pipeline")
+	}
+
+	asset := hc.findAsset(relation)
+	if asset == nil {
+		return "", errors.Errorf("asset '%s' does not exist in the pipeline", relation)
+	}
+	if len(asset.Columns) == 0 {
+		return "", errors.Errorf("asset '%s' does not have any columns declared", relation)
+	}
+
+	except, err := listArgument(params, 1, "except")
+	if err != nil {
+		return "", err
+	}
+	excluded := make(map[string]bool, len(except))
+	for _, column := range except {
+		excluded[strings.ToLower(column)] = true
+	}
+
+	alias := stringArgument(params, 2, "relation_alias", "")
+	columns := make([]string, 0, len(asset.Columns))
+	for _, column := range asset.Columns {
+		if excluded[strings.ToLower(column.Name)] {
+			continue
+		}
+		if alias != "" {
+			columns = append(columns, alias+"."+column.Name)
+			continue
+		}
+		columns = append(columns, column.Name)
+	}
+	if len(columns) == 0 {
+		return "", errors.Errorf("all the columns of the asset '%s' are excluded", relation)
+	}
+
+	return strings.Join(columns, ", "), nil
+}
+
+// ref refers to an asset that is built by the pipeline, e.g. `{{ ref('raw.users') }}`. The asset is added to the
+// upstreams of the asset and the relation is resolved to the schema of the environment.
+func ref(hc *helperContext, params *exec.VarArgs) (string, error) {
+	name := stringArgument(params, 0, "name", "")
+	if name == "" {
+		return "", errors.New("the name of the asset must be given")
+	}
+
+	return hc.relation(name), nil
+}
+
+// source refers to an asset that holds source data, e.g. `{{ source('raw.events') }}`. The asset is added to the
+// upstreams of the asset, but the relation always points to the production table since the source data is not
+// rebuilt in the developer environments.
+func source(hc *helperContext, params *exec.VarArgs) (string, error) {
+	name := stringArgument(params, 0, "name", "")
+	if name == "" {
+		return "", errors.New("the name of the source must be given")
+	}
+
+	return name, nil
+}
+
+// surrogateKey generates a hash of the given columns that can be used as a key, e.g.
+// `{{ surrogate_key(['order_id', 'line_number']) }}`.
+func surrogateKey(hc *helperContext, params *exec.VarArgs) (string, error) {
+	fields, err := listArgument(params, 0, "fields")
+	if err != nil {
+		return "", err
+	}
+	if len(params.Args) > 1 {
+		fields = make([]string, 0, len(params.Args))
+		for _, arg := range params.Args {
+			fields = append(fields, arg.String())
+		}
+	}
+	if len(fields) == 0 {
+		return "", errors.New("at least one field must be given")
+	}
+
+	dialect := hc.dialect()
+	stringType := "varchar"
+	switch dialect {
+	case "bigquery":
+		stringType = "string"
+	case "tsql":
+		stringType = "nvarchar(max)"
+	}
+
+	parts := make([]string, 0, len(fields)*2)
+	for i, field := range fields {
+		if i > 0 {
+			parts = append(parts, "'-'")
+		}
+		parts = append(parts, fmt.Sprintf("coalesce(cast(%s as %s), '_null_')", field, stringType))
+	}
+
+	key := parts[0]
+	if len(parts) > 1 {
+		key = "concat(" + strings.Join(parts, ", ") + ")"
+	}
+
+	switch dialect {
+	case "bigquery":
+		return "to_hex(md5(" + key + "))", nil
+	case "tsql":
+		return "lower(convert(varchar(32), hashbytes('md5', " + key + "), 2))", nil
+	}
+
+	return "md5(" + key + ")", nil
+}
@@ -0,0 +1,251 @@
+package jinja
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLHelpers(t *testing.T) {
+	t.Parallel()
+
+	users := &pipeline.Asset{
+		Name:    "raw.users",
+		Type:    pipeline.AssetTypeBigqueryQuery,
+		Columns: []pipeline.Column{{Name: "id"}, {Name: "email"}, {Name: "password"}},
+	}
+	p := &pipeline.Pipeline{Assets: []*pipeline.Asset{users}}
+	duckAsset := &pipeline.Asset{Name: "mart.users", Type: pipeline.AssetTypeDuckDBQuery}
+
+	tests := []struct {
+		name    string
+		query   string
+		asset   *pipeline.Asset
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "date spine",
+			query: "{{ date_spine('day', start_date, '2024-01-03') }}",
+			want: "select cast('2024-01-01' as date) as date_day\n" +
+				"union all\n" +
+				"select cast('2024-01-02' as date) as date_day",
+		},
+		{
+			name:  "hourly date spine",
+			query: "{{ date_spine(datepart='hour', start_date='2024-01-01T22:00:00', end_date='2024-01-02T00:00:00') }}",
+			want: "select cast('2024-01-01 22:00:00' as timestamp) as date_hour\n" +
+				"union all\n" +
+				"select cast('2024-01-01 23:00:00' as timestamp) as date_hour",
+		},
+		{
+			name:    "date spine with an empty range",
+			query:   "{{ date_spine('month', '2024-02-01', '2024-01-01') }}",
+			wantErr: "failed to run 'date_spine': the start date '2024-02-01' must be before the end date '2024-01-01'",
+		},
+		{
+			name:  "union relations",
+			query: "{{ union_relations(['raw.orders_eu', 'raw.orders_us']) }}",
+			want: "select *, 'raw.orders_eu' as _source_relation from raw.orders_eu\n" +
+				"union all\n" +
+				"select *, 'raw.orders_us' as _source_relation from raw.orders_us",
+		},
+		{
+			name:  "union relations with columns and without the source column",
+			query: "{{ union_relations(['a', 'b'], columns=['id', 'amount'], source_column_name='') }}",
+			want:  "select id, amount from a\nunion all\nselect id, amount from b",
+		},
+		{
+			name:  "pivot",
+			query: "select {{ pivot('status', ['paid', 'in progress'], then_value='amount', suffix='_amount') }} from orders",
+			want: "select sum(case when status = 'paid' then amount else 0 end) as paid_amount,\n" +
+				"sum(case when status = 'in progress' then amount else 0 end) as in_progress_amount from orders",
+		},
+		{
+			name:  "star with except",
+			query: "select {{ star('raw.users', except=['PASSWORD']) }} from raw.users",
+			asset: duckAsset,
+			want:  "select id, email from raw.users",
+		},
+		{
+			name:  "star with relation alias",
+			query: "select {{ star('raw.users', relation_alias='u') }} from raw.users u",
+			asset: duckAsset,
+			want:  "select u.id, u.email, u.password from raw.users u",
+		},
+		{
+			name:    "star for an unknown asset",
+			query:   "{{ star('raw.missing') }}",
+			asset:   duckAsset,
+			wantErr: "failed to run 'star': asset 'raw.missing' does not exist in the pipeline",
+		},
+		{
+			name:  "surrogate key",
+			query: "{{ surrogate_key(['order_id', 'line']) }}",
+			asset: duckAsset,
+			want:  "md5(concat(coalesce(cast(order_id as varchar), '_null_'), '-', coalesce(cast(line as varchar), '_null_')))",
+		},
+		{
+			name:  "surrogate key in bigquery",
+			query: "{{ surrogate_key('order_id') }}",
+			asset: users,
+			want:  "to_hex(md5(coalesce(cast(order_id as string), '_null_')))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			renderer := NewRenderer(Context{"start_date": "2024-01-01"}).CloneForAsset(context.Background(), p, tt.asset)
+			got, err := renderer.Render(tt.query)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{Assets: []*pipeline.Asset{
+		{Name: "raw.events"},
+		{Name: "mart.users", Columns: []pipeline.Column{{Name: "id"}, {Name: "email"}}},
+		{Name: "mart.orders"},
+	}}
+	// the asset names are already prefixed when running in a developer environment
+	devPipeline := &pipeline.Pipeline{Assets: []*pipeline.Asset{
+		{Name: "dev_raw.events"},
+		{Name: "dev_mart.users", Columns: []pipeline.Column{{Name: "id"}, {Name: "email"}}},
+		{Name: "dev_mart.orders"},
+	}}
+	query := "select {{ star('mart.users') }} from {{ ref('mart.users') }} join {{ source('raw.events') }} using (id)"
+
+	tests := []struct {
+		name     string
+		pipeline *pipeline.Pipeline
+		env      *config.Environment
+		query    string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "without an environment",
+			pipeline: p,
+			query:    query,
+			want:     "select id, email from mart.users join raw.events using (id)",
+		},
+		{
+			name:     "environment without a schema prefix",
+			pipeline: p,
+			env:      &config.Environment{},
+			query:    query,
+			want:     "select id, email from mart.users join raw.events using (id)",
+		},
+		{
+			name:     "developer environment",
+			pipeline: devPipeline,
+			env:      &config.Environment{SchemaPrefix: "dev_"},
+			query:    query,
+			want:     "select id, email from dev_mart.users join raw.events using (id)",
+		},
+		{
+			name:  "without a pipeline",
+			env:   &config.Environment{SchemaPrefix: "dev_"},
+			query: "{{ ref('mart.orders') }}, {{ ref('db.mart.orders') }}, {{ source(name='raw.events') }}",
+			want:  "dev_mart.orders, db.mart.orders, raw.events",
+		},
+		{
+			name:     "missing name",
+			pipeline: p,
+			query:    "{{ ref('') }}",
+			wantErr:  "failed to run 'ref': the name of the asset must be given",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.env != nil {
+				ctx = context.WithValue(ctx, config.EnvironmentContextKey, tt.env)
+			}
+
+			renderer := NewRenderer(Context{}).CloneForAsset(ctx, tt.pipeline, &pipeline.Asset{Name: "mart.report"})
+			got, err := renderer.Render(tt.query)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMacros(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, name), []byte(content), 0o600))
+	}
+
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+	writeFile("macros/money.sql", `
+{% macro cents_to_dollars(column, scale=2) %}round({{ column }} / 100, {{ scale }}){% endmacro %}
+{% macro currency() %}usd{% endmacro %}
+`)
+	writeFile("pipeline/pipeline.yml", "name: test")
+	writeFile("pipeline/macros/overrides.sql", `
+{% macro currency() %}eur{% endmacro %}
+{% macro amount(column) %}{{ cents_to_dollars(column) }} as amount_{{ currency() }}_{{ start_date }}{% endmacro %}
+`)
+
+	p := &pipeline.Pipeline{DefinitionFile: pipeline.DefinitionFile{Path: filepath.Join(repo, "pipeline", "pipeline.yml")}}
+	asset := &pipeline.Asset{Name: "mart.orders"}
+
+	renderer := NewRenderer(Context{"start_date": "2024-01-01"}).CloneForAsset(context.Background(), p, asset)
+	got, err := renderer.Render("select {{ amount('price') }}, {{ cents_to_dollars('tax', scale=0) }} from orders")
+	require.NoError(t, err)
+	assert.Equal(t, "select round(price / 100, 2) as amount_eur_2024-01-01, round(tax / 100, 0) from orders", got)
+
+	// the macro files are parsed once per pipeline and shared by the renderers of its assets
+	base := NewRenderer(Context{"start_date": "2024-01-01"})
+	_, err = base.CloneForAsset(context.Background(), p, asset).Render("{{ currency() }}")
+	require.NoError(t, err)
+	writeFile("pipeline/macros/overrides.sql", `{% macro currency() %}gbp{% endmacro %}`)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := base.CloneForAsset(context.Background(), p, &pipeline.Asset{Name: "mart.refunds"}).Render("{{ currency() }}")
+			assert.NoError(t, err)
+			assert.Equal(t, "eur", got)
+		}()
+	}
+	wg.Wait()
+
+	// the macros are only available when the renderer is bound to a pipeline
+	_, err = NewRenderer(Context{}).Render("{{ currency() }}")
+	require.Error(t, err)
+}
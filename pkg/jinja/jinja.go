@@ -18,6 +18,9 @@ import (
 type Renderer struct {
 	context         *exec.Context
 	queryRenderLock *sync.Mutex
+	macros          *macroLibrary
+	macroCache      *macroCache
+	helpers         *helperContext
 }
 
 func init() { //nolint: gochecknoinits
@@ -35,6 +38,7 @@ func NewRenderer(context Context) *Renderer {
 	return &Renderer{
 		context:         exec.NewContext(context),
 		queryRenderLock: &sync.Mutex{},
+		macroCache:      newMacroCache(),
 	}
 }
 
@@ -65,6 +69,7 @@ func NewRendererWithStartEndDates(startDate, endDate *time.Time, pipelineName, r
 	return &Renderer{
 		context:         exec.NewContext(ctx),
 		queryRenderLock: &sync.Mutex{},
+		macroCache:      newMacroCache(),
 	}
 }
 
@@ -105,9 +110,14 @@ func (r *Renderer) Render(query string) (string, error) {
 	}
 	r.queryRenderLock.Unlock()
 
+	renderContext, err := r.renderContext()
+	if err != nil {
+		return "", err
+	}
+
 	// Now you can render the template with the given
 	// gonja.context how often you want to.
-	out, err := tpl.ExecuteToString(r.context)
+	out, err := tpl.ExecuteToString(renderContext)
 	if err != nil {
 		customError := findRenderErrorType(err)
 		if customError == "" {
@@ -120,16 +130,42 @@ func (r *Renderer) Render(query string) (string, error) {
 	return out, nil
 }
 
+// renderContext merges the SQL helpers and the macros into the context of the renderer, the values given in the
+// context take precedence over both.
+func (r *Renderer) renderContext() (*exec.Context, error) {
+	helpers := r.helpers
+	if helpers == nil {
+		helpers = &helperContext{}
+	}
+
+	renderContext := gonja.DefaultContext.Inherit().Update(exec.NewContext(helpers.functions())).Update(r.context)
+	if r.macros == nil {
+		return renderContext, nil
+	}
+
+	macros, err := r.macros.bind(renderContext)
+	if err != nil {
+		return nil, err
+	}
+	for name, macro := range macros {
+		if !r.context.Has(name) {
+			renderContext.Set(name, macro)
+		}
+	}
+
+	return renderContext, nil
+}
+
 //nolint:ireturn
 func (r *Renderer) CloneForAsset(ctx context.Context, pipe *pipeline.Pipeline, asset *pipeline.Asset) RendererInterface {
 	startDate, ok := ctx.Value(pipeline.RunConfigStartDate).(time.Time)
 	if !ok {
-		return r
+		return r.withAsset(ctx, pipe, asset)
 	}
 
 	endDate, ok := ctx.Value(pipeline.RunConfigEndDate).(time.Time)
 	if !ok {
-		return r
+		return r.withAsset(ctx, pipe, asset)
 	}
 
 	applyModifiers, ok := ctx.Value(pipeline.RunConfigApplyIntervalModifiers).(bool)
@@ -145,6 +181,21 @@ func (r *Renderer) CloneForAsset(ctx context.Context, pipe *pipeline.Pipeline, a
 	return &Renderer{
 		context:         exec.NewContext(jinjaContext),
 		queryRenderLock: &sync.Mutex{},
+		macros:          r.macroCache.load(pipe),
+		macroCache:      r.macroCache,
+		helpers:         newHelperContext(ctx, pipe, asset),
+	}
+}
+
+// withAsset returns a copy of the renderer with the same context that has the macros of the pipeline and the SQL
+// helpers for the asset available.
+func (r *Renderer) withAsset(ctx context.Context, pipe *pipeline.Pipeline, asset *pipeline.Asset) *Renderer {
+	return &Renderer{
+		context:         r.context,
+		queryRenderLock: r.queryRenderLock,
+		macros:          r.macroCache.load(pipe),
+		macroCache:      r.macroCache,
+		helpers:         newHelperContext(ctx, pipe, asset),
 	}
 }
 
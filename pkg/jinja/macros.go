@@ -0,0 +1,148 @@
+package jinja
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/nikolalohinski/gonja/v2"
+	"github.com/nikolalohinski/gonja/v2/exec"
+	"github.com/pkg/errors"
+)
+
+const macrosDirectoryName = "macros"
+
+var macroFileExtensions = map[string]bool{".sql": true, ".jinja": true}
+
+type macroFile struct {
+	path     string
+	template *exec.Template
+}
+
+// macroLibrary holds the parsed macro files of a pipeline, the macros defined in them are available in every render
+// without having to import them.
+type macroLibrary struct {
+	files []*macroFile
+	err   error
+}
+
+// macroCache holds the macro libraries of the pipelines by the path of their definition files, so that the macro
+// files are read and parsed once per pipeline rather than for every asset.
+type macroCache struct {
+	lock      sync.Mutex
+	libraries map[string]*macroLibrary
+}
+
+func newMacroCache() *macroCache {
+	return &macroCache{libraries: make(map[string]*macroLibrary)}
+}
+
+func (c *macroCache) load(p *pipeline.Pipeline) *macroLibrary {
+	if c == nil {
+		return loadMacroLibrary(p)
+	}
+	if p == nil || p.DefinitionFile.Path == "" {
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if library, ok := c.libraries[p.DefinitionFile.Path]; ok {
+		return library
+	}
+
+	library := loadMacroLibrary(p)
+	c.libraries[p.DefinitionFile.Path] = library
+	return library
+}
+
+// loadMacroLibrary parses the macro files in the `macros` directory of the repository and of the pipeline, the
+// pipeline macros override the repository macros with the same name.
+func loadMacroLibrary(p *pipeline.Pipeline) *macroLibrary {
+	if p == nil || p.DefinitionFile.Path == "" {
+		return nil
+	}
+
+	pipelineDir := filepath.Dir(p.DefinitionFile.Path)
+	dirs := make([]string, 0, 2)
+	if repo, err := git.FindRepoFromPath(pipelineDir); err == nil {
+		dirs = append(dirs, filepath.Join(repo.Path, macrosDirectoryName))
+	}
+	if pipelineMacros := filepath.Join(pipelineDir, macrosDirectoryName); len(dirs) == 0 || dirs[0] != pipelineMacros {
+		dirs = append(dirs, pipelineMacros)
+	}
+
+	library := &macroLibrary{files: make([]*macroFile, 0)}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if !entry.IsDir() && macroFileExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			content, err := os.ReadFile(path)
+			if err != nil {
+				library.err = errors.Wrapf(err, "failed to read the macro file '%s'", path)
+				return library
+			}
+
+			tpl, err := gonja.FromString(string(content))
+			if err != nil {
+				library.err = errors.Wrapf(err, "failed to parse the macro file '%s'", path)
+				return library
+			}
+
+			library.files = append(library.files, &macroFile{path: path, template: tpl})
+		}
+	}
+
+	if len(library.files) == 0 {
+		return nil
+	}
+
+	return library
+}
+
+// bind returns the macros as functions that are evaluated against the given context, therefore once they are added
+// to the context they can use the variables of the render as well as each other.
+func (l *macroLibrary) bind(renderContext *exec.Context) (map[string]exec.Macro, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	macros := make(map[string]exec.Macro)
+	for _, file := range l.files {
+		renderer := exec.NewRenderer(&exec.Environment{
+			Context:           renderContext,
+			Filters:           gonja.DefaultEnvironment.Filters,
+			Tests:             gonja.DefaultEnvironment.Tests,
+			ControlStructures: gonja.DefaultEnvironment.ControlStructures,
+			Methods:           gonja.DefaultEnvironment.Methods,
+		}, io.Discard, gonja.DefaultConfig, gonja.DefaultLoader, file.template)
+
+		for name, node := range file.template.Macros() {
+			fn, err := exec.MacroNodeToFunc(node, renderer)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to load the macro '%s' in '%s'", name, file.path)
+			}
+			macros[name] = fn
+		}
+	}
+
+	return macros, nil
+}
@@ -0,0 +1,200 @@
+package lineage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// ImpactedColumn is a column that is derived from the analyzed column, Path holds the columns in between in the
+// `asset.column` format, starting with the analyzed column and ending with this one.
+type ImpactedColumn struct {
+	Pipeline string   `json:"pipeline"`
+	Asset    string   `json:"asset"`
+	Column   string   `json:"column"`
+	Path     []string `json:"path"`
+}
+
+// ImpactedCheck is a column check or a custom check that refers to one of the impacted columns. Column is empty for
+// custom checks.
+type ImpactedCheck struct {
+	Pipeline string   `json:"pipeline"`
+	Asset    string   `json:"asset"`
+	Column   string   `json:"column,omitempty"`
+	Name     string   `json:"name"`
+	Path     []string `json:"path"`
+}
+
+// ImpactedAsset is an asset that has at least one impacted column.
+type ImpactedAsset struct {
+	Pipeline string `json:"pipeline"`
+	Asset    string `json:"asset"`
+	File     string `json:"file"`
+}
+
+// Impact is the result of the impact analysis of a single column.
+type Impact struct {
+	Asset        string            `json:"asset"`
+	Column       string            `json:"column"`
+	Columns      []*ImpactedColumn `json:"columns"`
+	Checks       []*ImpactedCheck  `json:"checks"`
+	CustomChecks []*ImpactedCheck  `json:"custom_checks"`
+	Assets       []*ImpactedAsset  `json:"assets"`
+}
+
+type columnReference struct {
+	pipeline *pipeline.Pipeline
+	asset    *pipeline.Asset
+	column   *pipeline.Column
+}
+
+func columnKey(table, column string) string {
+	return strings.ToLower(table) + "." + strings.ToLower(column)
+}
+
+// ColumnImpact walks the column-level lineage downstream from the given column across all the given pipelines, and
+// returns every column, check, custom check and asset that depends on it. The column lineage of the pipelines is
+// expected to be extracted already.
+func ColumnImpact(pipelines []*pipeline.Pipeline, assetName, columnName string) (*Impact, error) {
+	var source *columnReference
+	dependents := make(map[string][]*columnReference)
+	for _, p := range pipelines {
+		for _, asset := range p.Assets {
+			for i := range asset.Columns {
+				ref := &columnReference{pipeline: p, asset: asset, column: &asset.Columns[i]}
+				if source == nil && strings.EqualFold(asset.Name, assetName) && strings.EqualFold(ref.column.Name, columnName) {
+					source = ref
+				}
+
+				for _, upstream := range ref.column.Upstreams {
+					key := columnKey(upstream.Table, upstream.Column)
+					dependents[key] = append(dependents[key], ref)
+				}
+			}
+		}
+	}
+
+	if source == nil {
+		source = undeclaredSource(pipelines, assetName, columnName, len(dependents[columnKey(assetName, columnName)]) > 0)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("failed to find the column '%s' in the asset '%s'", columnName, assetName)
+	}
+
+	impact := &Impact{
+		Asset:        source.asset.Name,
+		Column:       source.column.Name,
+		Columns:      make([]*ImpactedColumn, 0),
+		Checks:       make([]*ImpactedCheck, 0),
+		CustomChecks: make([]*ImpactedCheck, 0),
+		Assets:       make([]*ImpactedAsset, 0),
+	}
+
+	type queueItem struct {
+		ref  *columnReference
+		path []string
+	}
+
+	sourceKey := columnKey(source.asset.Name, source.column.Name)
+	visited := map[string]bool{sourceKey: true}
+	seenAssets := map[*pipeline.Asset]bool{}
+	queue := []queueItem{{ref: source, path: []string{source.asset.Name + "." + source.column.Name}}}
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		ref := item.ref
+		isSource := ref == source
+		if !isSource {
+			impact.Columns = append(impact.Columns, &ImpactedColumn{
+				Pipeline: ref.pipeline.Name,
+				Asset:    ref.asset.Name,
+				Column:   ref.column.Name,
+				Path:     item.path,
+			})
+		}
+
+		for _, check := range ref.column.Checks {
+			impact.Checks = append(impact.Checks, &ImpactedCheck{
+				Pipeline: ref.pipeline.Name,
+				Asset:    ref.asset.Name,
+				Column:   ref.column.Name,
+				Name:     check.Name,
+				Path:     item.path,
+			})
+		}
+
+		if !seenAssets[ref.asset] {
+			seenAssets[ref.asset] = true
+			if !isSource {
+				impact.Assets = append(impact.Assets, &ImpactedAsset{
+					Pipeline: ref.pipeline.Name,
+					Asset:    ref.asset.Name,
+					File:     ref.asset.DefinitionFile.Path,
+				})
+			}
+		}
+
+		impact.CustomChecks = append(impact.CustomChecks, customChecksReferringTo(ref, item.path)...)
+
+		for _, dependent := range dependents[columnKey(ref.asset.Name, ref.column.Name)] {
+			key := columnKey(dependent.asset.Name, dependent.column.Name)
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+
+			path := make([]string, len(item.path), len(item.path)+1)
+			copy(path, item.path)
+			queue = append(queue, queueItem{ref: dependent, path: append(path, dependent.asset.Name+"."+dependent.column.Name)})
+		}
+	}
+
+	return impact, nil
+}
+
+// undeclaredSource builds the root of the analysis for a column that is not declared in any asset, such as a column
+// of an external table or an asset without column definitions. It returns nil if neither the asset exists nor any
+// column is derived from it, since then the given column is most likely a typo.
+func undeclaredSource(pipelines []*pipeline.Pipeline, assetName, columnName string, hasDependents bool) *columnReference {
+	for _, p := range pipelines {
+		for _, asset := range p.Assets {
+			if strings.EqualFold(asset.Name, assetName) {
+				return &columnReference{pipeline: p, asset: asset, column: &pipeline.Column{Name: columnName}}
+			}
+		}
+	}
+
+	if !hasDependents {
+		return nil
+	}
+
+	return &columnReference{
+		pipeline: &pipeline.Pipeline{},
+		asset:    &pipeline.Asset{Name: assetName},
+		column:   &pipeline.Column{Name: columnName},
+	}
+}
+
+// customChecksReferringTo returns the custom checks of the asset whose queries mention the column.
+func customChecksReferringTo(ref *columnReference, path []string) []*ImpactedCheck {
+	pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(ref.column.Name) + `\b`)
+
+	checks := make([]*ImpactedCheck, 0)
+	for _, check := range ref.asset.CustomChecks {
+		if !pattern.MatchString(check.Query) {
+			continue
+		}
+
+		checks = append(checks, &ImpactedCheck{
+			Pipeline: ref.pipeline.Name,
+			Asset:    ref.asset.Name,
+			Name:     check.Name,
+			Path:     path,
+		})
+	}
+
+	return checks
+}
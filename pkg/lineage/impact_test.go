@@ -0,0 +1,112 @@
+package lineage
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnImpact(t *testing.T) {
+	t.Parallel()
+
+	raw := &pipeline.Asset{
+		Name:           "raw.users",
+		DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/ingestion/assets/users.asset.yml"},
+		Columns: []pipeline.Column{
+			{Name: "id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+			{Name: "email"},
+		},
+		CustomChecks: []pipeline.CustomCheck{
+			{Name: "emails are unique", Query: "select count(*) - count(distinct email) from raw.users"},
+		},
+	}
+	stg := &pipeline.Asset{
+		Name:           "stg.users",
+		DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/ingestion/assets/stg_users.sql"},
+		Columns: []pipeline.Column{
+			{Name: "user_id", Upstreams: []*pipeline.UpstreamColumn{{Table: "raw.users", Column: "id"}}},
+			{Name: "contact", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}, Upstreams: []*pipeline.UpstreamColumn{{Table: "raw.users", Column: "EMAIL"}}},
+		},
+		CustomChecks: []pipeline.CustomCheck{
+			{Name: "contact is set", Query: "select count(*) from stg.users where contact is null"},
+			{Name: "users exist", Query: "select count(*) > 0 from stg.users"},
+		},
+	}
+	mart := &pipeline.Asset{
+		Name:           "mart.contacts",
+		DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/analytics/assets/contacts.sql"},
+		Columns: []pipeline.Column{
+			{Name: "contact", Checks: []pipeline.ColumnCheck{{Name: "unique"}}, Upstreams: []*pipeline.UpstreamColumn{{Table: "stg.users", Column: "contact"}}},
+			{Name: "domain", Upstreams: []*pipeline.UpstreamColumn{{Table: "stg.users", Column: "contact"}, {Table: "raw.users", Column: "email"}}},
+		},
+	}
+
+	pipelines := []*pipeline.Pipeline{
+		{Name: "ingestion", Assets: []*pipeline.Asset{raw, stg}},
+		{Name: "analytics", Assets: []*pipeline.Asset{mart}},
+	}
+
+	impact, err := ColumnImpact(pipelines, "raw.users", "email")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*ImpactedColumn{
+		{Pipeline: "ingestion", Asset: "stg.users", Column: "contact", Path: []string{"raw.users.email", "stg.users.contact"}},
+		{Pipeline: "analytics", Asset: "mart.contacts", Column: "domain", Path: []string{"raw.users.email", "mart.contacts.domain"}},
+		{Pipeline: "analytics", Asset: "mart.contacts", Column: "contact", Path: []string{"raw.users.email", "stg.users.contact", "mart.contacts.contact"}},
+	}, impact.Columns)
+
+	assert.Equal(t, []*ImpactedCheck{
+		{Pipeline: "ingestion", Asset: "stg.users", Column: "contact", Name: "not_null", Path: []string{"raw.users.email", "stg.users.contact"}},
+		{Pipeline: "analytics", Asset: "mart.contacts", Column: "contact", Name: "unique", Path: []string{"raw.users.email", "stg.users.contact", "mart.contacts.contact"}},
+	}, impact.Checks)
+
+	assert.Equal(t, []*ImpactedCheck{
+		{Pipeline: "ingestion", Asset: "raw.users", Name: "emails are unique", Path: []string{"raw.users.email"}},
+		{Pipeline: "ingestion", Asset: "stg.users", Name: "contact is set", Path: []string{"raw.users.email", "stg.users.contact"}},
+	}, impact.CustomChecks)
+
+	assert.Equal(t, []*ImpactedAsset{
+		{Pipeline: "ingestion", Asset: "stg.users", File: "/repo/ingestion/assets/stg_users.sql"},
+		{Pipeline: "analytics", Asset: "mart.contacts", File: "/repo/analytics/assets/contacts.sql"},
+	}, impact.Assets)
+
+	impact, err = ColumnImpact(pipelines, "raw.users", "missing")
+	require.NoError(t, err)
+	assert.Empty(t, impact.Columns)
+
+	_, err = ColumnImpact(pipelines, "raw.missing", "id")
+	require.Error(t, err)
+}
+
+func TestColumnImpact_ExternalSource(t *testing.T) {
+	t.Parallel()
+
+	stg := &pipeline.Asset{
+		Name:           "stg.events",
+		DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/ingestion/assets/events.sql"},
+		Columns: []pipeline.Column{
+			{Name: "event_id", Checks: []pipeline.ColumnCheck{{Name: "unique"}}, Upstreams: []*pipeline.UpstreamColumn{{Table: "external.events", Column: "id"}}},
+		},
+	}
+	pipelines := []*pipeline.Pipeline{{Name: "ingestion", Assets: []*pipeline.Asset{stg}}}
+
+	impact, err := ColumnImpact(pipelines, "external.events", "id")
+	require.NoError(t, err)
+
+	assert.Equal(t, "external.events", impact.Asset)
+	assert.Equal(t, "id", impact.Column)
+	assert.Equal(t, []*ImpactedColumn{
+		{Pipeline: "ingestion", Asset: "stg.events", Column: "event_id", Path: []string{"external.events.id", "stg.events.event_id"}},
+	}, impact.Columns)
+	assert.Equal(t, []*ImpactedCheck{
+		{Pipeline: "ingestion", Asset: "stg.events", Column: "event_id", Name: "unique", Path: []string{"external.events.id", "stg.events.event_id"}},
+	}, impact.Checks)
+	assert.Equal(t, []*ImpactedAsset{
+		{Pipeline: "ingestion", Asset: "stg.events", File: "/repo/ingestion/assets/events.sql"},
+	}, impact.Assets)
+
+	_, err = ColumnImpact(pipelines, "external.events", "missing")
+	require.Error(t, err)
+}
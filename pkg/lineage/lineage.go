@@ -1,12 +1,14 @@
 package lineage
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/bruin-data/bruin/pkg/jinja"
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/seed"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 )
 
@@ -94,8 +96,15 @@ func (p *LineageExtractor) ColumnLineage(foundPipeline *pipeline.Pipeline, asset
 		}
 	}
 
-	// TODO: Currently we are ignoring non user errors, we should handle them
-	lineageError, _ := p.parseLineage(foundPipeline, asset, p.TableSchemaForUpstreams(foundPipeline, asset))
+	var lineageError *LineageIssue
+	if asset.Type == pipeline.AssetTypeIngestr {
+		lineageError = p.ingestrLineage(foundPipeline, asset)
+	} else if _, err := sqlparser.AssetTypeToDialect(asset.Type); err != nil {
+		lineageError = p.declaredLineage(foundPipeline, asset)
+	} else {
+		// TODO: Currently we are ignoring non user errors, we should handle them
+		lineageError, _ = p.parseLineage(foundPipeline, asset, p.TableSchemaForUpstreams(foundPipeline, asset))
+	}
 	if lineageError != nil {
 		issues.Issues = append(issues.Issues, lineageError)
 	}
@@ -103,6 +112,127 @@ func (p *LineageExtractor) ColumnLineage(foundPipeline *pipeline.Pipeline, asset
 	return &issues
 }
 
+// ingestrLineage maps the columns of an ingestr asset 1:1 to the columns of its `source_table`. The columns that
+// declare their upstreams are kept as they are, and if the source table is an asset in the pipeline, its columns that
+// are not declared are added with the names ingestr normalizes them into.
+func (p *LineageExtractor) ingestrLineage(foundPipeline *pipeline.Pipeline, asset *pipeline.Asset) *LineageIssue {
+	sourceTable := strings.TrimSpace(asset.Parameters["source_table"])
+	if sourceTable == "" {
+		return nil
+	}
+
+	sourceAsset := foundPipeline.GetAssetByNameCaseInsensitive(sourceTable)
+	if sourceAsset != nil {
+		sourceTable = sourceAsset.Name
+	}
+
+	for i := range asset.Columns {
+		column := &asset.Columns[i]
+		if len(column.Upstreams) > 0 {
+			continue
+		}
+
+		sourceColumnName := column.Name
+		if sourceAsset != nil {
+			if sourceColumn := findIngestrSourceColumn(sourceAsset, column.Name); sourceColumn != nil {
+				sourceColumnName = sourceColumn.Name
+				if column.Type == "" {
+					column.Type = sourceColumn.Type
+				}
+			}
+		}
+
+		column.Upstreams = []*pipeline.UpstreamColumn{
+			{
+				Column: sourceColumnName,
+				Table:  sourceTable,
+			},
+		}
+	}
+
+	if sourceAsset == nil {
+		return nil
+	}
+
+	for _, sourceColumn := range sourceAsset.Columns {
+		name := seed.NormalizeColumnName(sourceColumn.Name)
+		if asset.GetColumnWithName(name) != nil {
+			continue
+		}
+
+		asset.Columns = append(asset.Columns, pipeline.Column{
+			Name:        name,
+			Type:        sourceColumn.Type,
+			Description: sourceColumn.Description,
+			Checks:      []pipeline.ColumnCheck{},
+			Upstreams: []*pipeline.UpstreamColumn{
+				{
+					Column: sourceColumn.Name,
+					Table:  sourceAsset.Name,
+				},
+			},
+		})
+	}
+
+	return nil
+}
+
+// findIngestrSourceColumn finds the column of the source asset that is loaded into the given column, ingestr
+// normalizes the column names while loading them.
+func findIngestrSourceColumn(sourceAsset *pipeline.Asset, name string) *pipeline.Column {
+	if column := sourceAsset.GetColumnWithName(name); column != nil {
+		return column
+	}
+
+	for i, column := range sourceAsset.Columns {
+		if strings.EqualFold(seed.NormalizeColumnName(column.Name), name) {
+			return &sourceAsset.Columns[i]
+		}
+	}
+
+	return nil
+}
+
+// declaredLineage resolves the upstream columns that are declared in the asset definition, which is the only source
+// of the lineage for the assets that are not SQL, e.g. Python assets.
+func (p *LineageExtractor) declaredLineage(foundPipeline *pipeline.Pipeline, asset *pipeline.Asset) *LineageIssue {
+	missing := make([]string, 0)
+	for i := range asset.Columns {
+		column := &asset.Columns[i]
+		for _, upstream := range column.Upstreams {
+			upstreamAsset := foundPipeline.GetAssetByNameCaseInsensitive(upstream.Table)
+			if upstreamAsset == nil {
+				// the upstream is an external table
+				continue
+			}
+			upstream.Table = upstreamAsset.Name
+
+			upstreamColumn := upstreamAsset.GetColumnWithName(upstream.Column)
+			if upstreamColumn == nil {
+				// the assets without any columns are not documented yet, their columns cannot be verified
+				if len(upstreamAsset.Columns) > 0 {
+					missing = append(missing, fmt.Sprintf("%s.%s", upstreamAsset.Name, upstream.Column))
+				}
+				continue
+			}
+
+			if column.Type == "" && len(column.Upstreams) == 1 {
+				column.Type = upstreamColumn.Type
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return &LineageIssue{
+		Task:        asset,
+		Description: "the upstream columns declared in the asset do not exist in their assets: " + strings.Join(missing, ", "),
+		Context:     missing,
+	}
+}
+
 // ParseLineage analyzes the column lineage for a given asset within a
 // It traces column relationships between the asset and its upstream dependencies.
 func (p *LineageExtractor) parseLineage(foundPipeline *pipeline.Pipeline, asset *pipeline.Asset, metadata sqlparser.Schema) (*LineageIssue, error) {
@@ -116,6 +246,10 @@ func (p *LineageExtractor) parseLineage(foundPipeline *pipeline.Pipeline, asset
 	}
 
 	for _, upstream := range asset.Upstreams {
+		if upstream.Type == "uri" {
+			continue
+		}
+
 		upstreamAsset := foundPipeline.GetAssetByName(upstream.Value)
 		if upstreamAsset == nil {
 			return &LineageIssue{
@@ -128,7 +262,7 @@ func (p *LineageExtractor) parseLineage(foundPipeline *pipeline.Pipeline, asset
 		}
 	}
 
-	query, err := p.renderer.Render(asset.ExecutableFile.Content)
+	query, err := p.renderer.CloneForAsset(context.Background(), foundPipeline, asset).Render(asset.ExecutableFile.Content)
 	if err != nil {
 		return &LineageIssue{
 			Task:        asset,
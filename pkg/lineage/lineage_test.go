@@ -8,6 +8,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var SQLParser *sqlparser.SQLParser
@@ -1683,3 +1684,60 @@ func TestLineageError(t *testing.T) {
 		}
 	}
 }
+
+func TestColumnLineage_IngestrAndPythonAssets(t *testing.T) {
+	t.Parallel()
+
+	source := &pipeline.Asset{
+		Name: "src.users",
+		Type: pipeline.AssetTypeBigquerySource,
+		Columns: []pipeline.Column{
+			{Name: "userId", Type: "integer"},
+			{Name: "Email", Type: "string", Description: "the email of the user"},
+		},
+	}
+	ingestr := &pipeline.Asset{
+		Name:       "raw.users",
+		Type:       pipeline.AssetTypeIngestr,
+		Parameters: map[string]string{"source_table": "src.users"},
+		Upstreams:  []pipeline.Upstream{{Type: "asset", Value: "src.users"}},
+		Columns:    []pipeline.Column{{Name: "user_id"}},
+	}
+	python := &pipeline.Asset{
+		Name:      "py.enriched",
+		Type:      pipeline.AssetTypePython,
+		Upstreams: []pipeline.Upstream{{Type: "asset", Value: "raw.users"}},
+		Columns: []pipeline.Column{
+			{Name: "user_id", Upstreams: []*pipeline.UpstreamColumn{{Table: "RAW.USERS", Column: "user_id"}}},
+			{Name: "score", Type: "float", Upstreams: []*pipeline.UpstreamColumn{{Table: "raw.users", Column: "missing"}}},
+		},
+	}
+	mart := &pipeline.Asset{
+		Name:           "mart.report",
+		Type:           pipeline.AssetTypeBigqueryQuery,
+		ExecutableFile: pipeline.ExecutableFile{Content: "SELECT user_id FROM py.enriched"},
+		Upstreams:      []pipeline.Upstream{{Type: "asset", Value: "py.enriched"}},
+	}
+	p := &pipeline.Pipeline{Assets: []*pipeline.Asset{source, ingestr, python, mart}}
+
+	extractor := NewLineageExtractor(SQLParser)
+	lineageErrors := extractor.ColumnLineage(p, mart, make(map[string]bool))
+	require.NotNil(t, lineageErrors)
+	require.Len(t, lineageErrors.Issues, 1)
+	assert.Equal(t, python, lineageErrors.Issues[0].Task)
+	assert.Equal(t, []string{"raw.users.missing"}, lineageErrors.Issues[0].Context)
+
+	require.Len(t, ingestr.Columns, 2)
+	assert.Equal(t, "integer", ingestr.Columns[0].Type)
+	assert.Equal(t, []*pipeline.UpstreamColumn{{Table: "src.users", Column: "userId"}}, ingestr.Columns[0].Upstreams)
+	assert.Equal(t, "email", ingestr.Columns[1].Name)
+	assert.Equal(t, "the email of the user", ingestr.Columns[1].Description)
+	assert.Equal(t, []*pipeline.UpstreamColumn{{Table: "src.users", Column: "Email"}}, ingestr.Columns[1].Upstreams)
+
+	assert.Equal(t, "integer", python.Columns[0].Type)
+	assert.Equal(t, []*pipeline.UpstreamColumn{{Table: "raw.users", Column: "user_id"}}, python.Columns[0].Upstreams)
+
+	userID := mart.GetColumnWithName("user_id")
+	require.NotNil(t, userID)
+	assert.Equal(t, []*pipeline.UpstreamColumn{{Table: "py.enriched", Column: "user_id"}}, userID.Upstreams)
+}
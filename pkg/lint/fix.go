@@ -0,0 +1,320 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+)
+
+// FixedAsset is an asset that was changed by the fixers, along with its contents before and after the fixes.
+type FixedAsset struct {
+	Asset  *pipeline.Asset
+	Rules  []string
+	Before []byte
+	After  []byte
+}
+
+// Diff returns the changes as a unified diff.
+func (f *FixedAsset) Diff() (string, error) {
+	path := relativeIssuePath(f.Asset.ExecutableFile.Path)
+	fromFile, toFile := path, path
+	if !filepath.IsAbs(path) {
+		fromFile = "a/" + filepath.ToSlash(path)
+		toFile = "b/" + filepath.ToSlash(path)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(f.Before)),
+		B:        difflib.SplitLines(string(f.After)),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// FixResult contains the assets that were changed by the fixers, and the ones that could not be fixed.
+type FixResult struct {
+	Fixed   []*FixedAsset
+	Skipped map[string]error
+}
+
+// Fix runs the fixers of the rules that reported issues on the analyzed assets. The assets are only changed in
+// memory, the caller is expected to persist the fixed assets.
+func Fix(ctx context.Context, fs afero.Fs, analysis *PipelineAnalysisResult) (*FixResult, error) {
+	result := &FixResult{
+		Fixed:   make([]*FixedAsset, 0),
+		Skipped: make(map[string]error),
+	}
+
+	for _, pipelineIssues := range analysis.Pipelines {
+		rules := make([]FixableRule, 0)
+		for rule := range pipelineIssues.Issues {
+			if fixable, ok := rule.(FixableRule); ok && fixable.CanFix() {
+				rules = append(rules, fixable)
+			}
+		}
+		sort.Slice(rules, func(i, j int) bool {
+			return rules[i].Name() < rules[j].Name()
+		})
+
+		changedAssets := make([]*pipeline.Asset, 0)
+		appliedRules := make(map[*pipeline.Asset][]string)
+		for _, rule := range rules {
+			fixedAssets := make(map[*pipeline.Asset]bool)
+			for _, issue := range pipelineIssues.Issues[rule] {
+				if issue.Task == nil || issue.Task.ExecutableFile.Path == "" || fixedAssets[issue.Task] {
+					continue
+				}
+				fixedAssets[issue.Task] = true
+
+				changed, err := rule.FixAsset(ctx, pipelineIssues.Pipeline, issue.Task)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to fix the asset '%s' for the rule '%s'", issue.Task.Name, rule.Name())
+				}
+
+				for _, asset := range changed {
+					if _, ok := appliedRules[asset]; !ok {
+						changedAssets = append(changedAssets, asset)
+					}
+					appliedRules[asset] = append(appliedRules[asset], rule.Name())
+				}
+			}
+		}
+
+		for _, asset := range changedAssets {
+			// the asset definitions that live in a separate file than the executable cannot be persisted as a whole
+			if asset.DefinitionFile.Path != "" && asset.DefinitionFile.Path != asset.ExecutableFile.Path {
+				result.Skipped[asset.DefinitionFile.Path] = errors.New("the asset is defined in a separate file from its executable file, fix it manually")
+				continue
+			}
+
+			before, err := afero.ReadFile(fs, asset.ExecutableFile.Path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read the asset file '%s'", asset.ExecutableFile.Path)
+			}
+
+			after, err := asset.FormatContent()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to format the asset '%s'", asset.Name)
+			}
+
+			if bytes.Equal(before, after) {
+				continue
+			}
+
+			result.Fixed = append(result.Fixed, &FixedAsset{
+				Asset:  asset,
+				Rules:  appliedRules[asset],
+				Before: before,
+				After:  after,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// FixDuplicateColumnNames keeps the first definition of the duplicate columns, the type, the description and the
+// checks of the removed definitions are merged into it.
+func FixDuplicateColumnNames(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error) {
+	columnIndexes := make(map[string]int)
+	columns := make([]pipeline.Column, 0, len(asset.Columns))
+	for _, column := range asset.Columns {
+		lowercaseName := strings.ToLower(column.Name)
+		i, ok := columnIndexes[lowercaseName]
+		if !ok {
+			columnIndexes[lowercaseName] = len(columns)
+			columns = append(columns, column)
+			continue
+		}
+
+		existing := &columns[i]
+		if existing.Type == "" {
+			existing.Type = column.Type
+		}
+		if existing.Description == "" {
+			existing.Description = column.Description
+		}
+		for _, check := range column.Checks {
+			if !existing.HasCheck(check.Name) {
+				existing.Checks = append(existing.Checks, check)
+			}
+		}
+	}
+
+	if len(columns) == len(asset.Columns) {
+		return nil, nil
+	}
+
+	asset.Columns = columns
+	return []*pipeline.Asset{asset}, nil
+}
+
+// FixMissingAssetType infers the type of the SQL assets from the majority of the SQL assets in the pipeline, and the
+// type of the other assets from their file extension.
+func FixMissingAssetType(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error) {
+	if asset.Type != "" {
+		return nil, nil
+	}
+
+	var assetType pipeline.AssetType
+	switch strings.ToLower(filepath.Ext(asset.ExecutableFile.Path)) {
+	case ".sql":
+		assetType = p.GetMajorityAssetTypesFromSQLAssets("")
+	default:
+		assetType = pipeline.AssetTypeForFile(asset.ExecutableFile.Path)
+	}
+
+	if assetType == "" {
+		return nil, nil
+	}
+
+	asset.Type = assetType
+	return []*pipeline.Asset{asset}, nil
+}
+
+// fixAssetNameCase lowercases the asset name, the assets that depend on it are updated to use the new name as well.
+func fixAssetNameCase(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error) {
+	oldName := asset.Name
+	newName := strings.ToLower(oldName)
+	if oldName == newName {
+		return nil, nil
+	}
+
+	asset.Name = newName
+	changed := []*pipeline.Asset{asset}
+	for _, downstream := range p.Assets {
+		renamed := false
+		for i, upstream := range downstream.Upstreams {
+			if upstream.Type != "uri" && upstream.Value == oldName {
+				downstream.Upstreams[i].Value = newName
+				renamed = true
+			}
+		}
+
+		if renamed && downstream != asset {
+			changed = append(changed, downstream)
+		}
+	}
+
+	return changed, nil
+}
+
+// fixColumnNamesToSnakeCase converts the declared column names to snake_case. The columns of the SQL and Python assets
+// are produced by their queries, which cannot be renamed the same way, therefore these assets are not fixed.
+func fixColumnNamesToSnakeCase(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error) {
+	if asset.IsSQLAsset() || asset.Type == pipeline.AssetTypePython {
+		return nil, nil
+	}
+
+	changed := false
+	for i, column := range asset.Columns {
+		if snakeCasePattern.MatchString(column.Name) {
+			continue
+		}
+
+		name := toSnakeCase(column.Name)
+		if name == "" || name == column.Name {
+			continue
+		}
+
+		asset.Columns[i].Name = name
+		changed = true
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	return []*pipeline.Asset{asset}, nil
+}
+
+// fixPlaceholderDescriptions removes the placeholder descriptions, an empty description is more honest than a
+// placeholder one.
+func fixPlaceholderDescriptions(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error) {
+	changed := false
+	if findPlaceholder(asset.Description) != "" {
+		asset.Description = ""
+		changed = true
+	}
+
+	for i, column := range asset.Columns {
+		if findPlaceholder(column.Description) != "" {
+			asset.Columns[i].Description = ""
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	return []*pipeline.Asset{asset}, nil
+}
+
+// toSnakeCase converts camelCase, PascalCase, kebab-case and space separated names to snake_case.
+func toSnakeCase(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			// a new word starts with an uppercase letter, e.g. `userId`, or the last letter of an acronym, e.g. `HTTPCode`
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+
+	parts := strings.FieldsFunc(b.String(), func(r rune) bool {
+		return r == '_'
+	})
+
+	return strings.Join(parts, "_")
+}
+
+func (u UsedTableValidatorRule) CanFix() bool {
+	return true
+}
+
+// FixAsset adds the tables that are used in the query but are missing in the `depends` list as dependencies, if
+// they are assets in the same pipeline.
+func (u UsedTableValidatorRule) FixAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error) {
+	missingDeps, err := u.parser.GetMissingDependenciesForAsset(asset, p, u.renderer.CloneForAsset(ctx, p, asset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missing dependencies: %w", err)
+	}
+
+	changed := false
+	for _, dep := range missingDeps {
+		upstream := p.GetAssetByName(dep)
+		if upstream == nil || upstream.Name == asset.Name {
+			continue
+		}
+
+		asset.AddUpstream(upstream)
+		changed = true
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	return []*pipeline.Asset{asset}, nil
+}
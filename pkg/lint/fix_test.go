@@ -0,0 +1,241 @@
+package lint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"user_id":     "user_id",
+		"userId":      "user_id",
+		"UserID":      "user_id",
+		"HTTPCode":    "http_code",
+		"first name":  "first_name",
+		"first-name":  "first_name",
+		"Address2":    "address2",
+		" __Total__ ": "total",
+		"order.Total": "order_total",
+	}
+
+	for input, want := range tests {
+		assert.Equal(t, want, toSnakeCase(input), input)
+	}
+}
+
+func TestFixDuplicateColumnNames(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Columns: []pipeline.Column{
+			{Name: "id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+			{Name: "name", Type: "string"},
+			{Name: "ID", Type: "integer", Description: "the id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}, {Name: "unique"}}},
+		},
+	}
+
+	changed, err := FixDuplicateColumnNames(context.Background(), &pipeline.Pipeline{}, asset)
+	require.NoError(t, err)
+	assert.Equal(t, []*pipeline.Asset{asset}, changed)
+	assert.Equal(t, []pipeline.Column{
+		{Name: "id", Type: "integer", Description: "the id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}, {Name: "unique"}}},
+		{Name: "name", Type: "string"},
+	}, asset.Columns)
+
+	changed, err = FixDuplicateColumnNames(context.Background(), &pipeline.Pipeline{}, asset)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestFixMissingAssetType(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		Assets: []*pipeline.Asset{
+			{Type: pipeline.AssetTypeSnowflakeQuery},
+			{Type: pipeline.AssetTypeSnowflakeQuery},
+			{Type: pipeline.AssetTypeBigqueryQuery},
+		},
+	}
+
+	sqlAsset := &pipeline.Asset{ExecutableFile: pipeline.ExecutableFile{Path: "/assets/people.sql"}}
+	changed, err := FixMissingAssetType(context.Background(), p, sqlAsset)
+	require.NoError(t, err)
+	assert.Len(t, changed, 1)
+	assert.Equal(t, pipeline.AssetTypeSnowflakeQuery, sqlAsset.Type)
+
+	yamlAsset := &pipeline.Asset{ExecutableFile: pipeline.ExecutableFile{Path: "/assets/people.asset.yml"}}
+	changed, err = FixMissingAssetType(context.Background(), p, yamlAsset)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Empty(t, yamlAsset.Type)
+}
+
+func TestFixAssetNameCase(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{Name: "Raw.People"}
+	downstream := &pipeline.Asset{
+		Name: "mart.people",
+		Upstreams: []pipeline.Upstream{
+			{Type: "asset", Value: "Raw.People"},
+			{Type: "uri", Value: "Raw.People"},
+		},
+	}
+	unrelated := &pipeline.Asset{Name: "mart.orders", Upstreams: []pipeline.Upstream{{Type: "asset", Value: "raw.orders"}}}
+	p := &pipeline.Pipeline{Assets: []*pipeline.Asset{asset, downstream, unrelated}}
+
+	changed, err := fixAssetNameCase(context.Background(), p, asset)
+	require.NoError(t, err)
+	assert.Equal(t, []*pipeline.Asset{asset, downstream}, changed)
+	assert.Equal(t, "raw.people", asset.Name)
+	assert.Equal(t, "raw.people", downstream.Upstreams[0].Value)
+	assert.Equal(t, "Raw.People", downstream.Upstreams[1].Value)
+}
+
+func TestFixColumnNamesToSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Type:    pipeline.AssetTypeIngestr,
+		Columns: []pipeline.Column{{Name: "userId"}, {Name: "created_at"}},
+	}
+
+	changed, err := fixColumnNamesToSnakeCase(context.Background(), &pipeline.Pipeline{}, asset)
+	require.NoError(t, err)
+	assert.Equal(t, []*pipeline.Asset{asset}, changed)
+	assert.Equal(t, []pipeline.Column{{Name: "user_id"}, {Name: "created_at"}}, asset.Columns)
+
+	// the query would not produce the renamed columns anymore
+	for _, assetType := range []pipeline.AssetType{pipeline.AssetTypeDuckDBQuery, pipeline.AssetTypePython} {
+		queryAsset := &pipeline.Asset{
+			Type:           assetType,
+			ExecutableFile: pipeline.ExecutableFile{Content: "select 1 as userId"},
+			Columns:        []pipeline.Column{{Name: "userId"}},
+		}
+
+		changed, err = fixColumnNamesToSnakeCase(context.Background(), &pipeline.Pipeline{}, queryAsset)
+		require.NoError(t, err)
+		assert.Empty(t, changed)
+		assert.Equal(t, "userId", queryAsset.Columns[0].Name)
+	}
+}
+
+func TestFixPlaceholderDescriptions(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Description: "TODO: describe this",
+		Columns: []pipeline.Column{
+			{Name: "id", Description: "the id"},
+			{Name: "name", Description: "tbd"},
+		},
+	}
+
+	changed, err := fixPlaceholderDescriptions(context.Background(), &pipeline.Pipeline{}, asset)
+	require.NoError(t, err)
+	assert.Len(t, changed, 1)
+	assert.Empty(t, asset.Description)
+	assert.Equal(t, "the id", asset.Columns[0].Description)
+	assert.Empty(t, asset.Columns[1].Description)
+}
+
+func TestUsedTableValidatorRule_FixAsset(t *testing.T) {
+	t.Parallel()
+
+	upstream := &pipeline.Asset{Name: "raw.people"}
+	asset := &pipeline.Asset{Name: "mart.people"}
+	p := &pipeline.Pipeline{Assets: []*pipeline.Asset{upstream, asset}}
+
+	parser := new(mockSQLParser)
+	parser.On("GetMissingDependenciesForAsset", mock.Anything, mock.Anything, mock.Anything).
+		Return([]string{"raw.people", "mart.people", "external.table"}, nil)
+
+	rule := UsedTableValidatorRule{jinja.NewRendererWithYesterday("test", "test"), parser}
+	changed, err := rule.FixAsset(context.Background(), p, asset)
+	require.NoError(t, err)
+	assert.Equal(t, []*pipeline.Asset{asset}, changed)
+	assert.Equal(t, []pipeline.Upstream{{Type: "asset", Value: "raw.people", Mode: pipeline.UpstreamModeFull}}, asset.Upstreams)
+}
+
+func TestFix(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	newAsset := func(path string) *pipeline.Asset {
+		return &pipeline.Asset{
+			Name: "raw.people",
+			Type: pipeline.AssetTypeDuckDBQuery,
+			ExecutableFile: pipeline.ExecutableFile{
+				Path:    path,
+				Content: "select 1 as id",
+			},
+			DefinitionFile: pipeline.TaskDefinitionFile{Path: path},
+			Columns: []pipeline.Column{
+				{Name: "id", Type: "integer"},
+				{Name: "ID"},
+			},
+		}
+	}
+
+	asset := newAsset("/pipeline/assets/people.sql")
+	original, err := newAsset(asset.ExecutableFile.Path).FormatContent()
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, asset.ExecutableFile.Path, original, 0o644))
+
+	separateAsset := newAsset("/pipeline/assets/other.sql")
+	separateAsset.DefinitionFile.Path = "/pipeline/assets/other.asset.yml"
+
+	duplicateRule := &SimpleRule{
+		Identifier:     "duplicate-column-names",
+		AssetValidator: ValidateDuplicateColumnNames,
+		AssetFixer:     FixDuplicateColumnNames,
+	}
+	reportOnlyRule := &SimpleRule{
+		Identifier:     "report-only",
+		AssetValidator: ValidateDuplicateColumnNames,
+	}
+
+	analysis := &PipelineAnalysisResult{
+		Pipelines: []*PipelineIssues{
+			{
+				Pipeline: &pipeline.Pipeline{Assets: []*pipeline.Asset{asset, separateAsset}},
+				Issues: map[Rule][]*Issue{
+					duplicateRule:  {{Task: asset}, {Task: asset}, {Task: separateAsset}},
+					reportOnlyRule: {{Task: asset}},
+				},
+			},
+		},
+	}
+
+	result, err := Fix(context.Background(), fs, analysis)
+	require.NoError(t, err)
+
+	assert.Len(t, result.Skipped, 1)
+	assert.Contains(t, result.Skipped, "/pipeline/assets/other.asset.yml")
+
+	require.Len(t, result.Fixed, 1)
+	fixed := result.Fixed[0]
+	assert.Equal(t, asset, fixed.Asset)
+	assert.Equal(t, []string{"duplicate-column-names"}, fixed.Rules)
+	assert.Equal(t, original, fixed.Before)
+
+	// the fixes are not persisted by Fix itself
+	onDisk, err := afero.ReadFile(fs, asset.ExecutableFile.Path)
+	require.NoError(t, err)
+	assert.Equal(t, original, onDisk)
+
+	diff, err := fixed.Diff()
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- /pipeline/assets/people.sql\n+++ /pipeline/assets/people.sql\n")
+	assert.Contains(t, diff, "\n-  - name: ID\n")
+}
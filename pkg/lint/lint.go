@@ -11,6 +11,7 @@ import (
 
 	"github.com/bruin-data/bruin/pkg/logger"
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/bruin-data/bruin/pkg/telemetry"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
@@ -20,6 +21,13 @@ type (
 	pipelineFinder    func(root string, pipelineDefinitionFile []string) ([]string, error)
 	PipelineValidator func(pipeline *pipeline.Pipeline) ([]*Issue, error)
 	AssetValidator    func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error)
+
+	// PipelineValidatorWithContext is a pipeline validator that needs the context of the linter, e.g. to parse queries.
+	PipelineValidatorWithContext func(ctx context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error)
+
+	// AssetFixer fixes the issues of the given asset in place and returns the assets it changed, which is usually only
+	// the given asset, but renaming an asset also changes the assets that depend on it.
+	AssetFixer func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error)
 )
 
 type pipelineBuilder interface {
@@ -30,6 +38,15 @@ type Issue struct {
 	Task        *pipeline.Asset
 	Description string
 	Context     []string
+
+	// Field is the asset field the issue is about, e.g. `materialization.strategy` or `columns[2].name`, it is used to
+	// point at the offending line of the definition file.
+	Field string
+
+	// File, Line and Column are filled in by the linter, Line and Column are 0 if the position is not known.
+	File   string
+	Line   int
+	Column int
 }
 
 type Rule interface {
@@ -41,16 +58,39 @@ type Rule interface {
 	GetSeverity() ValidatorSeverity
 }
 
+// FixableRule is implemented by the rules that can fix the issues they report. The fixes are applied to the assets in
+// memory, it is up to the caller to persist the changed assets.
+type FixableRule interface {
+	Rule
+	CanFix() bool
+	FixAsset(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error)
+}
+
+// contextualRule is implemented by the rules that validate pipelines with the context of the linter.
+type contextualRule interface {
+	ValidateWithContext(ctx context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error)
+}
+
 type SimpleRule struct {
 	Identifier       string
 	Fast             bool
 	Validator        PipelineValidator
+	ContextValidator PipelineValidatorWithContext
 	AssetValidator   AssetValidator
+	AssetFixer       AssetFixer
 	ApplicableLevels []Level
 	Severity         ValidatorSeverity
 }
 
 func (g *SimpleRule) Validate(pipeline *pipeline.Pipeline) ([]*Issue, error) {
+	return g.ValidateWithContext(context.Background(), pipeline)
+}
+
+func (g *SimpleRule) ValidateWithContext(ctx context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error) {
+	if g.ContextValidator != nil {
+		return g.ContextValidator(ctx, pipeline)
+	}
+
 	return g.Validator(pipeline)
 }
 
@@ -66,6 +106,18 @@ func (g *SimpleRule) ValidateAsset(ctx context.Context, pipeline *pipeline.Pipel
 	return g.AssetValidator(ctx, pipeline, asset)
 }
 
+func (g *SimpleRule) CanFix() bool {
+	return g.AssetFixer != nil
+}
+
+func (g *SimpleRule) FixAsset(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error) {
+	if g.AssetFixer == nil {
+		return nil, errors.New(fmt.Sprintf("the rule '%s' cannot fix the issues it finds", g.Identifier))
+	}
+
+	return g.AssetFixer(ctx, pipeline, asset)
+}
+
 func (g *SimpleRule) Name() string {
 	return g.Identifier
 }
@@ -83,6 +135,7 @@ type Linter struct {
 	builder       pipelineBuilder
 	rules         []Rule
 	logger        logger.Logger
+	parser        policyParser
 }
 
 func NewLinter(findPipelines pipelineFinder, builder pipelineBuilder, rules []Rule, logger logger.Logger) *Linter {
@@ -94,6 +147,17 @@ func NewLinter(findPipelines pipelineFinder, builder pipelineBuilder, rules []Ru
 	}
 }
 
+// NewLinterWithParser creates a linter that can analyze the queries of the assets, which the SQL policy rules and
+// helpers require.
+func NewLinterWithParser(findPipelines pipelineFinder, builder pipelineBuilder, rules []Rule, logger logger.Logger, parser *sqlparser.SQLParser) *Linter {
+	l := NewLinter(findPipelines, builder, rules, logger)
+	if parser != nil {
+		l.parser = parser
+	}
+
+	return l
+}
+
 func (l *Linter) Lint(rootPath string, pipelineDefinitionFileName []string, c *cli.Context) (*PipelineAnalysisResult, error) {
 	pipelines, err := l.extractPipelinesFromPath(rootPath, pipelineDefinitionFileName)
 
@@ -183,7 +247,7 @@ func (l *Linter) LintAsset(rootPath string, pipelineDefinitionFileName []string,
 	}
 
 	rules := l.rules
-	policyRules, err := loadPolicy(assetPipeline.DefinitionFile.Path)
+	policyRules, err := loadPolicy(assetPipeline.DefinitionFile.Path, l.parser)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load policy: %w", err)
 	}
@@ -207,6 +271,7 @@ func (l *Linter) LintAsset(rootPath string, pipelineDefinitionFileName []string,
 		}
 
 		if len(issues) > 0 {
+			locateIssues(assetPipeline, issues)
 			pipelineResult.Issues[rule] = issues
 		}
 	}
@@ -356,17 +421,21 @@ func (l *Linter) LintPipelines(pipelines []*pipeline.Pipeline) (*PipelineAnalysi
 }
 
 func (l *Linter) LintPipeline(p *pipeline.Pipeline) (*PipelineIssues, error) {
-	return RunLintRulesOnPipeline(p, l.rules)
+	return runLintRulesOnPipeline(p, l.rules, l.parser)
 }
 
 func RunLintRulesOnPipeline(p *pipeline.Pipeline, rules []Rule) (*PipelineIssues, error) {
+	return runLintRulesOnPipeline(p, rules, nil)
+}
+
+func runLintRulesOnPipeline(p *pipeline.Pipeline, rules []Rule, parser policyParser) (*PipelineIssues, error) {
 	pipelineResult := &PipelineIssues{
 		Pipeline: p,
 		Issues:   make(map[Rule][]*Issue),
 	}
 	ctx := context.Background()
 
-	policyRules, err := loadPolicy(p.DefinitionFile.Path)
+	policyRules, err := loadPolicy(p.DefinitionFile.Path, parser)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load policy: %w", err)
 	}
@@ -377,11 +446,17 @@ func RunLintRulesOnPipeline(p *pipeline.Pipeline, rules []Rule) (*PipelineIssues
 	for _, rule := range rules {
 		levels := rule.GetApplicableLevels()
 		if slices.Contains(levels, LevelPipeline) {
-			issues, err := rule.Validate(p)
+			var issues []*Issue
+			if contextual, ok := rule.(contextualRule); ok {
+				issues, err = contextual.ValidateWithContext(ctx, p)
+			} else {
+				issues, err = rule.Validate(p)
+			}
 			if err != nil {
 				return nil, err
 			}
 			if len(issues) > 0 {
+				locateIssues(p, issues)
 				pipelineResult.Issues[rule] = append(pipelineResult.Issues[rule], issues...)
 			}
 		} else if slices.Contains(levels, LevelAsset) {
@@ -391,6 +466,7 @@ func RunLintRulesOnPipeline(p *pipeline.Pipeline, rules []Rule) (*PipelineIssues
 					return nil, err
 				}
 				if len(issues) > 0 {
+					locateIssues(p, issues)
 					pipelineResult.Issues[rule] = append(pipelineResult.Issues[rule], issues...)
 				}
 			}
@@ -400,6 +476,31 @@ func RunLintRulesOnPipeline(p *pipeline.Pipeline, rules []Rule) (*PipelineIssues
 	return pipelineResult, nil
 }
 
+// locateIssues fills in the file and the position of the issues based on the field they are about, falling back to
+// the name of the asset. The issues that do not belong to an asset point at the pipeline definition file.
+func locateIssues(p *pipeline.Pipeline, issues []*Issue) {
+	for _, issue := range issues {
+		if issue.File != "" {
+			continue
+		}
+
+		if issue.Task == nil || issue.Task.DefinitionFile.Path == "" {
+			issue.File = p.DefinitionFile.Path
+			continue
+		}
+
+		issue.File = issue.Task.DefinitionFile.Path
+		pos, ok := issue.Task.SourcePositions.Lookup(issue.Field)
+		if !ok {
+			pos, ok = issue.Task.SourcePositions.Lookup("name")
+		}
+		if ok {
+			issue.Line = pos.Line
+			issue.Column = pos.Column
+		}
+	}
+}
+
 func EnsureNoNestedPipelines(pipelinePaths []string) error {
 	var previousPath string
 	for i, path := range pipelinePaths {
@@ -76,6 +76,7 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 			Fast:             true,
 			Severity:         ValidatorSeverityCritical,
 			AssetValidator:   EnsureTypeIsCorrectForASingleAsset,
+			AssetFixer:       FixMissingAssetType,
 			ApplicableLevels: []Level{LevelAsset},
 		},
 		&SimpleRule{
@@ -146,6 +147,7 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 			Fast:             true,
 			Severity:         ValidatorSeverityCritical,
 			AssetValidator:   ValidateDuplicateColumnNames,
+			AssetFixer:       FixDuplicateColumnNames,
 			ApplicableLevels: []Level{LevelAsset},
 		},
 		&SimpleRule{
@@ -183,6 +185,13 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 			AssetValidator:   ValidateEMRServerlessAsset,
 			ApplicableLevels: []Level{LevelAsset},
 		},
+		&SimpleRule{
+			Identifier:       "databricks-job-validation",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			AssetValidator:   ValidateDatabricksJobAsset,
+			ApplicableLevels: []Level{LevelAsset},
+		},
 		&SimpleRule{
 			Identifier:       "plain-yaml-files",
 			Fast:             false,
@@ -29,19 +29,22 @@ var (
 var validRulePattern = regexp.MustCompile(`^[A-Za-z0-9\-]+$`)
 
 type assetValidatorEnv struct {
+	policyFunctions
 	Asset     *pipeline.Asset    `expr:"asset"`
 	Pipeline  *pipeline.Pipeline `expr:"pipeline"`
 	Variables map[string]any     `expr:"var"`
 }
 
 type pipelineValidatorEnv struct {
+	policyFunctions
 	Pipeline  *pipeline.Pipeline `expr:"pipeline"`
 	Variables map[string]any     `expr:"var"`
 }
 
 type validators struct {
-	Pipeline PipelineValidator
+	Pipeline PipelineValidatorWithContext
 	Asset    AssetValidator
+	Fixer    AssetFixer
 }
 
 func (v validators) GetApplicableLevels() (levels []Level) {
@@ -124,6 +127,72 @@ type RuleSet struct {
 	Name     string           `yaml:"name"`
 	Selector []map[string]any `yaml:"selector"`
 	Rules    []string         `yaml:"rules"`
+
+	// Severities overrides the severity of the rules in the set, the rules are critical by default.
+	Severities map[string]ValidatorSeverity `yaml:"-"`
+
+	// scope is the directory of the policy file the ruleset is defined in, the rules only apply to the pipelines and
+	// the assets under it.
+	scope string
+}
+
+// ruleReference is a rule in a ruleset, it is either the name of the rule, or a mapping with the name and the
+// severity of the rule.
+type ruleReference struct {
+	Name     string `yaml:"name"`
+	Severity string `yaml:"severity"`
+}
+
+func (ref *ruleReference) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&ref.Name)
+	}
+
+	type plain ruleReference
+	return value.Decode((*plain)(ref))
+}
+
+func (rs *RuleSet) UnmarshalYAML(value *yaml.Node) error {
+	var raw struct {
+		Name     string           `yaml:"name"`
+		Selector []map[string]any `yaml:"selector"`
+		Rules    []ruleReference  `yaml:"rules"`
+	}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	rs.Name = raw.Name
+	rs.Selector = raw.Selector
+	rs.Rules = make([]string, 0, len(raw.Rules))
+	for _, ref := range raw.Rules {
+		rs.Rules = append(rs.Rules, ref.Name)
+		if ref.Severity == "" {
+			continue
+		}
+
+		severity, err := parseSeverity(ref.Severity)
+		if err != nil {
+			return fmt.Errorf("invalid severity for rule %s: %w", ref.Name, err)
+		}
+		if rs.Severities == nil {
+			rs.Severities = make(map[string]ValidatorSeverity)
+		}
+		rs.Severities[ref.Name] = severity
+	}
+
+	return nil
+}
+
+func parseSeverity(severity string) (ValidatorSeverity, error) {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case "critical", "error":
+		return ValidatorSeverityCritical, nil
+	case "warning":
+		return ValidatorSeverityWarning, nil
+	default:
+		return ValidatorSeverityCritical, fmt.Errorf("unknown severity '%s', it must be either 'critical' or 'warning'", severity)
+	}
 }
 
 func (rs *RuleSet) validate() error {
@@ -145,9 +214,17 @@ type PolicySpecification struct {
 	RuleSets    []RuleSet         `yaml:"rulesets"`
 
 	compiledRules map[string]*RuleDefinition
+	sqlRules      map[string]validators
+	helpers       *policyHelpers
 }
 
 func (spec *PolicySpecification) init() error {
+	if spec.helpers == nil {
+		spec.helpers = newPolicyHelpers(nil)
+	}
+	if spec.sqlRules == nil {
+		spec.sqlRules = spec.helpers.sql.policyRules()
+	}
 	spec.compiledRules = make(map[string]*RuleDefinition)
 	for idx, def := range spec.Definitions {
 		err := def.validate()
@@ -160,6 +237,9 @@ func (spec *PolicySpecification) init() error {
 		if _, exists := builtinRules[def.Name]; exists {
 			return fmt.Errorf("rule is builtin: %s", def.Name)
 		}
+		if _, exists := spec.sqlRules[def.Name]; exists {
+			return fmt.Errorf("rule is builtin: %s", def.Name)
+		}
 		if err := def.compile(); err != nil {
 			return err
 		}
@@ -185,13 +265,21 @@ func (spec *PolicySpecification) Rules() ([]Rule, error) {
 			if !found {
 				return nil, fmt.Errorf("no such rule: %s", ruleName)
 			}
+			severity, ok := ruleSet.Severities[ruleName]
+			if !ok {
+				severity = ValidatorSeverityCritical
+			}
 			validators = withSelector(ruleSet.Selector, validators)
+			if ruleSet.scope != "" {
+				validators = withScope(ruleSet.scope, validators)
+			}
 			rules = append(rules, &SimpleRule{
 				Identifier:       fmt.Sprintf("policy:%s:%s", ruleSet.Name, ruleName),
 				Fast:             true,
-				Severity:         ValidatorSeverityCritical,
-				Validator:        validators.Pipeline,
+				Severity:         severity,
+				ContextValidator: validators.Pipeline,
 				AssetValidator:   validators.Asset,
+				AssetFixer:       validators.Fixer,
 				ApplicableLevels: validators.GetApplicableLevels(),
 			})
 		}
@@ -202,7 +290,10 @@ func (spec *PolicySpecification) Rules() ([]Rule, error) {
 func (spec *PolicySpecification) getValidators(name string) (validators, bool) {
 	def, found := spec.compiledRules[name]
 	if !found {
-		validators, found := builtinRules[name]
+		if validators, found := builtinRules[name]; found {
+			return validators, true
+		}
+		validators, found := spec.sqlRules[name]
 		return validators, found
 	}
 
@@ -210,17 +301,17 @@ func (spec *PolicySpecification) getValidators(name string) (validators, bool) {
 
 	switch def.RuleTarget {
 	case RuleTargetAsset:
-		v.Asset = assetValidatorFromRuleDef(def)
+		v.Asset = assetValidatorFromRuleDef(def, spec.helpers)
 	case RuleTargetPipeline:
-		v.Pipeline = pipelineValidatorFromRuleDef(def)
+		v.Pipeline = pipelineValidatorFromRuleDef(def, spec.helpers)
 	}
 
 	return v, true
 }
 
-func assetValidatorFromRuleDef(def *RuleDefinition) AssetValidator {
+func assetValidatorFromRuleDef(def *RuleDefinition, helpers *policyHelpers) AssetValidator {
 	return func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
-		env := assetValidatorEnv{asset, pipeline, pipeline.Variables.Value()}
+		env := assetValidatorEnv{helpers.functions(ctx, pipeline), asset, pipeline, pipeline.Variables.Value()}
 		result, err := expr.Run(def.evalutor, env)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating rule %s: %w", def.Name, err)
@@ -239,9 +330,9 @@ func assetValidatorFromRuleDef(def *RuleDefinition) AssetValidator {
 	}
 }
 
-func pipelineValidatorFromRuleDef(def *RuleDefinition) PipelineValidator {
-	return func(pipe *pipeline.Pipeline) ([]*Issue, error) {
-		env := pipelineValidatorEnv{pipe, pipe.Variables.Value()}
+func pipelineValidatorFromRuleDef(def *RuleDefinition, helpers *policyHelpers) PipelineValidatorWithContext {
+	return func(ctx context.Context, pipe *pipeline.Pipeline) ([]*Issue, error) {
+		env := pipelineValidatorEnv{helpers.functions(ctx, pipe), pipe, pipe.Variables.Value()}
 		result, err := expr.Run(def.evalutor, env)
 		if err != nil {
 			return nil, fmt.Errorf("error evaluating rule %s: %w", def.Name, err)
@@ -260,26 +351,60 @@ func pipelineValidatorFromRuleDef(def *RuleDefinition) PipelineValidator {
 }
 
 func withSelector(selector []map[string]any, downstream validators) validators {
+	return withMatcher(downstream, func(pipeline *pipeline.Pipeline, asset *pipeline.Asset) (bool, error) {
+		match, err := doesSelectorMatch(selector, pipeline, asset)
+		if err != nil {
+			return false, fmt.Errorf("error matching selector: %w", err)
+		}
+		return match, nil
+	})
+}
+
+// withScope limits the validators to the pipelines and the assets whose definition files are under the given directory.
+func withScope(scope string, downstream validators) validators {
+	return withMatcher(downstream, func(pipeline *pipeline.Pipeline, asset *pipeline.Asset) (bool, error) {
+		if asset == nil {
+			return isPathInScope(pipeline.DefinitionFile.Path, scope), nil
+		}
+		return isPathInScope(asset.DefinitionFile.Path, scope), nil
+	})
+}
+
+func isPathInScope(path, scope string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(scope, absPath)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// withMatcher wraps the validators so that they only run for the pipelines and the assets that match.
+func withMatcher(downstream validators, matches func(pipeline *pipeline.Pipeline, asset *pipeline.Asset) (bool, error)) validators {
 	middleware := validators{}
 	if downstream.Pipeline != nil {
-		middleware.Pipeline = func(pipeline *pipeline.Pipeline) ([]*Issue, error) {
-			match, err := doesSelectorMatch(selector, pipeline, nil)
+		middleware.Pipeline = func(ctx context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error) {
+			match, err := matches(pipeline, nil)
 			if err != nil {
-				return nil, fmt.Errorf("error matching selector: %w", err)
+				return nil, err
 			}
 
 			if !match {
 				return nil, nil
 			}
 
-			return downstream.Pipeline(pipeline)
+			return downstream.Pipeline(ctx, pipeline)
 		}
 	}
 	if downstream.Asset != nil {
 		middleware.Asset = func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
-			match, err := doesSelectorMatch(selector, pipeline, asset)
+			match, err := matches(pipeline, asset)
 			if err != nil {
-				return nil, fmt.Errorf("error matching selector: %w", err)
+				return nil, err
 			}
 
 			if !match {
@@ -288,6 +413,19 @@ func withSelector(selector []map[string]any, downstream validators) validators {
 			return downstream.Asset(ctx, pipeline, asset)
 		}
 	}
+	if downstream.Fixer != nil {
+		middleware.Fixer = func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*pipeline.Asset, error) {
+			match, err := matches(pipeline, asset)
+			if err != nil {
+				return nil, err
+			}
+
+			if !match {
+				return nil, nil
+			}
+			return downstream.Fixer(ctx, pipeline, asset)
+		}
+	}
 	return middleware
 }
 
@@ -344,7 +482,7 @@ func addBoundaryAnchors(pattern string) string {
 	return pattern
 }
 
-func loadPolicy(path string) (rules []Rule, err error) {
+func loadPolicy(path string, parser policyParser) (rules []Rule, err error) {
 	// TODO(turtledev): utilize cached FS to improve performance
 	repo, err := git.FindRepoFromPath(path)
 	if errors.Is(err, git.ErrNoGitRepoFound) {
@@ -354,45 +492,113 @@ func loadPolicy(path string) (rules []Rule, err error) {
 		return nil, fmt.Errorf("loadPolicy: %w", err)
 	}
 
-	policyFile := locatePolicy(repo.Path)
-	if policyFile == "" {
+	pipelineDir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("loadPolicy: %w", err)
+	}
+
+	policyFiles := locatePolicies(repo.Path, pipelineDir)
+	if len(policyFiles) == 0 {
 		return nil, nil
 	}
+
+	helpers := newPolicyHelpers(parser)
+	spec := PolicySpecification{
+		helpers:  helpers,
+		sqlRules: helpers.sql.policyRules(),
+	}
+	for _, policyFile := range policyFiles {
+		fileSpec, err := readPolicyFile(policyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		// the policy at the root of the repository applies everywhere, the others only apply to their own directory
+		scope := filepath.Dir(policyFile)
+		for _, ruleSet := range fileSpec.RuleSets {
+			if scope != repo.Path {
+				ruleSet.scope = scope
+			}
+			spec.RuleSets = append(spec.RuleSets, ruleSet)
+		}
+		spec.Definitions = append(spec.Definitions, fileSpec.Definitions...)
+	}
+
+	policyRules, err := spec.Rules()
+	if err != nil {
+		return nil, fmt.Errorf("error reading policy: %w", err)
+	}
+
+	rules = append(rules, policyRules...)
+	return rules, nil
+}
+
+func readPolicyFile(policyFile string) (*PolicySpecification, error) {
 	fd, err := os.Open(policyFile)
 	if err != nil {
 		return nil, fmt.Errorf("error opening policy file: %w", err)
 	}
 	defer fd.Close()
 
-	spec := PolicySpecification{}
+	var spec PolicySpecification
 	err = yaml.NewDecoder(fd).Decode(&spec)
 	if err != nil {
-		return nil, fmt.Errorf("error reading policy file: %w", err)
+		return nil, fmt.Errorf("error reading policy file '%s': %w", policyFile, err)
 	}
 
-	policyRules, err := spec.Rules()
-	if err != nil {
-		return nil, fmt.Errorf("error reading policy: %w", err)
+	return &spec, nil
+}
+
+// locatePolicies returns the policy files that apply to the pipeline in the given directory: the ones in the
+// directories from the root of the repository down to the pipeline, and the ones in the subdirectories of the pipeline.
+func locatePolicies(repo, pipelineDir string) []string {
+	dirs := make([]string, 0)
+	for dir := pipelineDir; isPathInScope(dir, repo); dir = filepath.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+		if dir == repo || dir == filepath.Dir(dir) {
+			break
+		}
 	}
 
-	rules = append(rules, policyRules...)
-	return rules, nil
+	files := make([]string, 0)
+	for _, dir := range dirs[:max(len(dirs)-1, 0)] {
+		files = append(files, policyFilesInDir(dir)...)
+	}
+
+	_ = filepath.WalkDir(pipelineDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil //nolint:nilerr
+		}
+		if path != pipelineDir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+
+		files = append(files, policyFilesInDir(path)...)
+		return nil
+	})
+
+	return files
 }
 
-func locatePolicy(repo string) string {
-	names := []string{
-		"policy.yaml",
-		"policy.yml",
+func policyFilesInDir(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
 	}
-	for _, name := range names {
-		fileName := filepath.Join(repo, name)
-		fi, err := os.Stat(fileName)
-		if err != nil {
+
+	files := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasPrefix(name, "policy") {
 			continue
 		}
-		if fi.Mode().IsRegular() {
-			return fileName
+		if strings.HasSuffix(name, ".asset.yml") || strings.HasSuffix(name, ".asset.yaml") {
+			continue
+		}
+		if strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml") {
+			files = append(files, filepath.Join(dir, name))
 		}
 	}
-	return ""
+
+	return files
 }
@@ -2,6 +2,7 @@ package lint
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -97,6 +98,22 @@ var placeholderDescriptions = []string{
 	"work in progress",
 }
 
+// findPlaceholder returns the placeholder the description contains, or an empty string if there is none.
+func findPlaceholder(description string) string {
+	lowerDesc := strings.ToLower(strings.TrimSpace(description))
+	if lowerDesc == "" {
+		return ""
+	}
+
+	for _, placeholder := range placeholderDescriptions {
+		if strings.Contains(lowerDesc, placeholder) {
+			return placeholder
+		}
+	}
+
+	return ""
+}
+
 var builtinRules = map[string]validators{
 	"asset-name-is-lowercase": {
 		Asset: func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
@@ -108,9 +125,11 @@ var builtinRules = map[string]validators{
 				{
 					Task:        asset,
 					Description: "Asset name must be lowercase",
+					Field:       "name",
 				},
 			}, nil
 		},
+		Fixer: fixAssetNameCase,
 	},
 	"asset-name-is-schema-dot-table": {
 		Asset: func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
@@ -255,7 +274,7 @@ var builtinRules = map[string]validators{
 	},
 	"column-name-is-snake-case": {
 		Asset: func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
-			for _, col := range asset.Columns {
+			for i, col := range asset.Columns {
 				if snakeCasePattern.MatchString(col.Name) {
 					continue
 				}
@@ -264,11 +283,13 @@ var builtinRules = map[string]validators{
 					{
 						Task:        asset,
 						Description: "Column names must be in snake_case",
+						Field:       fmt.Sprintf("columns[%d].name", i),
 					},
 				}, nil
 			}
 			return nil, nil
 		},
+		Fixer: fixColumnNamesToSnakeCase,
 	},
 	"column-name-is-camel-case": {
 		Asset: func(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
@@ -310,7 +331,7 @@ var builtinRules = map[string]validators{
 			}
 
 			var issues []*Issue
-			for _, col := range asset.Columns {
+			for i, col := range asset.Columns {
 				if strings.TrimSpace(col.Type) == "" {
 					// Let column-has-type rule handle this
 					continue
@@ -328,6 +349,7 @@ var builtinRules = map[string]validators{
 					issues = append(issues, &Issue{
 						Task:        asset,
 						Description: "Column '" + col.Name + "' has invalid type '" + col.Type + "' for platform '" + platformName + "'",
+						Field:       fmt.Sprintf("columns[%d].type", i),
 					})
 				}
 			}
@@ -339,38 +361,27 @@ var builtinRules = map[string]validators{
 		Asset: func(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
 			var issues []*Issue
 
-			lowerAssetDesc := strings.ToLower(strings.TrimSpace(asset.Description))
-			if lowerAssetDesc != "" {
-				for _, placeholder := range placeholderDescriptions {
-					if strings.Contains(lowerAssetDesc, placeholder) {
-						issues = append(issues, &Issue{
-							Task:        asset,
-							Description: "Asset description appears to contain placeholder text: '" + placeholder + "'",
-						})
-						break
-					}
-				}
+			if placeholder := findPlaceholder(asset.Description); placeholder != "" {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: "Asset description appears to contain placeholder text: '" + placeholder + "'",
+					Field:       "description",
+				})
 			}
 
-			for _, col := range asset.Columns {
-				lowerColDesc := strings.ToLower(strings.TrimSpace(col.Description))
-				if lowerColDesc == "" {
-					continue
-				}
-
-				for _, placeholder := range placeholderDescriptions {
-					if strings.Contains(lowerColDesc, placeholder) {
-						issues = append(issues, &Issue{
-							Task:        asset,
-							Description: "Column '" + col.Name + "' description appears to contain placeholder text: '" + placeholder + "'",
-						})
-						break
-					}
+			for i, col := range asset.Columns {
+				if placeholder := findPlaceholder(col.Description); placeholder != "" {
+					issues = append(issues, &Issue{
+						Task:        asset,
+						Description: "Column '" + col.Name + "' description appears to contain placeholder text: '" + placeholder + "'",
+						Field:       fmt.Sprintf("columns[%d].description", i),
+					})
 				}
 			}
 
 			return issues, nil
 		},
+		Fixer: fixPlaceholderDescriptions,
 	},
 	"asset-has-no-cross-pipeline-dependencies": {
 		Asset: func(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
@@ -390,7 +401,7 @@ var builtinRules = map[string]validators{
 		},
 	},
 	"pipeline-has-notifications": {
-		Pipeline: func(pipeline *pipeline.Pipeline) ([]*Issue, error) {
+		Pipeline: func(_ context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error) {
 			notifs := pipeline.Notifications
 			if len(notifs.Discord) > 0 || len(notifs.MSTeams) > 0 || len(notifs.Slack) > 0 {
 				return nil, nil
@@ -403,7 +414,7 @@ var builtinRules = map[string]validators{
 		},
 	},
 	"pipeline-has-retries": {
-		Pipeline: func(pipeline *pipeline.Pipeline) ([]*Issue, error) {
+		Pipeline: func(_ context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error) {
 			if pipeline.Retries > 0 {
 				return nil, nil
 			}
@@ -416,7 +427,7 @@ var builtinRules = map[string]validators{
 		},
 	},
 	"pipeline-has-start-date": {
-		Pipeline: func(pipeline *pipeline.Pipeline) ([]*Issue, error) {
+		Pipeline: func(_ context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error) {
 			if strings.TrimSpace(pipeline.StartDate) != "" {
 				return nil, nil
 			}
@@ -428,7 +439,7 @@ var builtinRules = map[string]validators{
 		},
 	},
 	"pipeline-has-metadata-push": {
-		Pipeline: func(pipeline *pipeline.Pipeline) ([]*Issue, error) {
+		Pipeline: func(_ context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error) {
 			if pipeline.MetadataPush.HasAnyEnabled() {
 				return nil, nil
 			}
@@ -0,0 +1,213 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/lineage"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+)
+
+// policyParser is the SQL parser used by the policy rules, both to analyze the queries and to extract the column
+// lineage of the assets.
+type policyParser interface {
+	queryAnalyzer
+	ColumnLineage(sql, dialect string, schema sqlparser.Schema) (*sqlparser.Lineage, error)
+}
+
+// policyFunctions are the helper functions that are available to the criteria of the custom rules.
+type policyFunctions struct {
+	UsedTables     func(asset *pipeline.Asset) []string            `expr:"used_tables"`
+	Upstream       func(asset *pipeline.Asset) []*pipeline.Asset   `expr:"upstream"`
+	Downstream     func(asset *pipeline.Asset) []*pipeline.Asset   `expr:"downstream"`
+	GlossaryEntity func(name string) (*glossary.Entity, error)     `expr:"glossary_entity"`
+	GitChanged     func(asset *pipeline.Asset) (bool, error)       `expr:"git_changed"`
+	ColumnLineage  func(asset *pipeline.Asset) map[string][]string `expr:"column_lineage"`
+}
+
+// policyHelpers holds the state behind the policy functions, the expensive results such as the parsed queries or
+// the changed files are computed once and shared between the rules.
+type policyHelpers struct {
+	sql      *sqlAssetAnalyzer
+	parser   policyParser
+	glossary *glossary.GlossaryReader
+
+	changedOnce  sync.Once
+	changedFiles map[string]bool
+	changedErr   error
+
+	lineageMutex sync.Mutex
+	lineages     map[*pipeline.Asset]map[string][]string
+}
+
+func newPolicyHelpers(parser policyParser) *policyHelpers {
+	var analyzer queryAnalyzer
+	if parser != nil {
+		analyzer = parser
+	}
+
+	return &policyHelpers{
+		sql:    newSQLAssetAnalyzer(analyzer),
+		parser: parser,
+		glossary: &glossary.GlossaryReader{
+			RepoFinder: &git.RepoFinder{},
+			FileNames:  []string{"glossary.yml", "glossary.yaml"},
+		},
+		lineages: make(map[*pipeline.Asset]map[string][]string),
+	}
+}
+
+func (h *policyHelpers) functions(ctx context.Context, p *pipeline.Pipeline) policyFunctions {
+	return policyFunctions{
+		UsedTables: func(asset *pipeline.Asset) []string {
+			analysis := h.sql.analyze(ctx, p, asset)
+			if analysis == nil {
+				return []string{}
+			}
+			return analysis.Tables
+		},
+		Upstream: func(asset *pipeline.Asset) []*pipeline.Asset {
+			return upstreamAssets(p, asset)
+		},
+		Downstream: func(asset *pipeline.Asset) []*pipeline.Asset {
+			return downstreamAssets(p, asset)
+		},
+		GlossaryEntity: func(name string) (*glossary.Entity, error) {
+			entities, err := h.glossary.GetEntities(p.DefinitionFile.Path)
+			if err != nil {
+				return nil, err
+			}
+			for _, entity := range entities {
+				if entity.Name == name {
+					return entity, nil
+				}
+			}
+			return nil, nil
+		},
+		GitChanged: func(asset *pipeline.Asset) (bool, error) {
+			return h.isChanged(p, asset)
+		},
+		ColumnLineage: func(asset *pipeline.Asset) map[string][]string {
+			return h.columnLineage(ctx, p, asset)
+		},
+	}
+}
+
+func upstreamAssets(p *pipeline.Pipeline, asset *pipeline.Asset) []*pipeline.Asset {
+	upstreams := make([]*pipeline.Asset, 0)
+	for _, upstream := range asset.Upstreams {
+		if upstream.Type != "asset" {
+			continue
+		}
+		if found := p.GetAssetByName(upstream.Value); found != nil {
+			upstreams = append(upstreams, found)
+		}
+	}
+
+	return upstreams
+}
+
+func downstreamAssets(p *pipeline.Pipeline, asset *pipeline.Asset) []*pipeline.Asset {
+	downstreams := make([]*pipeline.Asset, 0)
+	for _, candidate := range p.Assets {
+		for _, upstream := range candidate.Upstreams {
+			if upstream.Type == "asset" && strings.EqualFold(upstream.Value, asset.Name) {
+				downstreams = append(downstreams, candidate)
+				break
+			}
+		}
+	}
+
+	return downstreams
+}
+
+// gitBaseRefEnvVar overrides the ref the changed files are compared against, e.g. `origin/main` in CI.
+const gitBaseRefEnvVar = "BRUIN_GIT_BASE_REF"
+
+// isChanged checks if either the definition or the executable file of the asset changed compared to the base ref, see
+// git.ChangedFiles.
+func (h *policyHelpers) isChanged(p *pipeline.Pipeline, asset *pipeline.Asset) (bool, error) {
+	h.changedOnce.Do(func() {
+		repo, err := git.FindRepoFromPath(p.DefinitionFile.Path)
+		if err != nil {
+			h.changedErr = fmt.Errorf("failed to find the git repository: %w", err)
+			return
+		}
+
+		files, err := git.ChangedFiles(repo.Path, os.Getenv(gitBaseRefEnvVar))
+		if err != nil {
+			h.changedErr = fmt.Errorf("failed to list the changed files: %w", err)
+			return
+		}
+
+		h.changedFiles = make(map[string]bool, len(files))
+		for _, file := range files {
+			h.changedFiles[file] = true
+		}
+	})
+	if h.changedErr != nil {
+		return false, h.changedErr
+	}
+
+	for _, file := range []string{asset.DefinitionFile.Path, asset.ExecutableFile.Path} {
+		if file == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(file); err == nil && h.changedFiles[abs] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// columnLineage returns the upstream columns of every column of the asset in the `table.column` format. The lineage
+// that is already declared in the asset is used as is, otherwise the query of the asset is parsed.
+func (h *policyHelpers) columnLineage(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) map[string][]string {
+	result := make(map[string][]string)
+	for _, column := range asset.Columns {
+		for _, upstream := range column.Upstreams {
+			result[column.Name] = append(result[column.Name], upstream.Table+"."+upstream.Column)
+		}
+	}
+	if len(result) > 0 || h.parser == nil || strings.ToLower(filepath.Ext(asset.ExecutableFile.Path)) != ".sql" {
+		return result
+	}
+
+	dialect, err := sqlparser.AssetTypeToDialect(asset.Type)
+	if err != nil {
+		return result
+	}
+
+	h.lineageMutex.Lock()
+	defer h.lineageMutex.Unlock()
+
+	if cached, ok := h.lineages[asset]; ok {
+		return cached
+	}
+
+	rendered, err := h.sql.renderer.CloneForAsset(ctx, p, asset).Render(asset.ExecutableFile.Content)
+	if err == nil {
+		schema := lineage.NewLineageExtractor(h.parser).TableSchemaForUpstreams(p, asset)
+		parsed, err := h.parser.ColumnLineage(rendered, dialect, schema)
+		if err == nil {
+			for _, column := range parsed.Columns {
+				upstreams := make([]string, 0, len(column.Upstream))
+				for _, upstream := range column.Upstream {
+					upstreams = append(upstreams, upstream.Table+"."+upstream.Column)
+				}
+				result[column.Name] = upstreams
+			}
+		}
+	}
+
+	h.lineages[asset] = result
+	return result
+}
@@ -0,0 +1,164 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPolicyParser struct {
+	mockQueryAnalyzer
+}
+
+func (m *mockPolicyParser) ColumnLineage(sql, dialect string, schema sqlparser.Schema) (*sqlparser.Lineage, error) {
+	args := m.Called(sql, dialect, schema)
+	return args.Get(0).(*sqlparser.Lineage), args.Error(1)
+}
+
+func TestPolicyFunctions(t *testing.T) {
+	t.Parallel()
+
+	raw := &pipeline.Asset{
+		Name:    "raw.items",
+		Type:    pipeline.AssetTypeBigqueryQuery,
+		Columns: []pipeline.Column{{Name: "id"}, {Name: "price"}},
+	}
+	mart := &pipeline.Asset{
+		Name:           "mart.items",
+		Type:           pipeline.AssetTypeBigqueryQuery,
+		ExecutableFile: pipeline.ExecutableFile{Path: "/pipeline/assets/items.sql", Content: "select id, price * 2 as price from raw.items"},
+		Upstreams:      []pipeline.Upstream{{Type: "asset", Value: "raw.items"}},
+		Columns:        []pipeline.Column{{Name: "id"}, {Name: "price"}},
+	}
+	p := &pipeline.Pipeline{Name: "test", Assets: []*pipeline.Asset{raw, mart}}
+
+	parser := new(mockPolicyParser)
+	parser.On("AnalyzeQuery", mart.ExecutableFile.Content, "bigquery").
+		Return(&sqlparser.QueryAnalysis{Tables: []string{"raw.items"}}, nil).Once()
+	parser.On("ColumnLineage", mart.ExecutableFile.Content, "bigquery", sqlparser.Schema{"raw.items": {"id": "", "price": ""}}).
+		Return(&sqlparser.Lineage{Columns: []sqlparser.ColumnLineage{
+			{Name: "id", Upstream: []sqlparser.UpstreamColumn{{Table: "raw.items", Column: "id"}}},
+			{Name: "price", Upstream: []sqlparser.UpstreamColumn{{Table: "raw.items", Column: "price"}}},
+		}}, nil).Once()
+
+	tests := []struct {
+		name     string
+		criteria string
+		asset    *pipeline.Asset
+		want     bool
+	}{
+		{
+			name:     "used tables are parsed from the query",
+			criteria: `"raw.items" in used_tables(asset)`,
+			asset:    mart,
+			want:     true,
+		},
+		{
+			name:     "used tables of the assets without a query are empty",
+			criteria: `len(used_tables(asset)) == 0`,
+			asset:    raw,
+			want:     true,
+		},
+		{
+			name:     "upstream assets",
+			criteria: `all(upstream(asset), .Name startsWith "raw.")`,
+			asset:    mart,
+			want:     true,
+		},
+		{
+			name:     "downstream assets",
+			criteria: `len(downstream(asset)) == 0`,
+			asset:    raw,
+			want:     false,
+		},
+		{
+			name:     "glossary entities that do not exist are nil",
+			criteria: `glossary_entity("customer") == nil`,
+			asset:    raw,
+			want:     true,
+		},
+		{
+			name:     "column lineage is parsed from the query",
+			criteria: `column_lineage(asset)["price"] == ["raw.items.price"]`,
+			asset:    mart,
+			want:     true,
+		},
+	}
+
+	helpers := newPolicyHelpers(parser)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			def := &RuleDefinition{Name: "test-rule", Description: "test rule", Criteria: tt.criteria}
+			require.NoError(t, def.validate())
+			require.NoError(t, def.compile())
+
+			issues, err := assetValidatorFromRuleDef(def, helpers)(context.Background(), p, tt.asset)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, len(issues) == 0)
+		})
+	}
+}
+
+func TestLoadPolicy_ScopesPolicyFilesToTheirDirectories(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, name), []byte(content), 0o600))
+	}
+
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+	writeFile("policy.yml", `
+custom_rules:
+  - name: has-owner
+    description: assets must have an owner
+    criteria: asset.Owner != ""
+rulesets:
+  - name: global
+    rules:
+      - asset-has-description
+`)
+	writeFile("pipeline/pipeline.yml", "name: test")
+	writeFile("pipeline/assets/marts/policy-marts.yaml", `
+rulesets:
+  - name: marts
+    rules:
+      - has-owner
+`)
+	writeFile("other/policy.yml", `
+rulesets:
+  - name: other
+    rules:
+      - asset-has-type
+`)
+
+	rules, err := loadPolicy(filepath.Join(repo, "pipeline", "pipeline.yml"), nil)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Name())
+	}
+	require.Equal(t, []string{"policy:global:asset-has-description", "policy:marts:has-owner"}, names)
+
+	p := &pipeline.Pipeline{DefinitionFile: pipeline.DefinitionFile{Path: filepath.Join(repo, "pipeline", "pipeline.yml")}}
+	inScope := &pipeline.Asset{Name: "mart.items", DefinitionFile: pipeline.TaskDefinitionFile{Path: filepath.Join(repo, "pipeline", "assets", "marts", "items.sql")}}
+	outOfScope := &pipeline.Asset{Name: "raw.items", DefinitionFile: pipeline.TaskDefinitionFile{Path: filepath.Join(repo, "pipeline", "assets", "raw", "items.sql")}}
+
+	issues, err := rules[1].ValidateAsset(context.Background(), p, inScope)
+	require.NoError(t, err)
+	assert.Len(t, issues, 1)
+
+	issues, err = rules[1].ValidateAsset(context.Background(), p, outOfScope)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
@@ -0,0 +1,263 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// PrintSARIF prints the issues in the SARIF format, which can be uploaded to GitHub code scanning.
+func (l *Printer) PrintSARIF(analysis *PipelineAnalysisResult) error {
+	return l.writeSARIF(os.Stdout, analysis)
+}
+
+func (l *Printer) writeSARIF(w io.Writer, analysis *PipelineAnalysisResult) error {
+	issues := sortedIssues(analysis)
+
+	rules := make([]sarifRule, 0)
+	seenRules := make(map[string]bool)
+	results := make([]sarifResult, 0, len(issues))
+	for _, ri := range issues {
+		level := severityLevel(ri.rule.GetSeverity())
+		if !seenRules[ri.rule.Name()] {
+			seenRules[ri.rule.Name()] = true
+			rules = append(rules, sarifRule{
+				ID:                   ri.rule.Name(),
+				ShortDescription:     sarifMessage{Text: ri.rule.Name()},
+				DefaultConfiguration: sarifConfiguration{Level: level},
+			})
+		}
+
+		result := sarifResult{
+			RuleID:  ri.rule.Name(),
+			Level:   level,
+			Message: sarifMessage{Text: issueMessage(ri.issue)},
+		}
+
+		if ri.issue.File != "" {
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(relativeIssuePath(ri.issue.File))},
+				},
+			}
+			if ri.issue.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: ri.issue.Line, StartColumn: ri.issue.Column}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+
+		results = append(results, result)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	report := sarifReport{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "bruin",
+						Version:        version.Version,
+						InformationURI: "https://github.com/bruin-data/bruin",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	res, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to convert lint result to SARIF")
+	}
+
+	_, err = fmt.Fprintln(w, string(res))
+	return err
+}
+
+// PrintGitHubAnnotations prints the issues as GitHub Actions workflow commands, which are shown as annotations on
+// the offending lines of the pull requests.
+func (l *Printer) PrintGitHubAnnotations(analysis *PipelineAnalysisResult) {
+	l.writeGitHubAnnotations(os.Stdout, analysis)
+}
+
+func (l *Printer) writeGitHubAnnotations(w io.Writer, analysis *PipelineAnalysisResult) {
+	for _, ri := range sortedIssues(analysis) {
+		command := "error"
+		if ri.rule.GetSeverity() == ValidatorSeverityWarning {
+			command = "warning"
+		}
+
+		properties := make([]string, 0, 4)
+		if ri.issue.File != "" {
+			properties = append(properties, "file="+escapeGitHubProperty(filepath.ToSlash(relativeIssuePath(ri.issue.File))))
+			if ri.issue.Line > 0 {
+				properties = append(properties, fmt.Sprintf("line=%d", ri.issue.Line))
+			}
+			if ri.issue.Column > 0 {
+				properties = append(properties, fmt.Sprintf("col=%d", ri.issue.Column))
+			}
+		}
+		properties = append(properties, "title="+escapeGitHubProperty(ri.rule.Name()))
+
+		fmt.Fprintf(w, "::%s %s::%s\n", command, strings.Join(properties, ","), escapeGitHubData(issueMessage(ri.issue)))
+	}
+}
+
+// sortedIssues flattens the issues of all the pipelines, ordered by their location so that the output is stable.
+func sortedIssues(analysis *PipelineAnalysisResult) []*ruleIssue {
+	issues := make([]*ruleIssue, 0)
+	for _, pipelineIssues := range analysis.Pipelines {
+		for rule, ruleIssues := range pipelineIssues.Issues {
+			for _, issue := range ruleIssues {
+				issues = append(issues, &ruleIssue{rule: rule, issue: issue})
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].issue, issues[j].issue
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		if issues[i].rule.Name() != issues[j].rule.Name() {
+			return issues[i].rule.Name() < issues[j].rule.Name()
+		}
+		return a.Description < b.Description
+	})
+
+	return issues
+}
+
+func severityLevel(severity ValidatorSeverity) string {
+	if severity == ValidatorSeverityWarning {
+		return "warning"
+	}
+
+	return "error"
+}
+
+func issueMessage(issue *Issue) string {
+	message := strings.TrimSpace(issue.Description)
+	if issue.Task != nil && issue.Task.Name != "" {
+		message = fmt.Sprintf("%s: %s", issue.Task.Name, message)
+	}
+
+	if len(issue.Context) > 0 {
+		message += "\n" + strings.Join(issue.Context, "\n")
+	}
+
+	return message
+}
+
+// relativeIssuePath returns the path relative to the working directory, which is the repository root for the CI
+// runs that consume these outputs.
+func relativeIssuePath(file string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return file
+	}
+
+	rel, err := filepath.Rel(wd, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return file
+	}
+
+	return rel
+}
+
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
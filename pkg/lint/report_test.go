@@ -0,0 +1,134 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportTestAnalysis(t *testing.T) *PipelineAnalysisResult {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	asset := &pipeline.Asset{
+		Name: "raw.people",
+		DefinitionFile: pipeline.TaskDefinitionFile{
+			Path: filepath.Join(wd, "pipeline", "assets", "people.sql"),
+		},
+		SourcePositions: pipeline.SourcePositions{
+			"name":                     {Line: 2, Column: 11},
+			"materialization.strategy": {Line: 5, Column: 11},
+		},
+	}
+
+	p := &pipeline.Pipeline{
+		DefinitionFile: pipeline.DefinitionFile{Path: filepath.Join(wd, "pipeline", "pipeline.yml")},
+	}
+
+	strategyIssue := &Issue{Task: asset, Field: "materialization.strategy", Description: "invalid strategy, 100% wrong", Context: []string{"use merge"}}
+	ownerIssue := &Issue{Task: asset, Field: "owner", Description: "owner is missing"}
+	pipelineIssue := &Issue{Description: "pipeline name is missing"}
+	locateIssues(p, []*Issue{strategyIssue, ownerIssue, pipelineIssue})
+
+	return &PipelineAnalysisResult{
+		Pipelines: []*PipelineIssues{
+			{
+				Pipeline: p,
+				Issues: map[Rule][]*Issue{
+					&SimpleRule{Identifier: "valid-strategy", Severity: ValidatorSeverityCritical}:    {strategyIssue},
+					&SimpleRule{Identifier: "asset-has-owner", Severity: ValidatorSeverityWarning}:    {ownerIssue},
+					&SimpleRule{Identifier: "pipeline-has-name", Severity: ValidatorSeverityCritical}: {pipelineIssue},
+				},
+			},
+		},
+	}
+}
+
+func TestLocateIssues(t *testing.T) {
+	t.Parallel()
+
+	analysis := reportTestAnalysis(t)
+	issues := sortedIssues(analysis)
+	require.Len(t, issues, 3)
+
+	assert.Equal(t, filepath.Join("pipeline", "assets", "people.sql"), relativeIssuePath(issues[0].issue.File))
+	// the field has no position, the asset name is used instead
+	assert.Equal(t, "asset-has-owner", issues[0].rule.Name())
+	assert.Equal(t, 2, issues[0].issue.Line)
+	assert.Equal(t, 11, issues[0].issue.Column)
+
+	assert.Equal(t, "valid-strategy", issues[1].rule.Name())
+	assert.Equal(t, 5, issues[1].issue.Line)
+
+	assert.Equal(t, filepath.Join("pipeline", "pipeline.yml"), relativeIssuePath(issues[2].issue.File))
+	assert.Equal(t, 0, issues[2].issue.Line)
+}
+
+func TestPrinter_writeSARIF(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	printer := Printer{}
+	require.NoError(t, printer.writeSARIF(&buf, reportTestAnalysis(t)))
+
+	var report sarifReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+
+	assert.Equal(t, "2.1.0", report.Version)
+	require.Len(t, report.Runs, 1)
+
+	run := report.Runs[0]
+	assert.Equal(t, "bruin", run.Tool.Driver.Name)
+	assert.Equal(t, []sarifRule{
+		{ID: "asset-has-owner", ShortDescription: sarifMessage{Text: "asset-has-owner"}, DefaultConfiguration: sarifConfiguration{Level: "warning"}},
+		{ID: "pipeline-has-name", ShortDescription: sarifMessage{Text: "pipeline-has-name"}, DefaultConfiguration: sarifConfiguration{Level: "error"}},
+		{ID: "valid-strategy", ShortDescription: sarifMessage{Text: "valid-strategy"}, DefaultConfiguration: sarifConfiguration{Level: "error"}},
+	}, run.Tool.Driver.Rules)
+
+	require.Len(t, run.Results, 3)
+	assert.Equal(t, sarifResult{
+		RuleID:  "valid-strategy",
+		Level:   "error",
+		Message: sarifMessage{Text: "raw.people: invalid strategy, 100% wrong\nuse merge"},
+		Locations: []sarifLocation{
+			{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: "pipeline/assets/people.sql"},
+					Region:           &sarifRegion{StartLine: 5, StartColumn: 11},
+				},
+			},
+		},
+	}, run.Results[1])
+
+	// issues without a known position only point to the file
+	assert.Nil(t, run.Results[2].Locations[0].PhysicalLocation.Region)
+	assert.Equal(t, "pipeline/pipeline.yml", run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestPrinter_writeGitHubAnnotations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	printer := Printer{}
+	printer.writeGitHubAnnotations(&buf, reportTestAnalysis(t))
+
+	expected := "::warning file=pipeline/assets/people.sql,line=2,col=11,title=asset-has-owner::raw.people: owner is missing\n" +
+		"::error file=pipeline/assets/people.sql,line=5,col=11,title=valid-strategy::raw.people: invalid strategy, 100%25 wrong%0Ause merge\n" +
+		"::error file=pipeline/pipeline.yml,title=pipeline-has-name::pipeline name is missing\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestEscapeGitHubProperty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "a%3Ab%2Cc%25%0A", escapeGitHubProperty("a:b,c%\n"))
+	assert.Equal(t, "a:b,c%25%0D%0A", escapeGitHubData("a:b,c%\r\n"))
+}
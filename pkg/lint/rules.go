@@ -18,7 +18,6 @@ import (
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/pkg/errors"
-	"github.com/robfig/cron/v3"
 	"github.com/spf13/afero"
 	"github.com/yourbasic/graph"
 )
@@ -69,6 +68,7 @@ func EnsureTaskNameIsValidForASingleAsset(ctx context.Context, p *pipeline.Pipel
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: taskNameMustExist,
+			Field:       "name",
 		})
 
 		return issues, nil
@@ -78,6 +78,7 @@ func EnsureTaskNameIsValidForASingleAsset(ctx context.Context, p *pipeline.Pipel
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: taskNameMustBeAlphanumeric,
+			Field:       "name",
 		})
 	}
 
@@ -112,6 +113,7 @@ func EnsureTaskNameIsUnique(p *pipeline.Pipeline) ([]*Issue, error) {
 		issues = append(issues, &Issue{
 			Task:        files[0],
 			Description: fmt.Sprintf("Asset name '%s' is not unique, please make sure all the task names are unique", name),
+			Field:       "name",
 			Context:     taskPaths,
 		})
 	}
@@ -145,6 +147,7 @@ func EnsureTaskNameIsUniqueForASingleAsset(ctx context.Context, p *pipeline.Pipe
 	issues = append(issues, &Issue{
 		Task:        asset,
 		Description: fmt.Sprintf("Asset name '%s' is not unique, please make sure all the task names are unique", asset.Name),
+		Field:       "name",
 		Context:     taskPaths,
 	})
 
@@ -250,6 +253,7 @@ func EnsureIngestrAssetIsValidForASingleAsset(ctx context.Context, p *pipeline.P
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Ingestr assets require the following parameters: " + strings.Join(requiredKeys, ", "),
+				Field:       "parameters",
 			})
 
 			return issues, nil
@@ -260,6 +264,7 @@ func EnsureIngestrAssetIsValidForASingleAsset(ctx context.Context, p *pipeline.P
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Ingestr assets require the following parameters: " + strings.Join(requiredKeys, ", "),
+				Field:       "parameters",
 			})
 
 			return issues, nil
@@ -271,6 +276,7 @@ func EnsureIngestrAssetIsValidForASingleAsset(ctx context.Context, p *pipeline.P
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "Ingestr assets do not support the 'update_on_merge' field, the strategy used decide the update behavior",
+			Field:       "columns",
 		})
 	}
 	if value, exists := asset.Parameters["incremental_strategy"]; exists && value == "merge" {
@@ -279,6 +285,7 @@ func EnsureIngestrAssetIsValidForASingleAsset(ctx context.Context, p *pipeline.P
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Materialization strategy 'merge' requires the 'primary_key' field to be set on at least one column",
+				Field:       "parameters.incremental_strategy",
 			})
 		}
 	}
@@ -292,11 +299,12 @@ func isFileExecutable(mode os.FileMode) bool {
 
 func EnsureDependencyExistsForASingleAsset(ctx context.Context, p *pipeline.Pipeline, task *pipeline.Asset) ([]*Issue, error) {
 	issues := make([]*Issue, 0)
-	for _, dep := range task.Upstreams {
+	for i, dep := range task.Upstreams {
 		if dep.Value == "" {
 			issues = append(issues, &Issue{
 				Task:        task,
 				Description: "Assets cannot have empty dependencies",
+				Field:       fmt.Sprintf("depends[%d]", i),
 			})
 		}
 
@@ -309,6 +317,7 @@ func EnsureDependencyExistsForASingleAsset(ctx context.Context, p *pipeline.Pipe
 			issues = append(issues, &Issue{
 				Task:        task,
 				Description: fmt.Sprintf("Dependency '%s' does not exist", dep.Value),
+				Field:       fmt.Sprintf("depends[%d]", i),
 			})
 		}
 	}
@@ -318,21 +327,7 @@ func EnsureDependencyExistsForASingleAsset(ctx context.Context, p *pipeline.Pipe
 
 func EnsurePipelineScheduleIsValidCron(p *pipeline.Pipeline) ([]*Issue, error) {
 	issues := make([]*Issue, 0)
-	if p.Schedule == "" {
-		return issues, nil
-	}
-
-	if p.Schedule == "continuous" || p.Schedule == "@continuous" {
-		return issues, nil
-	}
-
-	schedule := p.Schedule
-	if schedule == "daily" || schedule == "hourly" || schedule == "weekly" || schedule == "monthly" {
-		schedule = "@" + schedule
-	}
-
-	_, err := cron.ParseStandard(string(schedule))
-	if err != nil {
+	if _, err := p.Schedule.CronSchedule(); err != nil {
 		issues = append(issues, &Issue{
 			Description: fmt.Sprintf("Invalid cron schedule '%s'", p.Schedule),
 		})
@@ -431,11 +426,12 @@ func EnsurePipelineStartDateIsValid(p *pipeline.Pipeline) ([]*Issue, error) {
 // It returns a slice of Issues, each representing a duplicate column check found.
 func ValidateCustomCheckQueryExists(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
 	var issues []*Issue
-	for _, check := range asset.CustomChecks {
+	for i, check := range asset.CustomChecks {
 		if check.Query == "" {
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: fmt.Sprintf("Custom check '%s' query cannot be empty", check.Name),
+				Field:       fmt.Sprintf("custom_checks[%d].query", i),
 			})
 		}
 	}
@@ -455,6 +451,18 @@ func ValidatePythonAssetMaterialization(ctx context.Context, p *pipeline.Pipelin
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "A task with materialization must have a connection defined",
+			Field:       "materialization",
+		})
+	}
+
+	switch asset.Materialization.Strategy { //nolint:exhaustive
+	case pipeline.MaterializationStrategyNone, pipeline.MaterializationStrategyCreateReplace, pipeline.MaterializationStrategyAppend,
+		pipeline.MaterializationStrategyMerge, pipeline.MaterializationStrategyDeleteInsert, pipeline.MaterializationStrategyTimeInterval:
+	default:
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Materialization strategy '%s' is not supported for Python assets, available strategies are: create+replace, append, merge, delete+insert, time_interval", asset.Materialization.Strategy),
+			Field:       "materialization.strategy",
 		})
 	}
 
@@ -468,12 +476,14 @@ func ValidateAssetSeedValidation(ctx context.Context, p *pipeline.Pipeline, asse
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Materialization is not allowed on a seed asset",
+				Field:       "materialization",
 			})
 		}
 		if asset.Parameters["path"] == "" {
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Seed file path is required",
+				Field:       "parameters",
 			})
 			return issues, nil
 		}
@@ -484,6 +494,7 @@ func ValidateAssetSeedValidation(ctx context.Context, p *pipeline.Pipeline, asse
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Seed file does not exist or cannot be found",
+				Field:       "parameters.path",
 			})
 			return issues, nil
 		}
@@ -493,6 +504,7 @@ func ValidateAssetSeedValidation(ctx context.Context, p *pipeline.Pipeline, asse
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Failed to open seed file",
+				Field:       "parameters.path",
 			})
 			return issues, nil
 		}
@@ -505,6 +517,7 @@ func ValidateAssetSeedValidation(ctx context.Context, p *pipeline.Pipeline, asse
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "CSV file cannot be parsed",
+					Field:       "parameters.path",
 				})
 				return issues, nil
 			}
@@ -513,11 +526,12 @@ func ValidateAssetSeedValidation(ctx context.Context, p *pipeline.Pipeline, asse
 			}
 		}
 
-		for _, column := range asset.Columns {
+		for i, column := range asset.Columns {
 			if !columnMap[column.Name] {
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: fmt.Sprintf("Column '%s' is defined in the asset but does not exist in the CSV", column.Name),
+					Field:       fmt.Sprintf("columns[%d].name", i),
 				})
 			}
 		}
@@ -574,6 +588,7 @@ func ValidateEMRServerlessAsset(ctx context.Context, p *pipeline.Pipeline, asset
 				Description: fmt.Sprintf( //nolint
 					"missing required field parameters.%s", key,
 				),
+				Field: "parameters." + key,
 			})
 		}
 	}
@@ -584,6 +599,7 @@ func ValidateEMRServerlessAsset(ctx context.Context, p *pipeline.Pipeline, asset
 				Description: fmt.Sprintf( //nolint
 					"prohibited field parameters.%s", key,
 				),
+				Field: "parameters." + key,
 			})
 		}
 	}
@@ -595,12 +611,14 @@ func ValidateEMRServerlessAsset(ctx context.Context, p *pipeline.Pipeline, asset
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "parameters.timeout is not a valid duration",
+				Field:       "parameters.timeout",
 			})
 		}
 		if timeout != 0 && timeout < (5*time.Minute) {
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "parameters.timeout must be atleast 5m or zero",
+				Field:       "parameters.timeout",
 			})
 		}
 	}
@@ -610,6 +628,7 @@ func ValidateEMRServerlessAsset(ctx context.Context, p *pipeline.Pipeline, asset
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "parameters.execution_role must be an Amazon Resource Name (ARN)",
+			Field:       "parameters.execution_role",
 		})
 	}
 
@@ -620,17 +639,65 @@ func ValidateEMRServerlessAsset(ctx context.Context, p *pipeline.Pipeline, asset
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "parameters.logs must be a valid URI",
+				Field:       "parameters.logs",
 			})
 		} else if logURI.Scheme != "s3" {
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "parameters.logs must be a valid S3 URI",
+				Field:       "parameters.logs",
 			})
 		}
 	}
 	return issues, nil
 }
 
+func ValidateDatabricksJobAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+
+	extension := filepath.Ext(asset.ExecutableFile.Path)
+	switch asset.Type { //nolint:exhaustive
+	case pipeline.AssetTypeDatabricksPyspark:
+		if extension != ".py" {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("%s assets must be a Python file", pipeline.AssetTypeDatabricksPyspark),
+			})
+		}
+	case pipeline.AssetTypeDatabricksNotebook:
+		if !slices.Contains([]string{".py", ".ipynb"}, extension) {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("%s assets must be a Python file or a Jupyter notebook", pipeline.AssetTypeDatabricksNotebook),
+			})
+		}
+	default:
+		return issues, nil
+	}
+
+	timeoutSpec := strings.TrimSpace(asset.Parameters["timeout"])
+	if timeoutSpec != "" {
+		if _, err := time.ParseDuration(timeoutSpec); err != nil {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "parameters.timeout is not a valid duration",
+				Field:       "parameters.timeout",
+			})
+		}
+	}
+
+	workspace := strings.TrimSpace(asset.Parameters["workspace"])
+	if workspace != "" && !strings.HasPrefix(workspace, "/") {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "parameters.workspace must be an absolute workspace path",
+			Field:       "parameters.workspace",
+		})
+	}
+
+	return issues, nil
+}
+
 // ValidateDuplicateColumnNames checks for duplicate column names within a single asset.
 // It returns a slice of Issues, each representing a duplicate column name found.
 //
@@ -648,12 +715,13 @@ func ValidateDuplicateColumnNames(ctx context.Context, p *pipeline.Pipeline, ass
 	var issues []*Issue
 
 	columnNames := make(map[string]bool)
-	for _, column := range asset.Columns {
+	for i, column := range asset.Columns {
 		lowercaseName := strings.ToLower(column.Name)
 		if columnNames[lowercaseName] {
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: fmt.Sprintf("Duplicate column name '%s' found ", column.Name),
+				Field:       fmt.Sprintf("columns[%d].name", i),
 			})
 		} else {
 			columnNames[lowercaseName] = true
@@ -682,6 +750,7 @@ func EnsureTypeIsCorrectForASingleAsset(ctx context.Context, p *pipeline.Pipelin
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: taskTypeMustExist,
+			Field:       "type",
 		})
 		return issues, nil
 	}
@@ -690,6 +759,7 @@ func EnsureTypeIsCorrectForASingleAsset(ctx context.Context, p *pipeline.Pipelin
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: fmt.Sprintf("Invalid asset type '%s'", asset.Type),
+			Field:       "type",
 		})
 	}
 
@@ -829,6 +899,32 @@ func EnsureMSTeamsFieldInPipelineIsValid(p *pipeline.Pipeline) ([]*Issue, error)
 func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
 	issues := make([]*Issue, 0)
 
+	switch asset.Materialization.OutputSchema {
+	case pipeline.MaterializationOutputSchemaNone:
+	case pipeline.MaterializationOutputSchemaContract, pipeline.MaterializationOutputSchemaInfer:
+		if asset.Type != pipeline.AssetTypePython || asset.Materialization.Type != pipeline.MaterializationTypeTable {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "'output_schema' is only supported for Python assets with 'table' materialization",
+				Field:       "materialization.output_schema",
+			})
+		}
+
+		if asset.Materialization.OutputSchema == pipeline.MaterializationOutputSchemaContract && len(asset.Columns) == 0 {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "'output_schema: contract' requires the 'columns' field to be set with the expected columns",
+				Field:       "columns",
+			})
+		}
+	default:
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("'output_schema' must be either 'contract' or 'infer', '%s' given", asset.Materialization.OutputSchema),
+			Field:       "materialization.output_schema",
+		})
+	}
+
 	switch asset.Materialization.Type {
 	case pipeline.MaterializationTypeNone:
 		return issues, nil
@@ -837,6 +933,7 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: materializationStrategyIsNotSupportedForViews,
+				Field:       "materialization.strategy",
 			})
 		}
 
@@ -844,6 +941,7 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: materializationIncrementalKeyNotSupportedForViews,
+				Field:       "materialization.incremental_key",
 			})
 		}
 
@@ -851,6 +949,7 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: materializationClusterByNotSupportedForViews,
+				Field:       "materialization.cluster_by",
 			})
 		}
 
@@ -858,6 +957,7 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: materializationPartitionByNotSupportedForViews,
+				Field:       "materialization.partition_by",
 			})
 		}
 
@@ -867,10 +967,21 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 		}
 
 		if asset.Materialization.IncrementalKey != "" &&
-			asset.Materialization.Strategy != pipeline.MaterializationStrategyDeleteInsert && asset.Materialization.Strategy != pipeline.MaterializationStrategyTimeInterval {
+			asset.Materialization.Strategy != pipeline.MaterializationStrategyDeleteInsert && asset.Materialization.Strategy != pipeline.MaterializationStrategyTimeInterval &&
+			asset.Materialization.Strategy != pipeline.MaterializationStrategyMicrobatch {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "Incremental key is only supported with 'delete+insert', 'time_interval' or 'microbatch' strategies.",
+				Field:       "materialization.incremental_key",
+			})
+		}
+
+		if (asset.Materialization.BatchSize != "" || asset.Materialization.Lookback != 0) &&
+			asset.Materialization.Strategy != pipeline.MaterializationStrategyMicrobatch {
 			issues = append(issues, &Issue{
 				Task:        asset,
-				Description: "Incremental key is only supported with 'delete+insert' or 'time_interval' strategies.",
+				Description: "'batch_size' and 'lookback' are only supported with the 'microbatch' strategy.",
+				Field:       "materialization.strategy",
 			})
 		}
 
@@ -881,12 +992,14 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "DDL strategy is not allowed on a view",
+					Field:       "materialization.type",
 				})
 			}
 			if asset.ExecutableFile.Content != "" {
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "DDL strategy builds the table, from bruin metadata and does not accept a custom query",
+					Field:       "materialization.strategy",
 				})
 			}
 		case pipeline.MaterializationStrategyCreateReplace:
@@ -897,6 +1010,7 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "Materialization strategy 'delete+insert' requires the 'incremental_key' field to be set",
+					Field:       "materialization.strategy",
 				})
 			}
 		case pipeline.MaterializationStrategyMerge:
@@ -904,6 +1018,7 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "Materialization strategy 'merge' requires the 'columns' field to be set with actual columns",
+					Field:       "columns",
 				})
 			}
 
@@ -912,6 +1027,7 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "Materialization strategy 'merge' requires the 'primary_key' field to be set on at least one column",
+					Field:       "columns",
 				})
 			}
 		case pipeline.MaterializationStrategyTimeInterval:
@@ -919,18 +1035,57 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "Materialization strategy 'time_interval' requires the 'incremental_key' field to be set",
+					Field:       "materialization.strategy",
 				})
 			}
 			if asset.Materialization.TimeGranularity == "" {
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "Materialization strategy 'time_interval' requires the 'time_granularity' field to be set",
+					Field:       "materialization.strategy",
 				})
 			}
 			if asset.Materialization.TimeGranularity != pipeline.MaterializationTimeGranularityDate && asset.Materialization.TimeGranularity != pipeline.MaterializationTimeGranularityTimestamp {
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "'time_granularity' can be either 'date' or 'timestamp'.",
+					Field:       "materialization.time_granularity",
+				})
+			}
+		case pipeline.MaterializationStrategyMicrobatch:
+			if asset.Materialization.IncrementalKey == "" {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: "Materialization strategy 'microbatch' requires the 'incremental_key' field to be set",
+					Field:       "materialization.strategy",
+				})
+			}
+			if asset.Materialization.TimeGranularity != pipeline.MaterializationTimeGranularityDate && asset.Materialization.TimeGranularity != pipeline.MaterializationTimeGranularityTimestamp {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: "Materialization strategy 'microbatch' requires the 'time_granularity' field to be either 'date' or 'timestamp'",
+					Field:       "materialization.time_granularity",
+				})
+			}
+			if !asset.Materialization.BatchSize.IsValid() {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: "Materialization strategy 'microbatch' requires the 'batch_size' field to be one of 'hour', 'day' or 'month'",
+					Field:       "materialization.batch_size",
+				})
+			}
+			if asset.Materialization.BatchSize == pipeline.MaterializationBatchSizeHour && asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: "'batch_size: hour' cannot be used with 'time_granularity: date', use 'timestamp' instead",
+					Field:       "materialization.batch_size",
+				})
+			}
+			if asset.Materialization.Lookback < 0 {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: "'lookback' must be zero or a positive number of batches",
+					Field:       "materialization.lookback",
 				})
 			}
 		default:
@@ -971,6 +1126,7 @@ func EnsureSnowflakeSensorHasQueryParameterForASingleAsset(ctx context.Context,
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "Snowflake query sensor requires a `query` parameter",
+			Field:       "parameters",
 		})
 		return issues, nil
 	}
@@ -979,6 +1135,7 @@ func EnsureSnowflakeSensorHasQueryParameterForASingleAsset(ctx context.Context,
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "Snowflake query sensor requires a `query` parameter that is not empty",
+			Field:       "parameters.query",
 		})
 	}
 
@@ -996,6 +1153,7 @@ func EnsureBigQueryTableSensorHasTableParameterForASingleAsset(ctx context.Conte
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "BigQuery table sensor requires a `table` parameter",
+			Field:       "parameters",
 		})
 		return issues, nil
 	}
@@ -1005,6 +1163,7 @@ func EnsureBigQueryTableSensorHasTableParameterForASingleAsset(ctx context.Conte
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "BigQuery table sensor `table` parameter must be either in the format `dataset.table` or `project.dataset.table`",
+			Field:       "parameters.table",
 		})
 	}
 
@@ -1022,6 +1181,7 @@ func EnsureBigQueryQuerySensorHasTableParameterForASingleAsset(ctx context.Conte
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "BigQuery query sensor requires a `query` parameter",
+			Field:       "parameters",
 		})
 		return issues, nil
 	}
@@ -1030,6 +1190,7 @@ func EnsureBigQueryQuerySensorHasTableParameterForASingleAsset(ctx context.Conte
 		issues = append(issues, &Issue{
 			Task:        asset,
 			Description: "BigQuery query sensor requires a `query` parameter that is not empty",
+			Field:       "parameters.query",
 		})
 	}
 
@@ -1060,7 +1221,7 @@ func ValidateCustomCheckQueryDryRun(connections connectionManager) AssetValidato
 			return issues, nil
 		}
 
-		for _, check := range asset.CustomChecks {
+		for i, check := range asset.CustomChecks {
 			if strings.TrimSpace(check.Query) == "" {
 				continue
 			}
@@ -1071,12 +1232,14 @@ func ValidateCustomCheckQueryDryRun(connections connectionManager) AssetValidato
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: fmt.Sprintf("Failed to validate custom check query '%s': %s", check.Name, err),
+					Field:       fmt.Sprintf("custom_checks[%d].query", i),
 					Context:     []string{check.Query},
 				})
 			} else if !valid {
 				issues = append(issues, &Issue{
 					Task:        asset,
 					Description: "Custom check query is invalid:" + check.Query,
+					Field:       fmt.Sprintf("custom_checks[%d].query", i),
 					Context:     []string{check.Query},
 				})
 			}
@@ -1111,7 +1274,7 @@ func (g *GlossaryChecker) EnsureAssetEntitiesExistInGlossary(ctx context.Context
 		}
 	}
 
-	for _, column := range asset.Columns {
+	for i, column := range asset.Columns {
 		if column.EntityAttribute == nil {
 			continue
 		}
@@ -1120,6 +1283,7 @@ func (g *GlossaryChecker) EnsureAssetEntitiesExistInGlossary(ctx context.Context
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Entity name cannot be empty",
+				Field:       fmt.Sprintf("columns[%d]", i),
 			})
 			continue
 		}
@@ -1128,6 +1292,7 @@ func (g *GlossaryChecker) EnsureAssetEntitiesExistInGlossary(ctx context.Context
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: "Attribute name cannot be empty",
+				Field:       fmt.Sprintf("columns[%d]", i),
 			})
 			continue
 		}
@@ -1137,6 +1302,7 @@ func (g *GlossaryChecker) EnsureAssetEntitiesExistInGlossary(ctx context.Context
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: fmt.Sprintf("Entity '%s' does not exist in the glossary", column.EntityAttribute.Entity),
+				Field:       fmt.Sprintf("columns[%d]", i),
 			})
 			continue
 		}
@@ -1146,6 +1312,7 @@ func (g *GlossaryChecker) EnsureAssetEntitiesExistInGlossary(ctx context.Context
 			issues = append(issues, &Issue{
 				Task:        asset,
 				Description: fmt.Sprintf("Attribute '%s' does not exist in the entity '%s'", column.EntityAttribute.Attribute, column.EntityAttribute.Entity),
+				Field:       fmt.Sprintf("columns[%d]", i),
 			})
 		}
 	}
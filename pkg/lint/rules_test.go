@@ -79,12 +79,14 @@ func TestEnsureTaskNameIsNotEmpty(t *testing.T) {
 				{
 					Task:        &taskWithEmptyName,
 					Description: taskNameMustExist,
+					Field:       "name",
 				},
 				{
 					Task: &pipeline.Asset{
 						Name: "task name with spaces",
 					},
 					Description: taskNameMustBeAlphanumeric,
+					Field:       "name",
 				},
 			},
 			wantErr: false,
@@ -515,6 +517,7 @@ func TestEnsureDependencyExists(t *testing.T) {
 						},
 					},
 					Description: "Dependency 'task5' does not exist",
+					Field:       "depends[2]",
 				},
 				{
 					Task: &pipeline.Asset{
@@ -531,6 +534,7 @@ func TestEnsureDependencyExists(t *testing.T) {
 						},
 					},
 					Description: "Dependency 'task4' does not exist",
+					Field:       "depends[1]",
 				},
 			},
 		},
@@ -656,6 +660,7 @@ func TestEnsureOnlyAcceptedTaskTypesAreThere(t *testing.T) {
 				{
 					Task:        &pipeline.Asset{},
 					Description: taskTypeMustExist,
+					Field:       "type",
 				},
 			},
 		},
@@ -676,6 +681,7 @@ func TestEnsureOnlyAcceptedTaskTypesAreThere(t *testing.T) {
 						Type: "some.random.type",
 					},
 					Description: "Invalid asset type 'some.random.type'",
+					Field:       "type",
 				},
 			},
 		},
@@ -764,6 +770,7 @@ func TestEnsureTaskNameIsUnique(t *testing.T) {
 						},
 					},
 					Description: "Asset name 'name1' is not unique, please make sure all the task names are unique",
+					Field:       "name",
 					Context:     []string{"path1", "path3"},
 				},
 			},
@@ -848,6 +855,7 @@ func TestEnsureTaskNameIsUniqueForASingleAsset(t *testing.T) {
 						},
 					},
 					Description: "Asset name 'name1' is not unique, please make sure all the task names are unique",
+					Field:       "name",
 					Context:     []string{"path1", "path3"},
 				},
 			},
@@ -1311,6 +1319,41 @@ func TestEnsureMaterializationValuesAreValid(t *testing.T) {
 				materializationPartitionByNotSupportedForViews,
 			},
 		},
+		{
+			name: "output schema is only supported for python tables",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Type: pipeline.AssetTypeBigqueryQuery,
+					Materialization: pipeline.Materialization{
+						Type:         pipeline.MaterializationTypeTable,
+						OutputSchema: pipeline.MaterializationOutputSchemaInfer,
+					},
+				},
+				{
+					Name: "task2",
+					Type: pipeline.AssetTypePython,
+					Materialization: pipeline.Materialization{
+						Type:         pipeline.MaterializationTypeTable,
+						OutputSchema: pipeline.MaterializationOutputSchemaContract,
+					},
+				},
+				{
+					Name: "task3",
+					Type: pipeline.AssetTypePython,
+					Materialization: pipeline.Materialization{
+						Type:         pipeline.MaterializationTypeTable,
+						OutputSchema: "enforced",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"'output_schema' is only supported for Python assets with 'table' materialization",
+				"'output_schema: contract' requires the 'columns' field to be set with the expected columns",
+				"'output_schema' must be either 'contract' or 'infer', 'enforced' given",
+			},
+		},
 		{
 			name: "table materialization has create+replace, all good",
 			assets: []*pipeline.Asset{
@@ -1351,7 +1394,7 @@ func TestEnsureMaterializationValuesAreValid(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 			want: []string{
-				"Incremental key is only supported with 'delete+insert' or 'time_interval' strategies.",
+				"Incremental key is only supported with 'delete+insert', 'time_interval' or 'microbatch' strategies.",
 			},
 		},
 		{
@@ -1370,6 +1413,60 @@ func TestEnsureMaterializationValuesAreValid(t *testing.T) {
 				"Materialization strategy 'delete+insert' requires the 'incremental_key' field to be set",
 			},
 		},
+		{
+			name: "table materialization has microbatch, all good",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Materialization: pipeline.Materialization{
+						Type:            pipeline.MaterializationTypeTable,
+						Strategy:        pipeline.MaterializationStrategyMicrobatch,
+						IncrementalKey:  "dt",
+						TimeGranularity: pipeline.MaterializationTimeGranularityTimestamp,
+						BatchSize:       pipeline.MaterializationBatchSizeHour,
+						Lookback:        2,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "table materialization has microbatch with missing fields",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Materialization: pipeline.Materialization{
+						Type:     pipeline.MaterializationTypeTable,
+						Strategy: pipeline.MaterializationStrategyMicrobatch,
+						Lookback: -1,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"Materialization strategy 'microbatch' requires the 'incremental_key' field to be set",
+				"Materialization strategy 'microbatch' requires the 'time_granularity' field to be either 'date' or 'timestamp'",
+				"Materialization strategy 'microbatch' requires the 'batch_size' field to be one of 'hour', 'day' or 'month'",
+				"'lookback' must be zero or a positive number of batches",
+			},
+		},
+		{
+			name: "table materialization has batch size but not microbatch",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Materialization: pipeline.Materialization{
+						Type:      pipeline.MaterializationTypeTable,
+						Strategy:  pipeline.MaterializationStrategyAppend,
+						BatchSize: pipeline.MaterializationBatchSizeDay,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"'batch_size' and 'lookback' are only supported with the 'microbatch' strategy.",
+			},
+		},
 		{
 			name: "some random materialization strategy is used",
 			assets: []*pipeline.Asset{
@@ -2230,6 +2327,7 @@ func TestValidateDuplicateColumnNames(t *testing.T) {
 				{
 					Task:        &pipeline.Asset{Name: "asset1", Columns: []pipeline.Column{{Name: "col1"}, {Name: "col2"}, {Name: "Col1"}}},
 					Description: "Duplicate column name 'Col1' found ",
+					Field:       "columns[2].name",
 				},
 			},
 			wantErr: false,
@@ -2249,10 +2347,12 @@ func TestValidateDuplicateColumnNames(t *testing.T) {
 				{
 					Task:        &pipeline.Asset{Name: "asset1", Columns: []pipeline.Column{{Name: "col1"}, {Name: "Col1"}, {Name: "col2"}, {Name: "COL2"}}},
 					Description: "Duplicate column name 'Col1' found ",
+					Field:       "columns[1].name",
 				},
 				{
 					Task:        &pipeline.Asset{Name: "asset1", Columns: []pipeline.Column{{Name: "col1"}, {Name: "Col1"}, {Name: "col2"}, {Name: "COL2"}}},
 					Description: "Duplicate column name 'COL2' found ",
+					Field:       "columns[3].name",
 				},
 			},
 			wantErr: false,
@@ -2326,6 +2426,49 @@ func TestEnsureValidPythonAssetMaterialization(t *testing.T) {
 				{
 					Task:        &pipeline.Asset{Name: "asset1", Type: pipeline.AssetTypePython, Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable}},
 					Description: "A task with materialization must have a connection defined",
+					Field:       "materialization",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "incremental python asset materialization",
+			p: &pipeline.Pipeline{
+				Assets: []*pipeline.Asset{
+					{
+						Name: "asset1",
+						Type: pipeline.AssetTypePython,
+						Materialization: pipeline.Materialization{
+							Type:     pipeline.MaterializationTypeTable,
+							Strategy: pipeline.MaterializationStrategyMerge,
+						},
+						Connection: "conn1",
+					},
+				},
+			},
+			want:    []*Issue{},
+			wantErr: false,
+		},
+		{
+			name: "unsupported python asset materialization strategy",
+			p: &pipeline.Pipeline{
+				Assets: []*pipeline.Asset{
+					{
+						Name: "asset1",
+						Type: pipeline.AssetTypePython,
+						Materialization: pipeline.Materialization{
+							Type:     pipeline.MaterializationTypeTable,
+							Strategy: pipeline.MaterializationStrategyDDL,
+						},
+						Connection: "conn1",
+					},
+				},
+			},
+			want: []*Issue{
+				{
+					Task:        &pipeline.Asset{Name: "asset1", Type: pipeline.AssetTypePython, Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable, Strategy: pipeline.MaterializationStrategyDDL}, Connection: "conn1"},
+					Description: "Materialization strategy 'ddl' is not supported for Python assets, available strategies are: create+replace, append, merge, delete+insert, time_interval",
+					Field:       "materialization.strategy",
 				},
 			},
 			wantErr: false,
@@ -2521,3 +2664,66 @@ func TestValidateCustomCheckQueryDryRun(t *testing.T) {
 		assert.Empty(t, issues)
 	})
 }
+
+func TestValidateDatabricksJobAsset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name: "other asset types are ignored",
+			asset: &pipeline.Asset{
+				Type:           pipeline.AssetTypeDatabricksQuery,
+				ExecutableFile: pipeline.ExecutableFile{Path: "query.sql"},
+			},
+			want: []string{},
+		},
+		{
+			name: "valid notebook asset",
+			asset: &pipeline.Asset{
+				Type:           pipeline.AssetTypeDatabricksNotebook,
+				ExecutableFile: pipeline.ExecutableFile{Path: "analysis.ipynb"},
+				Parameters:     map[string]string{"timeout": "1h", "workspace": "/Shared/jobs"},
+			},
+			want: []string{},
+		},
+		{
+			name: "pyspark assets must be python files",
+			asset: &pipeline.Asset{
+				Type:           pipeline.AssetTypeDatabricksPyspark,
+				ExecutableFile: pipeline.ExecutableFile{Path: "analysis.ipynb"},
+			},
+			want: []string{"databricks.pyspark assets must be a Python file"},
+		},
+		{
+			name: "invalid parameters are reported",
+			asset: &pipeline.Asset{
+				Type:           pipeline.AssetTypeDatabricksNotebook,
+				ExecutableFile: pipeline.ExecutableFile{Path: "job.sql"},
+				Parameters:     map[string]string{"timeout": "1 hour", "workspace": "Shared/jobs"},
+			},
+			want: []string{
+				"databricks.notebook assets must be a Python file or a Jupyter notebook",
+				"parameters.timeout is not a valid duration",
+				"parameters.workspace must be an absolute workspace path",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues, err := ValidateDatabricksJobAsset(context.Background(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				descriptions = append(descriptions, issue.Description)
+			}
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/diff"
+	"github.com/bruin-data/bruin/pkg/logger"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// schemaDriftTypeMappers holds the asset types whose tables can be compared with the live schema, along with the
+// mapper that normalizes the types of their platform.
+var schemaDriftTypeMappers = map[pipeline.AssetType]func() *diff.DatabaseTypeMapper{
+	pipeline.AssetTypeBigqueryQuery:  diff.NewBigQueryTypeMapper,
+	pipeline.AssetTypeBigquerySeed:   diff.NewBigQueryTypeMapper,
+	pipeline.AssetTypeBigquerySource: diff.NewBigQueryTypeMapper,
+	pipeline.AssetTypeDuckDBQuery:    diff.NewDuckDBTypeMapper,
+	pipeline.AssetTypeDuckDBSeed:     diff.NewDuckDBTypeMapper,
+	pipeline.AssetTypeTrinoQuery:     diff.NewTrinoTypeMapper,
+	pipeline.AssetTypeTrinoSeed:      diff.NewTrinoTypeMapper,
+}
+
+// SchemaDriftRule compares the columns declared in the assets with the columns of the tables in the warehouse, and
+// reports the columns that are missing, the ones that are not declared, and the ones whose types do not match.
+type SchemaDriftRule struct {
+	Connections connectionManager
+	Logger      logger.Logger
+}
+
+func (s *SchemaDriftRule) Name() string {
+	return "schema-drift"
+}
+
+func (s *SchemaDriftRule) IsFast() bool {
+	return false
+}
+
+func (s *SchemaDriftRule) GetApplicableLevels() []Level {
+	return []Level{LevelPipeline, LevelAsset}
+}
+
+func (s *SchemaDriftRule) GetSeverity() ValidatorSeverity {
+	return ValidatorSeverityWarning
+}
+
+func (s *SchemaDriftRule) Validate(p *pipeline.Pipeline) ([]*Issue, error) {
+	return CallFuncForEveryAsset(s.ValidateAsset)(p)
+}
+
+func (s *SchemaDriftRule) ValidateAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if len(asset.Columns) == 0 {
+		return issues, nil
+	}
+
+	newMapper, ok := schemaDriftTypeMappers[asset.Type]
+	if !ok {
+		return issues, nil
+	}
+
+	// queries without materialization do not create any table that could be compared
+	if strings.HasSuffix(string(asset.Type), ".sql") && asset.Materialization.Type == pipeline.MaterializationTypeNone {
+		return issues, nil
+	}
+
+	table, err := FetchLiveSchema(ctx, s.Connections, p, asset)
+	if err != nil {
+		s.Logger.Debugf("skipping the schema drift check for asset '%s': %s", asset.Name, err)
+		return issues, nil //nolint:nilerr
+	}
+
+	if len(table.Columns) == 0 {
+		s.Logger.Debugf("the table of asset '%s' does not exist yet, skipping the schema drift check", asset.Name)
+		return issues, nil
+	}
+
+	return compareSchemas(asset, table, newMapper()), nil
+}
+
+// FetchLiveSchema returns the schema of the table the asset writes to, using the connection of the asset.
+func FetchLiveSchema(ctx context.Context, connections connectionManager, p *pipeline.Pipeline, asset *pipeline.Asset) (*diff.Table, error) {
+	connectionName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := connections.GetConnection(connectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher, ok := conn.(diff.TableSchemaFetcher)
+	if !ok {
+		return nil, fmt.Errorf("connection '%s' does not support fetching table schemas", connectionName)
+	}
+
+	return fetcher.GetTableSchema(ctx, asset.Name)
+}
+
+func compareSchemas(asset *pipeline.Asset, table *diff.Table, mapper *diff.DatabaseTypeMapper) []*Issue {
+	liveColumns := make(map[string]*diff.Column, len(table.Columns))
+	for _, col := range table.Columns {
+		liveColumns[strings.ToLower(col.Name)] = col
+	}
+
+	missing := make([]string, 0)
+	mismatched := make([]string, 0)
+	declared := make(map[string]bool, len(asset.Columns))
+	for _, col := range asset.Columns {
+		name := strings.ToLower(col.Name)
+		declared[name] = true
+
+		live, ok := liveColumns[name]
+		if !ok {
+			missing = append(missing, col.Name)
+			continue
+		}
+
+		if col.Type != "" && !typesMatch(col.Type, live, mapper) {
+			mismatched = append(mismatched, fmt.Sprintf("%s: declared as '%s', the table has '%s'", col.Name, col.Type, live.Type))
+		}
+	}
+
+	extra := make([]string, 0)
+	for _, col := range table.Columns {
+		if !declared[strings.ToLower(col.Name)] {
+			extra = append(extra, col.Name)
+		}
+	}
+	sort.Strings(extra)
+
+	issues := make([]*Issue, 0)
+	if len(missing) > 0 {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Field:       "columns",
+			Description: "Some of the declared columns do not exist in the table in the warehouse",
+			Context:     missing,
+		})
+	}
+	if len(extra) > 0 {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Field:       "columns",
+			Description: "The table in the warehouse has columns that are not declared in the asset",
+			Context:     extra,
+		})
+	}
+	if len(mismatched) > 0 {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Field:       "columns",
+			Description: "The types of some of the declared columns do not match the table in the warehouse",
+			Context:     mismatched,
+		})
+	}
+
+	return issues
+}
+
+// typesMatch compares the types through their normalized categories, e.g. `int64` and `integer` are both numeric, and
+// falls back to comparing the base types if the mapper does not know either of them.
+func typesMatch(declaredType string, live *diff.Column, mapper *diff.DatabaseTypeMapper) bool {
+	declaredCategory := mapper.MapType(declaredType)
+	liveCategory := mapper.MapType(live.Type)
+	if declaredCategory != diff.CommonTypeUnknown && liveCategory != diff.CommonTypeUnknown {
+		return declaredCategory == liveCategory
+	}
+
+	return baseType(declaredType) == baseType(live.Type)
+}
+
+func baseType(columnType string) string {
+	columnType = strings.ToLower(strings.TrimSpace(columnType))
+	if i := strings.IndexAny(columnType, "(<"); i != -1 {
+		columnType = columnType[:i]
+	}
+
+	return strings.TrimSpace(columnType)
+}
@@ -0,0 +1,151 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/diff"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockSchemaFetcher struct {
+	mock.Mock
+}
+
+func (m *mockSchemaFetcher) GetTableSchema(ctx context.Context, tableName string) (*diff.Table, error) {
+	res := m.Called(ctx, tableName)
+	return res.Get(0).(*diff.Table), res.Error(1)
+}
+
+func TestSchemaDriftRule_ValidateAsset(t *testing.T) {
+	t.Parallel()
+
+	liveTable := &diff.Table{
+		Name: "analytics.users",
+		Columns: []*diff.Column{
+			{Name: "id", Type: "BIGINT"},
+			{Name: "name", Type: "VARCHAR"},
+			{Name: "created_at", Type: "TIMESTAMP"},
+			{Name: "payload", Type: "STRUCT(a INTEGER)"},
+			{Name: "country", Type: "VARCHAR"},
+		},
+	}
+
+	newAsset := func(columns ...pipeline.Column) *pipeline.Asset {
+		return &pipeline.Asset{
+			Name:            "analytics.users",
+			Type:            pipeline.AssetTypeDuckDBQuery,
+			Connection:      "duckdb-default",
+			Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			Columns:         columns,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		asset        *pipeline.Asset
+		table        *diff.Table
+		fetchErr     error
+		skipFetch    bool
+		wantIssues   int
+		wantContexts [][]string
+	}{
+		{
+			name: "columns in sync",
+			asset: newAsset(
+				pipeline.Column{Name: "id", Type: "integer"},
+				pipeline.Column{Name: "name", Type: "string"},
+				pipeline.Column{Name: "created_at", Type: "timestamp"},
+				pipeline.Column{Name: "payload", Type: "struct"},
+				pipeline.Column{Name: "country"},
+			),
+			table: liveTable,
+		},
+		{
+			name: "missing, extra and mismatched columns",
+			asset: newAsset(
+				pipeline.Column{Name: "ID", Type: "varchar"},
+				pipeline.Column{Name: "name", Type: "varchar"},
+				pipeline.Column{Name: "created_at", Type: "date"},
+				pipeline.Column{Name: "payload", Type: "map(varchar, integer)"},
+				pipeline.Column{Name: "deleted_at", Type: "timestamp"},
+			),
+			table:      liveTable,
+			wantIssues: 3,
+			wantContexts: [][]string{
+				{"deleted_at"},
+				{"country"},
+				{
+					"ID: declared as 'varchar', the table has 'BIGINT'",
+					"payload: declared as 'map(varchar, integer)', the table has 'STRUCT(a INTEGER)'",
+				},
+			},
+		},
+		{
+			name:  "table does not exist yet",
+			asset: newAsset(pipeline.Column{Name: "id", Type: "integer"}),
+			table: &diff.Table{Name: "analytics.users"},
+		},
+		{
+			name:     "failing to fetch the schema is not reported",
+			asset:    newAsset(pipeline.Column{Name: "id", Type: "integer"}),
+			table:    &diff.Table{},
+			fetchErr: errors.New("connection refused"),
+		},
+		{
+			name:      "assets without columns are skipped",
+			asset:     newAsset(),
+			skipFetch: true,
+		},
+		{
+			name: "views are compared as well, queries without materialization are skipped",
+			asset: &pipeline.Asset{
+				Name:       "analytics.users",
+				Type:       pipeline.AssetTypeDuckDBQuery,
+				Connection: "duckdb-default",
+				Columns:    []pipeline.Column{{Name: "id"}},
+			},
+			skipFetch: true,
+		},
+		{
+			name: "platforms without a type mapper are skipped",
+			asset: &pipeline.Asset{
+				Name:            "analytics.users",
+				Type:            pipeline.AssetTypePostgresQuery,
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Columns:         []pipeline.Column{{Name: "id"}},
+			},
+			skipFetch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fetcher := new(mockSchemaFetcher)
+			connections := new(mockConnectionManager)
+			if !tt.skipFetch {
+				connections.On("GetConnection", "duckdb-default").Return(fetcher, nil)
+				fetcher.On("GetTableSchema", mock.Anything, "analytics.users").Return(tt.table, tt.fetchErr)
+			}
+
+			rule := &SchemaDriftRule{Connections: connections, Logger: zap.NewNop().Sugar()}
+			issues, err := rule.ValidateAsset(context.Background(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+			require.Len(t, issues, tt.wantIssues)
+			for i, issue := range issues {
+				assert.Equal(t, "columns", issue.Field)
+				assert.Equal(t, tt.wantContexts[i], issue.Context)
+			}
+
+			connections.AssertExpectations(t)
+			fetcher.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package lint
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+)
+
+type queryAnalyzer interface {
+	AnalyzeQuery(sql, dialect string) (*sqlparser.QueryAnalysis, error)
+}
+
+// queryChecker reports the issues of a single asset based on the analysis of its query.
+type queryChecker func(p *pipeline.Pipeline, asset *pipeline.Asset, analysis *sqlparser.QueryAnalysis) []*Issue
+
+// sqlAssetAnalyzer renders and analyzes the queries of the SQL assets, the analysis is shared between the rules so
+// that every query is parsed only once.
+type sqlAssetAnalyzer struct {
+	analyzer queryAnalyzer
+	renderer jinja.RendererInterface
+
+	mutex    sync.Mutex
+	analyses map[*pipeline.Asset]*sqlparser.QueryAnalysis
+}
+
+func (s *sqlAssetAnalyzer) analyze(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) *sqlparser.QueryAnalysis {
+	if s.analyzer == nil || strings.ToLower(filepath.Ext(asset.ExecutableFile.Path)) != ".sql" {
+		return nil
+	}
+
+	dialect, err := sqlparser.AssetTypeToDialect(asset.Type)
+	if err != nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if analysis, ok := s.analyses[asset]; ok {
+		return analysis
+	}
+
+	// the queries that cannot be rendered or parsed are not analyzed, the other rules already report them
+	var analysis *sqlparser.QueryAnalysis
+	rendered, err := s.renderer.CloneForAsset(ctx, p, asset).Render(asset.ExecutableFile.Content)
+	if err == nil {
+		analysis, err = s.analyzer.AnalyzeQuery(rendered, dialect)
+		if err != nil {
+			analysis = nil
+		}
+	}
+
+	s.analyses[asset] = analysis
+	return analysis
+}
+
+func (s *sqlAssetAnalyzer) validator(check queryChecker) validators {
+	return validators{
+		Asset: func(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+			analysis := s.analyze(ctx, p, asset)
+			if analysis == nil {
+				return nil, nil
+			}
+
+			return check(p, asset, analysis), nil
+		},
+	}
+}
+
+func newSQLAssetAnalyzer(analyzer queryAnalyzer) *sqlAssetAnalyzer {
+	return &sqlAssetAnalyzer{
+		analyzer: analyzer,
+		renderer: jinja.NewRendererWithYesterday("your-pipeline", "some-run-id"),
+		analyses: make(map[*pipeline.Asset]*sqlparser.QueryAnalysis),
+	}
+}
+
+// sqlPolicyRules returns the opt-in policy rules that analyze the queries of the SQL assets. The rules are always
+// available by name, they simply do not report anything if there is no analyzer to parse the queries.
+func sqlPolicyRules(analyzer queryAnalyzer) map[string]validators {
+	return newSQLAssetAnalyzer(analyzer).policyRules()
+}
+
+func (s *sqlAssetAnalyzer) policyRules() map[string]validators {
+	return map[string]validators{
+		"query-has-no-select-star":            s.validator(checkSelectStar),
+		"query-has-no-cartesian-join":         s.validator(checkCartesianJoins),
+		"incremental-query-is-deterministic":  s.validator(checkNondeterministicFunctions),
+		"query-has-no-order-by-without-limit": s.validator(checkOrderByWithoutLimit),
+		"query-tables-are-declared":           s.validator(checkUndeclaredTables),
+	}
+}
+
+func checkSelectStar(p *pipeline.Pipeline, asset *pipeline.Asset, analysis *sqlparser.QueryAnalysis) []*Issue {
+	if !analysis.SelectStar {
+		return nil
+	}
+
+	return []*Issue{
+		{
+			Task:        asset,
+			Description: "The final projection of the query uses `SELECT *`, list the columns explicitly so that the upstream schema changes do not leak into the asset",
+		},
+	}
+}
+
+func checkCartesianJoins(p *pipeline.Pipeline, asset *pipeline.Asset, analysis *sqlparser.QueryAnalysis) []*Issue {
+	if len(analysis.CartesianJoins) == 0 {
+		return nil
+	}
+
+	return []*Issue{
+		{
+			Task:        asset,
+			Description: "The query joins some tables without any join condition, which results in a cartesian product, use `CROSS JOIN` if this is intended",
+			Context:     analysis.CartesianJoins,
+		},
+	}
+}
+
+func checkNondeterministicFunctions(p *pipeline.Pipeline, asset *pipeline.Asset, analysis *sqlparser.QueryAnalysis) []*Issue {
+	if len(analysis.NondeterministicFunctions) == 0 || !isIncrementalAsset(asset) {
+		return nil
+	}
+
+	return []*Issue{
+		{
+			Task:        asset,
+			Field:       "materialization.strategy",
+			Description: "Incremental assets should not use non-deterministic functions, re-running the asset for the same interval would produce different results",
+			Context:     analysis.NondeterministicFunctions,
+		},
+	}
+}
+
+func checkOrderByWithoutLimit(p *pipeline.Pipeline, asset *pipeline.Asset, analysis *sqlparser.QueryAnalysis) []*Issue {
+	if !analysis.OrderByWithoutLimit || asset.Materialization.Type != pipeline.MaterializationTypeTable {
+		return nil
+	}
+
+	return []*Issue{
+		{
+			Task:        asset,
+			Field:       "materialization.type",
+			Description: "The query uses `ORDER BY` without `LIMIT`, the order of the rows is not preserved in a materialized table, it only makes the query more expensive",
+		},
+	}
+}
+
+func checkUndeclaredTables(p *pipeline.Pipeline, asset *pipeline.Asset, analysis *sqlparser.QueryAnalysis) []*Issue {
+	known := []string{strings.ToLower(asset.Name)}
+	for _, a := range p.Assets {
+		known = append(known, strings.ToLower(a.Name))
+	}
+	for _, upstream := range asset.Upstreams {
+		value := strings.ToLower(upstream.Value)
+		if _, table, found := strings.Cut(value, "://"); found {
+			value = table
+		}
+		known = append(known, value)
+	}
+
+	undeclared := make([]string, 0)
+	for _, table := range analysis.Tables {
+		if !isKnownTable(strings.ToLower(table), known) {
+			undeclared = append(undeclared, table)
+		}
+	}
+
+	if len(undeclared) == 0 {
+		return nil
+	}
+
+	return []*Issue{
+		{
+			Task:        asset,
+			Field:       "depends",
+			Description: "The query refers to some tables that are neither assets in the pipeline nor declared in the 'depends' list",
+			Context:     undeclared,
+		},
+	}
+}
+
+// isKnownTable checks if the table is one of the known names, the table may be referred with a more qualified name,
+// e.g. `project.dataset.table` for an asset named `dataset.table`.
+func isKnownTable(table string, known []string) bool {
+	for _, name := range known {
+		if name == "" {
+			continue
+		}
+		if table == name || strings.HasSuffix(table, "."+name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isIncrementalAsset(asset *pipeline.Asset) bool {
+	if asset.Materialization.Type != pipeline.MaterializationTypeTable {
+		return false
+	}
+
+	switch asset.Materialization.Strategy {
+	case pipeline.MaterializationStrategyNone, pipeline.MaterializationStrategyCreateReplace, pipeline.MaterializationStrategyDDL:
+		return false
+	default:
+		return true
+	}
+}
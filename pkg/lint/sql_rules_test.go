@@ -0,0 +1,173 @@
+package lint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type mockQueryAnalyzer struct {
+	mock.Mock
+}
+
+func (m *mockQueryAnalyzer) AnalyzeQuery(sql, dialect string) (*sqlparser.QueryAnalysis, error) {
+	args := m.Called(sql, dialect)
+	return args.Get(0).(*sqlparser.QueryAnalysis), args.Error(1)
+}
+
+func TestSQLPolicyRules(t *testing.T) {
+	t.Parallel()
+
+	analysis := &sqlparser.QueryAnalysis{
+		SelectStar:                true,
+		CartesianJoins:            []string{"raw.orders"},
+		NondeterministicFunctions: []string{"current_timestamp"},
+		OrderByWithoutLimit:       true,
+		Tables:                    []string{"raw.items", "project.raw.orders", "raw.customers", "external.events", "mart.items"},
+	}
+
+	newAsset := func(materialization pipeline.Materialization) *pipeline.Asset {
+		return &pipeline.Asset{
+			Name:            "mart.items",
+			Type:            pipeline.AssetTypeBigqueryQuery,
+			ExecutableFile:  pipeline.ExecutableFile{Path: "/pipeline/assets/items.sql", Content: "select * from raw.items"},
+			Materialization: materialization,
+			Upstreams: []pipeline.Upstream{
+				{Type: "asset", Value: "raw.items"},
+				{Type: "uri", Value: "bigquery://raw.customers"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		rule            string
+		materialization pipeline.Materialization
+		wantContext     []string
+		wantNoIssues    bool
+	}{
+		{
+			name: "select star",
+			rule: "query-has-no-select-star",
+		},
+		{
+			name:        "cartesian join",
+			rule:        "query-has-no-cartesian-join",
+			wantContext: []string{"raw.orders"},
+		},
+		{
+			name:            "non-deterministic functions in an incremental asset",
+			rule:            "incremental-query-is-deterministic",
+			materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable, Strategy: pipeline.MaterializationStrategyAppend},
+			wantContext:     []string{"current_timestamp"},
+		},
+		{
+			name:            "non-deterministic functions in a table that is recreated",
+			rule:            "incremental-query-is-deterministic",
+			materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable, Strategy: pipeline.MaterializationStrategyCreateReplace},
+			wantNoIssues:    true,
+		},
+		{
+			name:            "order by without limit in a table",
+			rule:            "query-has-no-order-by-without-limit",
+			materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		},
+		{
+			name:            "order by without limit in a view",
+			rule:            "query-has-no-order-by-without-limit",
+			materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			wantNoIssues:    true,
+		},
+		{
+			name:        "undeclared tables",
+			rule:        "query-tables-are-declared",
+			wantContext: []string{"external.events"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			analyzer := new(mockQueryAnalyzer)
+			analyzer.On("AnalyzeQuery", "select * from raw.items", "bigquery").Return(analysis, nil).Once()
+
+			asset := newAsset(tt.materialization)
+			p := &pipeline.Pipeline{Assets: []*pipeline.Asset{asset, {Name: "raw.orders"}}}
+
+			rules := sqlPolicyRules(analyzer)
+			require.Contains(t, rules, tt.rule)
+
+			issues, err := rules[tt.rule].Asset(context.Background(), p, asset)
+			require.NoError(t, err)
+			if tt.wantNoIssues {
+				assert.Empty(t, issues)
+				return
+			}
+
+			require.Len(t, issues, 1)
+			assert.Equal(t, asset, issues[0].Task)
+			assert.Equal(t, tt.wantContext, issues[0].Context)
+			analyzer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSQLPolicyRules_SkipsNonSQLAssets(t *testing.T) {
+	t.Parallel()
+
+	analyzer := new(mockQueryAnalyzer)
+	rules := sqlPolicyRules(analyzer)
+
+	pythonAsset := &pipeline.Asset{Type: pipeline.AssetTypePython, ExecutableFile: pipeline.ExecutableFile{Path: "/assets/a.py"}}
+	issues, err := rules["query-has-no-select-star"].Asset(context.Background(), &pipeline.Pipeline{}, pythonAsset)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	// without an analyzer the rules do not report anything
+	sqlAsset := &pipeline.Asset{Type: pipeline.AssetTypeBigqueryQuery, ExecutableFile: pipeline.ExecutableFile{Path: "/assets/a.sql"}}
+	issues, err = sqlPolicyRules(nil)["query-has-no-select-star"].Asset(context.Background(), &pipeline.Pipeline{}, sqlAsset)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	analyzer.AssertNotCalled(t, "AnalyzeQuery", mock.Anything, mock.Anything)
+}
+
+func TestPolicySpecification_RuleSeverities(t *testing.T) {
+	t.Parallel()
+
+	policy := `
+rulesets:
+  - name: sql
+    rules:
+      - query-has-no-select-star
+      - name: query-has-no-cartesian-join
+        severity: warning
+`
+
+	var spec PolicySpecification
+	require.NoError(t, yaml.Unmarshal([]byte(policy), &spec))
+	assert.Equal(t, []string{"query-has-no-select-star", "query-has-no-cartesian-join"}, spec.RuleSets[0].Rules)
+
+	rules, err := spec.Rules()
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "policy:sql:query-has-no-select-star", rules[0].Name())
+	assert.Equal(t, ValidatorSeverityCritical, rules[0].GetSeverity())
+	assert.Equal(t, ValidatorSeverityWarning, rules[1].GetSeverity())
+
+	invalid := `
+rulesets:
+  - name: sql
+    rules:
+      - name: query-has-no-select-star
+        severity: fatal
+`
+	require.Error(t, yaml.Unmarshal([]byte(invalid), &spec))
+}
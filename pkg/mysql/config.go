@@ -58,8 +58,9 @@ func (c Config) ToDBConnectionURI() string {
 
 	hostPort := fmt.Sprintf("%s:%d", c.Host, c.Port)
 
+	// multiple statements are required to run the materialized queries in a single call
 	return fmt.Sprintf(
-		"%s:%s@tcp(%s)/%s",
+		"%s:%s@tcp(%s)/%s?multiStatements=true",
 		c.Username,
 		c.Password,
 		hostPort,
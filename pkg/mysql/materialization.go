@@ -0,0 +1,206 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+var matMap = pipeline.AssetMaterializationMap{
+	pipeline.MaterializationTypeView: {
+		pipeline.MaterializationStrategyNone:          viewMaterializer,
+		pipeline.MaterializationStrategyAppend:        errorMaterializer,
+		pipeline.MaterializationStrategyCreateReplace: errorMaterializer,
+		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
+	},
+	pipeline.MaterializationTypeTable: {
+		pipeline.MaterializationStrategyNone:          buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:        buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace: buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
+		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
+		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
+	},
+}
+
+func NewMaterializer(fullRefresh bool) *pipeline.Materializer {
+	return &pipeline.Materializer{
+		MaterializationMap: matMap,
+		FullRefresh:        fullRefresh,
+	}
+}
+
+func errorMaterializer(asset *pipeline.Asset, query string) (string, error) {
+	return "", fmt.Errorf("materialization strategy %s is not supported for materialization type %s and asset type %s", asset.Materialization.Strategy, asset.Materialization.Type, asset.Type)
+}
+
+func viewMaterializer(asset *pipeline.Asset, query string) (string, error) {
+	return fmt.Sprintf("CREATE OR REPLACE VIEW %s AS\n%s", asset.Name, strings.TrimSuffix(query, ";")), nil
+}
+
+func buildCreateReplaceQuery(asset *pipeline.Asset, query string) (string, error) {
+	if len(asset.Materialization.ClusterBy) > 0 {
+		return "", errors.New("MySQL assets do not support `cluster_by`")
+	}
+
+	query = strings.TrimSuffix(query, ";")
+	if !asset.HasColumnConstraints() && len(asset.ColumnNamesWithPrimaryKey()) == 0 {
+		// MySQL commits DDL statements implicitly, therefore there is no point in wrapping them in a transaction
+		return fmt.Sprintf("DROP TABLE IF EXISTS %s;\nCREATE TABLE %s AS\n%s;", asset.Name, asset.Name, query), nil
+	}
+
+	ddl, err := buildDDLQuery(asset, query)
+	if err != nil {
+		return "", err
+	}
+
+	columns := strings.Join(ansisql.ColumnNames(asset), ", ")
+	queries := []string{
+		"DROP TABLE IF EXISTS " + asset.Name,
+		ddl,
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM (%s) AS __bruin_source", asset.Name, columns, columns, query),
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+func buildAppendQuery(asset *pipeline.Asset, query string) (string, error) {
+	return fmt.Sprintf("INSERT INTO %s %s", asset.Name, query), nil
+}
+
+func buildIncrementalQuery(asset *pipeline.Asset, query string) (string, error) {
+	mat := asset.Materialization
+	strategy := pipeline.MaterializationStrategyDeleteInsert
+
+	if mat.IncrementalKey == "" {
+		return "", fmt.Errorf("materialization strategy %s requires the `incremental_key` field to be set", strategy)
+	}
+
+	tempTableName := "__bruin_tmp_" + helpers.PrefixGenerator()
+
+	queries := []string{
+		"START TRANSACTION",
+		fmt.Sprintf("CREATE TEMPORARY TABLE %s AS %s", tempTableName, strings.TrimSuffix(query, ";")),
+		fmt.Sprintf("DELETE FROM %s WHERE %s IN (SELECT DISTINCT %s FROM %s)", asset.Name, mat.IncrementalKey, mat.IncrementalKey, tempTableName),
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", asset.Name, tempTableName),
+		"DROP TEMPORARY TABLE IF EXISTS " + tempTableName,
+		"COMMIT",
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+// buildMergeQuery relies on the primary key of the target table to detect the existing rows, since MySQL does not
+// support `MERGE` statements.
+func buildMergeQuery(asset *pipeline.Asset, query string) (string, error) {
+	if len(asset.Columns) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `columns` field to be set", asset.Materialization.Strategy)
+	}
+
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `primary_key` field to be set on at least one column", asset.Materialization.Strategy)
+	}
+
+	updateColumns := asset.ColumnNamesWithUpdateOnMerge()
+	if len(updateColumns) == 0 {
+		// MySQL requires at least one assignment, updating a primary key with itself is a no-op
+		updateColumns = primaryKeys[:1]
+	}
+
+	updateStatements := make([]string, 0, len(updateColumns))
+	for _, col := range updateColumns {
+		updateStatements = append(updateStatements, fmt.Sprintf("%s = source.%s", col, col))
+	}
+
+	columns := strings.Join(asset.ColumnNames(), ", ")
+	mergeLines := []string{
+		fmt.Sprintf("INSERT INTO %s (%s)", asset.Name, columns),
+		fmt.Sprintf("SELECT %s FROM (%s) AS source", columns, strings.TrimSuffix(query, ";")),
+		"ON DUPLICATE KEY UPDATE " + strings.Join(updateStatements, ", "),
+	}
+
+	return strings.Join(mergeLines, "\n") + ";", nil
+}
+
+func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error) {
+	if asset.Materialization.IncrementalKey == "" {
+		return "", errors.New("incremental_key is required for time_interval strategy")
+	}
+
+	if asset.Materialization.TimeGranularity == "" {
+		return "", errors.New("time_granularity is required for time_interval strategy")
+	}
+
+	if !(asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityTimestamp || asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate) {
+		return "", errors.New("time_granularity must be either 'date', or 'timestamp'")
+	}
+
+	startVar := "{{start_timestamp}}"
+	endVar := "{{end_timestamp}}"
+	if asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate {
+		startVar = "{{start_date}}"
+		endVar = "{{end_date}}"
+	}
+
+	queries := []string{
+		"START TRANSACTION",
+		fmt.Sprintf(`DELETE FROM %s WHERE %s BETWEEN '%s' AND '%s'`,
+			asset.Name,
+			asset.Materialization.IncrementalKey,
+			startVar,
+			endVar),
+		fmt.Sprintf(`INSERT INTO %s %s`,
+			asset.Name,
+			strings.TrimSuffix(query, ";")),
+		"COMMIT",
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
+	columnDefs := make([]string, 0, len(asset.Columns))
+	primaryKeys := []string{}
+	foreignKeys := []string{}
+
+	for _, col := range asset.Columns {
+		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if !col.Nullable.Bool() {
+			def += " NOT NULL"
+		}
+		if col.Default != "" {
+			def += " DEFAULT " + col.Default
+		}
+		if col.CheckConstraint != "" {
+			def += fmt.Sprintf(" CHECK (%s)", col.CheckConstraint)
+		}
+		if col.Description != "" {
+			def += fmt.Sprintf(" COMMENT '%s'", strings.ReplaceAll(col.Description, "'", "''"))
+		}
+
+		if col.PrimaryKey {
+			primaryKeys = append(primaryKeys, col.Name)
+		}
+		if col.ForeignKey != nil {
+			foreignKeys = append(foreignKeys, fmt.Sprintf(
+				"CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+				ansisql.ConstraintName("fk", asset.Name, col.Name), col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
+			))
+		}
+		columnDefs = append(columnDefs, def)
+	}
+
+	if len(primaryKeys) > 0 {
+		columnDefs = append(columnDefs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+	columnDefs = append(columnDefs, foreignKeys...)
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n%s\n)", asset.Name, strings.Join(columnDefs, ",\n")), nil
+}
@@ -0,0 +1,219 @@
+package mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializer_Render(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		task        *pipeline.Asset
+		query       string
+		want        string
+		wantErr     bool
+		fullRefresh bool
+	}{
+		{
+			name:  "no materialization, return raw query",
+			task:  &pipeline.Asset{},
+			query: "SELECT 1",
+			want:  "SELECT 1",
+		},
+		{
+			name: "materialize to a view",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			query: "SELECT 1;",
+			want:  "CREATE OR REPLACE VIEW my.asset AS\nSELECT 1",
+		},
+		{
+			name: "materialize to a table, default to create+replace",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			},
+			query: "SELECT 1",
+			want:  "DROP TABLE IF EXISTS my.asset;\nCREATE TABLE my.asset AS\nSELECT 1;",
+		},
+		{
+			name: "full refresh defaults to create+replace",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+			},
+			fullRefresh: true,
+			query:       "SELECT 1",
+			want:        "DROP TABLE IF EXISTS my.asset;\nCREATE TABLE my.asset AS\nSELECT 1;",
+		},
+		{
+			name: "create+replace with constraints creates the table first",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT", PrimaryKey: true, Description: "the user's id"},
+					{Name: "status", Type: "VARCHAR(10)", Default: "'new'"},
+				},
+			},
+			query: "SELECT 1 AS id, 'old' AS status",
+			want: "DROP TABLE IF EXISTS my.asset;\n" +
+				"CREATE TABLE IF NOT EXISTS my.asset (\n" +
+				"id INT COMMENT 'the user''s id',\n" +
+				"status VARCHAR(10) DEFAULT 'new',\n" +
+				"PRIMARY KEY (id)\n" +
+				");\n" +
+				"INSERT INTO my.asset (id, status) SELECT id, status FROM (SELECT 1 AS id, 'old' AS status) AS __bruin_source;",
+		},
+		{
+			name: "create+replace keeps the primary key",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT", PrimaryKey: true},
+					{Name: "status", Type: "VARCHAR(10)"},
+				},
+			},
+			query: "SELECT 1 AS id, 'old' AS status",
+			want: "DROP TABLE IF EXISTS my.asset;\n" +
+				"CREATE TABLE IF NOT EXISTS my.asset (\n" +
+				"id INT,\n" +
+				"status VARCHAR(10),\n" +
+				"PRIMARY KEY (id)\n" +
+				");\n" +
+				"INSERT INTO my.asset (id, status) SELECT id, status FROM (SELECT 1 AS id, 'old' AS status) AS __bruin_source;",
+		},
+		{
+			name: "append",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			query: "SELECT 1",
+			want:  "INSERT INTO my.asset SELECT 1",
+		},
+		{
+			name: "merge updates the marked columns on duplicate keys",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+					{Name: "name", UpdateOnMerge: true},
+					{Name: "created_at"},
+				},
+			},
+			query: "SELECT id, name, created_at FROM source",
+			want: "INSERT INTO my.asset (id, name, created_at)\n" +
+				"SELECT id, name, created_at FROM (SELECT id, name, created_at FROM source) AS source\n" +
+				"ON DUPLICATE KEY UPDATE name = source.name;",
+		},
+		{
+			name: "merge without update columns keeps the existing rows",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+					{Name: "name"},
+				},
+			},
+			query: "SELECT id, name FROM source",
+			want: "INSERT INTO my.asset (id, name)\n" +
+				"SELECT id, name FROM (SELECT id, name FROM source) AS source\n" +
+				"ON DUPLICATE KEY UPDATE id = source.id;",
+		},
+		{
+			name: "merge requires a primary key",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{{Name: "id"}},
+			},
+			query:   "SELECT id FROM source",
+			wantErr: true,
+		},
+		{
+			name: "time_interval with date granularity",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyTimeInterval,
+					TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+					IncrementalKey:  "dt",
+				},
+			},
+			query: "SELECT dt FROM source;",
+			want: "START TRANSACTION;\n" +
+				"DELETE FROM my.asset WHERE dt BETWEEN '{{start_date}}' AND '{{end_date}}';\n" +
+				"INSERT INTO my.asset SELECT dt FROM source;\n" +
+				"COMMIT;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewMaterializer(tt.fullRefresh)
+			render, err := m.Render(tt.task, tt.query)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, render)
+		})
+	}
+}
+
+func TestMaterializer_Render_DeleteInsert(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "my.asset",
+		Materialization: pipeline.Materialization{
+			Type:           pipeline.MaterializationTypeTable,
+			Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+			IncrementalKey: "dt",
+		},
+	}
+
+	render, err := NewMaterializer(false).Render(asset, "SELECT dt FROM source")
+	require.NoError(t, err)
+
+	lines := strings.Split(render, "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, "START TRANSACTION;", lines[0])
+	assert.Regexp(t, "^CREATE TEMPORARY TABLE __bruin_tmp_[a-z0-9_]+ AS SELECT dt FROM source;$", lines[1])
+	assert.Regexp(t, "^DELETE FROM my.asset WHERE dt IN \\(SELECT DISTINCT dt FROM __bruin_tmp_[a-z0-9_]+\\);$", lines[2])
+	assert.Regexp(t, "^INSERT INTO my.asset SELECT \\* FROM __bruin_tmp_[a-z0-9_]+;$", lines[3])
+	assert.Regexp(t, "^DROP TEMPORARY TABLE IF EXISTS __bruin_tmp_[a-z0-9_]+;$", lines[4])
+	assert.Equal(t, "COMMIT;", lines[5])
+}
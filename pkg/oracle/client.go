@@ -1,13 +1,137 @@
 package oracle
 
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	_ "github.com/sijms/go-ora/v2"
+)
+
+type Querier interface {
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+}
+
+type Selector interface {
+	Select(ctx context.Context, query *query.Query) ([][]interface{}, error)
+	SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error)
+}
+
+type DB interface {
+	Querier
+	Selector
+}
+
 type Client struct {
+	conn   *sqlx.DB
 	config Config
 }
 
+func NewClient(c Config) (*Client, error) {
+	conn, err := sqlx.Open("oracle", c.ToDBConnectionURI())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		conn:   conn,
+		config: c,
+	}, nil
+}
+
 func (c *Client) GetIngestrURI() (string, error) {
 	return c.config.GetIngestrURI(), nil
 }
 
-func NewClient(c Config) (*Client, error) {
-	return &Client{c}, nil
+// prepareQuery removes the trailing semicolon from plain SQL statements since Oracle rejects them, while PL/SQL blocks
+// must keep theirs.
+func prepareQuery(q string) string {
+	q = strings.TrimSpace(q)
+	upper := strings.ToUpper(q)
+	if strings.HasPrefix(upper, "BEGIN") || strings.HasPrefix(upper, "DECLARE") {
+		return q
+	}
+
+	return strings.TrimSpace(strings.TrimSuffix(q, ";"))
+}
+
+func (c *Client) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
+	_, err := c.conn.ExecContext(ctx, prepareQuery(query.String()))
+	if err != nil {
+		return errors.Wrap(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (c *Client) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
+	result, err := c.SelectWithSchema(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Rows, nil
+}
+
+func (c *Client) SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error) {
+	rows, err := c.conn.QueryContext(ctx, prepareQuery(queryObj.String()))
+	if err != nil {
+		return nil, errors.New(strings.ReplaceAll(err.Error(), "\n", "  -  "))
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve column names")
+	}
+
+	result := &query.QueryResult{
+		Columns: cols,
+		Rows:    [][]interface{}{},
+	}
+
+	for rows.Next() {
+		row := make([]interface{}, len(cols))
+		columnPointers := make([]interface{}, len(cols))
+		for i := range row {
+			columnPointers[i] = &row[i]
+		}
+
+		if err := rows.Scan(columnPointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// convert []byte -> string
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+// Limit wraps the query with the row limiting clause of Oracle, which does not support `LIMIT`.
+func (c *Client) Limit(query string, limit int64) string {
+	query = strings.TrimRight(query, "; \n\t")
+	return fmt.Sprintf("SELECT * FROM (\n%s\n) FETCH FIRST %d ROWS ONLY", query, limit)
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	err := c.RunQueryWithoutResult(ctx, &query.Query{Query: "SELECT 1 FROM DUAL"})
+	if err != nil {
+		return errors.Wrap(err, "failed to run test query on Oracle connection")
+	}
+
+	return nil
 }
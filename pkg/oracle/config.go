@@ -3,6 +3,9 @@ package oracle
 import (
 	"fmt"
 	"net/url"
+	"strconv"
+
+	goora "github.com/sijms/go-ora/v2"
 )
 
 type Config struct {
@@ -23,3 +26,12 @@ func (c *Config) GetIngestrURI() string {
 	}
 	return url.String()
 }
+
+func (c *Config) ToDBConnectionURI() string {
+	port, err := strconv.Atoi(c.Port)
+	if err != nil || port == 0 {
+		port = 1521
+	}
+
+	return goora.BuildUrl(c.Host, port, c.DBName, c.Username, c.Password, nil)
+}
@@ -0,0 +1,247 @@
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// Oracle can only run a single statement per call, therefore the strategies that require multiple statements are
+// rendered as anonymous PL/SQL blocks.
+var matMap = pipeline.AssetMaterializationMap{
+	pipeline.MaterializationTypeView: {
+		pipeline.MaterializationStrategyNone:          viewMaterializer,
+		pipeline.MaterializationStrategyAppend:        errorMaterializer,
+		pipeline.MaterializationStrategyCreateReplace: errorMaterializer,
+		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
+	},
+	pipeline.MaterializationTypeTable: {
+		pipeline.MaterializationStrategyNone:          buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:        buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace: buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
+		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
+		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
+	},
+}
+
+func NewMaterializer(fullRefresh bool) *pipeline.Materializer {
+	return &pipeline.Materializer{
+		MaterializationMap: matMap,
+		FullRefresh:        fullRefresh,
+	}
+}
+
+func errorMaterializer(asset *pipeline.Asset, query string) (string, error) {
+	return "", fmt.Errorf("materialization strategy %s is not supported for materialization type %s and asset type %s", asset.Materialization.Strategy, asset.Materialization.Type, asset.Type)
+}
+
+func cleanQuery(query string) string {
+	return strings.TrimSuffix(strings.TrimSpace(query), ";")
+}
+
+func escape(query string) string {
+	return strings.ReplaceAll(query, "'", "''")
+}
+
+func viewMaterializer(asset *pipeline.Asset, query string) (string, error) {
+	return fmt.Sprintf("CREATE OR REPLACE VIEW %s AS\n%s", asset.Name, cleanQuery(query)), nil
+}
+
+func buildCreateReplaceQuery(asset *pipeline.Asset, query string) (string, error) {
+	if len(asset.Materialization.ClusterBy) > 0 {
+		return "", errors.New("Oracle assets do not support `cluster_by`")
+	}
+
+	if asset.Materialization.PartitionBy != "" {
+		return "", errors.New("Oracle assets do not support `partition_by`")
+	}
+
+	query = cleanQuery(query)
+
+	// ORA-00942 means the table does not exist, which is fine for the first run
+	lines := []string{
+		"BEGIN",
+		"  BEGIN",
+		fmt.Sprintf("    EXECUTE IMMEDIATE 'DROP TABLE %s PURGE';", asset.Name),
+		"  EXCEPTION",
+		"    WHEN OTHERS THEN",
+		"      IF SQLCODE != -942 THEN",
+		"        RAISE;",
+		"      END IF;",
+		"  END;",
+	}
+
+	if asset.HasColumnConstraints() || len(asset.ColumnNamesWithPrimaryKey()) > 0 {
+		ddl := buildCreateTableStatement(asset)
+
+		// the table does not exist when the block is compiled, therefore the insert has to be dynamic as well
+		columns := strings.Join(ansisql.ColumnNames(asset), ", ")
+		insert := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM (%s) __bruin_source", asset.Name, columns, columns, query)
+		lines = append(lines,
+			fmt.Sprintf("  EXECUTE IMMEDIATE '%s';", escape(ddl)),
+			fmt.Sprintf("  EXECUTE IMMEDIATE '%s';", escape(insert)),
+			"  COMMIT;",
+		)
+	} else {
+		lines = append(lines, fmt.Sprintf("  EXECUTE IMMEDIATE '%s';", escape(fmt.Sprintf("CREATE TABLE %s AS %s", asset.Name, query))))
+	}
+
+	lines = append(lines, "END;")
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func buildAppendQuery(asset *pipeline.Asset, query string) (string, error) {
+	return fmt.Sprintf("INSERT INTO %s %s", asset.Name, cleanQuery(query)), nil
+}
+
+func buildIncrementalQuery(asset *pipeline.Asset, query string) (string, error) {
+	mat := asset.Materialization
+	strategy := pipeline.MaterializationStrategyDeleteInsert
+
+	if mat.IncrementalKey == "" {
+		return "", fmt.Errorf("materialization strategy %s requires the `incremental_key` field to be set", strategy)
+	}
+
+	query = cleanQuery(query)
+	lines := []string{
+		"BEGIN",
+		fmt.Sprintf("  DELETE FROM %s WHERE %s IN (SELECT DISTINCT %s FROM (%s) __bruin_source);", asset.Name, mat.IncrementalKey, mat.IncrementalKey, query),
+		fmt.Sprintf("  INSERT INTO %s %s;", asset.Name, query),
+		"  COMMIT;",
+		"END;",
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func buildMergeQuery(asset *pipeline.Asset, query string) (string, error) {
+	if len(asset.Columns) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `columns` field to be set", asset.Materialization.Strategy)
+	}
+
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `primary_key` field to be set on at least one column", asset.Materialization.Strategy)
+	}
+
+	on := make([]string, 0, len(primaryKeys))
+	for _, key := range primaryKeys {
+		on = append(on, fmt.Sprintf("target.%s = source.%s", key, key))
+	}
+
+	mergeLines := []string{
+		fmt.Sprintf("MERGE INTO %s target", asset.Name),
+		fmt.Sprintf("USING (%s) source ON (%s)", cleanQuery(query), strings.Join(on, " AND ")),
+	}
+
+	nonPrimaryKeys := asset.ColumnNamesWithUpdateOnMerge()
+	if len(nonPrimaryKeys) > 0 {
+		updateStatements := make([]string, 0, len(nonPrimaryKeys))
+		for _, col := range nonPrimaryKeys {
+			updateStatements = append(updateStatements, fmt.Sprintf("target.%s = source.%s", col, col))
+		}
+		mergeLines = append(mergeLines, "WHEN MATCHED THEN UPDATE SET "+strings.Join(updateStatements, ", "))
+	}
+
+	columnNames := asset.ColumnNames()
+	sourceColumns := make([]string, 0, len(columnNames))
+	for _, col := range columnNames {
+		sourceColumns = append(sourceColumns, "source."+col)
+	}
+	mergeLines = append(mergeLines, fmt.Sprintf("WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)", strings.Join(columnNames, ", "), strings.Join(sourceColumns, ", ")))
+
+	return strings.Join(mergeLines, "\n"), nil
+}
+
+func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error) {
+	if asset.Materialization.IncrementalKey == "" {
+		return "", errors.New("incremental_key is required for time_interval strategy")
+	}
+
+	if asset.Materialization.TimeGranularity == "" {
+		return "", errors.New("time_granularity is required for time_interval strategy")
+	}
+
+	if !(asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityTimestamp || asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate) {
+		return "", errors.New("time_granularity must be either 'date', or 'timestamp'")
+	}
+
+	startVar := `TO_TIMESTAMP('{{start_datetime}}', 'YYYY-MM-DD"T"HH24:MI:SS')`
+	endVar := `TO_TIMESTAMP('{{end_datetime}}', 'YYYY-MM-DD"T"HH24:MI:SS')`
+	if asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate {
+		startVar = "TO_DATE('{{start_date}}', 'YYYY-MM-DD')"
+		endVar = "TO_DATE('{{end_date}}', 'YYYY-MM-DD')"
+	}
+
+	lines := []string{
+		"BEGIN",
+		fmt.Sprintf("  DELETE FROM %s WHERE %s BETWEEN %s AND %s;", asset.Name, asset.Materialization.IncrementalKey, startVar, endVar),
+		fmt.Sprintf("  INSERT INTO %s %s;", asset.Name, cleanQuery(query)),
+		"  COMMIT;",
+		"END;",
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// buildDDLQuery ignores ORA-00955, which means the table already exists, since Oracle does not support
+// `CREATE TABLE IF NOT EXISTS`.
+func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
+	lines := []string{
+		"BEGIN",
+		fmt.Sprintf("  EXECUTE IMMEDIATE '%s';", escape(buildCreateTableStatement(asset))),
+		"EXCEPTION",
+		"  WHEN OTHERS THEN",
+		"    IF SQLCODE != -955 THEN",
+		"      RAISE;",
+		"    END IF;",
+		"END;",
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func buildCreateTableStatement(asset *pipeline.Asset) string {
+	columnDefs := make([]string, 0, len(asset.Columns))
+	primaryKeys := []string{}
+	foreignKeys := []string{}
+
+	for _, col := range asset.Columns {
+		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		// Oracle requires the default value to come before the inline constraints
+		if col.Default != "" {
+			def += " DEFAULT " + col.Default
+		}
+		if !col.Nullable.Bool() {
+			def += " NOT NULL"
+		}
+		if col.CheckConstraint != "" {
+			def += fmt.Sprintf(" CHECK (%s)", col.CheckConstraint)
+		}
+
+		if col.PrimaryKey {
+			primaryKeys = append(primaryKeys, col.Name)
+		}
+		if col.ForeignKey != nil {
+			foreignKeys = append(foreignKeys, fmt.Sprintf(
+				"CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+				ansisql.ConstraintName("fk", asset.Name, col.Name), col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
+			))
+		}
+		columnDefs = append(columnDefs, def)
+	}
+
+	if len(primaryKeys) > 0 {
+		columnDefs = append(columnDefs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+	columnDefs = append(columnDefs, foreignKeys...)
+
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n)", asset.Name, strings.Join(columnDefs, ",\n"))
+}
@@ -0,0 +1,241 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializer_Render(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		task        *pipeline.Asset
+		query       string
+		want        string
+		wantErr     bool
+		fullRefresh bool
+	}{
+		{
+			name:  "no materialization, return raw query",
+			task:  &pipeline.Asset{},
+			query: "SELECT 1 FROM DUAL",
+			want:  "SELECT 1 FROM DUAL",
+		},
+		{
+			name: "materialize to a view",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			query: "SELECT 1 FROM DUAL;",
+			want:  "CREATE OR REPLACE VIEW my.asset AS\nSELECT 1 FROM DUAL",
+		},
+		{
+			name: "materialize to a table, default to create+replace",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			},
+			query: "SELECT 'a' AS col FROM DUAL;",
+			want: "BEGIN\n" +
+				"  BEGIN\n" +
+				"    EXECUTE IMMEDIATE 'DROP TABLE my.asset PURGE';\n" +
+				"  EXCEPTION\n" +
+				"    WHEN OTHERS THEN\n" +
+				"      IF SQLCODE != -942 THEN\n" +
+				"        RAISE;\n" +
+				"      END IF;\n" +
+				"  END;\n" +
+				"  EXECUTE IMMEDIATE 'CREATE TABLE my.asset AS SELECT ''a'' AS col FROM DUAL';\n" +
+				"END;",
+		},
+		{
+			name: "create+replace with constraints creates the table first",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "NUMBER", PrimaryKey: true},
+					{Name: "status", Type: "VARCHAR2(10)", Default: "'new'"},
+				},
+			},
+			query: "SELECT 1 AS id, 'old' AS status FROM DUAL",
+			want: "BEGIN\n" +
+				"  BEGIN\n" +
+				"    EXECUTE IMMEDIATE 'DROP TABLE my.asset PURGE';\n" +
+				"  EXCEPTION\n" +
+				"    WHEN OTHERS THEN\n" +
+				"      IF SQLCODE != -942 THEN\n" +
+				"        RAISE;\n" +
+				"      END IF;\n" +
+				"  END;\n" +
+				"  EXECUTE IMMEDIATE 'CREATE TABLE my.asset (\n" +
+				"id NUMBER,\n" +
+				"status VARCHAR2(10) DEFAULT ''new'',\n" +
+				"PRIMARY KEY (id)\n" +
+				")';\n" +
+				"  EXECUTE IMMEDIATE 'INSERT INTO my.asset (id, status) SELECT id, status FROM (SELECT 1 AS id, ''old'' AS status FROM DUAL) __bruin_source';\n" +
+				"  COMMIT;\n" +
+				"END;",
+		},
+		{
+			name: "create+replace keeps the primary key",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "NUMBER", PrimaryKey: true},
+					{Name: "status", Type: "VARCHAR2(10)"},
+				},
+			},
+			query: "SELECT 1 AS id, 'old' AS status FROM DUAL",
+			want: "BEGIN\n" +
+				"  BEGIN\n" +
+				"    EXECUTE IMMEDIATE 'DROP TABLE my.asset PURGE';\n" +
+				"  EXCEPTION\n" +
+				"    WHEN OTHERS THEN\n" +
+				"      IF SQLCODE != -942 THEN\n" +
+				"        RAISE;\n" +
+				"      END IF;\n" +
+				"  END;\n" +
+				"  EXECUTE IMMEDIATE 'CREATE TABLE my.asset (\n" +
+				"id NUMBER,\n" +
+				"status VARCHAR2(10),\n" +
+				"PRIMARY KEY (id)\n" +
+				")';\n" +
+				"  EXECUTE IMMEDIATE 'INSERT INTO my.asset (id, status) SELECT id, status FROM (SELECT 1 AS id, ''old'' AS status FROM DUAL) __bruin_source';\n" +
+				"  COMMIT;\n" +
+				"END;",
+		},
+		{
+			name: "partition_by is not supported",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					PartitionBy: "dt",
+				},
+			},
+			query:   "SELECT 1 FROM DUAL",
+			wantErr: true,
+		},
+		{
+			name: "append",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			query: "SELECT 1 FROM DUAL;",
+			want:  "INSERT INTO my.asset SELECT 1 FROM DUAL",
+		},
+		{
+			name: "delete+insert",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:           pipeline.MaterializationTypeTable,
+					Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+					IncrementalKey: "dt",
+				},
+			},
+			query: "SELECT dt FROM source",
+			want: "BEGIN\n" +
+				"  DELETE FROM my.asset WHERE dt IN (SELECT DISTINCT dt FROM (SELECT dt FROM source) __bruin_source);\n" +
+				"  INSERT INTO my.asset SELECT dt FROM source;\n" +
+				"  COMMIT;\n" +
+				"END;",
+		},
+		{
+			name: "merge",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+					{Name: "name", UpdateOnMerge: true},
+				},
+			},
+			query: "SELECT id, name FROM source",
+			want: "MERGE INTO my.asset target\n" +
+				"USING (SELECT id, name FROM source) source ON (target.id = source.id)\n" +
+				"WHEN MATCHED THEN UPDATE SET target.name = source.name\n" +
+				"WHEN NOT MATCHED THEN INSERT (id, name) VALUES (source.id, source.name)",
+		},
+		{
+			name: "time_interval with timestamp granularity",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyTimeInterval,
+					TimeGranularity: pipeline.MaterializationTimeGranularityTimestamp,
+					IncrementalKey:  "ts",
+				},
+			},
+			query: "SELECT ts FROM source",
+			want: "BEGIN\n" +
+				"  DELETE FROM my.asset WHERE ts BETWEEN TO_TIMESTAMP('{{start_datetime}}', 'YYYY-MM-DD\"T\"HH24:MI:SS') AND TO_TIMESTAMP('{{end_datetime}}', 'YYYY-MM-DD\"T\"HH24:MI:SS');\n" +
+				"  INSERT INTO my.asset SELECT ts FROM source;\n" +
+				"  COMMIT;\n" +
+				"END;",
+		},
+		{
+			name: "ddl ignores existing tables",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "NUMBER", PrimaryKey: true},
+				},
+			},
+			want: "BEGIN\n" +
+				"  EXECUTE IMMEDIATE 'CREATE TABLE my.asset (\n" +
+				"id NUMBER,\n" +
+				"PRIMARY KEY (id)\n" +
+				")';\n" +
+				"EXCEPTION\n" +
+				"  WHEN OTHERS THEN\n" +
+				"    IF SQLCODE != -955 THEN\n" +
+				"      RAISE;\n" +
+				"    END IF;\n" +
+				"END;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewMaterializer(tt.fullRefresh)
+			render, err := m.Render(tt.task, tt.query)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, render)
+		})
+	}
+}
+
+func TestPrepareQuery(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "SELECT 1 FROM DUAL", prepareQuery("SELECT 1 FROM DUAL;\n"))
+	assert.Equal(t, "BEGIN\n  NULL;\nEND;", prepareQuery("BEGIN\n  NULL;\nEND;"))
+	assert.Equal(t, "declare x number; begin null; end;", prepareQuery("  declare x number; begin null; end;"))
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-var SkipDirs = []string{".git", ".github", ".vscode", "node_modules", "dist", "build", "target", "vendor", ".venv", ".env", "env", "venv", "dbt_packages"}
+var SkipDirs = []string{".git", ".github", ".vscode", "node_modules", "dist", "build", "target", "vendor", ".venv", ".env", "env", "venv", "dbt_packages", ".ipynb_checkpoints"}
 
 func GetPipelinePaths(root string, pipelineDefinitionFile []string) ([]string, error) {
 	var pipelinePaths []string
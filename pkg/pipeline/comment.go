@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"path/filepath"
 	"strconv"
@@ -19,6 +20,17 @@ const (
 var commentMarkers = map[string]string{
 	".sql": "--",
 	".py":  "#",
+	".sh":  "#",
+	".R":   "#",
+	".r":   "#",
+}
+
+// lineCommentBlockExtensions are the file types that have no block comments, the YAML config is put in a block of
+// line comments instead, starting and ending with a `# @bruin` row.
+var lineCommentBlockExtensions = map[string]bool{
+	".sh": true,
+	".R":  true,
+	".r":  true,
 }
 
 var (
@@ -29,6 +41,10 @@ var (
 func CreateTaskFromFileComments(fs afero.Fs) TaskCreator {
 	return func(filePath string) (*Asset, error) {
 		extension := filepath.Ext(filePath)
+		if extension == ".ipynb" {
+			return notebookToTask(fs, filePath)
+		}
+
 		commentMarker, ok := commentMarkers[extension]
 		if !ok {
 			return nil, nil
@@ -40,6 +56,10 @@ func CreateTaskFromFileComments(fs afero.Fs) TaskCreator {
 		}
 		defer file.Close()
 
+		if lineCommentBlockExtensions[extension] && isLineCommentBlock(file, commentMarker) {
+			return lineCommentBlockToTask(file, commentMarker, filePath)
+		}
+
 		if !isEmbeddedYamlComment(file, possiblePrefixesForCommentBlocks) {
 			scanner := bufio.NewScanner(file)
 			return singleLineCommentsToTask(scanner, commentMarker, filePath)
@@ -73,7 +93,7 @@ func isEmbeddedYamlComment(file afero.File, prefixes []string) bool {
 }
 
 func commentedYamlToTask(file afero.File, filePath string) (*Asset, error) {
-	rows, commentRowEnd := readUntilComments(file, possiblePrefixesForCommentBlocks, possibleSuffixesForCommentBlocks)
+	rows, commentRowStart, commentRowEnd := readUntilComments(file, possiblePrefixesForCommentBlocks, possibleSuffixesForCommentBlocks)
 	if rows == "" {
 		return nil, &ParseError{"no embedded YAML found in the comments"}
 	}
@@ -82,6 +102,7 @@ func commentedYamlToTask(file afero.File, filePath string) (*Asset, error) {
 	if err != nil {
 		return nil, &ParseError{err.Error()}
 	}
+	task.SourcePositions = yamlSourcePositions([]byte(rows), commentRowStart-1)
 
 	absFilePath, err := filepath.Abs(filePath)
 	if err != nil {
@@ -107,11 +128,117 @@ func commentedYamlToTask(file afero.File, filePath string) (*Asset, error) {
 	return task, nil
 }
 
-func readUntilComments(file afero.File, prefixes, suffixes []string) (string, int) {
+// isLineCommentBlock checks if the first non-empty row of the file, ignoring the shebang, starts a block of line
+// comments that contains the YAML config, e.g. `# @bruin`.
+func isLineCommentBlock(file afero.File, commentMarker string) bool {
+	scanner := bufio.NewScanner(file)
+	defer func() { _, _ = file.Seek(0, io.SeekStart) }()
+	rowCount := 0
+	for scanner.Scan() {
+		rowCount++
+		rowText := strings.TrimSpace(scanner.Text())
+		if rowText == "" || (rowCount == 1 && strings.HasPrefix(rowText, "#!")) {
+			continue
+		}
+
+		return isLineCommentBlockMarker(rowText, commentMarker)
+	}
+
+	return false
+}
+
+func isLineCommentBlockMarker(rowText, commentMarker string) bool {
+	rowText = strings.TrimSpace(rowText)
+	if !strings.HasPrefix(rowText, commentMarker) {
+		return false
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(rowText, commentMarker)) == configMarkerString
+}
+
+// lineCommentBlockToTask parses the YAML config from a block of line comments, the rows before the block, such as
+// the shebang, are kept as part of the executable content.
+func lineCommentBlockToTask(file afero.File, commentMarker, filePath string) (*Asset, error) {
+	scanner := bufio.NewScanner(file)
+	var beforeRows, yamlRows, contentRows []string
+	commentRowStart := 0
+	inBlock := false
+	blockEnded := false
+	rowCount := 0
+	for scanner.Scan() {
+		rowCount++
+		rowText := scanner.Text()
+
+		switch {
+		case blockEnded:
+			contentRows = append(contentRows, rowText)
+		case isLineCommentBlockMarker(rowText, commentMarker):
+			if inBlock {
+				blockEnded = true
+				continue
+			}
+			inBlock = true
+			commentRowStart = rowCount + 1
+		case inBlock:
+			row := strings.TrimPrefix(strings.TrimSpace(rowText), commentMarker)
+			yamlRows = append(yamlRows, strings.TrimPrefix(row, " "))
+		default:
+			beforeRows = append(beforeRows, rowText)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read file %s", filePath)
+	}
+
+	if !blockEnded {
+		return nil, &ParseError{fmt.Sprintf("the comment block is not closed, add a '%s %s' row after the config", commentMarker, configMarkerString)}
+	}
+
+	rows := strings.Join(yamlRows, "\n")
+	if strings.TrimSpace(rows) == "" {
+		return nil, &ParseError{"no embedded YAML found in the comments"}
+	}
+
+	task, err := ConvertYamlToTask([]byte(rows))
+	if err != nil {
+		return nil, &ParseError{err.Error()}
+	}
+
+	// the rows are prefixed with the comment marker, the columns are shifted accordingly
+	task.SourcePositions = yamlSourcePositions([]byte(rows), commentRowStart-1)
+	for key, position := range task.SourcePositions {
+		position.Column += len(commentMarker) + 1
+		task.SourcePositions[key] = position
+	}
+
+	absFilePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get absolute path for file %s", filePath)
+	}
+
+	content := strings.TrimSpace(strings.Join(contentRows, "\n"))
+	if before := strings.TrimSpace(strings.Join(beforeRows, "\n")); before != "" {
+		content = strings.TrimSpace(before + "\n\n" + content)
+	}
+
+	task.ExecutableFile = ExecutableFile{
+		Name:    filepath.Base(filePath),
+		Path:    absFilePath,
+		Content: content,
+	}
+
+	return task, nil
+}
+
+// readUntilComments returns the content of the comment block, along with the row the content starts at and the row
+// the block ends at.
+func readUntilComments(file afero.File, prefixes, suffixes []string) (string, int, int) {
 	scanner := bufio.NewScanner(file)
 	defer func() { _, _ = file.Seek(0, io.SeekStart) }()
 	rows := ""
 	rowCount := 0
+	firstContentRow := 0
 
 OUTER:
 	for scanner.Scan() {
@@ -130,19 +257,27 @@ OUTER:
 			}
 		}
 
+		if firstContentRow == 0 && strings.TrimSpace(rowText) != "" {
+			firstContentRow = rowCount
+		}
+
 		rows += rowText + "\n"
 	}
 
-	return strings.TrimSpace(rows), rowCount
+	return strings.TrimSpace(rows), firstContentRow, rowCount
 }
 
 func singleLineCommentsToTask(scanner *bufio.Scanner, commentMarker, filePath string) (*Asset, error) {
 	var allRows []string
 	var commentRows []string
+	var commentRowPositions []Position
+	rowCount := 0
 	for scanner.Scan() {
+		rowCount++
 		rowText := scanner.Text()
 
-		if !strings.HasPrefix(rowText, commentMarker) {
+		// the shebang of the scripts is kept so that the content can still be executed
+		if !strings.HasPrefix(rowText, commentMarker) || (rowCount == 1 && strings.HasPrefix(rowText, "#!")) {
 			allRows = append(allRows, rowText)
 			continue
 		}
@@ -150,6 +285,7 @@ func singleLineCommentsToTask(scanner *bufio.Scanner, commentMarker, filePath st
 		commentValue := strings.TrimSpace(strings.TrimPrefix(rowText, commentMarker))
 		if strings.HasPrefix(commentValue, configMarkerForInlineComments) {
 			commentRows = append(commentRows, strings.TrimPrefix(commentValue, configMarkerForInlineComments))
+			commentRowPositions = append(commentRowPositions, Position{Line: rowCount, Column: strings.Index(rowText, configMarkerForInlineComments) + len(configMarkerForInlineComments) + 1})
 		}
 	}
 
@@ -170,6 +306,7 @@ func singleLineCommentsToTask(scanner *bufio.Scanner, commentMarker, filePath st
 	if err != nil {
 		return nil, &ParseError{"failed to parse comment formatted task in file " + filePath}
 	}
+	task.SourcePositions = commentRowSourcePositions(task, commentRows, commentRowPositions)
 
 	task.ExecutableFile = ExecutableFile{
 		Name:    filepath.Base(filePath),
@@ -295,6 +432,19 @@ func commentRowsToTask(commentRows []string) (*Asset, error) {
 			case "incremental_key":
 				task.Materialization.IncrementalKey = value
 				continue
+			case "batch_size":
+				task.Materialization.BatchSize = MaterializationBatchSize(strings.ToLower(value))
+				continue
+			case "output_schema":
+				task.Materialization.OutputSchema = MaterializationOutputSchema(strings.ToLower(value))
+				continue
+			case "lookback":
+				lookback, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, errors.Wrapf(err, "materialization lookback must be an integer, '%s' given", value)
+				}
+				task.Materialization.Lookback = lookback
+				continue
 			case "cluster_by":
 				values := strings.Split(value, ",")
 				for _, v := range values {
@@ -310,6 +460,38 @@ func commentRowsToTask(commentRows []string) (*Asset, error) {
 	return &task, nil
 }
 
+// commentRowSourcePositions maps the keys of the `@bruin.` comment rows to the fields of the asset, the column keys
+// are in the form of `columns.<name>.<field>` and are mapped to the index of the column.
+func commentRowSourcePositions(task *Asset, commentRows []string, rowPositions []Position) SourcePositions {
+	positions := make(SourcePositions, len(commentRows))
+	for i, row := range commentRows {
+		key, _, found := strings.Cut(row, ":")
+		if !found {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		if columnKeys := strings.Split(key, "."); len(columnKeys) == 3 && columnKeys[0] == "columns" {
+			for index, column := range task.Columns {
+				if column.Name == columnKeys[1] {
+					key = fmt.Sprintf("columns[%d].%s", index, columnKeys[2])
+					if _, ok := positions[fmt.Sprintf("columns[%d]", index)]; !ok {
+						positions[fmt.Sprintf("columns[%d]", index)] = rowPositions[i]
+					}
+					break
+				}
+			}
+		}
+
+		// keys such as `depends` can be repeated, the first row is kept
+		if _, ok := positions[key]; !ok {
+			positions[key] = rowPositions[i]
+		}
+	}
+
+	return positions
+}
+
 func handleColumnEntry(columnFields []string, task *Asset, value string) error {
 	columnName := columnFields[1]
 
@@ -337,11 +337,68 @@ func Test_createTaskFromFile(t *testing.T) {
 				return
 			}
 
+			// the source positions are covered by TestSourcePositions
+			got.SourcePositions = nil
 			assert.EqualExportedValues(t, *tt.want, *got)
 		})
 	}
 }
 
+func TestCreateTaskFromFileComments_ScriptsAndNotebooks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		filePath      string
+		wantName      string
+		wantContent   string
+		wantUpstreams []string
+		wantErr       string
+	}{
+		{
+			name:          "shell script with a comment block keeps the shebang",
+			filePath:      "testdata/comments/script.sh",
+			wantName:      "exports.orders",
+			wantContent:   "#!/bin/bash\n\necho \"hello\"",
+			wantUpstreams: []string{"raw.orders"},
+		},
+		{
+			name:     "comment block that is not closed fails",
+			filePath: "testdata/comments/unclosed.sh",
+			wantErr:  "the comment block is not closed",
+		},
+		{
+			name:          "notebook is parsed from the header cell",
+			filePath:      "testdata/comments/notebook.ipynb",
+			wantName:      "reports.analysis",
+			wantContent:   mustRead(t, "testdata/comments/notebook.ipynb"),
+			wantUpstreams: []string{"raw.orders"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := pipeline.CreateTaskFromFileComments(afero.NewOsFs())(tt.filePath)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.Equal(t, tt.wantContent, strings.TrimSpace(got.ExecutableFile.Content))
+			assert.Equal(t, path.AbsPathForTests(t, tt.filePath), got.ExecutableFile.Path)
+
+			upstreams := make([]string, 0, len(got.Upstreams))
+			for _, upstream := range got.Upstreams {
+				upstreams = append(upstreams, upstream.Value)
+			}
+			assert.Equal(t, tt.wantUpstreams, upstreams)
+		})
+	}
+}
+
 func BenchmarkCreateTaskFromFileComments(b *testing.B) {
 	b.ReportAllocs()
 
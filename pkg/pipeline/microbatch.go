@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type Batch struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (b Batch) String() string {
+	return fmt.Sprintf("%s - %s", b.Start.Format(time.RFC3339), b.End.Format(time.RFC3339))
+}
+
+func (s MaterializationBatchSize) IsValid() bool {
+	switch s {
+	case MaterializationBatchSizeHour, MaterializationBatchSizeDay, MaterializationBatchSizeMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s MaterializationBatchSize) truncate(t time.Time) time.Time {
+	switch s {
+	case MaterializationBatchSizeHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case MaterializationBatchSizeDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	case MaterializationBatchSizeMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+
+	return t
+}
+
+func (s MaterializationBatchSize) add(t time.Time, n int) time.Time {
+	switch s {
+	case MaterializationBatchSizeHour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case MaterializationBatchSizeDay:
+		return t.AddDate(0, 0, n)
+	case MaterializationBatchSizeMonth:
+		return t.AddDate(0, n, 0)
+	}
+
+	return t
+}
+
+// SplitIntoBatches splits the given run interval into batches that are aligned to the batch size, e.g. a `day` batch
+// always starts at midnight. The first batch is moved back by `lookback` batches, and the last batch ends at the given
+// end date, which allows re-processing late arriving data without touching the future.
+func SplitIntoBatches(start, end time.Time, size MaterializationBatchSize, lookback int) ([]Batch, error) {
+	if !size.IsValid() {
+		return nil, fmt.Errorf("invalid batch size '%s', it must be one of 'hour', 'day' or 'month'", size)
+	}
+
+	if lookback < 0 {
+		return nil, errors.New("lookback cannot be negative")
+	}
+
+	if end.Before(start) {
+		return nil, errors.New("end date cannot be before the start date")
+	}
+
+	batches := make([]Batch, 0)
+	batchStart := size.add(size.truncate(start), -lookback)
+	for !batchStart.After(end) {
+		nextBatchStart := size.add(batchStart, 1)
+		batchEnd := nextBatchStart.Add(-time.Nanosecond)
+		if batchEnd.After(end) {
+			batchEnd = end
+		}
+
+		batches = append(batches, Batch{Start: batchStart, End: batchEnd})
+		batchStart = nextBatchStart
+	}
+
+	return batches, nil
+}
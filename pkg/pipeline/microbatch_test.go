@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitIntoBatches(t *testing.T) {
+	t.Parallel()
+
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	endOf := func(t time.Time) time.Time {
+		return t.Add(-time.Nanosecond)
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		size     MaterializationBatchSize
+		lookback int
+		want     []Batch
+		wantErr  bool
+	}{
+		{
+			name:  "daily batches",
+			start: date(2024, 1, 1, 0),
+			end:   endOf(date(2024, 1, 3, 0)),
+			size:  MaterializationBatchSizeDay,
+			want: []Batch{
+				{Start: date(2024, 1, 1, 0), End: endOf(date(2024, 1, 2, 0))},
+				{Start: date(2024, 1, 2, 0), End: endOf(date(2024, 1, 3, 0))},
+			},
+		},
+		{
+			name:     "hourly batches with lookback are aligned to the hour",
+			start:    date(2024, 1, 1, 10).Add(15 * time.Minute),
+			end:      date(2024, 1, 1, 11).Add(30 * time.Minute),
+			size:     MaterializationBatchSizeHour,
+			lookback: 1,
+			want: []Batch{
+				{Start: date(2024, 1, 1, 9), End: endOf(date(2024, 1, 1, 10))},
+				{Start: date(2024, 1, 1, 10), End: endOf(date(2024, 1, 1, 11))},
+				{Start: date(2024, 1, 1, 11), End: date(2024, 1, 1, 11).Add(30 * time.Minute)},
+			},
+		},
+		{
+			name:  "monthly batches",
+			start: date(2024, 1, 15, 0),
+			end:   endOf(date(2024, 3, 1, 0)),
+			size:  MaterializationBatchSizeMonth,
+			want: []Batch{
+				{Start: date(2024, 1, 1, 0), End: endOf(date(2024, 2, 1, 0))},
+				{Start: date(2024, 2, 1, 0), End: endOf(date(2024, 3, 1, 0))},
+			},
+		},
+		{
+			name:    "invalid batch size",
+			start:   date(2024, 1, 1, 0),
+			end:     date(2024, 1, 2, 0),
+			size:    "week",
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			start:   date(2024, 1, 2, 0),
+			end:     date(2024, 1, 1, 0),
+			size:    MaterializationBatchSizeDay,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SplitIntoBatches(tt.start, tt.end, tt.size, tt.lookback)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
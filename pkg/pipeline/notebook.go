@@ -0,0 +1,191 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// notebookHeaderCellID is the ID of the cell the asset definition is put in when the notebook doesn't have one yet.
+const notebookHeaderCellID = "bruin-header"
+
+type notebookCell struct {
+	Source notebookSource `json:"source"`
+}
+
+// notebookSource is the source of a notebook cell, which can be either a single string or a list of lines.
+type notebookSource string
+
+func (s *notebookSource) UnmarshalJSON(data []byte) error {
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*s = notebookSource(strings.Join(lines, ""))
+		return nil
+	}
+
+	var source string
+	if err := json.Unmarshal(data, &source); err != nil {
+		return err
+	}
+
+	*s = notebookSource(source)
+	return nil
+}
+
+// isNotebookHeaderCell checks if the cell contains the asset definition, either as a `"""@bruin` block or as a block
+// of `# @bruin` line comments.
+func isNotebookHeaderCell(source string) bool {
+	for _, row := range strings.Split(source, "\n") {
+		rowText := strings.TrimSpace(row)
+		if rowText == "" {
+			continue
+		}
+
+		for _, prefix := range possiblePrefixesForCommentBlocks {
+			if strings.HasPrefix(rowText, prefix) {
+				return true
+			}
+		}
+
+		return isLineCommentBlockMarker(rowText, "#")
+	}
+
+	return false
+}
+
+// notebookToTask parses the asset definition from the first cell of the notebook that contains it, the executable
+// content of the asset is the notebook itself.
+func notebookToTask(fs afero.Fs, filePath string) (*Asset, error) {
+	content, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read file %s", filePath)
+	}
+
+	var notebook struct {
+		Cells []notebookCell `json:"cells"`
+	}
+	if err := json.Unmarshal(content, &notebook); err != nil {
+		return nil, &ParseError{"failed to parse the notebook " + filePath + ": " + err.Error()}
+	}
+
+	for _, cell := range notebook.Cells {
+		source := string(cell.Source)
+		if !isNotebookHeaderCell(source) {
+			continue
+		}
+
+		task, err := notebookCellToTask(source, filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		// the positions are relative to the cell, not the notebook file, therefore they are not useful
+		task.SourcePositions = nil
+
+		absFilePath, err := filepath.Abs(filePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get absolute path for file %s", filePath)
+		}
+
+		task.ExecutableFile = ExecutableFile{
+			Name:    filepath.Base(filePath),
+			Path:    absFilePath,
+			Content: string(content),
+		}
+
+		return task, nil
+	}
+
+	return nil, nil
+}
+
+// notebookCellToTask parses the cell the same way a file is parsed, so the same formats are supported.
+func notebookCellToTask(source, filePath string) (*Asset, error) {
+	cellFs := afero.NewMemMapFs()
+	if err := afero.WriteFile(cellFs, filePath, []byte(source), 0o644); err != nil {
+		return nil, errors.Wrap(err, "failed to read the notebook cell")
+	}
+
+	cellFile, err := cellFs.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the notebook cell")
+	}
+	defer cellFile.Close()
+
+	if isEmbeddedYamlComment(cellFile, possiblePrefixesForCommentBlocks) {
+		return commentedYamlToTask(cellFile, filePath)
+	}
+
+	return lineCommentBlockToTask(cellFile, "#", filePath)
+}
+
+// formatNotebook puts the given asset definition in the header cell of the notebook, a new raw cell is added to the
+// beginning of the notebook if there is no header cell yet. The rest of the notebook is kept as is.
+func formatNotebook(content string, header string) ([]byte, error) {
+	var notebook map[string]any
+	if err := json.Unmarshal([]byte(content), &notebook); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the notebook")
+	}
+
+	headerLines := strings.SplitAfter(strings.TrimSuffix(header, "\n"), "\n")
+	headerSource := make([]any, 0, len(headerLines))
+	for _, line := range headerLines {
+		headerSource = append(headerSource, line)
+	}
+
+	cells, _ := notebook["cells"].([]any)
+	headerIndex := -1
+	for i, cell := range cells {
+		cellMap, ok := cell.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		rawSource, err := json.Marshal(cellMap["source"])
+		if err != nil {
+			continue
+		}
+
+		var source notebookSource
+		if err := json.Unmarshal(rawSource, &source); err != nil {
+			continue
+		}
+
+		if isNotebookHeaderCell(string(source)) {
+			headerIndex = i
+			break
+		}
+	}
+
+	if headerIndex >= 0 {
+		cells[headerIndex].(map[string]any)["source"] = headerSource
+	} else {
+		headerCell := map[string]any{
+			"cell_type": "raw",
+			"metadata":  map[string]any{},
+			"source":    headerSource,
+		}
+
+		// the cell IDs are required starting from nbformat 4.5
+		if minor, ok := notebook["nbformat_minor"].(float64); ok && minor >= 5 {
+			headerCell["id"] = notebookHeaderCellID
+		}
+
+		cells = append([]any{headerCell}, cells...)
+	}
+	notebook["cells"] = cells
+
+	buf := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	if err := enc.Encode(notebook); err != nil {
+		return nil, errors.Wrap(err, "failed to write the notebook")
+	}
+
+	return buf.Bytes(), nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/glossary"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v3"
 )
@@ -29,6 +31,9 @@ const (
 	YamlTask    TaskDefinitionType = "yaml"
 
 	AssetTypePython                 = AssetType("python")
+	AssetTypeShell                  = AssetType("shell")
+	AssetTypeR                      = AssetType("r")
+	AssetTypeNotebook               = AssetType("notebook")
 	AssetTypeSnowflakeQuery         = AssetType("sf.sql")
 	AssetTypeSnowflakeSeed          = AssetType("sf.seed")
 	AssetTypeSnowflakeQuerySensor   = AssetType("sf.sensor.query")
@@ -56,6 +61,8 @@ const (
 	AssetTypeDatabricksQuery        = AssetType("databricks.sql")
 	AssetTypeDatabricksSeed         = AssetType("databricks.seed")
 	AssetTypeDatabricksQuerySensor  = AssetType("databricks.sensor.query")
+	AssetTypeDatabricksNotebook     = AssetType("databricks.notebook")
+	AssetTypeDatabricksPyspark      = AssetType("databricks.pyspark")
 	AssetTypeSynapseQuery           = AssetType("synapse.sql")
 	AssetTypeSynapseSeed            = AssetType("synapse.seed")
 	AssetTypeSynapseQuerySensor     = AssetType("synapse.sensor.query")
@@ -66,6 +73,21 @@ const (
 	AssetTypeClickHouseQuerySensor  = AssetType("clickhouse.sensor.query")
 	AssetTypeEMRServerlessSpark     = AssetType("emr_serverless.spark")
 	AssetTypeEMRServerlessPyspark   = AssetType("emr_serverless.pyspark")
+	AssetTypeMySQLQuery             = AssetType("my.sql")
+	AssetTypeMySQLSeed              = AssetType("my.seed")
+	AssetTypeMySQLQuerySensor       = AssetType("my.sensor.query")
+	AssetTypeOracleQuery            = AssetType("oracle.sql")
+	AssetTypeOracleSeed             = AssetType("oracle.seed")
+	AssetTypeOracleQuerySensor      = AssetType("oracle.sensor.query")
+	AssetTypeSQLiteQuery            = AssetType("sqlite.sql")
+	AssetTypeSQLiteSeed             = AssetType("sqlite.seed")
+	AssetTypeSQLiteQuerySensor      = AssetType("sqlite.sensor.query")
+	AssetTypeSpannerQuery           = AssetType("spanner.sql")
+	AssetTypeSpannerSeed            = AssetType("spanner.seed")
+	AssetTypeSpannerQuerySensor     = AssetType("spanner.sensor.query")
+	AssetTypeTrinoQuery             = AssetType("trino.sql")
+	AssetTypeTrinoSeed              = AssetType("trino.seed")
+	AssetTypeTrinoQuerySensor       = AssetType("trino.sensor.query")
 	RunConfigFullRefresh            = RunConfig("full-refresh")
 	RunConfigApplyIntervalModifiers = RunConfig("apply-interval-modifiers")
 	RunConfigStartDate              = RunConfig("start-date")
@@ -119,15 +141,53 @@ var defaultMapping = map[string]string{
 	"oracle":                "oracle-default",
 	"solidgate":             "solidgate-default",
 	"smartsheet":            "smartsheet-default",
+	"sqlite":                "sqlite-default",
+	"spanner":               "spanner-default",
+	"trino":                 "trino-default",
 }
 
-var SupportedFileSuffixes = []string{"asset.yml", "asset.yaml", ".sql", ".py", "task.yml", "task.yaml"}
+var SupportedFileSuffixes = []string{"asset.yml", "asset.yaml", ".sql", ".py", ".sh", ".R", ".r", ".ipynb", "task.yml", "task.yaml"}
+
+// fileExtensionAssetTypes maps the extensions of the files that can only contain a single type of asset to that type.
+var fileExtensionAssetTypes = map[string]AssetType{
+	".py":    AssetTypePython,
+	".sh":    AssetTypeShell,
+	".r":     AssetTypeR,
+	".ipynb": AssetTypeNotebook,
+}
+
+// AssetTypeForFile returns the asset type that can be inferred from the extension of the file, or an empty type if
+// the file can contain different types of assets.
+func AssetTypeForFile(filePath string) AssetType {
+	return fileExtensionAssetTypes[strings.ToLower(filepath.Ext(filePath))]
+}
 
 type (
 	Schedule           string
 	TaskDefinitionType string
 )
 
+// IsContinuous returns true if the pipeline is meant to run continuously rather than on a cron schedule.
+func (s Schedule) IsContinuous() bool {
+	return s == "continuous" || s == "@continuous"
+}
+
+// CronSchedule parses the schedule of the pipeline, the `hourly`, `daily`, `weekly` and `monthly` shortcuts are
+// accepted along with the standard cron expressions. It returns nil if the pipeline has no cron schedule.
+func (s Schedule) CronSchedule() (cron.Schedule, error) {
+	if s == "" || s.IsContinuous() {
+		return nil, nil //nolint:nilnil
+	}
+
+	expression := string(s)
+	switch expression {
+	case "hourly", "daily", "weekly", "monthly":
+		expression = "@" + expression
+	}
+
+	return cron.ParseStandard(expression)
+}
+
 type ExecutableFile struct {
 	Name    string `json:"name"`
 	Path    string `json:"path"`
@@ -276,6 +336,8 @@ const (
 type (
 	MaterializationStrategy        string
 	MaterializationTimeGranularity string
+	MaterializationBatchSize       string
+	MaterializationOutputSchema    string
 )
 
 const (
@@ -286,8 +348,15 @@ const (
 	MaterializationStrategyMerge            MaterializationStrategy        = "merge"
 	MaterializationStrategyTimeInterval     MaterializationStrategy        = "time_interval"
 	MaterializationStrategyDDL              MaterializationStrategy        = "ddl"
+	MaterializationStrategyMicrobatch       MaterializationStrategy        = "microbatch"
 	MaterializationTimeGranularityDate      MaterializationTimeGranularity = "date"
 	MaterializationTimeGranularityTimestamp MaterializationTimeGranularity = "timestamp"
+	MaterializationBatchSizeHour            MaterializationBatchSize       = "hour"
+	MaterializationBatchSizeDay             MaterializationBatchSize       = "day"
+	MaterializationBatchSizeMonth           MaterializationBatchSize       = "month"
+	MaterializationOutputSchemaNone         MaterializationOutputSchema    = ""
+	MaterializationOutputSchemaContract     MaterializationOutputSchema    = "contract"
+	MaterializationOutputSchemaInfer        MaterializationOutputSchema    = "infer"
 )
 
 var AllAvailableMaterializationStrategies = []MaterializationStrategy{
@@ -297,6 +366,7 @@ var AllAvailableMaterializationStrategies = []MaterializationStrategy{
 	MaterializationStrategyMerge,
 	MaterializationStrategyTimeInterval,
 	MaterializationStrategyDDL,
+	MaterializationStrategyMicrobatch,
 }
 
 type Materialization struct {
@@ -306,6 +376,9 @@ type Materialization struct {
 	ClusterBy       []string                       `json:"cluster_by" yaml:"cluster_by,omitempty" mapstructure:"cluster_by"`
 	IncrementalKey  string                         `json:"incremental_key" yaml:"incremental_key,omitempty" mapstructure:"incremental_key"`
 	TimeGranularity MaterializationTimeGranularity `json:"time_granularity" yaml:"time_granularity,omitempty" mapstructure:"time_granularity"`
+	BatchSize       MaterializationBatchSize       `json:"batch_size,omitempty" yaml:"batch_size,omitempty" mapstructure:"batch_size"`
+	Lookback        int                            `json:"lookback,omitempty" yaml:"lookback,omitempty" mapstructure:"lookback"`
+	OutputSchema    MaterializationOutputSchema    `json:"output_schema,omitempty" yaml:"output_schema,omitempty" mapstructure:"output_schema"`
 }
 
 func (m Materialization) MarshalJSON() ([]byte, error) {
@@ -478,6 +551,11 @@ type UpstreamColumn struct {
 	Table  string `json:"table" yaml:"table,omitempty" mapstructure:"table"`
 }
 
+type ColumnForeignKey struct {
+	Table  string `json:"table" yaml:"table" mapstructure:"table"`
+	Column string `json:"column" yaml:"column" mapstructure:"column"`
+}
+
 type Column struct {
 	EntityAttribute *EntityAttribute  `json:"entity_attribute" yaml:"-" mapstructure:"-"`
 	Name            string            `json:"name" yaml:"name,omitempty" mapstructure:"name"`
@@ -485,9 +563,20 @@ type Column struct {
 	Description     string            `json:"description" yaml:"description,omitempty" mapstructure:"description"`
 	PrimaryKey      bool              `json:"primary_key" yaml:"primary_key,omitempty" mapstructure:"primary_key"`
 	UpdateOnMerge   bool              `json:"update_on_merge" yaml:"update_on_merge,omitempty" mapstructure:"update_on_merge"`
+	Nullable        DefaultTrueBool   `json:"nullable" yaml:"nullable,omitempty" mapstructure:"nullable"`
+	Default         string            `json:"default,omitempty" yaml:"default,omitempty" mapstructure:"default"`
+	ForeignKey      *ColumnForeignKey `json:"foreign_key,omitempty" yaml:"foreign_key,omitempty" mapstructure:"foreign_key"`
+	CheckConstraint string            `json:"check_constraint,omitempty" yaml:"check_constraint,omitempty" mapstructure:"check_constraint"`
 	Extends         string            `json:"-" yaml:"extends,omitempty" mapstructure:"extends"`
 	Checks          []ColumnCheck     `json:"checks" yaml:"checks,omitempty" mapstructure:"checks"`
-	Upstreams       []*UpstreamColumn `json:"upstreams" yaml:"-" mapstructure:"-"`
+	Upstreams       []*UpstreamColumn `json:"upstreams" yaml:"upstreams,omitempty" mapstructure:"upstreams"`
+}
+
+// HasConstraints returns true if the column defines any attribute beyond its name and type that needs to be
+// part of the table definition. Primary keys are excluded since they can be added to a table created from a query
+// without the column definitions, the materializations handle them separately.
+func (c *Column) HasConstraints() bool {
+	return !c.Nullable.Bool() || c.Default != "" || c.ForeignKey != nil || c.CheckConstraint != ""
 }
 
 func (c *Column) HasCheck(check string) bool {
@@ -523,6 +612,8 @@ var AssetTypeConnectionMapping = map[AssetType]string{
 	AssetTypeDatabricksQuery:       "databricks",
 	AssetTypeDatabricksSeed:        "databricks",
 	AssetTypeDatabricksQuerySensor: "databricks",
+	AssetTypeDatabricksNotebook:    "databricks",
+	AssetTypeDatabricksPyspark:     "databricks",
 	AssetTypeSynapseQuery:          "synapse",
 	AssetTypeSynapseSeed:           "synapse",
 	AssetTypeSynapseQuerySensor:    "synapse",
@@ -537,6 +628,21 @@ var AssetTypeConnectionMapping = map[AssetType]string{
 	AssetTypeClickHouseQuerySensor: "clickhouse",
 	AssetTypeEMRServerlessSpark:    "emr_serverless",
 	AssetTypeEMRServerlessPyspark:  "emr_serverless",
+	AssetTypeMySQLQuery:            "mysql",
+	AssetTypeMySQLSeed:             "mysql",
+	AssetTypeMySQLQuerySensor:      "mysql",
+	AssetTypeOracleQuery:           "oracle",
+	AssetTypeOracleSeed:            "oracle",
+	AssetTypeOracleQuerySensor:     "oracle",
+	AssetTypeSQLiteQuery:           "sqlite",
+	AssetTypeSQLiteSeed:            "sqlite",
+	AssetTypeSQLiteQuerySensor:     "sqlite",
+	AssetTypeSpannerQuery:          "spanner",
+	AssetTypeSpannerSeed:           "spanner",
+	AssetTypeSpannerQuerySensor:    "spanner",
+	AssetTypeTrinoQuery:            "trino",
+	AssetTypeTrinoSeed:             "trino",
+	AssetTypeTrinoQuerySensor:      "trino",
 }
 
 var IngestrTypeConnectionMapping = map[string]AssetType{
@@ -664,6 +770,7 @@ type Asset struct { //nolint:recvcheck
 	Snowflake         SnowflakeConfig    `json:"snowflake" yaml:"snowflake,omitempty" mapstructure:"snowflake"`
 	Athena            AthenaConfig       `json:"athena" yaml:"athena,omitempty" mapstructure:"athena"`
 	IntervalModifiers IntervalModifiers  `json:"interval_modifiers" yaml:"interval_modifiers,omitempty" mapstructure:"interval_modifiers"`
+	SourcePositions   SourcePositions    `json:"-" yaml:"-" mapstructure:"-"`
 
 	upstream   []*Asset
 	downstream []*Asset
@@ -782,6 +889,47 @@ func (a *Asset) PrefixUpstreams(prefix string) {
 	}
 }
 
+var (
+	jinjaReferenceRegex = regexp.MustCompile(`\b(?:ref|source)\(\s*(?:name\s*=\s*)?['"]([^'"]+)['"]\s*\)`)
+
+	// sqlCommentRegex matches the string literals too, so that the comment markers within them are not mistaken for
+	// comments
+	sqlCommentRegex = regexp.MustCompile(`'(?:[^']|'')*'|"(?:[^"]|"")*"|--[^\n]*|/\*[\s\S]*?\*/|\{#[\s\S]*?#\}`)
+)
+
+// removeSQLComments removes the SQL and Jinja comments from the query, keeping the string literals as they are.
+func removeSQLComments(query string) string {
+	return sqlCommentRegex.ReplaceAllStringFunc(query, func(match string) string {
+		if strings.HasPrefix(match, "'") || strings.HasPrefix(match, `"`) {
+			return match
+		}
+
+		return " "
+	})
+}
+
+// ReferencedAssets returns the names of the assets the query of the asset refers to via the `ref()` and `source()`
+// Jinja functions, in the order they are first used. The references within comments are ignored.
+func (a *Asset) ReferencedAssets() []string {
+	if !strings.HasSuffix(a.ExecutableFile.Path, ".sql") {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, match := range jinjaReferenceRegex.FindAllStringSubmatch(removeSQLComments(a.ExecutableFile.Content), -1) {
+		name := strings.TrimSpace(match[1])
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+
+		seen[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
 // removeRedundanciesBeforePersisting aims to remove unnecessary configuration from the asset.
 // This is particularly useful when we save a formatted version of the asset itself.
 func (a *Asset) removeRedundanciesBeforePersisting() {
@@ -789,10 +937,17 @@ func (a *Asset) removeRedundanciesBeforePersisting() {
 	a.removeExtraSpacesAtLineEndingsInTextContent()
 	a.removeNameIfItCanBeInferredFromPath()
 
-	// python assets don't require a type anymore
-	if a.Type == AssetTypePython && strings.HasSuffix(a.ExecutableFile.Path, ".py") {
+	// python, shell, R and notebook assets don't require a type, it is inferred from the file extension
+	if a.Type != "" && a.Type == AssetTypeForFile(a.ExecutableFile.Path) {
 		a.Type = ""
 	}
+
+	// the column lineage of the SQL assets is extracted from their queries, only the other assets declare it
+	if strings.HasSuffix(a.ExecutableFile.Path, ".sql") {
+		for i := range a.Columns {
+			a.Columns[i].Upstreams = nil
+		}
+	}
 }
 
 // removeRedundanciesBeforePersisting aims to remove unnecessary configuration from the asset.
@@ -929,6 +1084,15 @@ func (a *Asset) ColumnNamesWithPrimaryKey() []string {
 	return columns
 }
 
+func (a *Asset) HasColumnConstraints() bool {
+	for _, c := range a.Columns {
+		if c.HasConstraints() {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *Asset) GetColumnWithName(name string) *Column {
 	for _, c := range a.Columns {
 		if strings.EqualFold(c.Name, name) {
@@ -1072,6 +1236,30 @@ func (a *Asset) FormatContent() ([]byte, error) {
 		executableContent = a.ExecutableFile.Content
 	}
 
+	if strings.HasSuffix(a.ExecutableFile.Path, ".ipynb") {
+		return formatNotebook(a.ExecutableFile.Content, `"""`+configMarkerString+"\n\n"+string(yamlConfig)+"\n"+configMarkerString+`"""`)
+	}
+
+	// shell and R files have no block comments, the config is put in line comments after the shebang
+	if extension := filepath.Ext(a.ExecutableFile.Path); lineCommentBlockExtensions[extension] {
+		marker := commentMarkers[extension]
+		executableContent = a.ExecutableFile.Content
+		if strings.HasPrefix(executableContent, "#!") {
+			shebang, rest, _ := strings.Cut(executableContent, "\n")
+			beginning = shebang + "\n"
+			executableContent = strings.TrimLeft(rest, "\n")
+		}
+
+		rows := strings.Split(strings.TrimRight(string(yamlConfig), "\n"), "\n")
+		for i, row := range rows {
+			rows[i] = strings.TrimRight(marker+" "+row, " ")
+		}
+
+		yamlConfig = []byte(strings.Join(rows, "\n") + "\n")
+		beginning += marker + " " + configMarkerString + "\n"
+		end = marker + " " + configMarkerString + "\n\n"
+	}
+
 	stringVersion := beginning + string(yamlConfig) + end + executableContent
 	if !strings.HasSuffix(stringVersion, "\n") {
 		stringVersion += "\n"
@@ -1194,10 +1382,56 @@ type Pipeline struct {
 	Snapshot           string                 `json:"snapshot"`
 	Agent              bool                   `json:"agent" yaml:"agent" mapstructure:"agent"`
 	Variables          Variables              `json:"variables" yaml:"variables" mapstructure:"variables"`
+	Format             *FormatOptions         `json:"format,omitempty" yaml:"format,omitempty" mapstructure:"format,omitempty"`
 	TasksByType        map[AssetType][]*Asset `json:"-"`
 	tasksByName        map[string]*Asset
 }
 
+// FormatOptions configures the `bruin format` command, it can be set both in `.bruin.yml` and in `pipeline.yml`.
+type FormatOptions struct {
+	SQL *SQLFormatOptions `json:"sql,omitempty" yaml:"sql,omitempty" mapstructure:"sql,omitempty"`
+}
+
+// SQLFormatOptions configures the formatting of the SQL queries of the assets.
+type SQLFormatOptions struct {
+	Enabled     *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty" mapstructure:"enabled,omitempty"`
+	KeywordCase string `json:"keyword_case,omitempty" yaml:"keyword_case,omitempty" mapstructure:"keyword_case,omitempty"`
+	Indent      int    `json:"indent,omitempty" yaml:"indent,omitempty" mapstructure:"indent,omitempty"`
+	LineWidth   int    `json:"line_width,omitempty" yaml:"line_width,omitempty" mapstructure:"line_width,omitempty"`
+}
+
+// IsEnabled returns true if the SQL formatting is explicitly enabled.
+func (o *SQLFormatOptions) IsEnabled() bool {
+	return o != nil && o.Enabled != nil && *o.Enabled
+}
+
+// Merge returns a copy of the options where the values that are set in the given options take precedence.
+func (o *SQLFormatOptions) Merge(override *SQLFormatOptions) *SQLFormatOptions {
+	merged := &SQLFormatOptions{}
+	if o != nil {
+		*merged = *o
+	}
+
+	if override == nil {
+		return merged
+	}
+
+	if override.Enabled != nil {
+		merged.Enabled = override.Enabled
+	}
+	if override.KeywordCase != "" {
+		merged.KeywordCase = override.KeywordCase
+	}
+	if override.Indent != 0 {
+		merged.Indent = override.Indent
+	}
+	if override.LineWidth != 0 {
+		merged.LineWidth = override.LineWidth
+	}
+
+	return merged
+}
+
 type DefaultValues struct {
 	Type              string            `json:"type" yaml:"type" mapstructure:"type"`
 	Parameters        map[string]string `json:"parameters" yaml:"parameters" mapstructure:"parameters"`
@@ -1327,6 +1561,11 @@ func (p *Pipeline) GetMajorityAssetTypesFromSQLAssets(defaultIfNone AssetType) A
 		AssetTypeSynapseQuery:    0,
 		AssetTypeAthenaQuery:     0,
 		AssetTypeDuckDBQuery:     0,
+		AssetTypeMySQLQuery:      0,
+		AssetTypeOracleQuery:     0,
+		AssetTypeSQLiteQuery:     0,
+		AssetTypeSpannerQuery:    0,
+		AssetTypeTrinoQuery:      0,
 	}
 	maxTasks := 0
 	maxTaskType := defaultIfNone
@@ -1536,6 +1775,7 @@ func resolvePipelineFilePath(basePath string, validFileNames []string, fs afero.
 type createPipelineConfig struct {
 	parseGitMetadata bool
 	isMutate         bool
+	onAssetError     func(path string, err error)
 }
 
 type CreatePipelineOption func(*createPipelineConfig)
@@ -1552,6 +1792,14 @@ func WithMutate() CreatePipelineOption {
 	}
 }
 
+// WithSkippedAssetErrors leaves the assets that fail to be built out of the pipeline instead of failing the whole
+// pipeline, the errors are given to the callback together with the paths of the asset files.
+func WithSkippedAssetErrors(onError func(path string, err error)) CreatePipelineOption {
+	return func(o *createPipelineConfig) {
+		o.onAssetError = onError
+	}
+}
+
 func (b *Builder) CreatePipelineFromPath(ctx context.Context, pathToPipeline string, opts ...CreatePipelineOption) (*Pipeline, error) {
 	config := createPipelineConfig{}
 	for _, opt := range opts {
@@ -1609,15 +1857,17 @@ func (b *Builder) CreatePipelineFromPath(ctx context.Context, pathToPipeline str
 
 	for _, file := range taskFiles {
 		task, err := b.CreateAssetFromFile(file, pipeline)
-		if err != nil {
-			return nil, err
+		if err == nil && config.isMutate {
+			task, err = b.MutateAsset(ctx, task, pipeline)
 		}
 
-		if config.isMutate {
-			task, err = b.MutateAsset(ctx, task, pipeline)
-			if err != nil {
-				return nil, err
+		if err != nil {
+			if config.onAssetError != nil {
+				config.onAssetError(file, err)
+				continue
 			}
+
+			return nil, err
 		}
 
 		if task == nil {
@@ -1645,6 +1895,10 @@ func (b *Builder) CreatePipelineFromPath(ctx context.Context, pathToPipeline str
 		}
 	}
 
+	for _, asset := range pipeline.Assets {
+		pipeline.SetUpstreamsFromReferences(asset)
+	}
+
 	for _, asset := range pipeline.Assets {
 		for _, upstream := range asset.Upstreams {
 			if upstream.Mode != UpstreamModeFull && upstream.Mode != UpstreamModeSymbolic {
@@ -1712,10 +1966,10 @@ func (b *Builder) CreateAssetFromFile(filePath string, foundPipeline *Pipeline)
 		return nil, nil
 	}
 
-	// if the definition comes from a Python file the asset is always a Python asset, so force it
+	// if the definition comes from a Python, shell, R or notebook file the asset is always of that type, so force it
 	// at least that's the hypothesis for now
-	if strings.HasSuffix(task.ExecutableFile.Path, ".py") && task.Type == "" {
-		task.Type = AssetTypePython
+	if task.Type == "" {
+		task.Type = AssetTypeForFile(task.ExecutableFile.Path)
 	}
 
 	task.DefinitionFile.Name = filepath.Base(filePath)
@@ -1864,6 +2118,11 @@ func (a *Asset) IsSQLAsset() bool {
 		AssetTypeAthenaQuery:     true,
 		AssetTypeDuckDBQuery:     true,
 		AssetTypeClickHouse:      true,
+		AssetTypeMySQLQuery:      true,
+		AssetTypeOracleQuery:     true,
+		AssetTypeSQLiteQuery:     true,
+		AssetTypeSpannerQuery:    true,
+		AssetTypeTrinoQuery:      true,
 	}
 
 	return sqlAssetTypes[a.Type]
@@ -1919,6 +2178,37 @@ func (b *Builder) SetNameFromPath(ctx context.Context, asset *Asset, foundPipeli
 	return asset, nil
 }
 
+// SetUpstreamsFromReferences adds the assets referred to via `ref()` and `source()` in the query of the asset to its
+// upstreams, so that the dependencies do not have to be declared in `depends` as well. The references are matched to
+// the assets of the pipeline case-insensitively, the upstreams get the actual names of the assets.
+func (p *Pipeline) SetUpstreamsFromReferences(asset *Asset) {
+	for _, name := range asset.ReferencedAssets() {
+		if strings.EqualFold(name, asset.Name) {
+			continue
+		}
+
+		for _, candidate := range p.Assets {
+			if strings.EqualFold(candidate.Name, name) {
+				name = candidate.Name
+				break
+			}
+		}
+
+		exists := false
+		for _, upstream := range asset.Upstreams {
+			if (upstream.Type == "" || upstream.Type == "asset") && strings.EqualFold(upstream.Value, name) {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+
+		asset.Upstreams = append(asset.Upstreams, Upstream{Type: "asset", Value: name, Columns: make([]DependsColumn, 0), Mode: UpstreamModeFull})
+	}
+}
+
 func (t TimeModifier) MarshalYAML() (interface{}, error) {
 	switch {
 	case t.Days != 0 && t.Months == 0 && t.Hours == 0 && t.Minutes == 0 && t.Seconds == 0 && t.CronPeriods == 0:
@@ -277,6 +277,11 @@ func Test_pipelineBuilder_CreatePipelineFromPath(t *testing.T) {
 			assert.Equal(t, tt.want.DefaultConnections, got.DefaultConnections)
 			assert.Equal(t, tt.want.Retries, got.Retries)
 
+			// the source positions are covered by TestSourcePositions
+			for _, gotAsset := range got.Assets {
+				gotAsset.SourcePositions = nil
+			}
+
 			for i, asset := range tt.want.Assets {
 				gotAsset := got.Assets[i]
 				assert.EqualExportedValues(t, *asset, *gotAsset)
@@ -548,6 +553,37 @@ func TestPipeline_GetAssetByPath(t *testing.T) {
 	assert.Equal(t, "task1", asset.Name)
 }
 
+func TestBuilder_CreatePipelineFromPath_SkippedAssetErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "assets"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pipeline.yml"), []byte("name: skipped\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets", "valid.sql"), []byte("/* @bruin\nname: valid\ntype: duckdb.sql\n@bruin */\nselect 1\n"), 0o644))
+	brokenPath := filepath.Join(dir, "assets", "broken.sql")
+	require.NoError(t, os.WriteFile(brokenPath, []byte("/* @bruin\nname: broken\nmaterialization: [\n@bruin */\nselect 1\n"), 0o644))
+
+	fs := afero.NewOsFs()
+	config := pipeline.BuilderConfig{
+		PipelineFileName:    []string{"pipeline.yml"},
+		TasksDirectoryNames: []string{"assets"},
+	}
+	builder := pipeline.NewBuilder(config, pipeline.CreateTaskFromYamlDefinition(fs), pipeline.CreateTaskFromFileComments(fs), fs, nil)
+
+	_, err := builder.CreatePipelineFromPath(context.Background(), dir, pipeline.WithMutate())
+	require.Error(t, err)
+
+	skipped := make(map[string]error)
+	p, err := builder.CreatePipelineFromPath(context.Background(), dir, pipeline.WithMutate(), pipeline.WithSkippedAssetErrors(func(path string, err error) {
+		skipped[path] = err
+	}))
+	require.NoError(t, err)
+	require.Len(t, p.Assets, 1)
+	assert.Equal(t, "valid", p.Assets[0].Name)
+	require.Len(t, skipped, 1)
+	assert.Contains(t, skipped, brokenPath)
+}
+
 func TestPipeline_GetConnectionNameForAsset(t *testing.T) {
 	t.Parallel()
 
@@ -872,11 +908,31 @@ func TestAsset_Persist(t *testing.T) {
 			assetPath:    path.AbsPathForTests(t, "testdata/persist/big.sql"),
 			expectedPath: path.AbsPathForTests(t, "testdata/persist/big.expected.sql"),
 		},
+		{
+			name:         "column upstreams of python assets are not lost",
+			assetPath:    path.AbsPathForTests(t, "testdata/persist/column_lineage.py"),
+			expectedPath: path.AbsPathForTests(t, "testdata/persist/column_lineage.expected.py"),
+		},
 		{
 			name:         "symbolic upstreams are not lost",
 			assetPath:    path.AbsPathForTests(t, "testdata/persist/symbolic_upstream.sql"),
 			expectedPath: path.AbsPathForTests(t, "testdata/persist/symbolic_upstream.expected.sql"),
 		},
+		{
+			name:         "shell assets keep the shebang",
+			assetPath:    path.AbsPathForTests(t, "testdata/persist/export.sh"),
+			expectedPath: path.AbsPathForTests(t, "testdata/persist/export.expected.sh"),
+		},
+		{
+			name:         "R assets with single line comments are converted to a comment block",
+			assetPath:    path.AbsPathForTests(t, "testdata/persist/model.R"),
+			expectedPath: path.AbsPathForTests(t, "testdata/persist/model.expected.R"),
+		},
+		{
+			name:         "notebooks only have their header cell updated",
+			assetPath:    path.AbsPathForTests(t, "testdata/persist/analysis.ipynb"),
+			expectedPath: path.AbsPathForTests(t, "testdata/persist/analysis.expected.ipynb"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1515,6 +1571,75 @@ func TestBuilder_SetNameFromPath(t *testing.T) {
 	}
 }
 
+func TestPipeline_SetUpstreamsFromReferences(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "mart.orders",
+		ExecutableFile: pipeline.ExecutableFile{
+			Path: filepath.Join("project", "assets", "mart", "orders.sql"),
+			Content: `select * from {{ ref('raw.orders') }} o
+join {{ ref( "raw.customers" ) }} c using (customer_id)
+join {{ source('raw.rates') }} r using (currency)
+join {{ ref(name='raw.products') }} p using (product_id)
+-- the old rates: {{ source('raw.old_rates') }}
+/* {{ ref('raw.archived_orders') }} */
+{# {{ ref('raw.drafts') }} #}
+where o.id not in (select id from {{ ref('mart.orders') }})
+and o.note != '-- not a comment'
+and o.status in (select status from {{ ref('RAW.ORDERS') }})`,
+		},
+		Upstreams: []pipeline.Upstream{
+			{Type: "asset", Value: "RAW.CUSTOMERS", Mode: pipeline.UpstreamModeSymbolic},
+		},
+	}
+
+	// the references get the actual names of the assets in the pipeline
+	p := &pipeline.Pipeline{Assets: []*pipeline.Asset{asset, {Name: "Raw.Rates"}}}
+	p.SetUpstreamsFromReferences(asset)
+	assert.Equal(t, []pipeline.Upstream{
+		{Type: "asset", Value: "RAW.CUSTOMERS", Mode: pipeline.UpstreamModeSymbolic},
+		{Type: "asset", Value: "raw.orders", Columns: []pipeline.DependsColumn{}, Mode: pipeline.UpstreamModeFull},
+		{Type: "asset", Value: "Raw.Rates", Columns: []pipeline.DependsColumn{}, Mode: pipeline.UpstreamModeFull},
+		{Type: "asset", Value: "raw.products", Columns: []pipeline.DependsColumn{}, Mode: pipeline.UpstreamModeFull},
+	}, asset.Upstreams)
+
+	// only the SQL queries are looked into
+	pythonAsset := &pipeline.Asset{
+		ExecutableFile: pipeline.ExecutableFile{Path: "asset.py", Content: "ref('raw.orders')"},
+	}
+	p.SetUpstreamsFromReferences(pythonAsset)
+	assert.Empty(t, pythonAsset.Upstreams)
+}
+
+func TestBuilder_CreatePipelineFromPath_References(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "assets"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pipeline.yml"), []byte("name: references\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets", "orders.sql"), []byte("/* @bruin\nname: raw.Orders\ntype: duckdb.sql\n@bruin */\nselect 1 as id\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets", "summary.sql"), []byte("/* @bruin\nname: mart.summary\ntype: duckdb.sql\n@bruin */\nselect count(*) from {{ ref('RAW.ORDERS') }}\n"), 0o644))
+
+	fs := afero.NewOsFs()
+	config := pipeline.BuilderConfig{
+		PipelineFileName:    []string{"pipeline.yml"},
+		TasksDirectoryNames: []string{"assets"},
+	}
+	builder := pipeline.NewBuilder(config, pipeline.CreateTaskFromYamlDefinition(fs), pipeline.CreateTaskFromFileComments(fs), fs, nil)
+
+	// the references are linked without the mutations as well, e.g. for the lineage
+	p, err := builder.CreatePipelineFromPath(context.Background(), dir)
+	require.NoError(t, err)
+
+	summary := p.GetAssetByName("mart.summary")
+	require.NotNil(t, summary)
+	assert.Equal(t, []pipeline.Upstream{
+		{Type: "asset", Value: "raw.Orders", Columns: []pipeline.DependsColumn{}, Mode: pipeline.UpstreamModeFull},
+	}, summary.Upstreams)
+	assert.Equal(t, []*pipeline.Asset{p.GetAssetByName("raw.Orders")}, summary.GetUpstream())
+}
+
 func hash(s string) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))[:64]
 }
@@ -1589,3 +1714,24 @@ func TestBuilder_SetupDefaultsFromPipeline(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLFormatOptions_Merge(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	disabled := false
+
+	var global *pipeline.SQLFormatOptions
+	merged := global.Merge(nil)
+	assert.False(t, merged.IsEnabled())
+
+	global = &pipeline.SQLFormatOptions{Enabled: &enabled, KeywordCase: "upper", Indent: 4}
+	merged = global.Merge(&pipeline.SQLFormatOptions{KeywordCase: "lower", LineWidth: 120})
+	assert.True(t, merged.IsEnabled())
+	assert.Equal(t, &pipeline.SQLFormatOptions{Enabled: &enabled, KeywordCase: "lower", Indent: 4, LineWidth: 120}, merged)
+	assert.Equal(t, "upper", global.KeywordCase)
+
+	merged = global.Merge(&pipeline.SQLFormatOptions{Enabled: &disabled})
+	assert.False(t, merged.IsEnabled())
+	assert.Equal(t, 4, merged.Indent)
+}
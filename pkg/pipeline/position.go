@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a 1-based line and column in the file the asset is defined in.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// SourcePositions maps the fields of an asset definition to their positions. The keys follow the structure of the
+// YAML definition, e.g. `materialization.strategy`, `parameters.path` or `columns[2].name`.
+type SourcePositions map[string]Position
+
+// Lookup returns the position of the given field, falling back to its closest parent that has a position, e.g. the
+// position of `columns[2]` is returned for `columns[2].checks[0]` if the check itself is not found.
+func (s SourcePositions) Lookup(field string) (Position, bool) {
+	for field != "" {
+		if pos, ok := s[field]; ok {
+			return pos, true
+		}
+
+		cut := strings.LastIndexAny(field, ".[")
+		if cut == -1 {
+			break
+		}
+		field = field[:cut]
+	}
+
+	return Position{}, false
+}
+
+// yamlSourcePositions records the positions of every key and sequence item in the given YAML content, the line
+// offset is added to the lines for the YAML blocks that are embedded in other files.
+func yamlSourcePositions(content []byte, lineOffset int) SourcePositions {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil
+	}
+
+	positions := make(SourcePositions)
+	collectYamlPositions(&root, "", lineOffset, positions)
+
+	return positions
+}
+
+func collectYamlPositions(node *yaml.Node, prefix string, lineOffset int, positions SourcePositions) {
+	switch node.Kind { //nolint:exhaustive
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectYamlPositions(child, prefix, lineOffset, positions)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			field := key.Value
+			if prefix != "" {
+				field = prefix + "." + key.Value
+			}
+
+			positions[field] = Position{Line: key.Line + lineOffset, Column: key.Column}
+			collectYamlPositions(node.Content[i+1], field, lineOffset, positions)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			field := fmt.Sprintf("%s[%d]", prefix, i)
+			positions[field] = Position{Line: item.Line + lineOffset, Column: item.Column}
+			collectYamlPositions(item, field, lineOffset, positions)
+		}
+	}
+}
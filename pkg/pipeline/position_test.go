@@ -0,0 +1,95 @@
+package pipeline_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourcePositions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		creator  pipeline.TaskCreator
+		filePath string
+		want     map[string]pipeline.Position
+	}{
+		{
+			name:     "yaml definitions",
+			creator:  pipeline.CreateTaskFromYamlDefinition(afero.NewOsFs()),
+			filePath: filepath.Join("testdata", "yaml", "upstream.yml"),
+			want: map[string]pipeline.Position{
+				"name":                       {Line: 1, Column: 1},
+				"depends":                    {Line: 3, Column: 1},
+				"depends[2]":                 {Line: 6, Column: 6},
+				"depends[4].columns[1].name": {Line: 12, Column: 11},
+			},
+		},
+		{
+			name:     "embedded yaml comment blocks are offset by the rows before them",
+			creator:  pipeline.CreateTaskFromFileComments(afero.NewOsFs()),
+			filePath: filepath.Join("testdata", "comments", "embeddedyaml.sql"),
+			want: map[string]pipeline.Position{
+				"name":                     {Line: 3, Column: 1},
+				"materialization.strategy": {Line: 23, Column: 5},
+				"custom_checks[0].query":   {Line: 29, Column: 5},
+			},
+		},
+		{
+			name:     "single line comments",
+			creator:  pipeline.CreateTaskFromFileComments(afero.NewOsFs()),
+			filePath: filepath.Join("testdata", "comments", "test.sql"),
+			want: map[string]pipeline.Position{
+				"name":                     {Line: 1, Column: 11},
+				"depends":                  {Line: 4, Column: 11},
+				"parameters.param2":        {Line: 8, Column: 11},
+				"materialization.strategy": {Line: 14, Column: 11},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			asset, err := tt.creator(tt.filePath)
+			require.NoError(t, err)
+
+			for field, want := range tt.want {
+				got, ok := asset.SourcePositions.Lookup(field)
+				assert.True(t, ok, field)
+				assert.Equal(t, want, got, field)
+			}
+		})
+	}
+}
+
+func TestSourcePositions_Lookup(t *testing.T) {
+	t.Parallel()
+
+	positions := pipeline.SourcePositions{
+		"columns":         {Line: 3, Column: 1},
+		"columns[1]":      {Line: 6, Column: 3},
+		"columns[1].name": {Line: 6, Column: 5},
+	}
+
+	tests := map[string]pipeline.Position{
+		"columns[1].name":           {Line: 6, Column: 5},
+		"columns[1].checks[0].name": {Line: 6, Column: 3},
+		"columns[4].name":           {Line: 3, Column: 1},
+	}
+
+	for field, want := range tests {
+		got, ok := positions.Lookup(field)
+		assert.True(t, ok, field)
+		assert.Equal(t, want, got, field)
+	}
+
+	_, ok := positions.Lookup("materialization.strategy")
+	assert.False(t, ok)
+}
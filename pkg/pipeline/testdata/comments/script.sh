@@ -0,0 +1,9 @@
+#!/bin/bash
+# @bruin
+# name: exports.orders
+# description: exports the orders
+# depends:
+#   - raw.orders
+# @bruin
+
+echo "hello"
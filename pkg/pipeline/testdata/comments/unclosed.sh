@@ -0,0 +1,4 @@
+# @bruin
+# name: exports.orders
+
+echo "hello"
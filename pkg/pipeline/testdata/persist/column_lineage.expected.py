@@ -0,0 +1,14 @@
+"""@bruin
+
+name: myschema.enriched
+
+columns:
+  - name: user_id
+    type: integer
+    upstreams:
+      - column: id
+        table: raw.users
+
+@bruin"""
+
+print("hello")
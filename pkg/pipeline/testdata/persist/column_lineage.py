@@ -0,0 +1,12 @@
+"""@bruin
+name: myschema.enriched
+columns:
+  - name: user_id
+    type: integer
+    upstreams:
+      - table: raw.users
+        column: id
+
+@bruin"""
+
+print("hello")
@@ -0,0 +1,12 @@
+#!/usr/bin/env bash
+# @bruin
+# name: exports.daily_orders
+# depends:
+#   - raw.orders
+# secrets:
+#   - key: my-bucket
+#     inject_as: BUCKET
+# @bruin
+
+set -euo pipefail
+echo "exporting orders for $BRUIN_START_DATE to $BUCKET"
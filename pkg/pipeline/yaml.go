@@ -204,6 +204,8 @@ type materialization struct {
 	ClusterBy       clusterBy `yaml:"cluster_by"`
 	IncrementalKey  string    `yaml:"incremental_key"`
 	TimeGranularity string    `yaml:"time_granularity,omitempty"`
+	BatchSize       string    `yaml:"batch_size,omitempty"`
+	Lookback        int       `yaml:"lookback,omitempty"`
 }
 
 type columnCheckValue struct {
@@ -265,15 +267,24 @@ type columnUpstream struct {
 	Table  string `yaml:"table"`
 }
 
+type columnForeignKey struct {
+	Table  string `yaml:"table"`
+	Column string `yaml:"column"`
+}
+
 type column struct {
-	Extends       string           `yaml:"extends"`
-	Name          string           `yaml:"name"`
-	Type          string           `yaml:"type"`
-	Description   string           `yaml:"description"`
-	Tests         []columnCheck    `yaml:"checks"`
-	PrimaryKey    bool             `yaml:"primary_key"`
-	UpdateOnMerge bool             `yaml:"update_on_merge"`
-	Upstreams     []columnUpstream `yaml:"upstreams"`
+	Extends         string            `yaml:"extends"`
+	Name            string            `yaml:"name"`
+	Type            string            `yaml:"type"`
+	Description     string            `yaml:"description"`
+	Tests           []columnCheck     `yaml:"checks"`
+	PrimaryKey      bool              `yaml:"primary_key"`
+	UpdateOnMerge   bool              `yaml:"update_on_merge"`
+	Nullable        *bool             `yaml:"nullable"`
+	Default         string            `yaml:"default"`
+	ForeignKey      *columnForeignKey `yaml:"foreign_key"`
+	CheckConstraint string            `yaml:"check_constraint"`
+	Upstreams       []columnUpstream  `yaml:"upstreams"`
 }
 
 type secretMapping struct {
@@ -348,6 +359,7 @@ func CreateTaskFromYamlDefinition(fs afero.Fs) TaskCreator {
 		if err != nil {
 			return nil, err
 		}
+		task.SourcePositions = yamlSourcePositions(buf, 0)
 
 		executableFile := ExecutableFile{
 			Name:    filepath.Base(filePath),
@@ -390,6 +402,8 @@ func ConvertYamlToTask(content []byte) (*Asset, error) {
 		PartitionBy:     definition.Materialization.PartitionBy,
 		IncrementalKey:  definition.Materialization.IncrementalKey,
 		TimeGranularity: MaterializationTimeGranularity(strings.ToLower(definition.Materialization.TimeGranularity)),
+		BatchSize:       MaterializationBatchSize(strings.ToLower(definition.Materialization.BatchSize)),
+		Lookback:        definition.Materialization.Lookback,
 	}
 
 	columns := make([]Column, len(definition.Columns))
@@ -433,6 +447,18 @@ func ConvertYamlToTask(content []byte) (*Asset, error) {
 			}
 		}
 
+		var foreignKey *ColumnForeignKey
+		if column.ForeignKey != nil {
+			if column.ForeignKey.Table == "" || column.ForeignKey.Column == "" {
+				return nil, &ParseError{Msg: "'foreign_key' field must have both 'table' and 'column' set"}
+			}
+
+			foreignKey = &ColumnForeignKey{
+				Table:  column.ForeignKey.Table,
+				Column: column.ForeignKey.Column,
+			}
+		}
+
 		columns[index] = Column{
 			Name:            column.Name,
 			Type:            strings.TrimSpace(column.Type),
@@ -440,6 +466,10 @@ func ConvertYamlToTask(content []byte) (*Asset, error) {
 			Checks:          tests,
 			PrimaryKey:      column.PrimaryKey,
 			UpdateOnMerge:   column.UpdateOnMerge,
+			Nullable:        DefaultTrueBool{Value: column.Nullable},
+			Default:         column.Default,
+			ForeignKey:      foreignKey,
+			CheckConstraint: column.CheckConstraint,
 			EntityAttribute: entityDefinition,
 			Extends:         column.Extends,
 			Upstreams:       upstreamColumns,
@@ -240,6 +240,10 @@ func TestCreateTaskFromYamlDefinition(t *testing.T) {
 				require.NoError(t, err)
 			}
 
+			// the source positions are covered by TestSourcePositions
+			if got != nil {
+				got.SourcePositions = nil
+			}
 			require.Equal(t, tt.want, got)
 		})
 	}
@@ -255,6 +259,7 @@ func TestUpstreams(t *testing.T) {
 
 	// Normalize the line endings in the actual content
 	got.ExecutableFile.Content = strings.ReplaceAll(got.ExecutableFile.Content, "\r\n", "\n")
+	got.SourcePositions = nil
 
 	// Define the expected result, normalizing the content
 	expected := &pipeline.Asset{
@@ -311,3 +316,47 @@ func TestUpstreams(t *testing.T) {
 	// Compare the expected and actual results
 	require.Equal(t, expected, got)
 }
+
+func TestConvertYamlToTask_ColumnConstraints(t *testing.T) {
+	t.Parallel()
+
+	got, err := pipeline.ConvertYamlToTask([]byte(`
+name: my.orders
+type: duckdb.sql
+columns:
+  - name: id
+    type: integer
+    primary_key: true
+    nullable: false
+  - name: status
+    type: varchar
+    default: "'new'"
+    check_constraint: "status IN ('new', 'done')"
+  - name: user_id
+    type: integer
+    foreign_key:
+      table: my.users
+      column: id
+`))
+	require.NoError(t, err)
+	require.Len(t, got.Columns, 3)
+
+	require.False(t, got.Columns[0].Nullable.Bool())
+	require.True(t, got.Columns[0].HasConstraints())
+
+	require.True(t, got.Columns[1].Nullable.Bool())
+	require.Equal(t, "'new'", got.Columns[1].Default)
+	require.Equal(t, "status IN ('new', 'done')", got.Columns[1].CheckConstraint)
+
+	require.Equal(t, &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}, got.Columns[2].ForeignKey)
+	require.True(t, got.HasColumnConstraints())
+
+	_, err = pipeline.ConvertYamlToTask([]byte(`
+name: my.orders
+columns:
+  - name: user_id
+    foreign_key:
+      table: my.users
+`))
+	require.Error(t, err)
+}
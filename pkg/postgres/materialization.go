@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/helpers"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 )
@@ -30,6 +31,7 @@ var matMap = pipeline.AssetMaterializationMap{
 		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
 		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
 		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
 		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
 	},
 }
@@ -113,11 +115,38 @@ func buildMergeQuery(asset *pipeline.Asset, query string) (string, error) {
 
 func buildCreateReplaceQuery(task *pipeline.Asset, query string) (string, error) {
 	query = strings.TrimSuffix(query, ";")
+	if task.HasColumnConstraints() {
+		return buildCreateReplaceWithConstraintsQuery(task, query)
+	}
+
+	// the primary key does not need the column definitions, it is added to the table created from the query
+	primaryKeyQuery := ""
+	if primaryKeys := task.ColumnNamesWithPrimaryKey(); len(primaryKeys) > 0 {
+		primaryKeyQuery = fmt.Sprintf("\nALTER TABLE %s ADD PRIMARY KEY (%s);", task.Name, strings.Join(primaryKeys, ", "))
+	}
+
 	return fmt.Sprintf(
 		`BEGIN TRANSACTION;
 DROP TABLE IF EXISTS %s; 
-CREATE TABLE %s AS %s;
-COMMIT;`, task.Name, task.Name, query), nil
+CREATE TABLE %s AS %s;%s
+COMMIT;`, task.Name, task.Name, query, primaryKeyQuery), nil
+}
+
+// buildCreateReplaceWithConstraintsQuery creates the table from the column definitions first and inserts the query results
+// afterward, since the constraints and defaults cannot be defined with a "CREATE TABLE AS" statement.
+func buildCreateReplaceWithConstraintsQuery(task *pipeline.Asset, query string) (string, error) {
+	ddl, err := buildDDLQuery(task, query)
+	if err != nil {
+		return "", err
+	}
+
+	columns := strings.Join(ansisql.ColumnNames(task), ", ")
+	return fmt.Sprintf(
+		`BEGIN TRANSACTION;
+DROP TABLE IF EXISTS %s;
+%s;
+INSERT INTO %s (%s) SELECT %s FROM (%s) AS __bruin_source;
+COMMIT;`, task.Name, strings.TrimSuffix(ddl, ";"), task.Name, columns, columns, query), nil
 }
 
 func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error) {
@@ -157,14 +186,30 @@ func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error)
 func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 	columnDefs := make([]string, 0, len(asset.Columns))
 	primaryKeys := []string{}
+	foreignKeys := []string{}
 	columnComments := []string{}
 
 	for _, col := range asset.Columns {
 		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if !col.Nullable.Bool() {
+			def += " not null"
+		}
+		if col.Default != "" {
+			def += " default " + col.Default
+		}
+		if col.CheckConstraint != "" {
+			def += fmt.Sprintf(" check (%s)", col.CheckConstraint)
+		}
 
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, col.Name)
 		}
+		if col.ForeignKey != nil {
+			foreignKeys = append(foreignKeys, fmt.Sprintf(
+				"constraint %s foreign key (%s) references %s (%s)",
+				ansisql.ConstraintName("fk", asset.Name, col.Name), col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
+			))
+		}
 		columnDefs = append(columnDefs, def)
 
 		if col.Description != "" {
@@ -177,6 +222,7 @@ func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 		primaryKeyClause := fmt.Sprintf("primary key (%s)", strings.Join(primaryKeys, ", "))
 		columnDefs = append(columnDefs, primaryKeyClause)
 	}
+	columnDefs = append(columnDefs, foreignKeys...)
 
 	q := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n"+
 		"%s\n)",
@@ -10,6 +10,7 @@ import (
 
 func TestMaterializer_Render(t *testing.T) {
 	t.Parallel()
+	falseValue := false
 	tests := []struct {
 		name        string
 		task        *pipeline.Asset
@@ -64,6 +65,25 @@ COMMIT;`,
 DROP TABLE IF EXISTS my.asset; 
 CREATE TABLE my.asset AS SELECT 1;
 COMMIT;`,
+		},
+		{
+			name: "materialize to a table, the primary key is added after creating the table",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyCreateReplace,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+				},
+			},
+			query: "SELECT 1 as id",
+			want: `^BEGIN TRANSACTION;
+DROP TABLE IF EXISTS my.asset; 
+CREATE TABLE my.asset AS SELECT 1 as id;
+ALTER TABLE my.asset ADD PRIMARY KEY \(id\);
+COMMIT;$`,
 		},
 		{
 			name: "materialize to a table with append",
@@ -304,6 +324,39 @@ COMMIT;`,
 				"\\);\n" +
 				"COMMENT ON COLUMN my_composite_primary_key_table\\.category IS \\'Category of the item\\';",
 		},
+		{
+			name: "table with constraints and defaults",
+			task: &pipeline.Asset{
+				Name: "my.orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &falseValue}},
+					{Name: "amount", Type: "DOUBLE", Default: "0", CheckConstraint: "amount >= 0"},
+					{Name: "user_id", Type: "INT", ForeignKey: &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}},
+				},
+			},
+			want: "^CREATE TABLE IF NOT EXISTS my\\.orders \\(\nid INT not null,\namount DOUBLE default 0 check \\(amount >= 0\\),\nuser_id INT,\nprimary key \\(id\\),\nconstraint fk_my_orders_user_id foreign key \\(user_id\\) references my\\.users \\(id\\)\n\\)$",
+		},
+		{
+			name: "create+replace with constraints creates the table before inserting",
+			task: &pipeline.Asset{
+				Name: "my.orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyCreateReplace,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &falseValue}},
+					{Name: "amount", Type: "DOUBLE", Default: "0", CheckConstraint: "amount >= 0"},
+					{Name: "user_id", Type: "INT", ForeignKey: &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}},
+				},
+			},
+			query: "SELECT 1 as id;",
+			want:  "^BEGIN TRANSACTION;\nDROP TABLE IF EXISTS my\\.orders;\nCREATE TABLE IF NOT EXISTS my\\.orders \\(\nid INT not null,\namount DOUBLE default 0 check \\(amount >= 0\\),\nuser_id INT,\nprimary key \\(id\\),\nconstraint fk_my_orders_user_id foreign key \\(user_id\\) references my\\.users \\(id\\)\n\\);\nINSERT INTO my\\.orders \\(id, amount, user_id\\) SELECT id, amount, user_id FROM \\(SELECT 1 as id\\) AS __bruin_source;\nCOMMIT;$",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
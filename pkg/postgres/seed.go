@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pkg/errors"
+)
+
+// seedColumnTypes maps the seed types to the column types dlt would create in Postgres.
+var seedColumnTypes = map[string]string{
+	seed.TypeText:      "varchar",
+	seed.TypeBigint:    "bigint",
+	seed.TypeDouble:    "double precision",
+	seed.TypeBinary:    "bytea",
+	seed.TypeBool:      "boolean",
+	seed.TypeDate:      "date",
+	seed.TypeTimestamp: "timestamp with time zone",
+	seed.TypeTime:      "time without time zone",
+}
+
+// LoadSeed replaces the table with the seed data using `COPY FROM STDIN`, all in a single transaction so that the
+// table is never left half-loaded. Redshift does not support copying from the client, it falls back to ingestr.
+func (c *Client) LoadSeed(ctx context.Context, asset *pipeline.Asset, data *seed.Data) error {
+	if _, ok := c.config.(RedShiftConfig); ok {
+		return seed.ErrNotSupported
+	}
+
+	if err := c.CreateSchemaIfNotExist(ctx, asset); err != nil {
+		return err
+	}
+
+	tx, err := c.connection.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to start the transaction")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	for _, q := range buildSeedTableQueries(asset.Name, data) {
+		if _, err := tx.Exec(ctx, q); err != nil {
+			return errors.Wrapf(err, "failed to run query: %s", q)
+		}
+	}
+
+	columnNames := make([]string, len(data.Columns))
+	for i, col := range data.Columns {
+		columnNames[i] = col.Name
+	}
+
+	rows := make([][]any, len(data.Rows))
+	for i, row := range data.Rows {
+		rows[i] = make([]any, len(row))
+		for j, value := range row {
+			rows[i][j] = toCopyValue(value)
+		}
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier(strings.Split(asset.Name, ".")), columnNames, pgx.CopyFromRows(rows)); err != nil {
+		return errors.Wrap(err, "failed to copy the seed data")
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "failed to commit the seed data")
+}
+
+func buildSeedTableQueries(tableName string, data *seed.Data) []string {
+	columnDefs := make([]string, len(data.Columns))
+	for i, col := range data.Columns {
+		columnDefs[i] = fmt.Sprintf("%s %s", col.Name, seedColumnTypes[col.Type])
+	}
+
+	return []string{
+		"DROP TABLE IF EXISTS " + tableName,
+		fmt.Sprintf("CREATE TABLE %s (%s)", tableName, strings.Join(columnDefs, ", ")),
+	}
+}
+
+// toCopyValue converts the seed values that pgx cannot encode on its own.
+func toCopyValue(value any) any {
+	if d, ok := value.(time.Duration); ok {
+		return pgtype.Time{Microseconds: d.Microseconds(), Valid: true}
+	}
+
+	return value
+}
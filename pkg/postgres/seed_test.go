@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LoadSeed(t *testing.T) {
+	t.Parallel()
+
+	data := &seed.Data{
+		Columns: []seed.Column{
+			{Name: "id", Type: seed.TypeBigint},
+			{Name: "opens_at", Type: seed.TypeTime},
+			{Name: "name", Type: seed.TypeText},
+		},
+		Rows: [][]any{
+			{int64(1), 9 * time.Hour, "john"},
+			{int64(2), nil, nil},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		config    PgConfig
+		setupMock func(mock pgxmock.PgxPoolIface)
+		wantErr   string
+	}{
+		{
+			name:   "the table is replaced and the rows are copied in a transaction",
+			config: Config{},
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(regexp.QuoteMeta("CREATE SCHEMA IF NOT EXISTS RAW")).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DROP TABLE IF EXISTS raw.people")).WillReturnResult(pgxmock.NewResult("DROP", 0))
+				mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE raw.people (id bigint, opens_at time without time zone, name varchar)")).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom([]string{"raw", "people"}, []string{"id", "opens_at", "name"}).WillReturnResult(2)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "failed copies are rolled back",
+			config: Config{},
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(regexp.QuoteMeta("CREATE SCHEMA IF NOT EXISTS RAW")).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DROP TABLE IF EXISTS raw.people")).WillReturnResult(pgxmock.NewResult("DROP", 0))
+				mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE raw.people")).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom([]string{"raw", "people"}, []string{"id", "opens_at", "name"}).WillReturnError(errors.New("copy failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: "failed to copy the seed data: copy failed",
+		},
+		{
+			name:      "redshift is not supported",
+			config:    RedShiftConfig{},
+			setupMock: func(mock pgxmock.PgxPoolIface) {},
+			wantErr:   seed.ErrNotSupported.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			tt.setupMock(mock)
+
+			client := Client{connection: mock, config: tt.config, schemaCreator: ansisql.NewSchemaCreator()}
+			err = client.LoadSeed(context.Background(), &pipeline.Asset{Name: "raw.people"}, data)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestToCopyValue(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, pgtype.Time{Microseconds: 34200000000, Valid: true}, toCopyValue(9*time.Hour+30*time.Minute))
+	assert.Equal(t, int64(1), toCopyValue(int64(1)))
+	assert.Nil(t, toCopyValue(nil))
+}
@@ -2,12 +2,136 @@ package python
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
 )
 
+// ingestrStrategies maps the materialization strategies supported by Python assets to the incremental strategies of
+// ingestr that load the data the same way.
+var ingestrStrategies = map[pipeline.MaterializationStrategy]string{
+	pipeline.MaterializationStrategyCreateReplace: "replace",
+	pipeline.MaterializationStrategyAppend:        "append",
+	pipeline.MaterializationStrategyMerge:         "merge",
+	pipeline.MaterializationStrategyDeleteInsert:  "delete+insert",
+	// the rows of the run interval are deleted through the destination connection before the data is appended
+	pipeline.MaterializationStrategyTimeInterval: "append",
+}
+
+type queryRunner interface {
+	RunQueryWithoutResult(ctx context.Context, q *query.Query) error
+}
+
+// setIngestrStrategy sets the ingestr parameters of the asset for its materialization strategy, the primary keys that
+// are used by the 'merge' strategy are passed to ingestr separately. Without a strategy the parameters are left as they
+// are, which means the table is replaced unless the ingestr parameters are given explicitly. The ingestr parameters
+// that are given explicitly must agree with the materialization.
+func setIngestrStrategy(asset *pipeline.Asset) error {
+	mat := asset.Materialization
+	if mat.Strategy == pipeline.MaterializationStrategyNone {
+		return nil
+	}
+
+	strategy, ok := ingestrStrategies[mat.Strategy]
+	if !ok {
+		return errors.Errorf("materialization strategy '%s' is not supported for Python assets", mat.Strategy)
+	}
+
+	switch mat.Strategy { //nolint:exhaustive
+	case pipeline.MaterializationStrategyMerge:
+		if len(asset.ColumnNamesWithPrimaryKey()) == 0 {
+			return errors.New("materialization strategy 'merge' requires the 'primary_key' field to be set on at least one column")
+		}
+	case pipeline.MaterializationStrategyDeleteInsert:
+		if mat.IncrementalKey == "" {
+			return errors.Errorf("materialization strategy '%s' requires the 'incremental_key' field to be set", mat.Strategy)
+		}
+		if err := setIngestrParameter(asset, "incremental_key", mat.IncrementalKey, "materialization incremental key"); err != nil {
+			return err
+		}
+	case pipeline.MaterializationStrategyTimeInterval:
+		if mat.IncrementalKey == "" {
+			return errors.Errorf("materialization strategy '%s' requires the 'incremental_key' field to be set", mat.Strategy)
+		}
+		if mat.TimeGranularity != pipeline.MaterializationTimeGranularityDate && mat.TimeGranularity != pipeline.MaterializationTimeGranularityTimestamp {
+			return errors.Errorf("materialization strategy '%s' requires the 'time_granularity' field to be either 'date' or 'timestamp'", mat.Strategy)
+		}
+	}
+
+	return setIngestrParameter(asset, "incremental_strategy", strategy, fmt.Sprintf("materialization strategy '%s'", mat.Strategy))
+}
+
+// setIngestrParameter sets the ingestr parameter of the asset, unless it is already set to a different value.
+func setIngestrParameter(asset *pipeline.Asset, name, value, source string) error {
+	if existing, ok := asset.Parameters[name]; ok && existing != "" && existing != value {
+		return errors.Errorf("the '%s' parameter is set to '%s', which conflicts with the %s that requires '%s', please remove the parameter", name, existing, source, value)
+	}
+
+	if asset.Parameters == nil {
+		asset.Parameters = make(map[string]string)
+	}
+	asset.Parameters[name] = value
+
+	return nil
+}
+
+// timeIntervalDeleteQuery builds the query that deletes the rows of the run interval from the table of the asset, the
+// dates are formatted the same way as the `time_interval` strategy of the SQL assets.
+func timeIntervalDeleteQuery(ctx context.Context, asset *pipeline.Asset) (string, error) {
+	startDate, ok := ctx.Value(pipeline.RunConfigStartDate).(time.Time)
+	if !ok {
+		return "", errors.New("materialization strategy 'time_interval' requires a start date for the run")
+	}
+
+	endDate, ok := ctx.Value(pipeline.RunConfigEndDate).(time.Time)
+	if !ok {
+		return "", errors.New("materialization strategy 'time_interval' requires an end date for the run")
+	}
+
+	if applyModifiers, ok := ctx.Value(pipeline.RunConfigApplyIntervalModifiers).(bool); ok && applyModifiers {
+		startDate = pipeline.ModifyDate(startDate, asset.IntervalModifiers.Start)
+		endDate = pipeline.ModifyDate(endDate, asset.IntervalModifiers.End)
+	}
+
+	layout := "2006-01-02T15:04:05.000000Z07:00"
+	if asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate {
+		layout = "2006-01-02"
+	}
+
+	return fmt.Sprintf(
+		"DELETE FROM %s WHERE %s BETWEEN '%s' AND '%s'",
+		asset.Name, asset.Materialization.IncrementalKey, startDate.Format(layout), endDate.Format(layout),
+	), nil
+}
+
+// deleteTimeInterval deletes the rows of the run interval from the table of the asset before the data is appended.
+// The table is recreated on full refreshes, which means there is nothing to delete then.
+func deleteTimeInterval(ctx context.Context, asset *pipeline.Asset, destConnection any) error {
+	if fullRefresh, ok := ctx.Value(pipeline.RunConfigFullRefresh).(bool); ok && fullRefresh {
+		return nil
+	}
+
+	runner, ok := destConnection.(queryRunner)
+	if !ok {
+		return errors.New("materialization strategy 'time_interval' requires a destination connection that can run queries")
+	}
+
+	deleteQuery, err := timeIntervalDeleteQuery(ctx, asset)
+	if err != nil {
+		return err
+	}
+
+	if err := runner.RunQueryWithoutResult(ctx, &query.Query{Query: deleteQuery}); err != nil {
+		return errors.Wrap(err, "failed to delete the rows of the run interval")
+	}
+
+	return nil
+}
+
 func ConsolidatedParameters(ctx context.Context, asset *pipeline.Asset, cmdArgs []string) ([]string, error) {
 	if value, exists := asset.Parameters["incremental_key"]; exists && value != "" {
 		cmdArgs = append(cmdArgs, "--incremental-key", value)
@@ -3,8 +3,10 @@ package python
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -113,3 +115,181 @@ func TestAddExtraPackages(t *testing.T) {
 		})
 	}
 }
+
+func TestSetIngestrStrategy(t *testing.T) {
+	t.Parallel()
+
+	primaryKey := []pipeline.Column{{Name: "id", PrimaryKey: true}, {Name: "name"}}
+
+	tests := []struct {
+		name     string
+		asset    *pipeline.Asset
+		expected []string
+		wantErr  string
+	}{
+		{
+			name:     "no strategy leaves the parameters as they are",
+			asset:    &pipeline.Asset{Parameters: map[string]string{"incremental_strategy": "append"}},
+			expected: []string{"--incremental-strategy", "append"},
+		},
+		{
+			name:     "create+replace",
+			asset:    &pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyCreateReplace}},
+			expected: []string{"--incremental-strategy", "replace"},
+		},
+		{
+			name:     "append",
+			asset:    &pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyAppend}},
+			expected: []string{"--incremental-strategy", "append"},
+		},
+		{
+			name: "merge uses the primary keys",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyMerge},
+				Columns:         primaryKey,
+			},
+			expected: []string{"--incremental-strategy", "merge", "--primary-key", "id"},
+		},
+		{
+			name:    "merge without primary keys",
+			asset:   &pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyMerge}},
+			wantErr: "materialization strategy 'merge' requires the 'primary_key' field to be set on at least one column",
+		},
+		{
+			name: "delete+insert",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyDeleteInsert, IncrementalKey: "dt"},
+			},
+			expected: []string{"--incremental-key", "dt", "--incremental-strategy", "delete+insert"},
+		},
+		{
+			name: "the parameters that agree with the materialization",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyDeleteInsert, IncrementalKey: "dt"},
+				Parameters:      map[string]string{"incremental_strategy": "delete+insert", "incremental_key": "dt"},
+			},
+			expected: []string{"--incremental-key", "dt", "--incremental-strategy", "delete+insert"},
+		},
+		{
+			name: "a conflicting incremental strategy parameter",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyAppend},
+				Parameters:      map[string]string{"incremental_strategy": "merge"},
+			},
+			wantErr: "the 'incremental_strategy' parameter is set to 'merge', which conflicts with the materialization strategy 'append' that requires 'append', please remove the parameter",
+		},
+		{
+			name: "a conflicting incremental key parameter",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyDeleteInsert, IncrementalKey: "dt"},
+				Parameters:      map[string]string{"incremental_key": "updated_at"},
+			},
+			wantErr: "the 'incremental_key' parameter is set to 'updated_at', which conflicts with the materialization incremental key that requires 'dt', please remove the parameter",
+		},
+		{
+			name: "time_interval appends the data",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyTimeInterval, IncrementalKey: "dt", TimeGranularity: pipeline.MaterializationTimeGranularityDate},
+			},
+			expected: []string{"--incremental-strategy", "append"},
+		},
+		{
+			name:    "time_interval without an incremental key",
+			asset:   &pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyTimeInterval, TimeGranularity: pipeline.MaterializationTimeGranularityDate}},
+			wantErr: "materialization strategy 'time_interval' requires the 'incremental_key' field to be set",
+		},
+		{
+			name:    "time_interval without a time granularity",
+			asset:   &pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyTimeInterval, IncrementalKey: "dt"}},
+			wantErr: "materialization strategy 'time_interval' requires the 'time_granularity' field to be either 'date' or 'timestamp'",
+		},
+		{
+			name:    "unsupported strategy",
+			asset:   &pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyDDL}},
+			wantErr: "materialization strategy 'ddl' is not supported for Python assets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := setIngestrStrategy(tt.asset)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			args, err := ConsolidatedParameters(context.Background(), tt.asset, []string{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, args)
+		})
+	}
+}
+
+type mockQueryRunner struct {
+	queries []string
+}
+
+func (m *mockQueryRunner) RunQueryWithoutResult(ctx context.Context, q *query.Query) error {
+	m.queries = append(m.queries, q.Query)
+	return nil
+}
+
+func TestDeleteTimeInterval(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.WithValue(context.Background(), pipeline.RunConfigStartDate, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))
+	ctx = context.WithValue(ctx, pipeline.RunConfigEndDate, time.Date(2025, 1, 2, 23, 59, 59, 999999999, time.UTC))
+
+	newAsset := func(granularity pipeline.MaterializationTimeGranularity) *pipeline.Asset {
+		return &pipeline.Asset{
+			Name: "incremental.daily_sales",
+			Materialization: pipeline.Materialization{
+				Type:            pipeline.MaterializationTypeTable,
+				Strategy:        pipeline.MaterializationStrategyTimeInterval,
+				IncrementalKey:  "dt",
+				TimeGranularity: granularity,
+			},
+			IntervalModifiers: pipeline.IntervalModifiers{Start: pipeline.TimeModifier{Days: -1}},
+		}
+	}
+
+	t.Run("timestamp granularity", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &mockQueryRunner{}
+		require.NoError(t, deleteTimeInterval(ctx, newAsset(pipeline.MaterializationTimeGranularityTimestamp), runner))
+		assert.Equal(t, []string{
+			"DELETE FROM incremental.daily_sales WHERE dt BETWEEN '2025-01-02T00:00:00.000000Z' AND '2025-01-02T23:59:59.999999Z'",
+		}, runner.queries)
+	})
+
+	t.Run("date granularity with the interval modifiers", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &mockQueryRunner{}
+		modifiedCtx := context.WithValue(ctx, pipeline.RunConfigApplyIntervalModifiers, true)
+		require.NoError(t, deleteTimeInterval(modifiedCtx, newAsset(pipeline.MaterializationTimeGranularityDate), runner))
+		assert.Equal(t, []string{
+			"DELETE FROM incremental.daily_sales WHERE dt BETWEEN '2025-01-01' AND '2025-01-02'",
+		}, runner.queries)
+	})
+
+	t.Run("full refresh recreates the table", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &mockQueryRunner{}
+		fullRefreshCtx := context.WithValue(ctx, pipeline.RunConfigFullRefresh, true)
+		require.NoError(t, deleteTimeInterval(fullRefreshCtx, newAsset(pipeline.MaterializationTimeGranularityDate), runner))
+		assert.Empty(t, runner.queries)
+	})
+
+	t.Run("destination without queries", func(t *testing.T) {
+		t.Parallel()
+
+		err := deleteTimeInterval(ctx, newAsset(pipeline.MaterializationTimeGranularityDate), struct{}{})
+		require.EqualError(t, err, "materialization strategy 'time_interval' requires a destination connection that can run queries")
+	})
+}
@@ -136,14 +136,17 @@ func (l *CommandRunner) RunAnyCommand(ctx context.Context, cmd *exec.Cmd) error
 		return errors.Wrap(err, "failed to start CommandInstance")
 	}
 
+	// the pipes must be drained before waiting for the command since Wait closes them, otherwise the output of the
+	// commands that exit quickly is lost
+	pipeErr := wg.Wait()
+
 	res := cmd.Wait()
 	if res != nil {
 		return res
 	}
 
-	err = wg.Wait()
-	if err != nil {
-		return errors.Wrap(err, "failed to consume pipe")
+	if pipeErr != nil {
+		return errors.Wrap(pipeErr, "failed to consume pipe")
 	}
 
 	return nil
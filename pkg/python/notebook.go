@@ -0,0 +1,114 @@
+package python
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/connection"
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/pkg/errors"
+)
+
+// notebookParameterVariables maps the parameters injected into the notebooks to the environment variables they are
+// taken from.
+var notebookParameterVariables = map[string]string{
+	"start_date":      "BRUIN_START_DATE",
+	"start_datetime":  "BRUIN_START_DATETIME",
+	"start_timestamp": "BRUIN_START_TIMESTAMP",
+	"end_date":        "BRUIN_END_DATE",
+	"end_datetime":    "BRUIN_END_DATETIME",
+	"end_timestamp":   "BRUIN_END_TIMESTAMP",
+	"run_id":          "BRUIN_RUN_ID",
+	"pipeline":        "BRUIN_PIPELINE",
+	"asset":           "BRUIN_ASSET",
+}
+
+func NewNotebookOperator(config *config.Config, conn *connection.Manager, envVariables map[string]string) *LocalOperator {
+	return &LocalOperator{
+		repoFinder: &git.RepoFinder{},
+		module:     &ModulePathFinder{},
+		runner: &UvNotebookRunner{
+			Cmd: &CommandRunner{},
+			UvInstaller: &UvChecker{
+				cmd: CommandRunner{},
+			},
+		},
+		envVariables: envVariables,
+		config:       config,
+		conn:         conn,
+	}
+}
+
+// UvNotebookRunner executes Jupyter notebooks headless with papermill in an isolated environment, the run parameters
+// are injected into the notebook the same way papermill does.
+type UvNotebookRunner struct {
+	Cmd         cmd
+	UvInstaller uvInstaller
+}
+
+func (u *UvNotebookRunner) Run(ctx context.Context, execCtx *executionContext) error {
+	binaryFullPath, err := u.UvInstaller.EnsureUvInstalled(ctx)
+	if err != nil {
+		return err
+	}
+
+	parameters, err := notebookParameters(execCtx.envVariables)
+	if err != nil {
+		return err
+	}
+
+	outputDir, err := os.MkdirTemp("", "bruin-notebook-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a directory for the executed notebook")
+	}
+	defer os.RemoveAll(outputDir)
+
+	notebookPath := execCtx.asset.ExecutableFile.Path
+	flags := []string{"run", "--python", pythonVersionForAsset(execCtx.asset), "--with", "papermill", "--with", "ipykernel"}
+	if execCtx.requirementsTxt != "" {
+		flags = append(flags, "--with-requirements", execCtx.requirementsTxt)
+	}
+
+	flags = append(flags,
+		"papermill", notebookPath, filepath.Join(outputDir, filepath.Base(notebookPath)),
+		"--kernel", "python3",
+		"--cwd", filepath.Dir(notebookPath),
+		"--parameters_yaml", parameters,
+		"--log-output",
+		"--no-progress-bar",
+	)
+
+	return u.Cmd.Run(ctx, execCtx.repo, &CommandInstance{
+		Name:    binaryFullPath,
+		Args:    flags,
+		EnvVars: execCtx.envVariables,
+	})
+}
+
+// notebookParameters builds the parameters of the notebook from the pipeline variables and the run details, the run
+// details take precedence over the variables with the same name. The result is JSON, which papermill accepts as YAML.
+func notebookParameters(envVariables map[string]string) (string, error) {
+	parameters := make(map[string]any)
+	if variables := envVariables["BRUIN_VARS"]; variables != "" {
+		if err := json.Unmarshal([]byte(variables), &parameters); err != nil {
+			return "", errors.Wrap(err, "failed to parse the pipeline variables")
+		}
+	}
+
+	for name, variable := range notebookParameterVariables {
+		if value, ok := envVariables[variable]; ok {
+			parameters[name] = value
+		}
+	}
+	parameters["full_refresh"] = envVariables["BRUIN_FULL_REFRESH"] == "1"
+
+	content, err := json.Marshal(parameters)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build the notebook parameters")
+	}
+
+	return string(content), nil
+}
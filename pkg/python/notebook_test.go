@@ -0,0 +1,76 @@
+package python
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_uvNotebookRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	repo := &git.Repo{Path: "/repo"}
+	envVariables := map[string]string{"BRUIN_START_DATE": "2024-01-01"}
+
+	cmd := new(mockCmd)
+	cmd.On("Run", mock.Anything, repo, mock.MatchedBy(func(c *CommandInstance) bool {
+		if c.Name != "~/.bruin/uv" || len(c.Args) != 20 {
+			return false
+		}
+
+		return assert.ObjectsAreEqual([]string{"run", "--python", "3.12", "--with", "papermill", "--with", "ipykernel", "--with-requirements", "/repo/requirements.txt", "papermill", "/repo/assets/analysis.ipynb"}, c.Args[:11]) &&
+			filepath.Base(c.Args[11]) == "analysis.ipynb" &&
+			assert.ObjectsAreEqual([]string{"--kernel", "python3", "--cwd", "/repo/assets", "--parameters_yaml", `{"full_refresh":false,"start_date":"2024-01-01"}`, "--log-output", "--no-progress-bar"}, c.Args[12:20]) &&
+			assert.ObjectsAreEqual(envVariables, c.EnvVars)
+	})).Return(nil)
+
+	inst := new(mockUvInstaller)
+	inst.On("EnsureUvInstalled", mock.Anything).Return("~/.bruin/uv", nil)
+
+	runner := &UvNotebookRunner{Cmd: cmd, UvInstaller: inst}
+	err := runner.Run(context.Background(), &executionContext{
+		repo:            repo,
+		requirementsTxt: "/repo/requirements.txt",
+		envVariables:    envVariables,
+		asset: &pipeline.Asset{
+			Image:          "python:3.12",
+			ExecutableFile: pipeline.ExecutableFile{Path: "/repo/assets/analysis.ipynb"},
+		},
+	})
+	require.NoError(t, err)
+	cmd.AssertExpectations(t)
+}
+
+func TestNotebookParameters(t *testing.T) {
+	t.Parallel()
+
+	got, err := notebookParameters(map[string]string{
+		"BRUIN_START_DATE":   "2024-01-01",
+		"BRUIN_END_DATE":     "2024-01-02",
+		"BRUIN_RUN_ID":       "run-1",
+		"BRUIN_FULL_REFRESH": "1",
+		"BRUIN_VARS":         `{"env":"dev","start_date":"overridden","users":["jhon"]}`,
+	})
+	require.NoError(t, err)
+
+	var parameters map[string]any
+	require.NoError(t, json.Unmarshal([]byte(got), &parameters))
+	assert.Equal(t, map[string]any{
+		"env":          "dev",
+		"users":        []any{"jhon"},
+		"start_date":   "2024-01-01",
+		"end_date":     "2024-01-02",
+		"run_id":       "run-1",
+		"full_refresh": true,
+	}, parameters)
+
+	_, err = notebookParameters(map[string]string{"BRUIN_VARS": "not-json"})
+	require.Error(t, err)
+}
@@ -2,7 +2,6 @@ package python
 
 import (
 	"context"
-	"fmt"
 	"os/exec"
 	"strings"
 
@@ -49,13 +48,14 @@ type LocalOperator struct {
 	runner       localRunner
 	envVariables map[string]string
 	config       secretFinder
+	conn         connectionFetcher
 }
 
 type secretFinder interface {
 	GetSecretByKey(key string) (string, error)
 }
 
-func NewLocalOperator(config *config.Config, envVariables map[string]string) *LocalOperator {
+func NewLocalOperator(config *config.Config, conn *connection.Manager, envVariables map[string]string) *LocalOperator {
 	cmdRunner := &CommandRunner{}
 	fs := afero.NewOsFs()
 
@@ -79,6 +79,7 @@ func NewLocalOperator(config *config.Config, envVariables map[string]string) *Lo
 		},
 		envVariables: envVariables,
 		config:       config,
+		conn:         conn,
 	}
 }
 
@@ -97,6 +98,7 @@ func NewLocalOperatorWithUv(config *config.Config, conn *connection.Manager, env
 		},
 		envVariables: envVariables,
 		config:       config,
+		conn:         conn,
 	}
 }
 
@@ -157,18 +159,22 @@ func (o *LocalOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pi
 	}
 	envVariables["BRUIN_ASSET"] = t.Name
 
-	for _, mapping := range t.Secrets {
-		val, err := o.config.GetSecretByKey(mapping.SecretKey)
-		if err != nil {
-			return errors.Wrapf(err, "there's no secret with the name '%s', make sure you are referring to the right secret and the secret is defined correctly in your .bruin.yml file.", mapping.SecretKey)
-		}
+	if err := env.InjectSecrets(envVariables, t.Secrets, o.config); err != nil {
+		return err
+	}
 
-		if val == "" {
-			return errors.New(fmt.Sprintf("there's no secret with the name '%s', make sure you are referring to the right secret and the secret is defined correctly in your .bruin.yml file.", mapping.SecretKey))
-		}
+	// the connection details are only available when the config is able to describe the connections
+	details, _ := o.config.(connectionDetailsFinder)
+	assetContext, err := buildAssetContext(p, t, envVariables, details, o.conn)
+	if err != nil {
+		return errors.Wrap(err, "failed to build the Bruin context")
+	}
 
-		envVariables[mapping.InjectedKey] = val
+	cleanup, err := prepareSDK(assetContext, envVariables)
+	if err != nil {
+		return err
 	}
+	defer cleanup()
 
 	err = o.runner.Run(ctx, &executionContext{
 		repo:            repo,
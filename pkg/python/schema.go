@@ -0,0 +1,325 @@
+package python
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// outputColumn is a column of the dataframe returned by a Python asset, the type is the string representation of the
+// Arrow type, e.g. `int64` or `timestamp[ns, tz=UTC]`.
+type outputColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func readOutputSchema(path string) ([]outputColumn, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the output schema of the asset")
+	}
+
+	var columns []outputColumn
+	if err := json.Unmarshal(content, &columns); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the output schema of the asset")
+	}
+
+	return columns, nil
+}
+
+var typeParameters = regexp.MustCompile(`[(<\[].*$`)
+
+var declaredTypeFamilies = map[string]string{
+	"int": "integer", "integer": "integer", "int2": "integer", "int4": "integer", "int8": "integer", "int16": "integer",
+	"int32": "integer", "int64": "integer", "bigint": "integer", "smallint": "integer", "tinyint": "integer",
+	"byteint": "integer", "hugeint": "integer", "ubigint": "integer", "uinteger": "integer", "usmallint": "integer",
+	"utinyint": "integer", "long": "integer", "short": "integer", "serial": "integer", "bigserial": "integer",
+
+	"float": "float", "float4": "float", "float8": "float", "float32": "float", "float64": "float", "double": "float",
+	"double precision": "float", "real": "float",
+
+	"decimal": "decimal", "numeric": "decimal", "number": "decimal", "bignumeric": "decimal", "bigdecimal": "decimal",
+
+	"string": "string", "varchar": "string", "char": "string", "character": "string", "character varying": "string",
+	"text": "string", "nvarchar": "string", "nchar": "string", "uuid": "string", "json": "string", "jsonb": "string",
+
+	"bool": "boolean", "boolean": "boolean",
+
+	"timestamp": "timestamp", "datetime": "timestamp", "timestamptz": "timestamp", "timestamp_ntz": "timestamp",
+	"timestamp_ltz": "timestamp", "timestamp_tz": "timestamp", "timestamp with time zone": "timestamp",
+	"timestamp without time zone": "timestamp",
+
+	"date": "date",
+	"time": "time",
+
+	"bytes": "binary", "binary": "binary", "varbinary": "binary", "blob": "binary", "bytea": "binary",
+
+	"array": "array", "list": "array",
+	"struct": "struct", "record": "struct", "object": "struct",
+	"map":      "map",
+	"interval": "interval",
+}
+
+// declaredTypeFamily returns the family of a type declared in the asset columns, or an empty string if it is unknown.
+func declaredTypeFamily(declared string) string {
+	declared = strings.TrimSpace(strings.ToLower(declared))
+	if strings.HasSuffix(declared, "[]") {
+		return "array"
+	}
+
+	return declaredTypeFamilies[strings.TrimSpace(typeParameters.ReplaceAllString(declared, ""))]
+}
+
+// arrowTypeFamily returns the family of an Arrow type, or an empty string if it is unknown or the column only holds
+// nulls.
+func arrowTypeFamily(arrowType string) string {
+	arrowType = strings.TrimSpace(strings.ToLower(arrowType))
+	if values, ok := strings.CutPrefix(arrowType, "dictionary<values="); ok {
+		values, _, _ = strings.Cut(values, ",")
+		return arrowTypeFamily(values)
+	}
+
+	base := typeParameters.ReplaceAllString(arrowType, "")
+	switch {
+	case strings.HasPrefix(base, "int"), strings.HasPrefix(base, "uint"):
+		return "integer"
+	case base == "halffloat", base == "float", base == "double":
+		return "float"
+	case strings.HasPrefix(base, "decimal"):
+		return "decimal"
+	case base == "string", base == "large_string", base == "utf8", base == "large_utf8", base == "string_view":
+		return "string"
+	case base == "bool":
+		return "boolean"
+	case base == "timestamp":
+		return "timestamp"
+	case strings.HasPrefix(base, "date"):
+		return "date"
+	case strings.HasPrefix(base, "time"):
+		return "time"
+	case strings.HasSuffix(base, "binary"):
+		return "binary"
+	case strings.HasSuffix(base, "list"):
+		return "array"
+	case base == "struct":
+		return "struct"
+	case base == "map":
+		return "map"
+	case base == "duration", strings.HasPrefix(base, "month_interval"), strings.HasPrefix(base, "month_day_nano_interval"):
+		return "interval"
+	}
+
+	return ""
+}
+
+func typeFamiliesMatch(declared, observed string) bool {
+	if declared == "" || observed == "" || declared == observed {
+		return true
+	}
+
+	// numbers without a scale are commonly declared as decimals, e.g. `NUMBER` in Snowflake
+	return declared == "decimal" && (observed == "integer" || observed == "float")
+}
+
+// validateOutputSchema compares the columns of the returned dataframe with the columns declared in the asset, and
+// returns an error describing every difference between the two.
+func validateOutputSchema(asset *pipeline.Asset, observed []outputColumn) error {
+	if len(asset.Columns) == 0 {
+		return errors.New("the 'contract' output schema requires the columns of the asset to be declared")
+	}
+
+	observedByName := make(map[string]outputColumn, len(observed))
+	for _, column := range observed {
+		observedByName[strings.ToLower(column.Name)] = column
+	}
+
+	differences := make([]string, 0)
+	declaredNames := make(map[string]bool, len(asset.Columns))
+	for _, column := range asset.Columns {
+		declaredNames[strings.ToLower(column.Name)] = true
+
+		found, ok := observedByName[strings.ToLower(column.Name)]
+		if !ok {
+			differences = append(differences, fmt.Sprintf("  - %s: declared but not returned by the asset", column.Name))
+			continue
+		}
+
+		if !typeFamiliesMatch(declaredTypeFamily(column.Type), arrowTypeFamily(found.Type)) {
+			differences = append(differences, fmt.Sprintf("  ~ %s: declared as '%s', the asset returned '%s'", column.Name, column.Type, found.Type))
+		}
+	}
+
+	for _, column := range observed {
+		if !declaredNames[strings.ToLower(column.Name)] {
+			differences = append(differences, fmt.Sprintf("  + %s: returned by the asset as '%s' but not declared", column.Name, column.Type))
+		}
+	}
+
+	if len(differences) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("the output of the asset does not match its declared columns:\n%s", strings.Join(differences, "\n"))
+}
+
+var arrowDecimalParameters = regexp.MustCompile(`^decimal(?:32|64|128|256)?\((\d+),\s*(\d+)\)$`)
+
+// platformColumnTypes maps the type families to the column types of the platforms, keyed by the scheme of the
+// ingestr URI of the destination. The families that are missing cannot be described without the element types,
+// the columns of those families are left without a type.
+var platformColumnTypes = map[string]map[string]string{
+	"duckdb": {
+		"integer": "BIGINT", "float": "DOUBLE", "decimal": "DECIMAL", "string": "VARCHAR", "boolean": "BOOLEAN",
+		"timestamp": "TIMESTAMP", "timestamptz": "TIMESTAMP WITH TIME ZONE", "date": "DATE", "time": "TIME",
+		"binary": "BLOB", "interval": "INTERVAL",
+	},
+	"postgresql": {
+		"integer": "bigint", "float": "double precision", "decimal": "numeric", "string": "varchar", "boolean": "boolean",
+		"timestamp": "timestamp", "timestamptz": "timestamptz", "date": "date", "time": "time", "binary": "bytea",
+		"interval": "interval",
+	},
+	"redshift": {
+		"integer": "BIGINT", "float": "DOUBLE PRECISION", "decimal": "DECIMAL", "string": "VARCHAR", "boolean": "BOOLEAN",
+		"timestamp": "TIMESTAMP", "timestamptz": "TIMESTAMPTZ", "date": "DATE", "time": "TIME", "binary": "VARBYTE",
+	},
+	"bigquery": {
+		"integer": "INT64", "float": "FLOAT64", "decimal": "NUMERIC", "string": "STRING", "boolean": "BOOL",
+		"timestamp": "DATETIME", "timestamptz": "TIMESTAMP", "date": "DATE", "time": "TIME", "binary": "BYTES",
+		"interval": "INTERVAL",
+	},
+	"snowflake": {
+		"integer": "INTEGER", "float": "FLOAT", "decimal": "NUMBER", "string": "VARCHAR", "boolean": "BOOLEAN",
+		"timestamp": "TIMESTAMP_NTZ", "timestamptz": "TIMESTAMP_TZ", "date": "DATE", "time": "TIME", "binary": "BINARY",
+		"array": "ARRAY", "struct": "OBJECT", "map": "OBJECT",
+	},
+	"databricks": {
+		"integer": "BIGINT", "float": "DOUBLE", "decimal": "DECIMAL", "string": "STRING", "boolean": "BOOLEAN",
+		"timestamp": "TIMESTAMP_NTZ", "timestamptz": "TIMESTAMP", "date": "DATE", "binary": "BINARY",
+	},
+	"mssql": {
+		"integer": "BIGINT", "float": "FLOAT", "decimal": "DECIMAL", "string": "NVARCHAR(MAX)", "boolean": "BIT",
+		"timestamp": "DATETIME2", "timestamptz": "DATETIMEOFFSET", "date": "DATE", "time": "TIME",
+		"binary": "VARBINARY(MAX)",
+	},
+	"clickhouse": {
+		"integer": "Int64", "float": "Float64", "decimal": "Decimal", "string": "String", "boolean": "Bool",
+		"timestamp": "DateTime64", "timestamptz": "DateTime64", "date": "Date32", "binary": "String",
+	},
+}
+
+// platformColumnType returns the column type of the given Arrow type in the platform of the destination URI, or an
+// empty string if the platform or the type is unknown.
+func platformColumnType(destURI, arrowType string) string {
+	scheme, _, _ := strings.Cut(destURI, "://")
+	types, ok := platformColumnTypes[strings.ToLower(scheme)]
+	if !ok {
+		return ""
+	}
+
+	family := arrowTypeFamily(arrowType)
+	arrowType = strings.TrimSpace(strings.ToLower(arrowType))
+	switch family {
+	case "timestamp":
+		if strings.Contains(arrowType, "tz=") {
+			family = "timestamptz"
+		}
+	case "decimal":
+		if parameters := arrowDecimalParameters.FindStringSubmatch(arrowType); parameters != nil && types[family] != "" {
+			return fmt.Sprintf("%s(%s,%s)", types[family], parameters[1], parameters[2])
+		}
+	}
+
+	return types[family]
+}
+
+// inferColumns updates the columns of the asset with the columns of the returned dataframe, using the column types of
+// the platform of the destination and keeping the metadata of the existing columns. The declared columns that are not
+// returned are kept as well, since they might carry checks and descriptions, and their names are returned so that they
+// can be reported. The returned boolean is true if the columns of the asset have changed.
+func inferColumns(asset *pipeline.Asset, observed []outputColumn, destURI string) (bool, []string) {
+	observedByName := make(map[string]outputColumn, len(observed))
+	for _, column := range observed {
+		observedByName[strings.ToLower(column.Name)] = column
+	}
+
+	changed := false
+	missing := make([]string, 0)
+	columns := make([]pipeline.Column, 0, len(observed))
+	existing := make(map[string]bool, len(asset.Columns))
+	for _, column := range asset.Columns {
+		existing[strings.ToLower(column.Name)] = true
+
+		found, ok := observedByName[strings.ToLower(column.Name)]
+		if !ok {
+			missing = append(missing, column.Name)
+			columns = append(columns, column)
+			continue
+		}
+
+		columnType := platformColumnType(destURI, found.Type)
+		if columnType != "" && columnType != column.Type && (column.Type == "" || !typeFamiliesMatch(declaredTypeFamily(column.Type), arrowTypeFamily(found.Type))) {
+			column.Type = columnType
+			changed = true
+		}
+		columns = append(columns, column)
+	}
+
+	for _, column := range observed {
+		if existing[strings.ToLower(column.Name)] {
+			continue
+		}
+
+		columns = append(columns, pipeline.Column{
+			Name:   column.Name,
+			Type:   platformColumnType(destURI, column.Type),
+			Checks: []pipeline.ColumnCheck{},
+		})
+		changed = true
+	}
+
+	asset.Columns = columns
+	return changed, missing
+}
+
+// applyOutputSchema enforces or infers the columns of the asset based on its `output_schema` setting, using the schema
+// of the dataframe that is exported next to the data.
+func applyOutputSchema(asset *pipeline.Asset, schemaFilePath, destURI string, output io.Writer) error {
+	mode := asset.Materialization.OutputSchema
+	if mode == pipeline.MaterializationOutputSchemaNone {
+		return nil
+	}
+
+	observed, err := readOutputSchema(schemaFilePath)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case pipeline.MaterializationOutputSchemaContract:
+		return validateOutputSchema(asset, observed)
+	case pipeline.MaterializationOutputSchemaInfer:
+		changed, missing := inferColumns(asset, observed, destURI)
+		for _, name := range missing {
+			_, _ = fmt.Fprintf(output, "Warning: the column '%s' is declared in the asset but not returned by it, keeping its definition.\n", name)
+		}
+		if !changed {
+			return nil
+		}
+
+		if err := asset.Persist(afero.NewOsFs()); err != nil {
+			return errors.Wrap(err, "failed to write the inferred columns to the asset definition")
+		}
+
+		_, _ = output.Write([]byte("Updated the columns of the asset definition with the schema of the returned data.\n"))
+		return nil
+	default:
+		return fmt.Errorf("unsupported output schema '%s', it must be either 'contract' or 'infer'", mode)
+	}
+}
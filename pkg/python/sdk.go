@@ -0,0 +1,232 @@
+package python
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// sdkModule is the `bruin` Python module that is made available to every Python asset, it reads the context file.
+//
+//go:embed sdk/bruin.py
+var sdkModule string
+
+const contextEnvVariable = "BRUIN_CONTEXT"
+
+type connectionDetailsFinder interface {
+	GetSecretByKey(key string) (string, error)
+	GetConnectionType(name string) string
+}
+
+type contextColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	PrimaryKey  bool   `json:"primary_key"`
+}
+
+type contextAsset struct {
+	Name        string          `json:"name"`
+	Type        string          `json:"type"`
+	Connection  string          `json:"connection"`
+	Description string          `json:"description"`
+	Owner       string          `json:"owner"`
+	Tags        []string        `json:"tags"`
+	Columns     []contextColumn `json:"columns"`
+}
+
+type contextConnection struct {
+	Type       string         `json:"type"`
+	Details    map[string]any `json:"details"`
+	URI        string         `json:"uri,omitempty"`
+	IngestrURI string         `json:"ingestr_uri,omitempty"`
+}
+
+// sqlAlchemyDialects maps the schemes of the ingestr URIs to the SQLAlchemy dialects and drivers that accept the rest of
+// the URI as it is.
+var sqlAlchemyDialects = map[string]string{
+	"postgresql": "postgresql",
+	"redshift":   "redshift+psycopg2",
+	"mysql":      "mysql+pymysql",
+	"mssql":      "mssql+pyodbc",
+	"clickhouse": "clickhouse+native",
+	"snowflake":  "snowflake",
+	"bigquery":   "bigquery",
+	"duckdb":     "duckdb",
+}
+
+// sqlAlchemyURI returns the SQLAlchemy URI of the connection based on its ingestr URI, or an empty string if the
+// platform is not supported by SQLAlchemy.
+func sqlAlchemyURI(ingestrURI string) string {
+	scheme, rest, ok := strings.Cut(ingestrURI, "://")
+	if !ok {
+		return ""
+	}
+
+	// the driver is already given explicitly, e.g. `mysql+mysqldb`
+	if strings.Contains(scheme, "+") {
+		return ingestrURI
+	}
+
+	dialect, ok := sqlAlchemyDialects[scheme]
+	if !ok {
+		return ""
+	}
+
+	return dialect + "://" + rest
+}
+
+// assetContext is the content of the context file the `bruin` Python module reads.
+type assetContext struct {
+	Pipeline      string                        `json:"pipeline"`
+	RunID         string                        `json:"run_id"`
+	StartDate     string                        `json:"start_date"`
+	EndDate       string                        `json:"end_date"`
+	StartDatetime string                        `json:"start_datetime"`
+	EndDatetime   string                        `json:"end_datetime"`
+	FullRefresh   bool                          `json:"full_refresh"`
+	Asset         contextAsset                  `json:"asset"`
+	Variables     map[string]any                `json:"variables"`
+	Connections   map[string]*contextConnection `json:"connections"`
+}
+
+// buildAssetContext gathers the context of the asset, the interval is taken from the environment variables of the asset
+// since the interval modifiers are already applied to them. Only the connections listed in the secrets of the asset are
+// included, the connection of the asset is only named unless it is listed in the secrets as well.
+func buildAssetContext(p *pipeline.Pipeline, t *pipeline.Asset, envVariables map[string]string, details connectionDetailsFinder, conn connectionFetcher) (*assetContext, error) {
+	ac := &assetContext{
+		Pipeline:      envVariables["BRUIN_PIPELINE"],
+		RunID:         envVariables["BRUIN_RUN_ID"],
+		StartDate:     envVariables["BRUIN_START_DATE"],
+		EndDate:       envVariables["BRUIN_END_DATE"],
+		StartDatetime: envVariables["BRUIN_START_DATETIME"],
+		EndDatetime:   envVariables["BRUIN_END_DATETIME"],
+		FullRefresh:   envVariables["BRUIN_FULL_REFRESH"] == "1",
+		Asset: contextAsset{
+			Name:        t.Name,
+			Type:        string(t.Type),
+			Connection:  t.Connection,
+			Description: t.Description,
+			Owner:       t.Owner,
+			Tags:        t.Tags,
+			Columns:     make([]contextColumn, 0, len(t.Columns)),
+		},
+		Variables:   map[string]any{},
+		Connections: map[string]*contextConnection{},
+	}
+	if ac.Asset.Tags == nil {
+		ac.Asset.Tags = []string{}
+	}
+	for _, column := range t.Columns {
+		ac.Asset.Columns = append(ac.Asset.Columns, contextColumn{
+			Name:        column.Name,
+			Type:        column.Type,
+			Description: column.Description,
+			PrimaryKey:  column.PrimaryKey,
+		})
+	}
+
+	if p != nil {
+		ac.Variables = p.Variables.Value()
+		if t.Connection != "" || t.Materialization.Type != pipeline.MaterializationTypeNone {
+			if name, err := p.GetConnectionNameForAsset(t); err == nil && name != "" {
+				ac.Asset.Connection = name
+			}
+		}
+	}
+
+	connectionNames := make([]string, 0, len(t.Secrets))
+	for _, secret := range t.Secrets {
+		connectionNames = append(connectionNames, secret.SecretKey)
+	}
+
+	if details == nil {
+		return ac, nil
+	}
+
+	for _, name := range connectionNames {
+		if _, ok := ac.Connections[name]; ok {
+			continue
+		}
+
+		connType := details.GetConnectionType(name)
+		if connType == "" {
+			continue
+		}
+
+		value, err := details.GetSecretByKey(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the details of the connection '%s'", name)
+		}
+
+		cc := &contextConnection{Type: connType, Details: map[string]any{}}
+		if connType == "generic" {
+			cc.Details["value"] = value
+		} else if err := json.Unmarshal([]byte(value), &cc.Details); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the details of the connection '%s'", name)
+		}
+
+		if conn != nil {
+			if instance, err := conn.GetConnection(name); err == nil {
+				if uriGetter, ok := instance.(pipelineConnection); ok {
+					if uri, err := uriGetter.GetIngestrURI(); err == nil {
+						cc.IngestrURI = uri
+						cc.URI = sqlAlchemyURI(uri)
+					}
+				}
+			}
+		}
+
+		ac.Connections[name] = cc
+	}
+
+	return ac, nil
+}
+
+// prepareSDK writes the context file and the `bruin` module to a temporary directory and points the environment
+// variables of the asset to them. The returned function removes the directory.
+func prepareSDK(ac *assetContext, envVariables map[string]string) (func(), error) {
+	dir, err := os.MkdirTemp("", "bruin-sdk-*")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a directory for the Bruin context")
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	content, err := json.Marshal(ac)
+	if err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, "failed to marshal the Bruin context")
+	}
+
+	contextFile := filepath.Join(dir, "context.json")
+	if err := os.WriteFile(contextFile, content, 0o600); err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, "failed to write the Bruin context")
+	}
+
+	modulePath := filepath.Join(dir, "lib")
+	if err := os.Mkdir(modulePath, 0o700); err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, "failed to create a directory for the Bruin module")
+	}
+	if err := os.WriteFile(filepath.Join(modulePath, "bruin.py"), []byte(sdkModule), 0o600); err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, "failed to write the Bruin module")
+	}
+
+	envVariables[contextEnvVariable] = contextFile
+	pythonPath := []string{modulePath}
+	if existing := envVariables["PYTHONPATH"]; existing != "" {
+		pythonPath = append(pythonPath, existing)
+	}
+	envVariables["PYTHONPATH"] = strings.Join(pythonPath, string(os.PathListSeparator))
+
+	return cleanup, nil
+}
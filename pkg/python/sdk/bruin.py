@@ -0,0 +1,167 @@
+"""Access the context of the Bruin asset that is being run.
+
+The module is made available to every Python asset run by Bruin, e.g.:
+
+    from bruin import context, get_connection
+
+    def materialize():
+        conn = get_connection("my-postgres")
+        return conn.read_sql(f"select * from orders where dt >= '{context.start_date}'")
+"""
+
+import json
+import os
+from dataclasses import dataclass, field
+from datetime import date, datetime
+from typing import Any, Dict, List, Optional
+
+CONTEXT_ENV_VARIABLE = "BRUIN_CONTEXT"
+
+__all__ = ["Asset", "Column", "Connection", "Context", "context", "get_connection", "load_context"]
+
+
+@dataclass
+class Column:
+    name: str
+    type: str = ""
+    description: str = ""
+    primary_key: bool = False
+
+
+@dataclass
+class Asset:
+    name: str
+    type: str = ""
+    connection: str = ""
+    description: str = ""
+    owner: str = ""
+    tags: List[str] = field(default_factory=list)
+    columns: List[Column] = field(default_factory=list)
+
+
+@dataclass
+class Connection:
+    """A connection defined in the `.bruin.yml` file.
+
+    `details` holds the fields of the connection as they are defined in the `.bruin.yml` file, `uri` is the
+    SQLAlchemy URI of the connection for the databases that support it, and `ingestr_uri` is the URI ingestr uses
+    for the connection.
+    """
+
+    name: str
+    type: str
+    details: Dict[str, Any] = field(default_factory=dict)
+    uri: Optional[str] = None
+    ingestr_uri: Optional[str] = None
+
+    def sqlalchemy_uri(self) -> str:
+        if not self.uri:
+            raise ValueError(f"connection '{self.name}' of type '{self.type}' does not have a SQLAlchemy URI")
+        return self.uri
+
+    def engine(self, **kwargs):
+        """Returns a SQLAlchemy engine for the connection, `sqlalchemy` and the driver of the database must be installed."""
+        from sqlalchemy import create_engine
+
+        return create_engine(self.sqlalchemy_uri(), **kwargs)
+
+    def connect(self):
+        """Returns a DB-API connection, it must be closed by the caller."""
+        return self.engine().raw_connection()
+
+    def read_sql(self, query: str, **kwargs):
+        """Runs the query and returns the result as a pandas dataframe, `pandas` must be installed."""
+        import pandas as pd
+
+        engine = self.engine()
+        try:
+            with engine.connect() as conn:
+                return pd.read_sql(query, conn, **kwargs)
+        finally:
+            engine.dispose()
+
+
+@dataclass
+class Context:
+    pipeline: str
+    run_id: str
+    start_date: date
+    end_date: date
+    start_datetime: datetime
+    end_datetime: datetime
+    full_refresh: bool
+    asset: Asset
+    variables: Dict[str, Any] = field(default_factory=dict)
+    connections: Dict[str, Connection] = field(default_factory=dict)
+
+    def get_connection(self, name: str) -> Connection:
+        if name not in self.connections:
+            available = ", ".join(sorted(self.connections)) or "none"
+            raise KeyError(
+                f"connection '{name}' is not available to the asset, make sure it is listed in its secrets, "
+                f"available connections: {available}"
+            )
+        return self.connections[name]
+
+
+def load_context(path: Optional[str] = None) -> Context:
+    """Reads the context file Bruin prepares for the asset, by default from the `BRUIN_CONTEXT` environment variable."""
+    path = path or os.environ.get(CONTEXT_ENV_VARIABLE)
+    if not path:
+        raise RuntimeError(f"the {CONTEXT_ENV_VARIABLE} environment variable is not set, is the asset run by Bruin?")
+
+    with open(path) as f:
+        raw = json.load(f)
+
+    asset = raw.get("asset") or {}
+    return Context(
+        pipeline=raw.get("pipeline", ""),
+        run_id=raw.get("run_id", ""),
+        start_date=date.fromisoformat(raw["start_date"]),
+        end_date=date.fromisoformat(raw["end_date"]),
+        start_datetime=datetime.fromisoformat(raw["start_datetime"]),
+        end_datetime=datetime.fromisoformat(raw["end_datetime"]),
+        full_refresh=bool(raw.get("full_refresh", False)),
+        asset=Asset(
+            name=asset.get("name", ""),
+            type=asset.get("type", ""),
+            connection=asset.get("connection", ""),
+            description=asset.get("description", ""),
+            owner=asset.get("owner", ""),
+            tags=asset.get("tags") or [],
+            columns=[Column(**column) for column in asset.get("columns") or []],
+        ),
+        variables=raw.get("variables") or {},
+        connections={
+            name: Connection(
+                name=name,
+                type=conn.get("type", ""),
+                details=conn.get("details") or {},
+                uri=conn.get("uri"),
+                ingestr_uri=conn.get("ingestr_uri"),
+            )
+            for name, conn in (raw.get("connections") or {}).items()
+        },
+    )
+
+
+_context: Optional[Context] = None
+
+
+def _get_context() -> Context:
+    global _context
+    if _context is None:
+        _context = load_context()
+    return _context
+
+
+def get_connection(name: str) -> Connection:
+    """Returns the connection with the given name, it must be listed in the secrets of the asset."""
+    return _get_context().get_connection(name)
+
+
+def __getattr__(name: str):
+    # the context is loaded lazily so that importing the module outside of Bruin does not fail
+    if name == "context":
+        return _get_context()
+    raise AttributeError(f"module {__name__!r} has no attribute {name!r}")
@@ -17,6 +17,7 @@ import (
 	duck "github.com/bruin-data/bruin/pkg/duckdb"
 	"github.com/bruin-data/bruin/pkg/executor"
 	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/user"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
@@ -164,20 +165,25 @@ func (u *UvPythonRunner) Run(ctx context.Context, execCtx *executionContext) err
 
 	u.binaryFullPath = binaryFullPath
 
+	pythonVersion := pythonVersionForAsset(execCtx.asset)
+	if execCtx.asset.Materialization.Type == "" {
+		return u.runWithNoMaterialization(ctx, execCtx, pythonVersion)
+	}
+
+	return u.runWithMaterialization(ctx, execCtx, pythonVersion)
+}
+
+// pythonVersionForAsset returns the Python version from the `python:<version>` image of the asset, 3.11 by default.
+func pythonVersionForAsset(asset *pipeline.Asset) string {
 	pythonVersion := "3.11"
-	if execCtx.asset.Image != "" {
-		image := execCtx.asset.Image
-		parts := strings.Split(image, ":")
+	if asset.Image != "" {
+		parts := strings.Split(asset.Image, ":")
 		if len(parts) > 1 && parts[0] == "python" && AvailablePythonVersions[parts[1]] {
 			pythonVersion = parts[1]
 		}
 	}
 
-	if execCtx.asset.Materialization.Type == "" {
-		return u.runWithNoMaterialization(ctx, execCtx, pythonVersion)
-	}
-
-	return u.runWithMaterialization(ctx, execCtx, pythonVersion)
+	return pythonVersion
 }
 
 func (u *UvPythonRunner) RunIngestr(ctx context.Context, args, extraPackages []string, repo *git.Repo) error {
@@ -237,6 +243,11 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 		_ = os.Remove(name)
 	}(arrowFilePath)
 
+	schemaFilePath := arrowFilePath + ".schema.json"
+	defer func(name string) {
+		_ = os.Remove(name)
+	}(schemaFilePath)
+
 	tempPyScript, err := os.CreateTemp("", "bruin-arrow-*.py")
 	if err != nil {
 		return fmt.Errorf("failed to create temp file: %w", err)
@@ -251,6 +262,7 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 	arrowScript := strings.ReplaceAll(PythonArrowTemplate, "$REPO_ROOT", strings.ReplaceAll(execCtx.repo.Path, "\\", "\\\\"))
 	arrowScript = strings.ReplaceAll(arrowScript, "$MODULE_PATH", execCtx.module)
 	arrowScript = strings.ReplaceAll(arrowScript, "$ARROW_FILE_PATH", strings.ReplaceAll(arrowFilePath, "\\", "\\\\"))
+	arrowScript = strings.ReplaceAll(arrowScript, "$SCHEMA_FILE_PATH", strings.ReplaceAll(schemaFilePath, "\\", "\\\\"))
 
 	if _, err := io.WriteString(tempPyScript, arrowScript); err != nil {
 		return fmt.Errorf("failed to write to temp file: %w", err)
@@ -272,23 +284,49 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 		return errors.Wrap(err, "failed to run asset code")
 	}
 
+	destConnectionName, err := execCtx.pipeline.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return err
+	}
+
+	destConnection, err := u.conn.GetConnection(destConnectionName)
+	if err != nil {
+		return fmt.Errorf("destination connection %s not found", destConnectionName)
+	}
+
+	destConnectionInst, ok := destConnection.(pipelineConnection)
+	if !ok {
+		return errors.New("destination connection does not implement pipelineConnection, please create an issue in Bruin repo: https://github.com/bruin-data/bruin")
+	}
+
+	destURI, err := destConnectionInst.GetIngestrURI()
+	if err != nil {
+		return errors.New("could not get the source uri")
+	}
+
+	if destURI == "" {
+		return errors.New("could not determine the destination, please set the `connection` value in the asset definition.")
+	}
+
 	var output io.Writer = os.Stdout
 	if ctx.Value(executor.KeyPrinter) != nil {
 		output = ctx.Value(executor.KeyPrinter).(io.Writer)
 	}
 
-	_, _ = output.Write([]byte("Successfully collected the data from the asset, uploading to the destination...\n"))
-
-	if len(asset.Parameters) == 0 {
-		asset.Parameters = make(map[string]string)
+	if err := applyOutputSchema(asset, schemaFilePath, destURI, output); err != nil {
+		return err
 	}
 
-	if mat.Strategy != "" {
-		asset.Parameters["incremental_strategy"] = string(mat.Strategy)
+	_, _ = output.Write([]byte("Successfully collected the data from the asset, uploading to the destination...\n"))
+
+	if err := setIngestrStrategy(asset); err != nil {
+		return err
 	}
 
-	if mat.IncrementalKey != "" {
-		asset.Parameters["incremental_key"] = mat.IncrementalKey
+	if mat.Strategy == pipeline.MaterializationStrategyTimeInterval {
+		if err := deleteTimeInterval(ctx, asset, destConnection); err != nil {
+			return err
+		}
 	}
 
 	// build ingestr flags
@@ -308,30 +346,6 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 		return err
 	}
 
-	destConnectionName, err := execCtx.pipeline.GetConnectionNameForAsset(asset)
-	if err != nil {
-		return err
-	}
-
-	destConnection, err := u.conn.GetConnection(destConnectionName)
-	if err != nil {
-		return fmt.Errorf("destination connection %s not found", destConnectionName)
-	}
-
-	destConnectionInst, ok := destConnection.(pipelineConnection)
-	if !ok {
-		return errors.New("destination connection does not implement pipelineConnection, please create an issue in Bruin repo: https://github.com/bruin-data/bruin")
-	}
-
-	destURI, err := destConnectionInst.GetIngestrURI()
-	if err != nil {
-		return errors.New("could not get the source uri")
-	}
-
-	if destURI == "" {
-		return errors.New("could not determine the destination, please set the `connection` value in the asset definition.")
-	}
-
 	cmdArgs = append(cmdArgs, "--dest-uri", destURI)
 
 	if strings.HasPrefix(destURI, "duckdb://") {
@@ -415,6 +429,7 @@ const PythonArrowTemplate = `
 # ///
 
 import sys
+import json
 import importlib.util
 from pathlib import Path
 
@@ -446,4 +461,7 @@ with pa.OSFile("$ARROW_FILE_PATH", 'wb') as f:
 	writer = ipc.new_file(f, table.schema)
 	writer.write_table(table)
 	writer.close()
+
+with open("$SCHEMA_FILE_PATH", 'w') as f:
+	json.dump([{"name": field.name, "type": str(field.type)} for field in table.schema], f)
 `
@@ -0,0 +1,30 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// NewCommand returns the `bruin run` command for a run that is executed in a separate process, so that the run cannot
+// affect the process starting it or the other runs. The args are the flags and the path passed to `bruin run`.
+//
+// When the context is cancelled the run is interrupted the same way as with ctrl+c, so that it can stop its tasks and
+// save its state, and it is killed if it does not stop within the grace period.
+func NewCommand(ctx context.Context, executable string, args []string, runID string, gracePeriod time.Duration) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, executable, append([]string{"run", "--no-color"}, args...)...) //nolint:gosec
+	cmd.Env = append(os.Environ(), "BRUIN_RUN_ID="+runID, "NO_COLOR=1")
+	cmd.Cancel = func() error {
+		if runtime.GOOS == "windows" {
+			// signals cannot be sent to other processes on Windows, the run is stopped right away instead
+			return cmd.Process.Kill()
+		}
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = gracePeriod
+
+	return cmd
+}
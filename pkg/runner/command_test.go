@@ -0,0 +1,52 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := NewCommand(context.Background(), "/usr/local/bin/bruin", []string{"--full-refresh", "/pipelines/acme"}, "run-1", 5*time.Second)
+
+	assert.Equal(t, []string{"/usr/local/bin/bruin", "run", "--no-color", "--full-refresh", "/pipelines/acme"}, cmd.Args)
+	assert.Contains(t, cmd.Env, "BRUIN_RUN_ID=run-1")
+	assert.Contains(t, cmd.Env, "NO_COLOR=1")
+	assert.Equal(t, 5*time.Second, cmd.WaitDelay)
+}
+
+func TestNewCommand_Cancel(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the runs are killed on Windows")
+	}
+
+	// the fake bruin exits with a distinct code when it is interrupted, which it can only do if it gets a SIGTERM
+	executable := filepath.Join(t.TempDir(), "bruin")
+	script := "#!/bin/sh\ntrap 'exit 3' TERM\necho started\nwhile true; do sleep 0.01; done\n"
+	require.NoError(t, os.WriteFile(executable, []byte(script), 0o755)) //nolint:gosec
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := NewCommand(ctx, executable, nil, "run-1", 5*time.Second)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+
+	// wait for the trap to be set up before interrupting the run
+	_, err = stdout.Read(make([]byte, 8))
+	require.NoError(t, err)
+	cancel()
+
+	err = cmd.Wait()
+	require.Error(t, err)
+	assert.Equal(t, 3, cmd.ProcessState.ExitCode())
+}
@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -143,6 +145,7 @@ type RunConfig struct {
 	ConfigFilePath         string   `json:"configFilePath"`
 	SensorMode             string   `json:"sensorMode"`
 	ApplyIntervalModifiers bool     `json:"applyIntervalModifiers"`
+	SparkLocal             bool     `json:"sparkLocal"`
 }
 
 type PipelineAssetState struct {
@@ -298,7 +301,7 @@ func (i InstancesByType) AddDownstreamByType(instanceType TaskInstanceType, down
 type Scheduler struct {
 	logger           logger.Logger
 	taskScheduleLock sync.Mutex
-	pipeline         *pipeline.Pipeline
+	pipelines        []*pipeline.Pipeline
 
 	taskInstances []TaskInstance
 	taskNameMap   map[string]InstancesByType
@@ -306,7 +309,14 @@ type Scheduler struct {
 	WorkQueue chan TaskInstance
 	Results   chan *TaskExecutionResult
 
-	runID string
+	runID          string
+	onTaskFinished func()
+}
+
+// OnTaskFinished registers a function that is called from the scheduler loop every time the result of a task is
+// processed, e.g. to persist the progress of the run.
+func (s *Scheduler) OnTaskFinished(fn func()) {
+	s.onTaskFinished = fn
 }
 
 func (s *Scheduler) InstanceCount() int {
@@ -459,10 +469,86 @@ func (s *Scheduler) WillRunTaskOfType(taskType pipeline.AssetType) bool {
 }
 
 func (s *Scheduler) FindMajorityOfTypes(defaultIfNone pipeline.AssetType) pipeline.AssetType {
-	return s.pipeline.GetMajorityAssetTypesFromSQLAssets(defaultIfNone)
+	if len(s.pipelines) == 1 {
+		return s.pipelines[0].GetMajorityAssetTypesFromSQLAssets(defaultIfNone)
+	}
+
+	combined := &pipeline.Pipeline{}
+	for _, p := range s.pipelines {
+		combined.Assets = append(combined.Assets, p.Assets...)
+	}
+
+	return combined.GetMajorityAssetTypesFromSQLAssets(defaultIfNone)
 }
 
 func NewScheduler(logger logger.Logger, p *pipeline.Pipeline, runID string) *Scheduler {
+	s := &Scheduler{
+		logger:           logger,
+		pipelines:        []*pipeline.Pipeline{p},
+		taskInstances:    newInstancesForPipeline(p),
+		taskScheduleLock: sync.Mutex{},
+		WorkQueue:        make(chan TaskInstance, 100),
+		Results:          make(chan *TaskExecutionResult),
+		runID:            runID,
+	}
+	s.initialize()
+
+	return s
+}
+
+// NewSchedulerForPipelines builds a single DAG out of the assets of multiple pipelines, so that the assets that depend
+// on assets in other pipelines through their URIs run after them. The instances keep a reference to their own
+// pipeline, which means the connection defaults and the variables of each pipeline are still applied separately.
+func NewSchedulerForPipelines(logger logger.Logger, pipelines []*pipeline.Pipeline, runID string) (*Scheduler, error) {
+	owners := make(map[string]string)
+	instances := make([]TaskInstance, 0)
+	for _, p := range pipelines {
+		for _, asset := range p.Assets {
+			if owner, ok := owners[asset.Name]; ok {
+				return nil, fmt.Errorf("the asset '%s' is defined in both pipelines '%s' and '%s', asset names must be unique across the pipelines that run together", asset.Name, owner, p.Name)
+			}
+			owners[asset.Name] = p.Name
+		}
+
+		instances = append(instances, newInstancesForPipeline(p)...)
+	}
+
+	// the asset names are only resolved within the pipeline they are declared in, the dependencies across pipelines
+	// must use the URIs so that each pipeline stays valid on its own
+	for _, p := range pipelines {
+		for _, asset := range p.Assets {
+			for _, dep := range asset.Upstreams {
+				if dep.Type != "asset" {
+					continue
+				}
+
+				if owner, ok := owners[dep.Value]; ok && owner != p.Name {
+					return nil, fmt.Errorf("the asset '%s' in the pipeline '%s' depends on the asset '%s' of the pipeline '%s' by its name, the dependencies across pipelines must refer to the `uri` of the upstream asset instead", asset.Name, p.Name, dep.Value, owner)
+				}
+			}
+		}
+	}
+
+	s := &Scheduler{
+		logger:           logger,
+		pipelines:        pipelines,
+		taskInstances:    instances,
+		taskScheduleLock: sync.Mutex{},
+		WorkQueue:        make(chan TaskInstance, 100),
+		Results:          make(chan *TaskExecutionResult),
+		runID:            runID,
+	}
+	s.initialize()
+	s.constructCrossPipelineRelationships()
+
+	if cycle := s.findCycle(); len(cycle) > 0 {
+		return nil, fmt.Errorf("the dependencies between the pipelines contain a cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	return s, nil
+}
+
+func newInstancesForPipeline(p *pipeline.Pipeline) []TaskInstance {
 	instances := make([]TaskInstance, 0)
 	for _, task := range p.Assets {
 		parentID := uuid.New().String()
@@ -529,18 +615,7 @@ func NewScheduler(logger logger.Logger, p *pipeline.Pipeline, runID string) *Sch
 		}
 	}
 
-	s := &Scheduler{
-		logger:           logger,
-		pipeline:         p,
-		taskInstances:    instances,
-		taskScheduleLock: sync.Mutex{},
-		WorkQueue:        make(chan TaskInstance, 100),
-		Results:          make(chan *TaskExecutionResult),
-		runID:            runID,
-	}
-	s.initialize()
-
-	return s
+	return instances
 }
 
 func (s *Scheduler) initialize() {
@@ -631,6 +706,94 @@ func (s *Scheduler) constructInstanceRelationships() {
 	}
 }
 
+// constructCrossPipelineRelationships links the assets to the assets of the other pipelines they depend on, the
+// dependencies across pipelines are declared with the URIs of the upstream assets, the dependencies by name are
+// rejected when the scheduler is created.
+func (s *Scheduler) constructCrossPipelineRelationships() {
+	instancesByURI := make(map[string][]TaskInstance)
+	for _, ti := range s.taskInstances {
+		if ti.GetType() != TaskInstanceTypeMain || ti.GetAsset().URI == "" {
+			continue
+		}
+
+		instancesByURI[ti.GetAsset().URI] = append(instancesByURI[ti.GetAsset().URI], ti)
+	}
+
+	for _, ti := range s.taskInstances {
+		if ti.GetType() != TaskInstanceTypeMain {
+			continue
+		}
+
+		for _, dep := range ti.GetAsset().Upstreams {
+			if dep.Mode == pipeline.UpstreamModeSymbolic || dep.Type != "uri" {
+				continue
+			}
+
+			for _, upstreamMain := range instancesByURI[dep.Value] {
+				if upstreamMain.GetPipeline() == ti.GetPipeline() {
+					continue
+				}
+
+				for _, instances := range s.taskNameMap[upstreamMain.GetAsset().Name] {
+					for _, upstream := range instances {
+						if !upstream.Blocking() {
+							continue
+						}
+
+						ti.AddUpstream(upstream)
+						upstream.AddDownstream(ti)
+					}
+				}
+			}
+		}
+	}
+}
+
+// findCycle returns the names of the assets that form a cycle, or nil if the instances form a DAG.
+func (s *Scheduler) findCycle() []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	states := make(map[TaskInstance]int, len(s.taskInstances))
+	path := make([]TaskInstance, 0)
+
+	var visit func(ti TaskInstance) []string
+	visit = func(ti TaskInstance) []string {
+		switch states[ti] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := make([]string, 0)
+			for i := slices.Index(path, ti); i < len(path); i++ {
+				cycle = append(cycle, path[i].GetHumanID())
+			}
+			return append(cycle, ti.GetHumanID())
+		}
+
+		states[ti] = visiting
+		path = append(path, ti)
+		for _, downstream := range ti.GetDownstream() {
+			if cycle := visit(downstream); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		states[ti] = visited
+
+		return nil
+	}
+
+	for _, ti := range s.taskInstances {
+		if cycle := visit(ti); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
 func (s *Scheduler) Run(ctx context.Context) []*TaskExecutionResult {
 	results := make([]*TaskExecutionResult, 0)
 	if len(s.GetTaskInstancesByStatus(Pending)) == 0 {
@@ -650,6 +813,9 @@ func (s *Scheduler) Run(ctx context.Context) []*TaskExecutionResult {
 			s.logger.Debug("received task result: ", result.Instance.GetAsset().Name)
 			results = append(results, result)
 			finished := s.Tick(result)
+			if s.onTaskFinished != nil {
+				s.onTaskFinished()
+			}
 			if finished {
 				s.logger.Debug("pipeline has completed, finishing the scheduler loop")
 				return results
@@ -768,7 +934,7 @@ func (s *Scheduler) SavePipelineState(fs afero.Fs, param *RunConfig, runID, stat
 		Version:           "1.0.0",
 		TimeStamp:         time.Now(),
 		RunID:             runID,
-		CompatibilityHash: s.pipeline.GetCompatibilityHash(),
+		CompatibilityHash: s.compatibilityHash(),
 	}
 	file := filepath.Join(statePath, runID+".json")
 	if err := helpers.WriteJSONToFile(fs, pipelineState, file); err != nil {
@@ -778,8 +944,23 @@ func (s *Scheduler) SavePipelineState(fs afero.Fs, param *RunConfig, runID, stat
 	return nil
 }
 
+// compatibilityHash identifies the structure of the pipelines the scheduler runs, so that a saved state is only
+// restored into the same set of assets.
+func (s *Scheduler) compatibilityHash() string {
+	if len(s.pipelines) == 1 {
+		return s.pipelines[0].GetCompatibilityHash()
+	}
+
+	hash := sha256.New()
+	for _, p := range s.pipelines {
+		hash.Write([]byte(p.GetCompatibilityHash()))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func (s *Scheduler) RestoreState(state *PipelineState) error {
-	if s.pipeline.GetCompatibilityHash() != state.CompatibilityHash {
+	if s.compatibilityHash() != state.CompatibilityHash {
 		return errors.New("the pipeline has changed since the last run; please rerun the pipeline")
 	}
 	stateMap := make(map[string]string)
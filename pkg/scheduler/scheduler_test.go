@@ -634,3 +634,99 @@ func TestScheduler_RestoreState(t *testing.T) {
 	assert.Equal(t, expectedState.RunID, pipelineState.RunID, "RunID should match")
 	assert.Equal(t, expectedState.Version, pipelineState.Version, "Version should match")
 }
+
+func TestNewSchedulerForPipelines(t *testing.T) {
+	t.Parallel()
+
+	upstream := &pipeline.Pipeline{
+		Name: "upstream",
+		Assets: []*pipeline.Asset{
+			{Name: "raw.events", URI: "bigquery://project.raw.events"},
+		},
+	}
+	downstream := &pipeline.Pipeline{
+		Name: "downstream",
+		Assets: []*pipeline.Asset{
+			{
+				Name: "mart.events",
+				Upstreams: []pipeline.Upstream{
+					{Type: "uri", Value: "bigquery://project.raw.events"},
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+				},
+			},
+		},
+	}
+
+	s, err := NewSchedulerForPipelines(zap.NewNop().Sugar(), []*pipeline.Pipeline{downstream, upstream}, "test")
+	require.NoError(t, err)
+
+	s.Kickstart()
+
+	ti := <-s.WorkQueue
+	assert.Equal(t, "raw.events", ti.GetAsset().Name)
+	assert.Equal(t, upstream, ti.GetPipeline())
+	assert.False(t, s.Tick(&TaskExecutionResult{Instance: ti}))
+
+	ti = <-s.WorkQueue
+	assert.Equal(t, "mart.events", ti.GetAsset().Name)
+	assert.Equal(t, downstream, ti.GetPipeline())
+	assert.False(t, s.Tick(&TaskExecutionResult{Instance: ti}))
+
+	ti = <-s.WorkQueue
+	assert.Equal(t, "mart.events:id:not_null", ti.GetHumanID())
+	assert.Equal(t, downstream, ti.GetPipeline())
+	assert.True(t, s.Tick(&TaskExecutionResult{Instance: ti}))
+}
+
+func TestNewSchedulerForPipelines_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pipelines []*pipeline.Pipeline
+		wantErr   string
+	}{
+		{
+			name: "duplicate asset names",
+			pipelines: []*pipeline.Pipeline{
+				{Name: "p1", Assets: []*pipeline.Asset{{Name: "raw.events"}}},
+				{Name: "p2", Assets: []*pipeline.Asset{{Name: "raw.events"}}},
+			},
+			wantErr: "the asset 'raw.events' is defined in both pipelines 'p1' and 'p2'",
+		},
+		{
+			name: "cycle across pipelines",
+			pipelines: []*pipeline.Pipeline{
+				{Name: "p1", Assets: []*pipeline.Asset{
+					{Name: "a", URI: "test://a", Upstreams: []pipeline.Upstream{{Type: "uri", Value: "test://b"}}},
+				}},
+				{Name: "p2", Assets: []*pipeline.Asset{
+					{Name: "b", URI: "test://b", Upstreams: []pipeline.Upstream{{Type: "uri", Value: "test://a"}}},
+				}},
+			},
+			wantErr: "the dependencies between the pipelines contain a cycle: a -> b -> a",
+		},
+		{
+			name: "dependency on the asset of another pipeline by name",
+			pipelines: []*pipeline.Pipeline{
+				{Name: "p1", Assets: []*pipeline.Asset{{Name: "raw.events"}}},
+				{Name: "p2", Assets: []*pipeline.Asset{
+					{Name: "mart.events", Upstreams: []pipeline.Upstream{{Type: "asset", Value: "raw.events"}}},
+				}},
+			},
+			wantErr: "the asset 'mart.events' in the pipeline 'p2' depends on the asset 'raw.events' of the pipeline 'p1' by its name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewSchedulerForPipelines(zap.NewNop().Sugar(), tt.pipelines, "test")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package script
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/env"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/python"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type repoFinder interface {
+	Repo(path string) (*git.Repo, error)
+}
+
+type secretFinder interface {
+	GetSecretByKey(key string) (string, error)
+}
+
+type commandRunner interface {
+	Run(ctx context.Context, repo *git.Repo, command *python.CommandInstance) error
+}
+
+// interpreterFinder returns the command that runs the script at the given path, without the path itself.
+type interpreterFinder func(scriptPath string) ([]string, error)
+
+// LocalOperator runs shell and R scripts on the local machine, the scripts are run from the root of the repository
+// with the same environment variables and secrets as the Python assets.
+type LocalOperator struct {
+	repoFinder   repoFinder
+	cmd          commandRunner
+	config       secretFinder
+	envVariables map[string]string
+	interpreter  interpreterFinder
+}
+
+func NewShellOperator(config *config.Config, envVariables map[string]string) *LocalOperator {
+	return &LocalOperator{
+		repoFinder:   &git.RepoFinder{},
+		cmd:          &python.CommandRunner{},
+		config:       config,
+		envVariables: envVariables,
+		interpreter:  shellInterpreter,
+	}
+}
+
+func NewROperator(config *config.Config, envVariables map[string]string) *LocalOperator {
+	return &LocalOperator{
+		repoFinder:   &git.RepoFinder{},
+		cmd:          &python.CommandRunner{},
+		config:       config,
+		envVariables: envVariables,
+		interpreter:  rInterpreter,
+	}
+}
+
+// shellInterpreter uses the shebang of the script if there is one, `sh` otherwise.
+func shellInterpreter(scriptPath string) ([]string, error) {
+	file, err := os.Open(scriptPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open the script '%s'", scriptPath)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if scanner.Scan() {
+		if firstLine := strings.TrimSpace(scanner.Text()); strings.HasPrefix(firstLine, "#!") {
+			if interpreter := strings.Fields(strings.TrimPrefix(firstLine, "#!")); len(interpreter) > 0 {
+				return interpreter, nil
+			}
+		}
+	}
+
+	return []string{"sh"}, nil
+}
+
+func rInterpreter(_ string) ([]string, error) {
+	if _, err := exec.LookPath("Rscript"); err != nil {
+		return nil, errors.New("'Rscript' could not be found, are you sure R is installed and 'Rscript' is in your PATH?")
+	}
+
+	return []string{"Rscript"}, nil
+}
+
+func (o *LocalOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	_, ok := ti.(*scheduler.AssetInstance)
+	if !ok {
+		return errors.New("script assets can only be run as a main asset")
+	}
+
+	return o.RunTask(ctx, ti.GetPipeline(), ti.GetAsset())
+}
+
+func (o *LocalOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
+	repo, err := o.repoFinder.Repo(t.ExecutableFile.Path)
+	if err != nil {
+		return errors.Wrap(err, "failed to find repo to run the script")
+	}
+
+	if ctx.Value(executor.ContextLogger) == nil {
+		ctx = context.WithValue(ctx, executor.ContextLogger, zap.NewNop().Sugar())
+	}
+
+	interpreter, err := o.interpreter(t.ExecutableFile.Path)
+	if err != nil {
+		return err
+	}
+
+	perAssetEnvVariables, err := env.SetupVariables(ctx, p, t, o.envVariables)
+	if err != nil {
+		return errors.Wrap(err, "failed to setup environment variables")
+	}
+
+	envVariables := make(map[string]string)
+	for k, v := range perAssetEnvVariables {
+		envVariables[k] = v
+	}
+	envVariables["BRUIN_ASSET"] = t.Name
+
+	// scripts are expected to call other executables, unlike the Python assets
+	if _, ok := envVariables["PATH"]; !ok {
+		envVariables["PATH"] = os.Getenv("PATH")
+	}
+
+	if err := env.InjectSecrets(envVariables, t.Secrets, o.config); err != nil {
+		return err
+	}
+
+	args := make([]string, 0, len(interpreter))
+	args = append(args, interpreter[1:]...)
+	args = append(args, t.ExecutableFile.Path)
+
+	err = o.cmd.Run(ctx, repo, &python.CommandInstance{
+		Name:    interpreter[0],
+		Args:    args,
+		EnvVars: envVariables,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to execute the script")
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package script
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/python"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRepoFinder struct {
+	mock.Mock
+}
+
+func (m *mockRepoFinder) Repo(path string) (*git.Repo, error) {
+	args := m.Called(path)
+	return args.Get(0).(*git.Repo), args.Error(1)
+}
+
+type mockSecretFinder struct {
+	mock.Mock
+}
+
+func (m *mockSecretFinder) GetSecretByKey(key string) (string, error) {
+	args := m.Called(key)
+	return args.String(0), args.Error(1)
+}
+
+type mockCmd struct {
+	mock.Mock
+}
+
+func (m *mockCmd) Run(ctx context.Context, repo *git.Repo, command *python.CommandInstance) error {
+	args := m.Called(ctx, repo, command)
+	return args.Error(0)
+}
+
+func Test_shellInterpreter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "shebang is used",
+			content: "#!/usr/bin/env bash\necho 'hi'\n",
+			want:    []string{"/usr/bin/env", "bash"},
+		},
+		{
+			name:    "sh is used without a shebang",
+			content: "# @bruin\n# name: a\n# @bruin\necho 'hi'\n",
+			want:    []string{"sh"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scriptPath := filepath.Join(t.TempDir(), "script.sh")
+			require.NoError(t, os.WriteFile(scriptPath, []byte(tt.content), 0o600))
+
+			got, err := shellInterpreter(scriptPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLocalOperator_RunTask(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, pipeline.RunConfigStartDate, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx = context.WithValue(ctx, pipeline.RunConfigEndDate, time.Date(2024, 1, 1, 23, 59, 59, 0, time.UTC))
+	ctx = context.WithValue(ctx, pipeline.RunConfigPipelineName, "exports")
+	ctx = context.WithValue(ctx, pipeline.RunConfigRunID, "run-1")
+	ctx = context.WithValue(ctx, pipeline.RunConfigFullRefresh, false)
+
+	asset := &pipeline.Asset{
+		Name:           "exports.orders",
+		ExecutableFile: pipeline.ExecutableFile{Path: "/repo/assets/orders.sh"},
+		Secrets:        []pipeline.SecretMapping{{SecretKey: "bucket", InjectedKey: "BUCKET"}},
+	}
+	repo := &git.Repo{Path: "/repo"}
+
+	tests := []struct {
+		name    string
+		setup   func(msf *mockSecretFinder, cmd *mockCmd)
+		wantErr string
+	}{
+		{
+			name: "script is run with the interpreter, the variables and the secrets",
+			setup: func(msf *mockSecretFinder, cmd *mockCmd) {
+				msf.On("GetSecretByKey", "bucket").Return("s3://bucket", nil)
+				cmd.On("Run", mock.Anything, repo, mock.MatchedBy(func(c *python.CommandInstance) bool {
+					return c.Name == "bash" &&
+						assert.ObjectsAreEqual([]string{"-e", "/repo/assets/orders.sh"}, c.Args) &&
+						c.EnvVars["BRUIN_START_DATE"] == "2024-01-01" &&
+						c.EnvVars["BRUIN_ASSET"] == "exports.orders" &&
+						c.EnvVars["BUCKET"] == "s3://bucket" &&
+						c.EnvVars["PATH"] == os.Getenv("PATH")
+				})).Return(nil)
+			},
+		},
+		{
+			name: "missing secrets fail the asset",
+			setup: func(msf *mockSecretFinder, cmd *mockCmd) {
+				msf.On("GetSecretByKey", "bucket").Return("", nil)
+			},
+			wantErr: "there's no secret with the name 'bucket'",
+		},
+		{
+			name: "script errors are propagated",
+			setup: func(msf *mockSecretFinder, cmd *mockCmd) {
+				msf.On("GetSecretByKey", "bucket").Return("s3://bucket", nil)
+				cmd.On("Run", mock.Anything, repo, mock.Anything).Return(assert.AnError)
+			},
+			wantErr: "failed to execute the script",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rf := new(mockRepoFinder)
+			rf.On("Repo", "/repo/assets/orders.sh").Return(repo, nil)
+			msf := new(mockSecretFinder)
+			cmd := new(mockCmd)
+			tt.setup(msf, cmd)
+
+			o := &LocalOperator{
+				repoFinder:   rf,
+				cmd:          cmd,
+				config:       msf,
+				envVariables: map[string]string{},
+				interpreter: func(string) ([]string, error) {
+					return []string{"bash", "-e"}, nil
+				},
+			}
+
+			err := o.RunTask(ctx, &pipeline.Pipeline{Name: "exports"}, asset)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			cmd.AssertExpectations(t)
+		})
+	}
+}
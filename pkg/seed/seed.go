@@ -0,0 +1,261 @@
+package seed
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// ErrNotSupported is returned by the loaders that cannot load the seed natively for a given connection, the seed is
+// then loaded through ingestr instead.
+var ErrNotSupported = errors.New("native seed loading is not supported for this connection")
+
+// Loader is implemented by the connections that can load seed files with their own bulk APIs.
+type Loader interface {
+	LoadSeed(ctx context.Context, asset *pipeline.Asset, data *Data) error
+}
+
+type Column struct {
+	// Name is the normalized column name, as dlt would create it.
+	Name string
+	// Type is one of the logical Type* constants.
+	Type string
+}
+
+// Data is a parsed seed file, every value is either nil or the Go type matching the column type: int64, float64,
+// bool, time.Time for dates and timestamps, time.Duration for times, []byte for binaries and string for texts.
+type Data struct {
+	Path    string
+	Columns []Column
+	Rows    [][]any
+}
+
+var (
+	timestampLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04:05Z0700",
+		"2006-01-02 15:04:05",
+		time.DateOnly,
+	}
+	timeLayouts = []string{
+		"15:04:05",
+		"15:04",
+	}
+)
+
+// ReadCSV reads the seed file at the given path and parses the values using the types of the given columns. The
+// columns that are not defined in the asset, or the ones without a type hint, are kept as text. Empty values are
+// parsed as nulls.
+func ReadCSV(path string, columns []pipeline.Column) (*Data, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open seed file")
+	}
+	defer file.Close()
+
+	data, err := ParseCSV(file, columns)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse seed file '%s'", path)
+	}
+
+	data.Path = path
+	return data, nil
+}
+
+// ParseCSV parses the CSV content from the reader, see ReadCSV for the details.
+func ParseCSV(r io.Reader, columns []pipeline.Column) (*Data, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the seed file is empty, a header row is required")
+		}
+		return nil, err
+	}
+
+	hints := make(map[string]string, len(columns))
+	for _, col := range columns {
+		if hint, ok := TypeHint(col.Type); ok {
+			hints[NormalizeColumnName(col.Name)] = hint
+		}
+	}
+
+	data := &Data{Columns: make([]Column, len(header)), Rows: make([][]any, 0)}
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		normalized := NormalizeColumnName(name)
+		if normalized == "" {
+			return nil, fmt.Errorf("column %d has an empty name", i+1)
+		}
+		if seen[normalized] {
+			return nil, fmt.Errorf("duplicate column '%s' in the header", normalized)
+		}
+		seen[normalized] = true
+
+		typ, ok := hints[normalized]
+		if !ok {
+			typ = TypeText
+		}
+		data.Columns[i] = Column{Name: normalized, Type: typ}
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make([]any, len(record))
+		for i, raw := range record {
+			value, err := parseValue(raw, data.Columns[i].Type)
+			if err != nil {
+				line, _ := reader.FieldPos(i)
+				return nil, fmt.Errorf("line %d, column '%s': %w", line, data.Columns[i].Name, err)
+			}
+			row[i] = value
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	return data, nil
+}
+
+func parseValue(raw, typ string) (any, error) {
+	if raw == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	value := strings.TrimSpace(raw)
+	switch typ {
+	case TypeBigint:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i, nil
+		}
+		// decimals are hinted as bigint as well, integral values such as `1.0` are still accepted
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || f != float64(int64(f)) {
+			return nil, fmt.Errorf("'%s' is not a valid integer", raw)
+		}
+		return int64(f), nil
+	case TypeDouble:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid number", raw)
+		}
+		return f, nil
+	case TypeBool:
+		switch strings.ToLower(value) {
+		case "true", "t", "yes", "y", "1":
+			return true, nil
+		case "false", "f", "no", "n", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("'%s' is not a valid boolean", raw)
+	case TypeDate:
+		t, err := parseTime(value, timestampLayouts)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid date", raw)
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	case TypeTimestamp:
+		t, err := parseTime(value, timestampLayouts)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid timestamp", raw)
+		}
+		return t.UTC(), nil
+	case TypeTime:
+		t, err := parseTime(value, timeLayouts)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid time", raw)
+		}
+		return t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())), nil
+	case TypeBinary:
+		return []byte(raw), nil
+	default:
+		return raw, nil
+	}
+}
+
+func parseTime(value string, layouts []string) (time.Time, error) {
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		t, err = time.Parse(layout, value)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// WriteCSV writes the parsed values back as a CSV file with the normalized header and canonical value formats, so
+// that the destination can load it without guessing any formats. Binary values are written using the given encoder.
+func (d *Data) WriteCSV(w io.Writer, encodeBinary func([]byte) string) error {
+	writer := csv.NewWriter(w)
+
+	header := make([]string, len(d.Columns))
+	for i, col := range d.Columns {
+		header[i] = col.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(d.Columns))
+	for _, row := range d.Rows {
+		for i, value := range row {
+			record[i] = FormatValue(value, d.Columns[i].Type, encodeBinary)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// FormatValue formats a parsed value into its canonical text representation, nulls are formatted as empty strings.
+func FormatValue(value any, typ string, encodeBinary func([]byte) string) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if typ == TypeDate {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format("2006-01-02 15:04:05.999999-07:00")
+	case time.Duration:
+		return time.Time{}.Add(v).Format("15:04:05.999999")
+	case []byte:
+		if encodeBinary == nil {
+			return string(v)
+		}
+		return encodeBinary(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
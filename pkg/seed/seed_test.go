@@ -0,0 +1,161 @@
+package seed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeHint(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"INTEGER":              TypeBigint,
+		"decimal":              TypeBigint,
+		"Double   Precision":   TypeDouble,
+		"boolean":              TypeBool,
+		"timestamp_tz":         TypeTimestamp,
+		"date":                 TypeDate,
+		"time":                 TypeTime,
+		"varbinary":            TypeBinary,
+		"datetime":             TypeTimestamp,
+		" float4 ":             TypeDouble,
+		"unknown_type_or_text": "",
+	}
+
+	for in, want := range tests {
+		got, ok := TypeHint(in)
+		assert.Equal(t, want != "", ok, in)
+		assert.Equal(t, want, got, in)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+
+	columns := []pipeline.Column{
+		{Name: "id", Type: "integer"},
+		{Name: "Amount", Type: "float"},
+		{Name: "isActive", Type: "boolean"},
+		{Name: "signup date", Type: "date"},
+		{Name: "created_at", Type: "timestamp"},
+		{Name: "opens_at", Type: "time"},
+		{Name: "name", Type: "varchar"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    *Data
+		wantErr string
+	}{
+		{
+			name:    "values are parsed with the column types",
+			content: "id,Amount,isActive,signup date,created_at,opens_at,name,extra\n1,1.5,true,2024-01-02,2024-01-02 10:11:12+02:00,09:30:00,john,x\n2.0,,no,2024-01-03,2024-01-03T10:11:12Z,09:30,,\n",
+			want: &Data{
+				Columns: []Column{
+					{Name: "id", Type: TypeBigint},
+					{Name: "amount", Type: TypeDouble},
+					{Name: "is_active", Type: TypeBool},
+					{Name: "signup_date", Type: TypeDate},
+					{Name: "created_at", Type: TypeTimestamp},
+					{Name: "opens_at", Type: TypeTime},
+					{Name: "name", Type: TypeText},
+					{Name: "extra", Type: TypeText},
+				},
+				Rows: [][]any{
+					{
+						int64(1), 1.5, true,
+						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 2, 8, 11, 12, 0, time.UTC),
+						9*time.Hour + 30*time.Minute,
+						"john", "x",
+					},
+					{
+						int64(2), nil, false,
+						time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 3, 10, 11, 12, 0, time.UTC),
+						9*time.Hour + 30*time.Minute,
+						nil, nil,
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid integers are reported with their position",
+			content: "id\n1\n1.5\n",
+			wantErr: "line 3, column 'id': '1.5' is not a valid integer",
+		},
+		{
+			name:    "invalid booleans are reported",
+			content: "is_active\nmaybe\n",
+			wantErr: "line 2, column 'is_active': 'maybe' is not a valid boolean",
+		},
+		{
+			name:    "duplicate columns after normalization are rejected",
+			content: "isActive,is_active\ntrue,false\n",
+			wantErr: "duplicate column 'is_active' in the header",
+		},
+		{
+			name:    "empty files are rejected",
+			content: "",
+			wantErr: "the seed file is empty, a header row is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseCSV(strings.NewReader(tt.content), columns)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestData_WriteCSV(t *testing.T) {
+	t.Parallel()
+
+	data := &Data{
+		Columns: []Column{
+			{Name: "id", Type: TypeBigint},
+			{Name: "amount", Type: TypeDouble},
+			{Name: "signup_date", Type: TypeDate},
+			{Name: "created_at", Type: TypeTimestamp},
+			{Name: "opens_at", Type: TypeTime},
+			{Name: "payload", Type: TypeBinary},
+			{Name: "name", Type: TypeText},
+		},
+		Rows: [][]any{
+			{
+				int64(1), 1.25,
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 8, 11, 12, 500000000, time.UTC),
+				9*time.Hour + 30*time.Minute,
+				[]byte("ab"),
+				"doe, john",
+			},
+			{nil, nil, nil, nil, nil, nil, nil},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := data.WriteCSV(&buf, func(b []byte) string { return strings.ToUpper(string(b)) })
+	require.NoError(t, err)
+
+	expected := "id,amount,signup_date,created_at,opens_at,payload,name\n" +
+		"1,1.25,2024-01-02,2024-01-02 08:11:12.5+00:00,09:30:00,AB,\"doe, john\"\n" +
+		",,,,,,\n"
+	assert.Equal(t, expected, buf.String())
+}
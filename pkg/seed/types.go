@@ -0,0 +1,101 @@
+package seed
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// The logical types the seed values are parsed into, they match the dlt types ingestr uses for the type hints.
+const (
+	TypeText      = "text"
+	TypeBigint    = "bigint"
+	TypeDouble    = "double"
+	TypeBinary    = "binary"
+	TypeBool      = "bool"
+	TypeDate      = "date"
+	TypeTimestamp = "timestamp"
+	TypeTime      = "time"
+)
+
+// typeHintMapping maps the column types from different destinations to dlt types.
+// 'text' mappings are omitted, since they are the default.
+var typeHintMapping = map[string]string{
+	"number":           TypeBigint,
+	"decimal":          TypeBigint,
+	"numeric":          TypeBigint,
+	"int":              TypeBigint,
+	"integer":          TypeBigint,
+	"bigint":           TypeBigint,
+	"smallint":         TypeBigint,
+	"tinyint":          TypeBigint,
+	"byteint":          TypeBigint,
+	"float":            TypeDouble,
+	"float4":           TypeDouble,
+	"float8":           TypeDouble,
+	"double":           TypeDouble,
+	"double precision": TypeDouble,
+	"real":             TypeDouble,
+	"binary":           TypeBinary,
+	"varbinary":        TypeBinary,
+	"boolean":          TypeBool,
+	"date":             TypeDate,
+	"datetime":         TypeTimestamp,
+	"time":             TypeTime,
+	"timestamp":        TypeTimestamp,
+	"timestamp_ltz":    TypeTimestamp,
+	"timestamp_ntz":    TypeTimestamp,
+	"timestamp_tz":     TypeTimestamp,
+}
+
+// TypeHint returns the dlt type for the given column type, the second return value is false if the type has no hint
+// and the column should be treated as text.
+func TypeHint(typ string) (string, bool) {
+	hint, exists := typeHintMapping[normaliseColumnType(typ)]
+	return hint, exists
+}
+
+var (
+	camelPattern         = regexp.MustCompile(`([\w])([A-Z][a-z]+)`)
+	multipleSpacePattern = regexp.MustCompile(`\s+`)
+)
+
+func normaliseColumnType(typ string) string {
+	typ = multipleSpacePattern.ReplaceAllString(typ, " ")
+	typ = strings.ToLower(typ)
+	typ = strings.TrimSpace(typ)
+	return typ
+}
+
+// NormalizeColumnName converts the column name the same way dlt does, so that the seed columns end up with the same
+// names regardless of them being loaded natively or through ingestr.
+func NormalizeColumnName(name string) string {
+	// https://dlthub.com/docs/general-usage/schema#naming-convention
+	// nested column normalization is not implemented.
+
+	// remove non ASCII characters
+	name = strings.Map(func(c rune) rune {
+		if c > unicode.MaxASCII {
+			return rune(-1)
+		}
+		return c
+	}, name)
+
+	name = strings.TrimSpace(name)
+
+	// merge multiple spaces into one
+	name = multipleSpacePattern.ReplaceAllString(name, " ")
+
+	// convert to snake case
+	name = camelPattern.ReplaceAllString(name, "${1}_${2}")
+
+	// replace space with underscore
+	name = strings.ReplaceAll(name, " ", "_")
+
+	// add underscore if name starts with a number
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+
+	return strings.ToLower(name)
+}
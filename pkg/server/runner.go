@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/runner"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+)
+
+// Job is a run to be executed, the paths are absolute.
+type Job struct {
+	RunID        string
+	PipelinePath string
+	// AssetPath is the definition file of the asset to run, empty to run the whole pipeline.
+	AssetPath  string
+	Parameters scheduler.RunConfig
+}
+
+type Runner interface {
+	Run(ctx context.Context, job *Job, output io.Writer) error
+}
+
+// CommandRunner runs the jobs with `bruin run`, see runner.NewCommand.
+type CommandRunner struct {
+	// Executable is the path to the bruin binary.
+	Executable string
+	// Args are the flags passed to every run, e.g. the config file.
+	Args []string
+	// GracePeriod is how long a run is given to stop after it is cancelled before it is killed.
+	GracePeriod time.Duration
+}
+
+func (r *CommandRunner) Run(ctx context.Context, job *Job, output io.Writer) error {
+	target := job.PipelinePath
+	if job.AssetPath != "" {
+		target = job.AssetPath
+	}
+
+	args := append([]string{}, r.Args...)
+	args = append(args, runArgs(job.Parameters)...)
+	args = append(args, target)
+
+	cmd := runner.NewCommand(ctx, r.Executable, args, job.RunID, r.GracePeriod)
+	cmd.Dir = job.PipelinePath
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	return cmd.Run()
+}
+
+// runArgs converts the parameters of a run to the flags of `bruin run`. The config file and the output format are
+// decided by the server, they cannot be given per run.
+func runArgs(params scheduler.RunConfig) []string {
+	args := make([]string, 0)
+	addString := func(flag, value string) {
+		if value != "" {
+			// the values are attached to the flags so that they cannot be mistaken for other flags
+			args = append(args, "--"+flag+"="+value)
+		}
+	}
+	addBool := func(flag string, value bool) {
+		if value {
+			args = append(args, "--"+flag)
+		}
+	}
+
+	addString("start-date", params.StartDate)
+	addString("end-date", params.EndDate)
+	addString("environment", params.Environment)
+	addString("tag", params.Tag)
+	addString("exclude-tag", params.ExcludeTag)
+	addString("sensor-mode", params.SensorMode)
+	for _, only := range params.Only {
+		addString("only", only)
+	}
+	if params.Workers > 0 {
+		args = append(args, "--workers="+strconv.Itoa(params.Workers))
+	}
+
+	addBool("downstream", params.Downstream)
+	addBool("force", params.Force)
+	addBool("push-metadata", params.PushMetadata)
+	addBool("no-log-file", params.NoLogFile)
+	addBool("full-refresh", params.FullRefresh)
+	addBool("use-pip", params.UsePip)
+	addBool("exp-use-winget-for-uv", params.ExpUseWingetForUv)
+	addBool("apply-interval-modifiers", params.ApplyIntervalModifiers)
+	addBool("spark-local", params.SparkLocal)
+
+	return args
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/scheduler"
+)
+
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+
+	// maxLogLines is the number of log lines kept for each run, the older lines are dropped.
+	maxLogLines = 10000
+)
+
+// RunRequest is the body of a request that triggers a run, the parameters are the same as the flags of `bruin run`.
+type RunRequest struct {
+	Pipeline string `json:"pipeline"`
+	// Asset is the name of the asset to run, the whole pipeline is run if it is empty.
+	Asset string `json:"asset"`
+	scheduler.RunConfig
+}
+
+// Run is a run triggered through the API.
+type Run struct {
+	ID         string                          `json:"id"`
+	Pipeline   string                          `json:"pipeline"`
+	Asset      string                          `json:"asset,omitempty"`
+	Parameters scheduler.RunConfig             `json:"parameters"`
+	Status     string                          `json:"status"`
+	Error      string                          `json:"error,omitempty"`
+	StartedAt  *time.Time                      `json:"started_at,omitempty"`
+	FinishedAt *time.Time                      `json:"finished_at,omitempty"`
+	Assets     []*scheduler.PipelineAssetState `json:"assets"`
+}
+
+// activeRun is a run that was started by this server, it keeps the logs of the run in memory.
+type activeRun struct {
+	mu     sync.Mutex
+	run    Run
+	logs   *runLog
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (r *activeRun) snapshot() Run {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.run
+}
+
+func (r *activeRun) finish(status string, err error, finishedAt time.Time) {
+	r.mu.Lock()
+	r.run.Status = status
+	r.run.FinishedAt = &finishedAt
+	if err != nil {
+		r.run.Error = err.Error()
+	}
+	r.mu.Unlock()
+
+	r.logs.close()
+	close(r.done)
+}
+
+// runLog collects the output of a run line by line, and lets multiple readers follow it while the run continues.
+type runLog struct {
+	mu      sync.Mutex
+	lines   []string
+	dropped int
+	partial []byte
+	closed  bool
+	changed chan struct{}
+}
+
+func newRunLog() *runLog {
+	return &runLog{changed: make(chan struct{})}
+}
+
+func (l *runLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data := append(l.partial, p...) //nolint:gocritic
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		l.append(string(bytes.TrimRight(data[:i], "\r")))
+		data = data[i+1:]
+	}
+	l.partial = append([]byte(nil), data...)
+
+	return len(p), nil
+}
+
+func (l *runLog) append(line string) {
+	l.lines = append(l.lines, line)
+	if len(l.lines) > maxLogLines {
+		l.dropped += len(l.lines) - maxLogLines
+		l.lines = l.lines[len(l.lines)-maxLogLines:]
+	}
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// close flushes the last line that did not end with a newline, the readers stop once they reach the end of the log.
+func (l *runLog) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.partial) > 0 {
+		l.append(string(l.partial))
+		l.partial = nil
+	}
+	l.closed = true
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// read returns the lines starting from the given position, the position to continue from, whether the log is closed
+// and a channel that is closed once there's more to read.
+func (l *runLog) read(from int) ([]string, int, bool, <-chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := from - l.dropped
+	if start < 0 {
+		start = 0
+	}
+	if start > len(l.lines) {
+		start = len(l.lines)
+	}
+
+	lines := append([]string(nil), l.lines[start:]...)
+
+	return lines, l.dropped + len(l.lines), l.closed, l.changed
+}
@@ -0,0 +1,574 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/spf13/afero"
+)
+
+const (
+	// StatusIncomplete is the status of a saved run that stopped before all of its assets finished, e.g. it was
+	// interrupted or it is still running in another process.
+	StatusIncomplete = "incomplete"
+
+	// maxFinishedRuns is the number of finished runs kept in memory with their logs, the older ones are still
+	// available through their saved state.
+	maxFinishedRuns = 100
+
+	// repositoryStateFolder is the folder the runs of all the pipelines in a folder save their state in.
+	repositoryStateFolder = "_repository"
+)
+
+var validRunID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+type pipelineFinder func(root string, pipelineDefinitionFile []string) ([]string, error)
+
+type pipelineBuilder func(ctx context.Context, pipelinePath string) (*pipeline.Pipeline, error)
+
+type Options struct {
+	// Token is the secret the requests are authenticated with, as a bearer token.
+	Token string
+	// MaxConcurrentRuns is the maximum number of runs that can be active at the same time.
+	MaxConcurrentRuns int
+	// StateDir is the folder the runs save their state in, one folder per pipeline.
+	StateDir string
+	// PipelineDefinitionFiles are the file names the pipelines are discovered with.
+	PipelineDefinitionFiles []string
+}
+
+// Server exposes the pipelines of a repository over a REST API, and runs them on request.
+type Server struct {
+	fs            afero.Fs
+	root          string
+	runner        Runner
+	findPipelines pipelineFinder
+	buildPipeline pipelineBuilder
+	logger        *log.Logger
+	opts          Options
+	now           func() time.Time
+
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
+
+	mu   sync.Mutex
+	runs map[string]*activeRun
+	wg   sync.WaitGroup
+}
+
+func New(fs afero.Fs, root string, runner Runner, findPipelines pipelineFinder, buildPipeline pipelineBuilder, output io.Writer, opts Options) *Server {
+	if opts.MaxConcurrentRuns < 1 {
+		opts.MaxConcurrentRuns = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Server{
+		fs:            fs,
+		root:          root,
+		runner:        runner,
+		findPipelines: findPipelines,
+		buildPipeline: buildPipeline,
+		logger:        log.New(output, "", log.LstdFlags|log.LUTC),
+		opts:          opts,
+		now:           func() time.Time { return time.Now().UTC() },
+		ctx:           ctx,
+		cancel:        cancel,
+		runs:          map[string]*activeRun{},
+	}
+}
+
+// Handler returns the routes of the API, all of them require the token.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/pipelines", s.listPipelines)
+	mux.HandleFunc("GET /api/pipelines/{name}", s.getPipeline)
+	mux.HandleFunc("GET /api/runs", s.listRuns)
+	mux.HandleFunc("POST /api/runs", s.createRun)
+	mux.HandleFunc("GET /api/runs/{id}", s.getRun)
+	mux.HandleFunc("POST /api/runs/{id}/cancel", s.cancelRun)
+	mux.HandleFunc("GET /api/runs/{id}/logs", s.streamLogs)
+
+	return s.authenticate(mux)
+}
+
+// Close cancels the active runs and waits for them to stop, the log streams are ended as well.
+func (s *Server) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) listPipelines(w http.ResponseWriter, r *http.Request) {
+	pipelines, err := s.discover(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pipelines)
+}
+
+func (s *Server) getPipeline(w http.ResponseWriter, r *http.Request) {
+	p, status, err := s.findPipeline(r.Context(), r.PathValue("name"))
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, p.pipeline)
+}
+
+func (s *Server) listRuns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	runs := make([]Run, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, run.snapshot())
+	}
+	s.mu.Unlock()
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(*runs[j].StartedAt)
+	})
+
+	writeJSON(w, http.StatusOK, runs)
+}
+
+func (s *Server) createRun(w http.ResponseWriter, r *http.Request) {
+	req := &RunRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Pipeline == "" {
+		writeError(w, http.StatusBadRequest, "the pipeline to run is required")
+		return
+	}
+
+	p, status, err := s.findPipeline(r.Context(), req.Pipeline)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+
+	job := &Job{
+		RunID:        newRunID(s.now()),
+		PipelinePath: p.path,
+		Parameters:   req.RunConfig,
+	}
+	if req.Asset != "" {
+		asset := p.pipeline.GetAssetByName(req.Asset)
+		if asset == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("the asset '%s' does not exist in the pipeline '%s'", req.Asset, req.Pipeline))
+			return
+		}
+		job.AssetPath = asset.DefinitionFile.Path
+	}
+
+	run, err := s.start(job, p.pipeline.Name, req.Asset)
+	if err != nil {
+		writeError(w, http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, run)
+}
+
+func (s *Server) start(job *Job, pipelineName, assetName string) (Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := 0
+	finished := make([]Run, 0)
+	for _, run := range s.runs {
+		snapshot := run.snapshot()
+		if snapshot.Status == StatusRunning {
+			active++
+		} else {
+			finished = append(finished, snapshot)
+		}
+	}
+	if active >= s.opts.MaxConcurrentRuns {
+		return Run{}, fmt.Errorf("the limit of %d concurrent runs is reached, try again once one of the active runs finishes", s.opts.MaxConcurrentRuns)
+	}
+
+	if len(finished) >= maxFinishedRuns {
+		sort.Slice(finished, func(i, j int) bool {
+			return finished[i].StartedAt.Before(*finished[j].StartedAt)
+		})
+		for _, run := range finished[:len(finished)-maxFinishedRuns+1] {
+			delete(s.runs, run.ID)
+		}
+	}
+
+	startedAt := s.now()
+	ctx, cancel := context.WithCancel(s.ctx)
+	run := &activeRun{
+		run: Run{
+			ID:         job.RunID,
+			Pipeline:   pipelineName,
+			Asset:      assetName,
+			Parameters: job.Parameters,
+			Status:     StatusRunning,
+			StartedAt:  &startedAt,
+		},
+		logs:   newRunLog(),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	s.runs[job.RunID] = run
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+
+		s.logger.Printf("starting the run '%s' of '%s'", job.RunID, pipelineName)
+		err := s.runner.Run(ctx, job, run.logs)
+
+		switch {
+		case ctx.Err() != nil:
+			run.finish(StatusCancelled, nil, s.now())
+			s.logger.Printf("the run '%s' was cancelled", job.RunID)
+		case err != nil:
+			run.finish(StatusFailed, err, s.now())
+			s.logger.Printf("the run '%s' failed: %v", job.RunID, err)
+		default:
+			run.finish(StatusSucceeded, nil, s.now())
+			s.logger.Printf("the run '%s' succeeded", job.RunID)
+		}
+	}()
+
+	return run.snapshot(), nil
+}
+
+func (s *Server) getRun(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !validRunID.MatchString(id) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("the run '%s' does not exist", id))
+		return
+	}
+
+	if run := s.activeRun(id); run != nil {
+		snapshot := run.snapshot()
+		state, err := s.readState(snapshot.Pipeline, id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if state != nil {
+			snapshot.Assets = state.State
+		}
+
+		writeJSON(w, http.StatusOK, snapshot)
+		return
+	}
+
+	// the runs that are not started by this server, or before a restart, are read from their saved state
+	run, err := s.findSavedRun(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if run == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("the run '%s' does not exist", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, run)
+}
+
+func (s *Server) cancelRun(w http.ResponseWriter, r *http.Request) {
+	run := s.activeRun(r.PathValue("id"))
+	if run == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("the run '%s' is not active on this server", r.PathValue("id")))
+		return
+	}
+
+	// the run is given the grace period to stop, its status changes once it has stopped
+	run.cancel()
+
+	writeJSON(w, http.StatusAccepted, run.snapshot())
+}
+
+// streamLogs sends the logs of the run as server-sent events, starting from the beginning of the run and following it
+// until it finishes. The final status of the run is sent as an `end` event.
+func (s *Server) streamLogs(w http.ResponseWriter, r *http.Request) {
+	run := s.activeRun(r.PathValue("id"))
+	if run == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("the run '%s' is not active on this server", r.PathValue("id")))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	position := 0
+	for {
+		lines, next, closed, changed := run.logs.read(position)
+		for _, line := range lines {
+			_, _ = fmt.Fprintf(w, "event: log\ndata: %s\n\n", line)
+		}
+		position = next
+
+		if closed {
+			status, _ := json.Marshal(map[string]string{"status": run.snapshot().Status})
+			_, _ = fmt.Fprintf(w, "event: end\ndata: %s\n\n", status)
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		case <-s.ctx.Done():
+			// the run is being cancelled, the remaining logs and its final status are still sent
+			<-run.done
+		}
+	}
+}
+
+func (s *Server) activeRun(id string) *activeRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.runs[id]
+}
+
+// readState reads the state of the run from the given state folder, relative to the state directory.
+func (s *Server) readState(stateFolder, runID string) (*scheduler.PipelineState, error) {
+	state := &scheduler.PipelineState{}
+	err := helpers.ReadJSONToFile(s.fs, filepath.Join(s.opts.StateDir, stateFolder, runID+".json"), state)
+	if err != nil {
+		// the state is written as the run progresses, a partially written file is read again on the next request
+		var syntaxErr *json.SyntaxError
+		if errors.Is(err, os.ErrNotExist) || errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, nil //nolint:nilnil
+		}
+
+		return nil, fmt.Errorf("failed to read the state of the run '%s': %w", runID, err)
+	}
+
+	return state, nil
+}
+
+func (s *Server) findSavedRun(id string) (*Run, error) {
+	var matches []string
+	// the runs of all the pipelines in a folder are saved under a separate folder, see `bruin run`
+	for _, pattern := range []string{"*", filepath.Join(repositoryStateFolder, "*")} {
+		found, err := afero.Glob(s.fs, filepath.Join(s.opts.StateDir, pattern, id+".json"))
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, found...)
+	}
+	if len(matches) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	stateFolder, err := filepath.Rel(s.opts.StateDir, filepath.Dir(matches[0]))
+	if err != nil {
+		return nil, err
+	}
+	pipelineName := filepath.Base(stateFolder)
+	state, err := s.readState(stateFolder, id)
+	if err != nil || state == nil {
+		return nil, err
+	}
+
+	run := &Run{
+		ID:         id,
+		Pipeline:   pipelineName,
+		Parameters: state.Parameters,
+		Status:     StatusSucceeded,
+		FinishedAt: &state.TimeStamp,
+		Assets:     state.State,
+	}
+	for _, asset := range state.State {
+		switch asset.Status {
+		case scheduler.Failed.String():
+			run.Status = StatusFailed
+		case scheduler.Succeeded.String(), scheduler.Skipped.String():
+		default:
+			if run.Status != StatusFailed {
+				run.Status = StatusIncomplete
+			}
+		}
+	}
+
+	return run, nil
+}
+
+type discoveredPipeline struct {
+	path     string
+	pipeline *pipeline.Pipeline
+}
+
+// PipelineList is the response of the pipeline list, the pipelines that cannot be built are reported separately so
+// that a broken pipeline does not hide the others.
+type PipelineList struct {
+	Pipelines []*pipeline.Pipeline `json:"pipelines"`
+	Errors    []PipelineError      `json:"errors"`
+}
+
+// PipelineError is a pipeline that cannot be built, the name is empty if the pipeline definition cannot be read.
+type PipelineError struct {
+	Name  string `json:"name,omitempty"`
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// discover builds the pipelines in the repository on every request, so that the changes are picked up without a
+// restart. The content of the assets is not included, the same as `bruin internal parse-pipeline`.
+func (s *Server) discover(ctx context.Context) (*PipelineList, error) {
+	paths, err := s.findPipelines(s.root, s.opts.PipelineDefinitionFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the pipelines: %w", err)
+	}
+
+	list := &PipelineList{
+		Pipelines: make([]*pipeline.Pipeline, 0, len(paths)),
+		Errors:    make([]PipelineError, 0),
+	}
+	for _, pipelinePath := range paths {
+		p, err := s.buildPipeline(ctx, pipelinePath)
+		if err != nil {
+			name, _ := s.pipelineName(pipelinePath)
+			list.Errors = append(list.Errors, PipelineError{Name: name, Path: pipelinePath, Error: err.Error()})
+			continue
+		}
+
+		p.WipeContentOfAssets()
+		list.Pipelines = append(list.Pipelines, p)
+	}
+
+	sort.Slice(list.Pipelines, func(i, j int) bool {
+		return list.Pipelines[i].Name < list.Pipelines[j].Name
+	})
+	sort.Slice(list.Errors, func(i, j int) bool {
+		return list.Errors[i].Path < list.Errors[j].Path
+	})
+
+	return list, nil
+}
+
+// findPipeline matches the pipelines by the names in their definitions, and builds only the one that is requested so
+// that the other pipelines cannot fail the request.
+func (s *Server) findPipeline(ctx context.Context, name string) (*discoveredPipeline, int, error) {
+	paths, err := s.findPipelines(s.root, s.opts.PipelineDefinitionFiles)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to find the pipelines: %w", err)
+	}
+
+	found := ""
+	for _, pipelinePath := range paths {
+		// the pipelines with a broken definition are reported by the pipeline list, they cannot be referred to by name
+		pipelineName, err := s.pipelineName(pipelinePath)
+		if err != nil || pipelineName != name {
+			continue
+		}
+		if found != "" {
+			return nil, http.StatusConflict, fmt.Errorf("the pipeline name '%s' is used by both '%s' and '%s', rename one of them to run it through the API", name, found, pipelinePath)
+		}
+		found = pipelinePath
+	}
+
+	if found == "" {
+		return nil, http.StatusNotFound, fmt.Errorf("the pipeline '%s' does not exist", name)
+	}
+
+	p, err := s.buildPipeline(ctx, found)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to build the pipeline in '%s': %w", found, err)
+	}
+	p.WipeContentOfAssets()
+
+	return &discoveredPipeline{path: found, pipeline: p}, http.StatusOK, nil
+}
+
+// pipelineName reads the name of the pipeline from its definition file without building the pipeline.
+func (s *Server) pipelineName(pipelinePath string) (string, error) {
+	for _, file := range s.opts.PipelineDefinitionFiles {
+		definitionPath := filepath.Join(pipelinePath, file)
+		if exists, _ := afero.Exists(s.fs, definitionPath); !exists {
+			continue
+		}
+
+		p, err := pipeline.PipelineFromPath(definitionPath, s.fs)
+		if err != nil {
+			return "", err
+		}
+
+		return p.Name, nil
+	}
+
+	return "", fmt.Errorf("no pipeline definition file found in '%s'", pipelinePath)
+}
+
+// newRunID returns a run ID in the same format as `bruin run`, with a random suffix so that the runs started in the
+// same second do not share their state.
+func newRunID(now time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+
+	return now.Format("2006_01_02_15_04_05") + "_" + hex.EncodeToString(suffix)
+}
+
+// GenerateToken returns a random token for the servers that are started without one.
+func GenerateToken() (string, error) {
+	token := make([]byte, 24)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
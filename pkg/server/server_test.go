@@ -0,0 +1,355 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "secret"
+
+type fakeRunner struct {
+	jobs    chan *Job
+	release chan error
+}
+
+func (r *fakeRunner) Run(ctx context.Context, job *Job, output io.Writer) error {
+	r.jobs <- job
+	_, _ = fmt.Fprintf(output, "running %s\npartial", job.RunID)
+
+	select {
+	case err := <-r.release:
+		_, _ = fmt.Fprint(output, " line\n")
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newTestServer(t *testing.T, maxConcurrentRuns int) (*Server, *fakeRunner, afero.Fs, *httptest.Server) {
+	fs := afero.NewMemMapFs()
+	runner := &fakeRunner{jobs: make(chan *Job, 10), release: make(chan error)}
+
+	finder := func(root string, files []string) ([]string, error) {
+		return []string{"/repo/second", "/repo/first"}, nil
+	}
+	builder := func(ctx context.Context, pipelinePath string) (*pipeline.Pipeline, error) {
+		name := strings.TrimPrefix(pipelinePath, "/repo/")
+		return &pipeline.Pipeline{
+			Name: name,
+			Assets: []*pipeline.Asset{
+				{
+					Name:           name + ".asset",
+					ExecutableFile: pipeline.ExecutableFile{Content: "select 1"},
+					DefinitionFile: pipeline.TaskDefinitionFile{Path: pipelinePath + "/assets/asset.sql"},
+				},
+			},
+		}, nil
+	}
+
+	writePipelineDefinitions(t, fs, map[string]string{"/repo/first": "first", "/repo/second": "second"})
+
+	s := New(fs, "/repo", runner, finder, builder, &bytes.Buffer{}, Options{
+		Token:                   testToken,
+		MaxConcurrentRuns:       maxConcurrentRuns,
+		StateDir:                "/repo/logs/runs",
+		PipelineDefinitionFiles: []string{"pipeline.yml"},
+	})
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(func() {
+		s.Close()
+		ts.Close()
+	})
+
+	return s, runner, fs, ts
+}
+
+func writePipelineDefinitions(t *testing.T, fs afero.Fs, names map[string]string) {
+	for pipelinePath, name := range names {
+		require.NoError(t, afero.WriteFile(fs, pipelinePath+"/pipeline.yml", []byte("name: "+name+"\n"), 0o644))
+	}
+}
+
+func request(t *testing.T, ts *httptest.Server, method, path, body string, out any) int {
+	req, err := http.NewRequestWithContext(context.Background(), method, ts.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+
+	return resp.StatusCode
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	t.Parallel()
+
+	_, _, _, ts := newTestServer(t, 1)
+
+	for _, header := range []string{"", "Bearer wrong", testToken} {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL+"/api/pipelines", nil)
+		require.NoError(t, err)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, header)
+	}
+}
+
+func TestServer_Pipelines(t *testing.T) {
+	t.Parallel()
+
+	_, _, _, ts := newTestServer(t, 1)
+
+	var pipelines PipelineList
+	require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/pipelines", "", &pipelines))
+	require.Len(t, pipelines.Pipelines, 2)
+	assert.Equal(t, "first", pipelines.Pipelines[0].Name)
+	assert.Equal(t, "second", pipelines.Pipelines[1].Name)
+	assert.Empty(t, pipelines.Pipelines[0].Assets[0].ExecutableFile.Content)
+	assert.Empty(t, pipelines.Errors)
+
+	var p pipeline.Pipeline
+	require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/pipelines/second", "", &p))
+	assert.Equal(t, "second.asset", p.Assets[0].Name)
+
+	assert.Equal(t, http.StatusNotFound, request(t, ts, http.MethodGet, "/api/pipelines/missing", "", nil))
+}
+
+func TestServer_Runs(t *testing.T) {
+	t.Parallel()
+
+	_, runner, fs, ts := newTestServer(t, 1)
+
+	var run Run
+	status := request(t, ts, http.MethodPost, "/api/runs", `{"pipeline":"first","asset":"first.asset","startDate":"2024-01-01","fullRefresh":true}`, &run)
+	require.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, StatusRunning, run.Status)
+	assert.Equal(t, "first.asset", run.Asset)
+
+	job := <-runner.jobs
+	assert.Equal(t, run.ID, job.RunID)
+	assert.Equal(t, "/repo/first", job.PipelinePath)
+	assert.Equal(t, "/repo/first/assets/asset.sql", job.AssetPath)
+	assert.Equal(t, []string{"--start-date=2024-01-01", "--full-refresh"}, runArgs(job.Parameters))
+
+	// the limit of concurrent runs is reached
+	assert.Equal(t, http.StatusTooManyRequests, request(t, ts, http.MethodPost, "/api/runs", `{"pipeline":"second"}`, nil))
+	assert.Equal(t, http.StatusNotFound, request(t, ts, http.MethodPost, "/api/runs", `{"pipeline":"first","asset":"missing"}`, nil))
+	assert.Equal(t, http.StatusBadRequest, request(t, ts, http.MethodPost, "/api/runs", `{}`, nil))
+
+	// the progress of the run is read from its saved state
+	require.NoError(t, helpers.WriteJSONToFile(fs, &scheduler.PipelineState{
+		RunID: run.ID,
+		State: []*scheduler.PipelineAssetState{{Name: "first.asset", Status: "running"}},
+	}, "/repo/logs/runs/first/"+run.ID+".json"))
+
+	var current Run
+	require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/runs/"+run.ID, "", &current))
+	assert.Equal(t, StatusRunning, current.Status)
+	assert.Equal(t, []*scheduler.PipelineAssetState{{Name: "first.asset", Status: "running"}}, current.Assets)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL+"/api/runs/"+run.ID+"/logs", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() string {
+		event := ""
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if line == "\n" {
+				return event
+			}
+			event += line
+		}
+	}
+
+	assert.Equal(t, "event: log\ndata: running "+run.ID+"\n", readEvent())
+	runner.release <- errors.New("exit status 1")
+	assert.Equal(t, "event: log\ndata: partial line\n", readEvent())
+	assert.Equal(t, "event: end\ndata: {\"status\":\"failed\"}\n", readEvent())
+
+	var runs []Run
+	require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/runs", "", &runs))
+	require.Len(t, runs, 1)
+	assert.Equal(t, StatusFailed, runs[0].Status)
+	assert.Equal(t, "exit status 1", runs[0].Error)
+	assert.NotNil(t, runs[0].FinishedAt)
+}
+
+func TestServer_CancelRun(t *testing.T) {
+	t.Parallel()
+
+	s, runner, _, ts := newTestServer(t, 1)
+
+	var run Run
+	require.Equal(t, http.StatusAccepted, request(t, ts, http.MethodPost, "/api/runs", `{"pipeline":"second"}`, &run))
+	<-runner.jobs
+
+	require.Equal(t, http.StatusAccepted, request(t, ts, http.MethodPost, "/api/runs/"+run.ID+"/cancel", "", nil))
+	<-s.activeRun(run.ID).done
+
+	var cancelled Run
+	require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/runs/"+run.ID, "", &cancelled))
+	assert.Equal(t, StatusCancelled, cancelled.Status)
+
+	assert.Equal(t, http.StatusNotFound, request(t, ts, http.MethodPost, "/api/runs/missing/cancel", "", nil))
+}
+
+func TestServer_SavedRuns(t *testing.T) {
+	t.Parallel()
+
+	_, _, fs, ts := newTestServer(t, 1)
+
+	finishedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	states := map[string][]*scheduler.PipelineAssetState{
+		"succeeded":  {{Name: "a", Status: "succeeded"}, {Name: "b", Status: "skipped"}},
+		"failed":     {{Name: "a", Status: "failed"}, {Name: "b", Status: "pending"}},
+		"incomplete": {{Name: "a", Status: "succeeded"}, {Name: "b", Status: "pending"}},
+	}
+	for id, state := range states {
+		require.NoError(t, helpers.WriteJSONToFile(fs, &scheduler.PipelineState{
+			RunID:      id,
+			TimeStamp:  finishedAt,
+			State:      state,
+			Parameters: scheduler.RunConfig{Environment: "dev"},
+		}, "/repo/logs/runs/first/"+id+".json"))
+	}
+
+	for id, state := range states {
+		var run Run
+		require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/runs/"+id, "", &run))
+		assert.Equal(t, id, run.Status)
+		assert.Equal(t, "first", run.Pipeline)
+		assert.Equal(t, "dev", run.Parameters.Environment)
+		assert.Equal(t, state, run.Assets)
+		assert.True(t, finishedAt.Equal(*run.FinishedAt))
+	}
+
+	// the runs of all the pipelines in a folder are saved separately
+	require.NoError(t, helpers.WriteJSONToFile(fs, &scheduler.PipelineState{
+		RunID:     "combined",
+		TimeStamp: finishedAt,
+		State:     states["succeeded"],
+	}, "/repo/logs/runs/_repository/pipelines/combined.json"))
+
+	var run Run
+	require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/runs/combined", "", &run))
+	assert.Equal(t, StatusSucceeded, run.Status)
+	assert.Equal(t, "pipelines", run.Pipeline)
+
+	assert.Equal(t, http.StatusNotFound, request(t, ts, http.MethodGet, "/api/runs/missing", "", nil))
+	assert.Equal(t, http.StatusNotFound, request(t, ts, http.MethodGet, "/api/runs/..%2Ffirst%2Fsucceeded", "", nil))
+}
+
+func TestServer_AmbiguousPipelineName(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{jobs: make(chan *Job, 10), release: make(chan error)}
+	finder := func(root string, files []string) ([]string, error) {
+		return []string{"/repo/first", "/repo/copy/first"}, nil
+	}
+	builder := func(ctx context.Context, pipelinePath string) (*pipeline.Pipeline, error) {
+		return &pipeline.Pipeline{Name: "first"}, nil
+	}
+
+	fs := afero.NewMemMapFs()
+	writePipelineDefinitions(t, fs, map[string]string{"/repo/first": "first", "/repo/copy/first": "first"})
+
+	s := New(fs, "/repo", runner, finder, builder, &bytes.Buffer{}, Options{
+		Token:                   testToken,
+		MaxConcurrentRuns:       1,
+		StateDir:                "/repo/logs/runs",
+		PipelineDefinitionFiles: []string{"pipeline.yml"},
+	})
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(func() {
+		s.Close()
+		ts.Close()
+	})
+
+	assert.Equal(t, http.StatusConflict, request(t, ts, http.MethodGet, "/api/pipelines/first", "", nil))
+	assert.Equal(t, http.StatusConflict, request(t, ts, http.MethodPost, "/api/runs", `{"pipeline":"first"}`, nil))
+	assert.Empty(t, runner.jobs)
+}
+
+func TestServer_BrokenPipelines(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{jobs: make(chan *Job, 10), release: make(chan error)}
+	finder := func(root string, files []string) ([]string, error) {
+		return []string{"/repo/healthy", "/repo/broken", "/repo/invalid"}, nil
+	}
+	builder := func(ctx context.Context, pipelinePath string) (*pipeline.Pipeline, error) {
+		if pipelinePath != "/repo/healthy" {
+			return nil, errors.New("an asset cannot be parsed")
+		}
+
+		return &pipeline.Pipeline{Name: "healthy"}, nil
+	}
+
+	fs := afero.NewMemMapFs()
+	writePipelineDefinitions(t, fs, map[string]string{"/repo/healthy": "healthy", "/repo/broken": "broken"})
+	require.NoError(t, afero.WriteFile(fs, "/repo/invalid/pipeline.yml", []byte("name: [invalid"), 0o644))
+
+	s := New(fs, "/repo", runner, finder, builder, &bytes.Buffer{}, Options{
+		Token:                   testToken,
+		MaxConcurrentRuns:       1,
+		StateDir:                "/repo/logs/runs",
+		PipelineDefinitionFiles: []string{"pipeline.yml"},
+	})
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(func() {
+		s.Close()
+		ts.Close()
+	})
+
+	var pipelines PipelineList
+	require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/pipelines", "", &pipelines))
+	require.Len(t, pipelines.Pipelines, 1)
+	assert.Equal(t, "healthy", pipelines.Pipelines[0].Name)
+	assert.Equal(t, []PipelineError{
+		{Name: "broken", Path: "/repo/broken", Error: "an asset cannot be parsed"},
+		{Path: "/repo/invalid", Error: "an asset cannot be parsed"},
+	}, pipelines.Errors)
+
+	var p pipeline.Pipeline
+	require.Equal(t, http.StatusOK, request(t, ts, http.MethodGet, "/api/pipelines/healthy", "", &p))
+	assert.Equal(t, "healthy", p.Name)
+
+	var body map[string]string
+	require.Equal(t, http.StatusInternalServerError, request(t, ts, http.MethodPost, "/api/runs", `{"pipeline":"broken"}`, &body))
+	assert.Equal(t, "failed to build the pipeline in '/repo/broken': an asset cannot be parsed", body["error"])
+	assert.Empty(t, runner.jobs)
+}
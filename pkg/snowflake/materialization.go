@@ -23,6 +23,7 @@ var matMap = pipeline.AssetMaterializationMap{
 		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
 		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
 		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
 		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
 	},
 }
@@ -76,7 +77,51 @@ func buildCreateReplaceQuery(task *pipeline.Asset, query string) (string, error)
 		clusterByClause = fmt.Sprintf("CLUSTER BY (%s)", strings.Join(mat.ClusterBy, ", "))
 	}
 
-	return fmt.Sprintf("CREATE OR REPLACE TABLE %s %s AS\n%s", task.Name, clusterByClause, query), nil
+	createQuery := fmt.Sprintf("CREATE OR REPLACE TABLE %s %s AS\n%s", task.Name, clusterByClause, query)
+	if !task.HasColumnConstraints() && len(task.ColumnNamesWithPrimaryKey()) == 0 {
+		return createQuery, nil
+	}
+
+	constraints, err := buildConstraintQueries(task)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(append([]string{strings.TrimSuffix(createQuery, ";")}, constraints...), ";\n") + ";", nil
+}
+
+// buildConstraintQueries adds the column constraints to a table that is created via "CREATE TABLE AS", which does not
+// accept constraints in the column list. Column defaults cannot be added to an existing table in Snowflake, therefore
+// they are only supported with the `ddl` strategy.
+func buildConstraintQueries(asset *pipeline.Asset) ([]string, error) {
+	queries := make([]string, 0)
+	for _, col := range asset.Columns {
+		if col.CheckConstraint != "" {
+			return nil, fmt.Errorf("check constraints are not supported in Snowflake, column '%s' has one", col.Name)
+		}
+		if col.Default != "" {
+			return nil, fmt.Errorf("column defaults are only supported with the `ddl` strategy in Snowflake, column '%s' has one", col.Name)
+		}
+		if !col.Nullable.Bool() {
+			queries = append(queries, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", asset.Name, col.Name))
+		}
+	}
+
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) > 0 {
+		queries = append(queries, fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", asset.Name, strings.Join(primaryKeys, ", ")))
+	}
+
+	for _, col := range asset.Columns {
+		if col.ForeignKey != nil {
+			queries = append(queries, fmt.Sprintf(
+				"ALTER TABLE %s ADD FOREIGN KEY (%s) REFERENCES %s (%s)",
+				asset.Name, col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
+			))
+		}
+	}
+
+	return queries, nil
 }
 
 func buildMergeQuery(asset *pipeline.Asset, query string) (string, error) {
@@ -159,11 +204,25 @@ func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error)
 func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 	columnDefs := make([]string, 0, len(asset.Columns))
 	primaryKeys := make([]string, 0)
+	foreignKeys := make([]string, 0)
 	for _, col := range asset.Columns {
+		if col.CheckConstraint != "" {
+			return "", fmt.Errorf("check constraints are not supported in Snowflake, column '%s' has one", col.Name)
+		}
+
 		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if !col.Nullable.Bool() {
+			def += " NOT NULL"
+		}
+		if col.Default != "" {
+			def += " DEFAULT " + col.Default
+		}
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, col.Name)
 		}
+		if col.ForeignKey != nil {
+			foreignKeys = append(foreignKeys, fmt.Sprintf(",\nforeign key (%s) references %s (%s)", col.Name, col.ForeignKey.Table, col.ForeignKey.Column))
+		}
 		if col.Description != "" {
 			desc := strings.ReplaceAll(col.Description, `'`, `''`)
 			def += fmt.Sprintf(" COMMENT '%s'", desc)
@@ -180,12 +239,13 @@ func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 	}
 	ddl := fmt.Sprintf(
 		"CREATE TABLE IF NOT EXISTS %s %s(\n"+
-			"%s%s\n"+
+			"%s%s%s\n"+
 			")",
 		asset.Name,
 		clusterByClause,
 		strings.Join(columnDefs, ",\n"),
 		primaryKeyClause,
+		strings.Join(foreignKeys, ""),
 	)
 
 	return ddl, nil
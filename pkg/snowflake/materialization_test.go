@@ -10,6 +10,7 @@ import (
 
 func TestMaterializer_Render(t *testing.T) {
 	t.Parallel()
+	falseValue := false
 	tests := []struct {
 		name        string
 		task        *pipeline.Asset
@@ -339,6 +340,83 @@ func TestMaterializer_Render(t *testing.T) {
 				"primary key \\(id, category\\)\n" +
 				"\\)",
 		},
+		{
+			name: "table with constraints and defaults",
+			task: &pipeline.Asset{
+				Name: "my.orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &falseValue}},
+					{Name: "status", Type: "STRING", Default: "'new'"},
+					{Name: "user_id", Type: "INT", ForeignKey: &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}},
+				},
+			},
+			want: "^CREATE TABLE IF NOT EXISTS my\\.orders \\(\nid INT NOT NULL,\nstatus STRING DEFAULT 'new',\nuser_id INT,\nprimary key \\(id\\),\nforeign key \\(user_id\\) references my\\.users \\(id\\)\n\\)$",
+		},
+		{
+			name: "create+replace adds the constraints after creating the table",
+			task: &pipeline.Asset{
+				Name: "my.orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyCreateReplace,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &falseValue}},
+					{Name: "status", Type: "STRING"},
+					{Name: "user_id", Type: "INT", ForeignKey: &pipeline.ColumnForeignKey{Table: "my.users", Column: "id"}},
+				},
+			},
+			query: "SELECT 1 as id",
+			want:  "^CREATE OR REPLACE TABLE my\\.orders  AS\nSELECT 1 as id;\nALTER TABLE my\\.orders ALTER COLUMN id SET NOT NULL;\nALTER TABLE my\\.orders ADD PRIMARY KEY \\(id\\);\nALTER TABLE my\\.orders ADD FOREIGN KEY \\(user_id\\) REFERENCES my\\.users \\(id\\);$",
+		},
+		{
+			name: "create+replace adds the primary key after creating the table",
+			task: &pipeline.Asset{
+				Name: "my.orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyCreateReplace,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+				},
+			},
+			query: "SELECT 1 as id",
+			want:  "^CREATE OR REPLACE TABLE my\\.orders  AS\nSELECT 1 as id;\nALTER TABLE my\\.orders ADD PRIMARY KEY \\(id\\);$",
+		},
+		{
+			name: "create+replace does not support column defaults",
+			task: &pipeline.Asset{
+				Name: "my.orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyCreateReplace,
+				},
+				Columns: []pipeline.Column{
+					{Name: "status", Type: "STRING", Default: "'new'"},
+				},
+			},
+			query:   "SELECT 'new' as status",
+			wantErr: true,
+		},
+		{
+			name: "check constraints are not supported",
+			task: &pipeline.Asset{
+				Name: "my.orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "amount", Type: "FLOAT", CheckConstraint: "amount > 0"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,90 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/pkg/errors"
+)
+
+// seedColumnTypes maps the seed types to the column types dlt would create in Snowflake.
+var seedColumnTypes = map[string]string{
+	seed.TypeText:      "VARCHAR",
+	seed.TypeBigint:    "NUMBER(19,0)",
+	seed.TypeDouble:    "FLOAT",
+	seed.TypeBinary:    "BINARY",
+	seed.TypeBool:      "BOOLEAN",
+	seed.TypeDate:      "DATE",
+	seed.TypeTimestamp: "TIMESTAMP_TZ",
+	seed.TypeTime:      "TIME",
+}
+
+// LoadSeed replaces the table with the seed data by uploading the file to the table stage with `PUT` and loading it
+// with `COPY INTO`. The values are re-encoded in the formats Snowflake expects, binaries are hex encoded.
+func (db *DB) LoadSeed(ctx context.Context, asset *pipeline.Asset, data *seed.Data) error {
+	if err := db.initializeDB(); err != nil {
+		return err
+	}
+
+	if err := db.CreateSchemaIfNotExist(ctx, asset); err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "bruin-seed-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a temporary directory for the seed file")
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "seed.csv")
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the seed file")
+	}
+
+	err = data.WriteCSV(file, hex.EncodeToString)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write the seed file")
+	}
+
+	for _, q := range buildSeedQueries(asset.Name, path, data) {
+		if _, err := db.conn.ExecContext(ctx, q); err != nil {
+			return errors.Wrapf(err, "failed to run query: %s", q)
+		}
+	}
+
+	return nil
+}
+
+func buildSeedQueries(tableName, path string, data *seed.Data) []string {
+	columnDefs := make([]string, len(data.Columns))
+	for i, col := range data.Columns {
+		columnDefs[i] = fmt.Sprintf("%s %s", col.Name, seedColumnTypes[col.Type])
+	}
+
+	return []string{
+		fmt.Sprintf("CREATE OR REPLACE TABLE %s (%s)", tableName, strings.Join(columnDefs, ", ")),
+		fmt.Sprintf("PUT 'file://%s' %s AUTO_COMPRESS = TRUE OVERWRITE = TRUE", filepath.ToSlash(path), tableStage(tableName)),
+		fmt.Sprintf(
+			"COPY INTO %s FROM %s FILE_FORMAT = (TYPE = CSV SKIP_HEADER = 1 FIELD_OPTIONALLY_ENCLOSED_BY = '\"' EMPTY_FIELD_AS_NULL = TRUE BINARY_FORMAT = HEX) PURGE = TRUE",
+			tableName,
+			tableStage(tableName),
+		),
+	}
+}
+
+// tableStage returns the stage of the given table, the stage name is prefixed with `%` after the namespace,
+// e.g. `@schema.%table`.
+func tableStage(tableName string) string {
+	idx := strings.LastIndex(tableName, ".")
+	return fmt.Sprintf("@%s%%%s", tableName[:idx+1], tableName[idx+1:])
+}
@@ -0,0 +1,35 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSeedQueries(t *testing.T) {
+	t.Parallel()
+
+	data := &seed.Data{
+		Columns: []seed.Column{
+			{Name: "id", Type: seed.TypeBigint},
+			{Name: "created_at", Type: seed.TypeTimestamp},
+			{Name: "name", Type: seed.TypeText},
+		},
+	}
+
+	expected := []string{
+		"CREATE OR REPLACE TABLE raw.people (id NUMBER(19,0), created_at TIMESTAMP_TZ, name VARCHAR)",
+		"PUT 'file:///tmp/seed.csv' @raw.%people AUTO_COMPRESS = TRUE OVERWRITE = TRUE",
+		"COPY INTO raw.people FROM @raw.%people FILE_FORMAT = (TYPE = CSV SKIP_HEADER = 1 FIELD_OPTIONALLY_ENCLOSED_BY = '\"' EMPTY_FIELD_AS_NULL = TRUE BINARY_FORMAT = HEX) PURGE = TRUE",
+	}
+	assert.Equal(t, expected, buildSeedQueries("raw.people", "/tmp/seed.csv", data))
+}
+
+func TestTableStage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "@%people", tableStage("people"))
+	assert.Equal(t, "@raw.%people", tableStage("raw.people"))
+	assert.Equal(t, "@db.raw.%people", tableStage("db.raw.people"))
+}
@@ -1,13 +1,165 @@
 package spanner
 
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/query"
+	_ "github.com/googleapis/go-sql-spanner"
+	"github.com/pkg/errors"
+)
+
+type Querier interface {
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+}
+
+type Selector interface {
+	Select(ctx context.Context, query *query.Query) ([][]interface{}, error)
+	SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error)
+}
+
+type DB interface {
+	Querier
+	Selector
+}
+
 type Client struct {
 	config Config
+
+	connOnce sync.Once
+	conn     *sql.DB
+	connErr  error
+}
+
+func NewClient(c Config) (*Client, error) {
+	return &Client{config: c}, nil
 }
 
 func (c *Client) GetIngestrURI() (string, error) {
 	return c.config.GetIngestrURI(), nil
 }
 
-func NewClient(c Config) (*Client, error) {
-	return &Client{c}, nil
+// db opens the connection lazily. The Spanner driver only accepts credential files, which means inline service account
+// credentials are written to a private temporary file that is removed as soon as the driver has loaded them.
+func (c *Client) db(ctx context.Context) (*sql.DB, error) {
+	c.connOnce.Do(func() {
+		if c.config.ServiceAccountJSON == "" {
+			c.conn, c.connErr = sql.Open("spanner", c.config.ToDBConnectionURI(c.config.ServiceAccountFile))
+			return
+		}
+
+		c.conn, c.connErr = openWithCredentialsJSON(ctx, c.config)
+	})
+
+	return c.conn, c.connErr
+}
+
+func openWithCredentialsJSON(ctx context.Context, config Config) (*sql.DB, error) {
+	file, err := os.CreateTemp("", "bruin-spanner-*.json")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a temporary file for the service account credentials")
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(config.ServiceAccountJSON)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to write the service account credentials")
+	}
+
+	db, err := sql.Open("spanner", config.ToDBConnectionURI(file.Name()))
+	if err != nil {
+		return nil, err
+	}
+
+	// the driver reads the credentials while opening the first connection, the later connections reuse the same client
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "failed to connect to Spanner")
+	}
+	_ = conn.Close()
+
+	return db, nil
+}
+
+func (c *Client) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
+	conn, err := c.db(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, strings.TrimSuffix(strings.TrimSpace(query.String()), ";"))
+	if err != nil {
+		return errors.Wrap(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (c *Client) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
+	result, err := c.SelectWithSchema(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Rows, nil
+}
+
+func (c *Client) SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error) {
+	conn, err := c.db(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, strings.TrimSuffix(strings.TrimSpace(queryObj.String()), ";"))
+	if err != nil {
+		return nil, errors.New(strings.ReplaceAll(err.Error(), "\n", "  -  "))
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve column names")
+	}
+
+	result := &query.QueryResult{
+		Columns: cols,
+		Rows:    [][]interface{}{},
+	}
+
+	for rows.Next() {
+		row := make([]interface{}, len(cols))
+		columnPointers := make([]interface{}, len(cols))
+		for i := range row {
+			columnPointers[i] = &row[i]
+		}
+
+		if err := rows.Scan(columnPointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Select(ctx, &query.Query{Query: "SELECT 1"})
+	if err != nil {
+		return errors.Wrap(err, "failed to run test query on Spanner connection")
+	}
+
+	return nil
 }
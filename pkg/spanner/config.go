@@ -2,6 +2,7 @@ package spanner
 
 import (
 	"encoding/base64"
+	"fmt"
 	"net/url"
 )
 
@@ -27,3 +28,12 @@ func (c *Config) GetIngestrURI() string {
 	}
 	return "spanner://?" + q.Encode()
 }
+
+func (c *Config) ToDBConnectionURI(credentialsFile string) string {
+	dsn := fmt.Sprintf("projects/%s/instances/%s/databases/%s", c.ProjectID, c.InstanceID, c.Database)
+	if credentialsFile != "" {
+		dsn += ";credentials=" + credentialsFile
+	}
+
+	return dsn
+}
@@ -0,0 +1,216 @@
+package spanner
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+type (
+	MaterializerFunc        func(asset *pipeline.Asset, query string) ([]string, error)
+	AssetMaterializationMap map[pipeline.MaterializationType]map[pipeline.MaterializationStrategy]MaterializerFunc
+)
+
+var matMap = AssetMaterializationMap{
+	pipeline.MaterializationTypeView: {
+		pipeline.MaterializationStrategyNone:          viewMaterializer,
+		pipeline.MaterializationStrategyAppend:        errorMaterializer,
+		pipeline.MaterializationStrategyCreateReplace: errorMaterializer,
+		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
+	},
+	pipeline.MaterializationTypeTable: {
+		pipeline.MaterializationStrategyNone:          buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:        buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace: buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
+		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
+		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
+	},
+}
+
+func errorMaterializer(asset *pipeline.Asset, query string) ([]string, error) {
+	return nil, fmt.Errorf("materialization strategy %s is not supported for materialization type %s and asset type %s", asset.Materialization.Strategy, asset.Materialization.Type, asset.Type)
+}
+
+func viewMaterializer(asset *pipeline.Asset, query string) ([]string, error) {
+	return []string{fmt.Sprintf("CREATE OR REPLACE VIEW %s SQL SECURITY INVOKER AS\n%s", asset.Name, query)}, nil
+}
+
+// insertStatement builds an insert with an explicit column list, since Spanner does not support inserts without one.
+func insertStatement(asset *pipeline.Asset, query string) (string, error) {
+	if len(asset.Columns) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `columns` field to be set for Spanner assets", asset.Materialization.Strategy)
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s)\n%s", asset.Name, strings.Join(ansisql.ColumnNames(asset), ", "), query), nil
+}
+
+// buildCreateReplaceQuery creates the table from the column definitions, since Spanner does not support
+// `CREATE TABLE AS` statements and every table requires a primary key.
+func buildCreateReplaceQuery(asset *pipeline.Asset, query string) ([]string, error) {
+	if len(asset.Materialization.ClusterBy) > 0 {
+		return nil, errors.New("Spanner assets do not support `cluster_by`")
+	}
+
+	if asset.Materialization.PartitionBy != "" {
+		return nil, errors.New("Spanner assets do not support `partition_by`")
+	}
+
+	ddl, err := createTableStatement(asset, false)
+	if err != nil {
+		return nil, err
+	}
+
+	insert, err := insertStatement(asset, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		"DROP TABLE IF EXISTS " + asset.Name,
+		ddl,
+		insert,
+	}, nil
+}
+
+func buildAppendQuery(asset *pipeline.Asset, query string) ([]string, error) {
+	insert, err := insertStatement(asset, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{insert}, nil
+}
+
+func buildIncrementalQuery(asset *pipeline.Asset, query string) ([]string, error) {
+	mat := asset.Materialization
+	if mat.IncrementalKey == "" {
+		return nil, fmt.Errorf("materialization strategy %s requires the `incremental_key` field to be set", mat.Strategy)
+	}
+
+	insert, err := insertStatement(asset, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		fmt.Sprintf("DELETE FROM %s WHERE %s IN (SELECT DISTINCT %s FROM (%s))", asset.Name, mat.IncrementalKey, mat.IncrementalKey, query),
+		insert,
+	}, nil
+}
+
+// buildMergeQuery uses `INSERT OR UPDATE`, which updates every given column of the existing rows. Spanner has no
+// way of updating only a subset of them, therefore either all or none of the non-primary key columns must be marked
+// with `update_on_merge`.
+func buildMergeQuery(asset *pipeline.Asset, query string) ([]string, error) {
+	if len(asset.Columns) == 0 {
+		return nil, fmt.Errorf("materialization strategy %s requires the `columns` field to be set", asset.Materialization.Strategy)
+	}
+
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) == 0 {
+		return nil, fmt.Errorf("materialization strategy %s requires the `primary_key` field to be set on at least one column", asset.Materialization.Strategy)
+	}
+
+	updateColumns := asset.ColumnNamesWithUpdateOnMerge()
+	nonPrimaryKeyCount := len(asset.Columns) - len(primaryKeys)
+
+	mode := "UPDATE"
+	switch len(updateColumns) {
+	case 0:
+		mode = "IGNORE"
+	case nonPrimaryKeyCount:
+	default:
+		return nil, errors.New("Spanner assets must either set `update_on_merge` on all of the non-primary key columns or none of them")
+	}
+
+	return []string{
+		fmt.Sprintf("INSERT OR %s INTO %s (%s)\n%s", mode, asset.Name, strings.Join(ansisql.ColumnNames(asset), ", "), query),
+	}, nil
+}
+
+func buildTimeIntervalQuery(asset *pipeline.Asset, query string) ([]string, error) {
+	if asset.Materialization.IncrementalKey == "" {
+		return nil, errors.New("incremental_key is required for time_interval strategy")
+	}
+
+	if asset.Materialization.TimeGranularity == "" {
+		return nil, errors.New("time_granularity is required for time_interval strategy")
+	}
+
+	if !(asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityTimestamp || asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate) {
+		return nil, errors.New("time_granularity must be either 'date', or 'timestamp'")
+	}
+
+	startVar := "{{start_timestamp}}"
+	endVar := "{{end_timestamp}}"
+	if asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate {
+		startVar = "{{start_date}}"
+		endVar = "{{end_date}}"
+	}
+
+	insert, err := insertStatement(asset, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		fmt.Sprintf("DELETE FROM %s WHERE %s BETWEEN '%s' AND '%s'", asset.Name, asset.Materialization.IncrementalKey, startVar, endVar),
+		insert,
+	}, nil
+}
+
+func buildDDLQuery(asset *pipeline.Asset, query string) ([]string, error) {
+	ddl, err := createTableStatement(asset, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{ddl}, nil
+}
+
+func createTableStatement(asset *pipeline.Asset, ifNotExists bool) (string, error) {
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) == 0 {
+		return "", errors.New("Spanner tables require at least one column with `primary_key` set")
+	}
+
+	columnDefs := make([]string, 0, len(asset.Columns))
+	constraints := make([]string, 0)
+	for _, col := range asset.Columns {
+		if col.Type == "" {
+			return "", fmt.Errorf("column '%s' requires a type to create the Spanner table", col.Name)
+		}
+
+		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if !col.Nullable.Bool() {
+			def += " NOT NULL"
+		}
+		if col.Default != "" {
+			def += fmt.Sprintf(" DEFAULT (%s)", col.Default)
+		}
+		if col.CheckConstraint != "" {
+			constraints = append(constraints, fmt.Sprintf("CONSTRAINT %s CHECK (%s)", ansisql.ConstraintName("chk", asset.Name, col.Name), col.CheckConstraint))
+		}
+		if col.ForeignKey != nil {
+			constraints = append(constraints, fmt.Sprintf(
+				"CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+				ansisql.ConstraintName("fk", asset.Name, col.Name), col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
+			))
+		}
+		columnDefs = append(columnDefs, def)
+	}
+	columnDefs = append(columnDefs, constraints...)
+
+	createTable := "CREATE TABLE "
+	if ifNotExists {
+		createTable = "CREATE TABLE IF NOT EXISTS "
+	}
+
+	return fmt.Sprintf("%s%s (\n%s\n) PRIMARY KEY (%s)", createTable, asset.Name, strings.Join(columnDefs, ",\n"), strings.Join(primaryKeys, ", ")), nil
+}
@@ -0,0 +1,212 @@
+package spanner
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializer_Render(t *testing.T) {
+	t.Parallel()
+
+	columns := []pipeline.Column{
+		{Name: "id", Type: "INT64", PrimaryKey: true},
+		{Name: "name", Type: "STRING(MAX)", UpdateOnMerge: true},
+	}
+
+	tests := []struct {
+		name        string
+		task        *pipeline.Asset
+		query       string
+		want        []string
+		wantErr     string
+		fullRefresh bool
+	}{
+		{
+			name:  "no materialization, return raw query",
+			task:  &pipeline.Asset{},
+			query: "SELECT 1",
+			want:  []string{"SELECT 1"},
+		},
+		{
+			name: "materialize to a view",
+			task: &pipeline.Asset{
+				Name:            "my_asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			query: "SELECT 1;",
+			want:  []string{"CREATE OR REPLACE VIEW my_asset SQL SECURITY INVOKER AS\nSELECT 1"},
+		},
+		{
+			name: "create+replace builds the table from the columns",
+			task: &pipeline.Asset{
+				Name:            "my_asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64", PrimaryKey: true},
+					{Name: "amount", Type: "FLOAT64", Default: "0", CheckConstraint: "amount >= 0"},
+				},
+			},
+			query: "SELECT 1 AS id, 2.0 AS amount",
+			want: []string{
+				"DROP TABLE IF EXISTS my_asset",
+				"CREATE TABLE my_asset (\n" +
+					"id INT64,\n" +
+					"amount FLOAT64 DEFAULT (0),\n" +
+					"CONSTRAINT chk_my_asset_amount CHECK (amount >= 0)\n" +
+					") PRIMARY KEY (id)",
+				"INSERT INTO my_asset (id, amount)\nSELECT 1 AS id, 2.0 AS amount",
+			},
+		},
+		{
+			name: "create+replace requires a primary key",
+			task: &pipeline.Asset{
+				Name:            "my_asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Columns:         []pipeline.Column{{Name: "id", Type: "INT64"}},
+			},
+			query:   "SELECT 1 AS id",
+			wantErr: "Spanner tables require at least one column with `primary_key` set",
+		},
+		{
+			name: "append requires columns",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			query:   "SELECT 1",
+			wantErr: "materialization strategy append requires the `columns` field to be set for Spanner assets",
+		},
+		{
+			name: "delete+insert",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:           pipeline.MaterializationTypeTable,
+					Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+					IncrementalKey: "id",
+				},
+				Columns: columns,
+			},
+			query: "SELECT id, name FROM source",
+			want: []string{
+				"DELETE FROM my_asset WHERE id IN (SELECT DISTINCT id FROM (SELECT id, name FROM source))",
+				"INSERT INTO my_asset (id, name)\nSELECT id, name FROM source",
+			},
+		},
+		{
+			name: "merge updates all columns",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: columns,
+			},
+			query: "SELECT id, name FROM source",
+			want:  []string{"INSERT OR UPDATE INTO my_asset (id, name)\nSELECT id, name FROM source"},
+		},
+		{
+			name: "merge without update columns ignores the existing rows",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+					{Name: "name"},
+				},
+			},
+			query: "SELECT id, name FROM source",
+			want:  []string{"INSERT OR IGNORE INTO my_asset (id, name)\nSELECT id, name FROM source"},
+		},
+		{
+			name: "merge cannot update a subset of the columns",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+					{Name: "name", UpdateOnMerge: true},
+					{Name: "created_at"},
+				},
+			},
+			query:   "SELECT id, name, created_at FROM source",
+			wantErr: "Spanner assets must either set `update_on_merge` on all of the non-primary key columns or none of them",
+		},
+		{
+			name: "time_interval",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyTimeInterval,
+					TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+					IncrementalKey:  "dt",
+				},
+				Columns: []pipeline.Column{{Name: "dt", Type: "DATE"}},
+			},
+			query: "SELECT dt FROM source",
+			want: []string{
+				"DELETE FROM my_asset WHERE dt BETWEEN '{{start_date}}' AND '{{end_date}}'",
+				"INSERT INTO my_asset (dt)\nSELECT dt FROM source",
+			},
+		},
+		{
+			name: "ddl",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64", PrimaryKey: true},
+					{Name: "user_id", Type: "INT64", ForeignKey: &pipeline.ColumnForeignKey{Table: "users", Column: "id"}},
+				},
+			},
+			want: []string{
+				"CREATE TABLE IF NOT EXISTS my_asset (\n" +
+					"id INT64,\n" +
+					"user_id INT64,\n" +
+					"CONSTRAINT fk_my_asset_user_id FOREIGN KEY (user_id) REFERENCES users (id)\n" +
+					") PRIMARY KEY (id)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewMaterializer(tt.fullRefresh)
+			render, err := m.Render(tt.task, tt.query)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, render)
+		})
+	}
+}
+
+func TestConfig_ToDBConnectionURI(t *testing.T) {
+	t.Parallel()
+
+	c := Config{ProjectID: "project", InstanceID: "instance", Database: "db"}
+	assert.Equal(t, "projects/project/instances/instance/databases/db", c.ToDBConnectionURI(""))
+	assert.Equal(t, "projects/project/instances/instance/databases/db;credentials=/tmp/creds.json", c.ToDBConnectionURI("/tmp/creds.json"))
+}
@@ -0,0 +1,62 @@
+package spanner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// Spanner runs a single statement per request and cannot mix DDL and DML statements in the same batch, therefore the
+// materializer returns a slice of statements that are executed one after another.
+type Materializer struct {
+	MaterializationMap AssetMaterializationMap
+	fullRefresh        bool
+}
+
+func NewMaterializer(fullRefresh bool) *Materializer {
+	return &Materializer{
+		MaterializationMap: matMap,
+		fullRefresh:        fullRefresh,
+	}
+}
+
+func (m *Materializer) Render(asset *pipeline.Asset, query string) ([]string, error) {
+	mat := asset.Materialization
+	if mat.Type == pipeline.MaterializationTypeNone {
+		return []string{query}, nil
+	}
+
+	strategy := mat.Strategy
+	if m.fullRefresh && mat.Type == pipeline.MaterializationTypeTable {
+		if mat.Strategy != pipeline.MaterializationStrategyDDL {
+			strategy = pipeline.MaterializationStrategyCreateReplace
+		}
+	}
+
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if matFunc, ok := m.MaterializationMap[mat.Type][strategy]; ok {
+		return matFunc(asset, query)
+	}
+
+	return []string{}, fmt.Errorf("unsupported materialization type - strategy combination: (`%s` - `%s`)", mat.Type, mat.Strategy)
+}
+
+type Renderer struct {
+	mat *Materializer
+}
+
+func NewRenderer(fullRefresh bool) *Renderer {
+	return &Renderer{
+		mat: NewMaterializer(fullRefresh),
+	}
+}
+
+func (r *Renderer) Render(asset *pipeline.Asset, query string) (string, error) {
+	queries, err := r.mat.Render(asset, query)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
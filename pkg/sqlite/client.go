@@ -1,13 +1,147 @@
 package sqlite
 
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"modernc.org/sqlite"
+)
+
+type Querier interface {
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+}
+
+type Selector interface {
+	Select(ctx context.Context, query *query.Query) ([][]interface{}, error)
+	SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error)
+}
+
+type DB interface {
+	Querier
+	Selector
+}
+
+func init() {
+	// SQLite supports the `REGEXP` operator syntax but ships without an implementation for it, `X REGEXP Y` is
+	// evaluated as `regexp(Y, X)`.
+	_ = sqlite.RegisterDeterministicScalarFunction("regexp", 2, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		pattern, ok := args[0].(string)
+		if !ok {
+			return nil, errors.New("the regexp pattern must be a string")
+		}
+
+		var value string
+		switch v := args[1].(type) {
+		case nil:
+			return nil, nil
+		case string:
+			value = v
+		case []byte:
+			value = string(v)
+		default:
+			value = fmt.Sprint(v)
+		}
+
+		return regexp.MatchString(pattern, value)
+	})
+}
+
 type Client struct {
+	conn   *sqlx.DB
 	config Config
 }
 
+func NewClient(c Config) (*Client, error) {
+	// the database file is only opened on the first query, therefore the connection can be defined before the file exists
+	conn, err := sqlx.Open("sqlite", c.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		conn:   conn,
+		config: c,
+	}, nil
+}
+
 func (c *Client) GetIngestrURI() (string, error) {
 	return c.config.GetIngestrURI(), nil
 }
 
-func NewClient(c Config) (*Client, error) {
-	return &Client{c}, nil
+func (c *Client) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
+	_, err := c.conn.ExecContext(ctx, query.String())
+	if err != nil {
+		return errors.Wrap(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (c *Client) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
+	result, err := c.SelectWithSchema(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Rows, nil
+}
+
+func (c *Client) SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error) {
+	rows, err := c.conn.QueryContext(ctx, queryObj.String())
+	if err != nil {
+		return nil, errors.New(strings.ReplaceAll(err.Error(), "\n", "  -  "))
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve column names")
+	}
+
+	result := &query.QueryResult{
+		Columns: cols,
+		Rows:    [][]interface{}{},
+	}
+
+	for rows.Next() {
+		row := make([]interface{}, len(cols))
+		columnPointers := make([]interface{}, len(cols))
+		for i := range row {
+			columnPointers[i] = &row[i]
+		}
+
+		if err := rows.Scan(columnPointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// convert []byte -> string
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", rows.Err())
+	}
+
+	return result, nil
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	err := c.RunQueryWithoutResult(ctx, &query.Query{Query: "SELECT 1"})
+	if err != nil {
+		return errors.Wrap(err, "failed to run test query on SQLite connection")
+	}
+
+	return nil
 }
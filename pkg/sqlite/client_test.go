@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	client, err := NewClient(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.conn.Close() })
+
+	return client
+}
+
+func TestClient_SelectWithSchema(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	err := client.RunQueryWithoutResult(ctx, &query.Query{Query: "CREATE TABLE users (id INTEGER, name TEXT); INSERT INTO users VALUES (1, 'alice'), (2, 'bob');"})
+	require.NoError(t, err)
+
+	result, err := client.SelectWithSchema(ctx, &query.Query{Query: "SELECT id, name FROM users ORDER BY id"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "name"}, result.Columns)
+	assert.Equal(t, [][]interface{}{{int64(1), "alice"}, {int64(2), "bob"}}, result.Rows)
+
+	rows, err := client.Select(ctx, &query.Query{Query: "SELECT count(*) FROM users WHERE name REGEXP '^a'"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{int64(1)}}, rows)
+
+	_, err = client.Select(ctx, &query.Query{Query: "SELECT * FROM missing_table"})
+	require.Error(t, err)
+
+	require.NoError(t, client.Ping(ctx))
+}
+
+func TestConfig_GetIngestrURI(t *testing.T) {
+	t.Parallel()
+
+	c := Config{Path: "/tmp/test.db"}
+	assert.Equal(t, "sqlite:////tmp/test.db", c.GetIngestrURI())
+}
@@ -0,0 +1,214 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+var matMap = pipeline.AssetMaterializationMap{
+	pipeline.MaterializationTypeView: {
+		pipeline.MaterializationStrategyNone:          viewMaterializer,
+		pipeline.MaterializationStrategyAppend:        errorMaterializer,
+		pipeline.MaterializationStrategyCreateReplace: errorMaterializer,
+		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
+	},
+	pipeline.MaterializationTypeTable: {
+		pipeline.MaterializationStrategyNone:          buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:        buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace: buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
+		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
+		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
+	},
+}
+
+func NewMaterializer(fullRefresh bool) *pipeline.Materializer {
+	return &pipeline.Materializer{
+		MaterializationMap: matMap,
+		FullRefresh:        fullRefresh,
+	}
+}
+
+func errorMaterializer(asset *pipeline.Asset, query string) (string, error) {
+	return "", fmt.Errorf("materialization strategy %s is not supported for materialization type %s and asset type %s", asset.Materialization.Strategy, asset.Materialization.Type, asset.Type)
+}
+
+// viewMaterializer drops the view first since SQLite does not support `CREATE OR REPLACE VIEW`.
+func viewMaterializer(asset *pipeline.Asset, query string) (string, error) {
+	return fmt.Sprintf("DROP VIEW IF EXISTS %s;\nCREATE VIEW %s AS\n%s;", asset.Name, asset.Name, strings.TrimSuffix(query, ";")), nil
+}
+
+func buildCreateReplaceQuery(asset *pipeline.Asset, query string) (string, error) {
+	if len(asset.Materialization.ClusterBy) > 0 {
+		return "", errors.New("SQLite assets do not support `cluster_by`")
+	}
+
+	if asset.Materialization.PartitionBy != "" {
+		return "", errors.New("SQLite assets do not support `partition_by`")
+	}
+
+	query = strings.TrimSuffix(query, ";")
+	queries := []string{
+		"BEGIN TRANSACTION",
+		"DROP TABLE IF EXISTS " + asset.Name,
+	}
+
+	// the primary key cannot be added to a table created with CTAS, the merge strategy depends on it though
+	if asset.HasColumnConstraints() || len(asset.ColumnNamesWithPrimaryKey()) > 0 {
+		ddl, err := buildDDLQuery(asset, query)
+		if err != nil {
+			return "", err
+		}
+
+		columns := strings.Join(ansisql.ColumnNames(asset), ", ")
+		queries = append(queries,
+			ddl,
+			fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM (%s) AS __bruin_source", asset.Name, columns, columns, query),
+		)
+	} else {
+		queries = append(queries, fmt.Sprintf("CREATE TABLE %s AS %s", asset.Name, query))
+	}
+
+	queries = append(queries, "COMMIT")
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+func buildAppendQuery(asset *pipeline.Asset, query string) (string, error) {
+	return fmt.Sprintf("INSERT INTO %s %s", asset.Name, query), nil
+}
+
+func buildIncrementalQuery(asset *pipeline.Asset, query string) (string, error) {
+	mat := asset.Materialization
+	strategy := pipeline.MaterializationStrategyDeleteInsert
+
+	if mat.IncrementalKey == "" {
+		return "", fmt.Errorf("materialization strategy %s requires the `incremental_key` field to be set", strategy)
+	}
+
+	tempTableName := "__bruin_tmp_" + helpers.PrefixGenerator()
+
+	queries := []string{
+		"BEGIN TRANSACTION",
+		fmt.Sprintf("CREATE TEMP TABLE %s AS %s", tempTableName, strings.TrimSuffix(query, ";")),
+		fmt.Sprintf("DELETE FROM %s WHERE %s IN (SELECT DISTINCT %s FROM %s)", asset.Name, mat.IncrementalKey, mat.IncrementalKey, tempTableName),
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", asset.Name, tempTableName),
+		"DROP TABLE IF EXISTS " + tempTableName,
+		"COMMIT",
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+// buildMergeQuery uses the upsert syntax of SQLite, which requires a primary key or a unique index on the primary key
+// columns of the target table.
+func buildMergeQuery(asset *pipeline.Asset, query string) (string, error) {
+	if len(asset.Columns) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `columns` field to be set", asset.Materialization.Strategy)
+	}
+
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `primary_key` field to be set on at least one column", asset.Materialization.Strategy)
+	}
+
+	onConflict := "DO NOTHING"
+	updateColumns := asset.ColumnNamesWithUpdateOnMerge()
+	if len(updateColumns) > 0 {
+		updateStatements := make([]string, 0, len(updateColumns))
+		for _, col := range updateColumns {
+			updateStatements = append(updateStatements, fmt.Sprintf("%s = excluded.%s", col, col))
+		}
+		onConflict = "DO UPDATE SET " + strings.Join(updateStatements, ", ")
+	}
+
+	columns := strings.Join(asset.ColumnNames(), ", ")
+	mergeLines := []string{
+		fmt.Sprintf("INSERT INTO %s (%s)", asset.Name, columns),
+		// the `WHERE true` is required to avoid the parsing ambiguity between the join constraint and the upsert clause
+		fmt.Sprintf("SELECT %s FROM (%s) AS source WHERE true", columns, strings.TrimSuffix(query, ";")),
+		fmt.Sprintf("ON CONFLICT (%s) %s", strings.Join(primaryKeys, ", "), onConflict),
+	}
+
+	return strings.Join(mergeLines, "\n") + ";", nil
+}
+
+func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error) {
+	if asset.Materialization.IncrementalKey == "" {
+		return "", errors.New("incremental_key is required for time_interval strategy")
+	}
+
+	if asset.Materialization.TimeGranularity == "" {
+		return "", errors.New("time_granularity is required for time_interval strategy")
+	}
+
+	if !(asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityTimestamp || asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate) {
+		return "", errors.New("time_granularity must be either 'date', or 'timestamp'")
+	}
+
+	startVar := "{{start_timestamp}}"
+	endVar := "{{end_timestamp}}"
+	if asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate {
+		startVar = "{{start_date}}"
+		endVar = "{{end_date}}"
+	}
+
+	queries := []string{
+		"BEGIN TRANSACTION",
+		fmt.Sprintf(`DELETE FROM %s WHERE %s BETWEEN '%s' AND '%s'`,
+			asset.Name,
+			asset.Materialization.IncrementalKey,
+			startVar,
+			endVar),
+		fmt.Sprintf(`INSERT INTO %s %s`,
+			asset.Name,
+			strings.TrimSuffix(query, ";")),
+		"COMMIT",
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
+	columnDefs := make([]string, 0, len(asset.Columns))
+	primaryKeys := []string{}
+	foreignKeys := []string{}
+
+	for _, col := range asset.Columns {
+		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if !col.Nullable.Bool() {
+			def += " NOT NULL"
+		}
+		if col.Default != "" {
+			def += " DEFAULT " + col.Default
+		}
+		if col.CheckConstraint != "" {
+			def += fmt.Sprintf(" CHECK (%s)", col.CheckConstraint)
+		}
+
+		if col.PrimaryKey {
+			primaryKeys = append(primaryKeys, col.Name)
+		}
+		if col.ForeignKey != nil {
+			foreignKeys = append(foreignKeys, fmt.Sprintf(
+				"CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+				ansisql.ConstraintName("fk", asset.Name, col.Name), col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
+			))
+		}
+		columnDefs = append(columnDefs, def)
+	}
+
+	if len(primaryKeys) > 0 {
+		columnDefs = append(columnDefs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+	columnDefs = append(columnDefs, foreignKeys...)
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n%s\n)", asset.Name, strings.Join(columnDefs, ",\n")), nil
+}
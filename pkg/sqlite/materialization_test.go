@@ -0,0 +1,228 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializer_Render(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		task        *pipeline.Asset
+		query       string
+		want        string
+		wantErr     bool
+		fullRefresh bool
+	}{
+		{
+			name:  "no materialization, return raw query",
+			task:  &pipeline.Asset{},
+			query: "SELECT 1",
+			want:  "SELECT 1",
+		},
+		{
+			name: "materialize to a view",
+			task: &pipeline.Asset{
+				Name:            "my_asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			query: "SELECT 1;",
+			want:  "DROP VIEW IF EXISTS my_asset;\nCREATE VIEW my_asset AS\nSELECT 1;",
+		},
+		{
+			name: "materialize to a table, default to create+replace",
+			task: &pipeline.Asset{
+				Name:            "my_asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			},
+			query: "SELECT 1",
+			want: "BEGIN TRANSACTION;\n" +
+				"DROP TABLE IF EXISTS my_asset;\n" +
+				"CREATE TABLE my_asset AS SELECT 1;\n" +
+				"COMMIT;",
+		},
+		{
+			name: "full refresh defaults to create+replace",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			fullRefresh: true,
+			query:       "SELECT 1",
+			want: "BEGIN TRANSACTION;\n" +
+				"DROP TABLE IF EXISTS my_asset;\n" +
+				"CREATE TABLE my_asset AS SELECT 1;\n" +
+				"COMMIT;",
+		},
+		{
+			name: "create+replace with constraints creates the table first",
+			task: &pipeline.Asset{
+				Name:            "my_asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INTEGER", PrimaryKey: true},
+					{Name: "status", Type: "TEXT", Default: "'new'"},
+				},
+			},
+			query: "SELECT 1 AS id, 'old' AS status",
+			want: "BEGIN TRANSACTION;\n" +
+				"DROP TABLE IF EXISTS my_asset;\n" +
+				"CREATE TABLE IF NOT EXISTS my_asset (\n" +
+				"id INTEGER,\n" +
+				"status TEXT DEFAULT 'new',\n" +
+				"PRIMARY KEY (id)\n" +
+				");\n" +
+				"INSERT INTO my_asset (id, status) SELECT id, status FROM (SELECT 1 AS id, 'old' AS status) AS __bruin_source;\n" +
+				"COMMIT;",
+		},
+		{
+			name: "cluster_by is not supported",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:      pipeline.MaterializationTypeTable,
+					ClusterBy: []string{"id"},
+				},
+			},
+			query:   "SELECT 1",
+			wantErr: true,
+		},
+		{
+			name: "append",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			query: "SELECT 1",
+			want:  "INSERT INTO my_asset SELECT 1",
+		},
+		{
+			name: "delete+insert requires an incremental key",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDeleteInsert,
+				},
+			},
+			query:   "SELECT 1",
+			wantErr: true,
+		},
+		{
+			name: "merge with update columns",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+					{Name: "name", UpdateOnMerge: true},
+					{Name: "created_at"},
+				},
+			},
+			query: "SELECT id, name, created_at FROM source",
+			want: "INSERT INTO my_asset (id, name, created_at)\n" +
+				"SELECT id, name, created_at FROM (SELECT id, name, created_at FROM source) AS source WHERE true\n" +
+				"ON CONFLICT (id) DO UPDATE SET name = excluded.name;",
+		},
+		{
+			name: "merge without update columns ignores the existing rows",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", PrimaryKey: true},
+					{Name: "name"},
+				},
+			},
+			query: "SELECT id, name FROM source",
+			want: "INSERT INTO my_asset (id, name)\n" +
+				"SELECT id, name FROM (SELECT id, name FROM source) AS source WHERE true\n" +
+				"ON CONFLICT (id) DO NOTHING;",
+		},
+		{
+			name: "merge requires a primary key",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{{Name: "id"}},
+			},
+			query:   "SELECT id FROM source",
+			wantErr: true,
+		},
+		{
+			name: "time_interval with timestamp granularity",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyTimeInterval,
+					TimeGranularity: pipeline.MaterializationTimeGranularityTimestamp,
+					IncrementalKey:  "ts",
+				},
+			},
+			query: "SELECT ts FROM source;",
+			want: "BEGIN TRANSACTION;\n" +
+				"DELETE FROM my_asset WHERE ts BETWEEN '{{start_timestamp}}' AND '{{end_timestamp}}';\n" +
+				"INSERT INTO my_asset SELECT ts FROM source;\n" +
+				"COMMIT;",
+		},
+		{
+			name: "ddl",
+			task: &pipeline.Asset{
+				Name: "my_asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INTEGER", PrimaryKey: true},
+					{Name: "amount", Type: "REAL", CheckConstraint: "amount > 0"},
+					{Name: "user_id", Type: "INTEGER", ForeignKey: &pipeline.ColumnForeignKey{Table: "users", Column: "id"}},
+				},
+			},
+			want: "CREATE TABLE IF NOT EXISTS my_asset (\n" +
+				"id INTEGER,\n" +
+				"amount REAL CHECK (amount > 0),\n" +
+				"user_id INTEGER,\n" +
+				"PRIMARY KEY (id),\n" +
+				"CONSTRAINT fk_my_asset_user_id FOREIGN KEY (user_id) REFERENCES users (id)\n" +
+				")",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewMaterializer(tt.fullRefresh)
+			render, err := m.Render(tt.task, tt.query)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, render)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticConnectionFetcher struct {
+	client *Client
+}
+
+func (s *staticConnectionFetcher) GetSQLiteConnection(name string) (DB, error) {
+	return s.client, nil
+}
+
+func (s *staticConnectionFetcher) GetConnection(name string) (interface{}, error) {
+	return s.client, nil
+}
+
+func TestBasicOperator_RunTask_Materializations(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	fetcher := &staticConnectionFetcher{client: client}
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 2, 23, 59, 59, 0, time.UTC)
+	extractor := &query.WholeFileExtractor{
+		Renderer: jinja.NewRendererWithStartEndDates(&start, &end, "test-pipeline", "test-run", nil),
+	}
+
+	err := client.RunQueryWithoutResult(ctx, &query.Query{Query: `
+		CREATE TABLE source (id INTEGER, dt TEXT, value TEXT);
+		INSERT INTO source VALUES (1, '2024-01-01', 'a'), (2, '2024-01-02', 'b'), (3, '2024-01-03', 'c');`,
+	})
+	require.NoError(t, err)
+
+	p := &pipeline.Pipeline{DefaultConnections: map[string]string{"sqlite": "test"}}
+	op := NewBasicOperator(fetcher, extractor, NewMaterializer(false))
+	run := func(asset *pipeline.Asset, sql string) {
+		asset.Type = pipeline.AssetTypeSQLiteQuery
+		asset.ExecutableFile.Content = sql
+		require.NoError(t, op.RunTask(ctx, p, asset))
+	}
+	selectAll := func(table string) [][]interface{} {
+		rows, err := client.Select(ctx, &query.Query{Query: "SELECT id, dt, value FROM " + table + " ORDER BY id"})
+		require.NoError(t, err)
+		return rows
+	}
+
+	// create+replace builds the table from scratch
+	run(&pipeline.Asset{
+		Name:            "target",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+	}, "SELECT id, dt, value FROM source WHERE id < 3")
+	assert.Equal(t, [][]interface{}{{int64(1), "2024-01-01", "a"}, {int64(2), "2024-01-02", "b"}}, selectAll("target"))
+
+	// delete+insert replaces the rows with the same incremental key
+	run(&pipeline.Asset{
+		Name: "target",
+		Materialization: pipeline.Materialization{
+			Type:           pipeline.MaterializationTypeTable,
+			Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+			IncrementalKey: "dt",
+		},
+	}, "SELECT id, dt, 'updated' AS value FROM source WHERE id >= 2;")
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "2024-01-01", "a"},
+		{int64(2), "2024-01-02", "updated"},
+		{int64(3), "2024-01-03", "updated"},
+	}, selectAll("target"))
+
+	// time_interval only touches the rows within the run interval
+	run(&pipeline.Asset{
+		Name: "target",
+		Materialization: pipeline.Materialization{
+			Type:            pipeline.MaterializationTypeTable,
+			Strategy:        pipeline.MaterializationStrategyTimeInterval,
+			IncrementalKey:  "dt",
+			TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+		},
+	}, "SELECT id, dt, 'interval' AS value FROM source WHERE dt BETWEEN '{{ start_date }}' AND '{{ end_date }}'")
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "2024-01-01", "a"},
+		{int64(2), "2024-01-02", "interval"},
+		{int64(3), "2024-01-03", "updated"},
+	}, selectAll("target"))
+
+	// merge requires a primary key on the target table, which is created through the column definitions
+	mergeAsset := &pipeline.Asset{
+		Name: "merged",
+		Materialization: pipeline.Materialization{
+			Type:     pipeline.MaterializationTypeTable,
+			Strategy: pipeline.MaterializationStrategyDDL,
+		},
+		Columns: []pipeline.Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "dt", Type: "TEXT"},
+			{Name: "value", Type: "TEXT", UpdateOnMerge: true},
+		},
+	}
+	run(mergeAsset, "")
+	mergeAsset.Materialization.Strategy = pipeline.MaterializationStrategyMerge
+	run(mergeAsset, "SELECT id, dt, value FROM source WHERE id < 3")
+	run(mergeAsset, "SELECT id, 'ignored' AS dt, 'merged' AS value FROM source WHERE id > 1")
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "2024-01-01", "a"},
+		{int64(2), "2024-01-02", "merged"},
+		{int64(3), "ignored", "merged"},
+	}, selectAll("merged"))
+
+	// create+replace keeps the primary key, which the merge runs afterwards depend on
+	createdAsset := &pipeline.Asset{
+		Name:            "created",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		Columns: []pipeline.Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "dt", Type: "TEXT"},
+			{Name: "value", Type: "TEXT", UpdateOnMerge: true},
+		},
+	}
+	run(createdAsset, "SELECT id, dt, value FROM source WHERE id < 3")
+	createdAsset.Materialization.Strategy = pipeline.MaterializationStrategyMerge
+	run(createdAsset, "SELECT id, dt, 'merged' AS value FROM source WHERE id > 1")
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "2024-01-01", "a"},
+		{int64(2), "2024-01-02", "merged"},
+		{int64(3), "2024-01-03", "merged"},
+	}, selectAll("created"))
+
+	// views are replaced on every run
+	run(&pipeline.Asset{
+		Name:            "target_view",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+	}, "SELECT id, dt, value FROM target WHERE id = 1")
+	run(&pipeline.Asset{
+		Name:            "target_view",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+	}, "SELECT id, dt, value FROM target WHERE id = 3")
+	assert.Equal(t, [][]interface{}{{int64(3), "2024-01-03", "updated"}}, selectAll("target_view"))
+}
+
+func TestBasicOperator_RunTask_MicrobatchFullRefresh(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	fetcher := &staticConnectionFetcher{client: client}
+
+	err := client.RunQueryWithoutResult(ctx, &query.Query{Query: `
+		CREATE TABLE source (id INTEGER, dt TEXT, value TEXT);
+		INSERT INTO source VALUES (1, '2024-01-01', 'a'), (2, '2024-01-02', 'b'), (3, '2024-01-03', 'c');`,
+	})
+	require.NoError(t, err)
+
+	instance := &scheduler.AssetInstance{
+		Pipeline: &pipeline.Pipeline{DefaultConnections: map[string]string{"sqlite": "test"}},
+		Asset: &pipeline.Asset{
+			Name: "target",
+			Type: pipeline.AssetTypeSQLiteQuery,
+			ExecutableFile: pipeline.ExecutableFile{
+				Content: "SELECT id, dt, value FROM source WHERE dt BETWEEN '{{ start_date }}' AND '{{ end_date }}'",
+			},
+			Materialization: pipeline.Materialization{
+				Type:            pipeline.MaterializationTypeTable,
+				Strategy:        pipeline.MaterializationStrategyMicrobatch,
+				IncrementalKey:  "dt",
+				TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+				BatchSize:       pipeline.MaterializationBatchSizeDay,
+			},
+		},
+	}
+	extractor := &query.WholeFileExtractor{Renderer: jinja.NewRenderer(jinja.Context{})}
+	selectAll := func() [][]interface{} {
+		rows, err := client.Select(ctx, &query.Query{Query: "SELECT id, dt, value FROM target ORDER BY id"})
+		require.NoError(t, err)
+		return rows
+	}
+
+	runCtx := context.WithValue(ctx, pipeline.RunConfigPipelineName, "test-pipeline")
+	runCtx = context.WithValue(runCtx, pipeline.RunConfigRunID, "test-run")
+	runCtx = context.WithValue(runCtx, pipeline.RunConfigStartDate, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	runCtx = context.WithValue(runCtx, pipeline.RunConfigEndDate, time.Date(2024, 1, 3, 23, 59, 59, 0, time.UTC))
+
+	// the full refresh recreates the table with the rows of every batch
+	fullRefresh := executor.NewMicrobatchOperator(NewBasicOperator(fetcher, extractor, NewMaterializer(true)))
+	require.NoError(t, fullRefresh.Run(context.WithValue(runCtx, pipeline.RunConfigFullRefresh, true), instance))
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "2024-01-01", "a"},
+		{int64(2), "2024-01-02", "b"},
+		{int64(3), "2024-01-03", "c"},
+	}, selectAll())
+
+	// the regular runs replace the batches one by one and keep the rest of the table
+	err = client.RunQueryWithoutResult(ctx, &query.Query{Query: "UPDATE source SET value = 'updated' WHERE id > 1"})
+	require.NoError(t, err)
+
+	runCtx = context.WithValue(runCtx, pipeline.RunConfigStartDate, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	incremental := executor.NewMicrobatchOperator(NewBasicOperator(fetcher, extractor, NewMaterializer(false)))
+	require.NoError(t, incremental.Run(runCtx, instance))
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "2024-01-01", "a"},
+		{int64(2), "2024-01-02", "updated"},
+		{int64(3), "2024-01-03", "updated"},
+	}, selectAll())
+}
+
+func TestColumnCheckOperator(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	err := client.RunQueryWithoutResult(ctx, &query.Query{Query: `
+		CREATE TABLE users (id INTEGER, name TEXT);
+		INSERT INTO users VALUES (1, 'alice'), (2, 'bob'), (2, NULL);`,
+	})
+	require.NoError(t, err)
+
+	checker := NewColumnCheckOperator(&staticConnectionFetcher{client: client})
+	pattern := "^[a-z]+$"
+	invalidPattern := "^a"
+	acceptedValues := []string{"alice", "bob"}
+	acceptedIDs := []int{1}
+
+	tests := []struct {
+		name    string
+		column  string
+		check   pipeline.ColumnCheck
+		wantErr string
+	}{
+		{
+			name:    "not_null fails for null values",
+			column:  "name",
+			check:   pipeline.ColumnCheck{Name: "not_null"},
+			wantErr: "column 'name' has 1 null values",
+		},
+		{
+			name:   "positive passes",
+			column: "id",
+			check:  pipeline.ColumnCheck{Name: "positive"},
+		},
+		{
+			name:    "unique fails for duplicates",
+			column:  "id",
+			check:   pipeline.ColumnCheck{Name: "unique"},
+			wantErr: "column 'id' has 1 non-unique values",
+		},
+		{
+			name:   "accepted_values passes",
+			column: "name",
+			check:  pipeline.ColumnCheck{Name: "accepted_values", Value: pipeline.ColumnCheckValue{StringArray: &acceptedValues}},
+		},
+		{
+			name:    "accepted_values with integers fails",
+			column:  "id",
+			check:   pipeline.ColumnCheck{Name: "accepted_values", Value: pipeline.ColumnCheckValue{IntArray: &acceptedIDs}},
+			wantErr: "column 'id' has 2 rows that are not in the accepted values",
+		},
+		{
+			name:   "pattern passes",
+			column: "name",
+			check:  pipeline.ColumnCheck{Name: "pattern", Value: pipeline.ColumnCheckValue{String: &pattern}},
+		},
+		{
+			name:    "pattern fails",
+			column:  "name",
+			check:   pipeline.ColumnCheck{Name: "pattern", Value: pipeline.ColumnCheckValue{String: &invalidPattern}},
+			wantErr: "column name has 1 values that don't satisfy the pattern ^a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ti := &scheduler.ColumnCheckInstance{
+				AssetInstance: &scheduler.AssetInstance{
+					Asset: &pipeline.Asset{Name: "users", Type: pipeline.AssetTypeSQLiteQuery},
+					Pipeline: &pipeline.Pipeline{
+						DefaultConnections: map[string]string{"sqlite": "test"},
+					},
+				},
+				Column: &pipeline.Column{Name: tt.column},
+				Check:  &tt.check,
+			}
+
+			err := checker.Run(ctx, ti)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
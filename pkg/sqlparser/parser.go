@@ -230,6 +230,95 @@ func (s *SQLParser) RenameTables(sql string, dialect string, tableMapping map[st
 	return resp.Query, nil
 }
 
+// FormatOptions configures how the queries are formatted, the zero values fall back to the formatter defaults.
+type FormatOptions struct {
+	KeywordCase string `json:"keyword_case,omitempty"`
+	Indent      int    `json:"indent,omitempty"`
+	LineWidth   int    `json:"line_width,omitempty"`
+}
+
+// FormatSQL pretty-prints the given query in the given dialect. The Jinja expressions are kept as they are, as well
+// as the Jinja statements at the beginning and at the end of the query.
+func (s *SQLParser) FormatSQL(sql, dialect string, options FormatOptions) (string, error) {
+	err := s.Start()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to start sql parser")
+	}
+
+	command := parserCommand{
+		Command: "format-query",
+		Contents: map[string]interface{}{
+			"query":   sql,
+			"dialect": dialect,
+			"options": options,
+		},
+	}
+
+	responsePayload, err := s.sendCommand(&command)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to send command")
+	}
+
+	var resp struct {
+		Query string `json:"query"`
+		Error string `json:"error"`
+	}
+	err = json.Unmarshal([]byte(responsePayload), &resp)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal response")
+	}
+
+	if resp.Error != "" {
+		return "", errors.New(resp.Error)
+	}
+
+	return resp.Query, nil
+}
+
+// QueryAnalysis contains the common anti-patterns found in a query, along with the tables it refers to.
+type QueryAnalysis struct {
+	SelectStar                bool     `json:"select_star"`
+	CartesianJoins            []string `json:"cartesian_joins"`
+	NondeterministicFunctions []string `json:"nondeterministic_functions"`
+	OrderByWithoutLimit       bool     `json:"order_by_without_limit"`
+	Tables                    []string `json:"tables"`
+}
+
+func (s *SQLParser) AnalyzeQuery(sql, dialect string) (*QueryAnalysis, error) {
+	err := s.Start()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start sql parser")
+	}
+
+	command := parserCommand{
+		Command: "analyze-query",
+		Contents: map[string]interface{}{
+			"query":   sql,
+			"dialect": dialect,
+		},
+	}
+
+	responsePayload, err := s.sendCommand(&command)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send command")
+	}
+
+	var resp struct {
+		QueryAnalysis
+		Error string `json:"error"`
+	}
+	err = json.Unmarshal([]byte(responsePayload), &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response")
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	return &resp.QueryAnalysis, nil
+}
+
 func (s *SQLParser) sendCommand(pc *parserCommand) (string, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -301,6 +390,12 @@ var assetTypeDialectMap = map[pipeline.AssetType]string{
 	pipeline.AssetTypeMsSQLQuery:      "tsql",
 	pipeline.AssetTypeSynapseQuery:    "tsql",
 	pipeline.AssetTypeDuckDBQuery:     "duckdb",
+	pipeline.AssetTypeMySQLQuery:      "mysql",
+	pipeline.AssetTypeOracleQuery:     "oracle",
+	pipeline.AssetTypeSQLiteQuery:     "sqlite",
+	// sqlglot has no dedicated Spanner dialect, GoogleSQL is the closest match
+	pipeline.AssetTypeSpannerQuery: "bigquery",
+	pipeline.AssetTypeTrinoQuery:   "trino",
 }
 
 func AssetTypeToDialect(assetType pipeline.AssetType) (string, error) {
@@ -833,6 +833,91 @@ func TestSqlParser_RenameTables(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSqlParser_FormatSQL(t *testing.T) {
+	s, err := NewSQLParser(true)
+	require.NoError(t, err)
+
+	err = s.Start()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		query   string
+		options FormatOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "simple select is formatted with the defaults",
+			query: "select id, name from raw.items where id > 1",
+			want:  "SELECT\n  id,\n  name\nFROM raw.items\nWHERE\n  id > 1\n",
+		},
+		{
+			name:    "keywords are lowercased and the indentation is respected",
+			query:   "SELECT id FROM raw.items",
+			options: FormatOptions{KeywordCase: "lower", Indent: 4},
+			want:    "select\n    id\nfrom raw.items\n",
+		},
+		{
+			name:  "jinja expressions and leading statements are preserved",
+			query: "{% set limit = 10 %}\nselect id from {{ this }} where dt = '{{ start_date }}' -- the day\n",
+			want:  "{% set limit = 10 %}\nSELECT\n  id\nFROM {{ this }}\nWHERE\n  dt = '{{ start_date }}' -- the day\n",
+		},
+		{
+			name:    "jinja statements in the middle of the query are not supported",
+			query:   "select id from items {% if is_dev %} limit 10 {% endif %}",
+			wantErr: true,
+		},
+		{
+			name:    "invalid keyword case",
+			query:   "select 1",
+			options: FormatOptions{KeywordCase: "title"},
+			wantErr: true,
+		},
+	}
+
+	t.Run("blocking group", func(t *testing.T) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				got, err := s.FormatSQL(tt.query, "bigquery", tt.options)
+				if tt.wantErr {
+					require.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			})
+		}
+	})
+
+	s.Close()
+}
+
+func TestSqlParser_AnalyzeQuery(t *testing.T) {
+	s, err := NewSQLParser(true)
+	require.NoError(t, err)
+
+	err = s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	got, err := s.AnalyzeQuery("select *, current_timestamp() as loaded_at from raw.items i, raw.orders o order by 1", "bigquery")
+	require.NoError(t, err)
+	require.Equal(t, &QueryAnalysis{
+		SelectStar:                true,
+		CartesianJoins:            []string{"raw.orders"},
+		NondeterministicFunctions: []string{"current_timestamp"},
+		OrderByWithoutLimit:       true,
+		Tables:                    []string{"raw.items", "raw.orders"},
+	}, got)
+
+	_, err = s.AnalyzeQuery("select from where", "bigquery")
+	require.Error(t, err)
+}
+
 func TestSqlParser_AddLimit(t *testing.T) { //nolint
 	tests := []struct {
 		name     string
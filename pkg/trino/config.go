@@ -0,0 +1,93 @@
+package trino
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/trinodb/trino-go-client/trino"
+)
+
+const (
+	TableFormatIceberg = "iceberg"
+	TableFormatHive    = "hive"
+
+	defaultPort = 8080
+)
+
+type Config struct {
+	Username    string
+	Password    string
+	Host        string
+	Port        int
+	Catalog     string
+	Schema      string
+	TableFormat string
+}
+
+func (c *Config) port() int {
+	if c.Port == 0 {
+		return defaultPort
+	}
+
+	return c.Port
+}
+
+// serverURI builds the coordinator address, the driver only sends passwords over HTTPS, therefore password
+// authentication implies a secure connection.
+func (c *Config) serverURI() string {
+	u := url.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("%s:%d", c.Host, c.port()),
+		User:   url.User(c.Username),
+	}
+
+	if c.Password != "" {
+		u.Scheme = "https"
+		u.User = url.UserPassword(c.Username, c.Password)
+	}
+
+	return u.String()
+}
+
+func (c *Config) ToDBConnectionURI() (string, error) {
+	conf := trino.Config{
+		ServerURI: c.serverURI(),
+		Source:    "bruin",
+		Catalog:   c.Catalog,
+		Schema:    c.Schema,
+	}
+
+	return conf.FormatDSN()
+}
+
+// GetIngestrURI builds the SQLAlchemy style URI of the connection, the HTTPS scheme is given explicitly with
+// password authentication, the same way as for the connections of the driver.
+func (c *Config) GetIngestrURI() string {
+	u := url.URL{
+		Scheme: "trino",
+		Host:   fmt.Sprintf("%s:%d", c.Host, c.port()),
+		User:   url.User(c.Username),
+		Path:   c.Catalog,
+	}
+
+	if c.Password != "" {
+		u.User = url.UserPassword(c.Username, c.Password)
+		u.RawQuery = url.Values{"http_scheme": []string{"https"}}.Encode()
+	}
+
+	if c.Schema != "" {
+		u.Path = c.Catalog + "/" + c.Schema
+	}
+
+	return u.String()
+}
+
+// GetTableFormat returns the table format of the catalog the connection points to, which decides the statements
+// the materializations can use.
+func (c *Config) GetTableFormat() string {
+	if c.TableFormat == "" {
+		return TableFormatIceberg
+	}
+
+	return c.TableFormat
+}
@@ -0,0 +1,393 @@
+package trino
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/diff"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	_ "github.com/trinodb/trino-go-client/trino" // registers the trino driver
+)
+
+type DB struct {
+	conn       *sqlx.DB
+	config     *Config
+	mutex      sync.Mutex
+	typeMapper *diff.DatabaseTypeMapper
+}
+
+func NewDB(c *Config) *DB {
+	return &DB{
+		config:     c,
+		mutex:      sync.Mutex{},
+		typeMapper: diff.NewTrinoTypeMapper(),
+	}
+}
+
+func (db *DB) GetIngestrURI() (string, error) {
+	return db.config.GetIngestrURI(), nil
+}
+
+func (db *DB) GetTableFormat() string {
+	return db.config.GetTableFormat()
+}
+
+// prepareQuery removes the trailing semicolon, since Trino rejects statements that end with one.
+func prepareQuery(q *query.Query) string {
+	return strings.TrimSuffix(strings.TrimSpace(q.String()), ";")
+}
+
+// RunQueryWithoutResult consumes all the rows of the result, since Trino only finishes executing a statement once the
+// client has fetched all of its results.
+func (db *DB) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
+	_, err := db.Select(ctx, query)
+	return err
+}
+
+func (db *DB) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
+	err := db.initializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.QueryContext(ctx, prepareQuery(query))
+	if err != nil {
+		return nil, formatError(err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result [][]interface{}
+	for rows.Next() {
+		columns := make([]interface{}, len(cols))
+		columnPointers := make([]interface{}, len(cols))
+		for i := range columns {
+			columnPointers[i] = &columns[i]
+		}
+
+		if err := rows.Scan(columnPointers...); err != nil {
+			return nil, err
+		}
+
+		result = append(result, columns)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, formatError(err)
+	}
+
+	return result, nil
+}
+
+func (db *DB) SelectWithSchema(ctx context.Context, queryObject *query.Query) (*query.QueryResult, error) {
+	err := db.initializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.QueryContext(ctx, prepareQuery(queryObject))
+	if err != nil {
+		return nil, formatError(err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve column names: %w", err)
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve column types: %w", err)
+	}
+
+	result := &query.QueryResult{
+		Columns:     columns,
+		Rows:        [][]interface{}{},
+		ColumnTypes: make([]string, len(columnTypes)),
+	}
+	for i, ct := range columnTypes {
+		result.ColumnTypes[i] = ct.DatabaseTypeName()
+	}
+
+	for rows.Next() {
+		columnValues := make([]interface{}, len(columns))
+		columnPointers := make([]interface{}, len(columns))
+		for i := range columnValues {
+			columnPointers[i] = &columnValues[i]
+		}
+
+		if err := rows.Scan(columnPointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		result.Rows = append(result.Rows, columnValues)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, formatError(err)
+	}
+
+	return result, nil
+}
+
+func (db *DB) Ping(ctx context.Context) error {
+	err := db.RunQueryWithoutResult(ctx, &query.Query{Query: "SELECT 1"})
+	if err != nil {
+		return errors.Wrap(err, "failed to run test query on Trino connection")
+	}
+
+	return nil
+}
+
+func (db *DB) GetTableSummary(ctx context.Context, tableName string) (*diff.TableSummaryResult, error) {
+	err := db.initializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	var rowCount int64
+	if err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+tableName).Scan(&rowCount); err != nil {
+		return nil, fmt.Errorf("failed to execute count query for table '%s': %w", tableName, formatError(err))
+	}
+
+	table, err := db.GetTableSchema(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, col := range table.Columns {
+		switch col.NormalizedType {
+		case diff.CommonTypeNumeric:
+			col.Stats, err = db.fetchNumericalStats(ctx, tableName, col.Name)
+		case diff.CommonTypeString:
+			col.Stats, err = db.fetchStringStats(ctx, tableName, col.Name)
+		case diff.CommonTypeBoolean:
+			col.Stats, err = db.fetchBooleanStats(ctx, tableName, col.Name)
+		case diff.CommonTypeDateTime:
+			col.Stats, err = db.fetchDateTimeStats(ctx, tableName, col.Name)
+		case diff.CommonTypeJSON:
+			col.Stats, err = db.fetchJSONStats(ctx, tableName, col.Name)
+		case diff.CommonTypeBinary, diff.CommonTypeUnknown:
+			col.Stats = &diff.UnknownStatistics{}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &diff.TableSummaryResult{
+		RowCount: rowCount,
+		Table:    table,
+	}, nil
+}
+
+func (db *DB) GetTableSchema(ctx context.Context, tableName string) (*diff.Table, error) {
+	err := db.initializeDB()
+	if err != nil {
+		return nil, err
+	}
+
+	// DESCRIBE returns the column name, type, extra information and the comment for each column
+	schemaRows, err := db.conn.QueryContext(ctx, "DESCRIBE "+tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe table '%s': %w", tableName, formatError(err))
+	}
+	defer schemaRows.Close()
+
+	columns := make([]*diff.Column, 0)
+	for schemaRows.Next() {
+		var name, colType, extra, comment *string
+		if err := schemaRows.Scan(&name, &colType, &extra, &comment); err != nil {
+			return nil, fmt.Errorf("failed to scan the description of table '%s': %w", tableName, err)
+		}
+		if name == nil || colType == nil {
+			continue
+		}
+
+		// Trino does not enforce primary keys or uniqueness, and the connectors do not expose nullability consistently
+		columns = append(columns, &diff.Column{
+			Name:           *name,
+			Type:           *colType,
+			NormalizedType: db.typeMapper.MapType(*colType),
+			Nullable:       true,
+		})
+	}
+	if err := schemaRows.Err(); err != nil {
+		return nil, fmt.Errorf("error after iterating the description of table '%s': %w", tableName, formatError(err))
+	}
+
+	return &diff.Table{
+		Name:    tableName,
+		Columns: columns,
+	}, nil
+}
+
+func (db *DB) fetchNumericalStats(ctx context.Context, tableName, columnName string) (*diff.NumericalStatistics, error) {
+	stats := &diff.NumericalStatistics{}
+	query := fmt.Sprintf(`
+        SELECT
+            CAST(MIN(%s) AS DOUBLE) as min_val,
+            CAST(MAX(%s) AS DOUBLE) as max_val,
+            CAST(AVG(%s) AS DOUBLE) as avg_val,
+            CAST(SUM(%s) AS DOUBLE) as sum_val,
+            COUNT(%s) as count_val,
+            COUNT(*) - COUNT(%s) as null_count,
+            CAST(STDDEV(%s) AS DOUBLE) as stddev_val
+        FROM %s
+    `, columnName, columnName, columnName, columnName, columnName, columnName, columnName, tableName)
+
+	err := db.conn.QueryRowContext(ctx, query).Scan(
+		&stats.Min,
+		&stats.Max,
+		&stats.Avg,
+		&stats.Sum,
+		&stats.Count,
+		&stats.NullCount,
+		&stats.StdDev,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch numerical stats for column '%s': %w", columnName, formatError(err))
+	}
+
+	return stats, nil
+}
+
+func (db *DB) fetchStringStats(ctx context.Context, tableName, columnName string) (*diff.StringStatistics, error) {
+	stats := &diff.StringStatistics{}
+	query := fmt.Sprintf(`
+        SELECT
+            COALESCE(MIN(LENGTH(%s)), 0) as min_len,
+            COALESCE(MAX(LENGTH(%s)), 0) as max_len,
+            COALESCE(AVG(LENGTH(%s)), 0) as avg_len,
+            COUNT(DISTINCT %s) as distinct_count,
+            COUNT(*) as total_count,
+            COUNT(*) - COUNT(%s) as null_count,
+            COUNT(CASE WHEN CAST(%s AS VARCHAR) = '' THEN 1 END) as empty_count
+        FROM %s
+    `, columnName, columnName, columnName, columnName, columnName, columnName, tableName)
+
+	err := db.conn.QueryRowContext(ctx, query).Scan(
+		&stats.MinLength,
+		&stats.MaxLength,
+		&stats.AvgLength,
+		&stats.DistinctCount,
+		&stats.Count,
+		&stats.NullCount,
+		&stats.EmptyCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch string stats for column '%s': %w", columnName, formatError(err))
+	}
+
+	return stats, nil
+}
+
+func (db *DB) fetchBooleanStats(ctx context.Context, tableName, columnName string) (*diff.BooleanStatistics, error) {
+	stats := &diff.BooleanStatistics{}
+	query := fmt.Sprintf(`
+        SELECT
+            COUNT(CASE WHEN %s = true THEN 1 END) as true_count,
+            COUNT(CASE WHEN %s = false THEN 1 END) as false_count,
+            COUNT(*) as total_count,
+            COUNT(*) - COUNT(%s) as null_count
+        FROM %s
+    `, columnName, columnName, columnName, tableName)
+
+	err := db.conn.QueryRowContext(ctx, query).Scan(
+		&stats.TrueCount,
+		&stats.FalseCount,
+		&stats.Count,
+		&stats.NullCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch boolean stats for column '%s': %w", columnName, formatError(err))
+	}
+
+	return stats, nil
+}
+
+func (db *DB) fetchDateTimeStats(ctx context.Context, tableName, columnName string) (*diff.DateTimeStatistics, error) {
+	stats := &diff.DateTimeStatistics{}
+	query := fmt.Sprintf(`
+        SELECT
+            CAST(MIN(%s) AS VARCHAR) as min_date,
+            CAST(MAX(%s) AS VARCHAR) as max_date,
+            COUNT(DISTINCT %s) as unique_count,
+            COUNT(*) as count_val,
+            COUNT(*) - COUNT(%s) as null_count
+        FROM %s
+    `, columnName, columnName, columnName, columnName, tableName)
+
+	err := db.conn.QueryRowContext(ctx, query).Scan(
+		&stats.EarliestDate,
+		&stats.LatestDate,
+		&stats.UniqueCount,
+		&stats.Count,
+		&stats.NullCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch datetime stats for column '%s': %w", columnName, formatError(err))
+	}
+
+	return stats, nil
+}
+
+func (db *DB) fetchJSONStats(ctx context.Context, tableName, columnName string) (*diff.JSONStatistics, error) {
+	stats := &diff.JSONStatistics{}
+	query := fmt.Sprintf(`
+        SELECT
+            COUNT(*) as count_val,
+            COUNT(*) - COUNT(%s) as null_count
+        FROM %s
+    `, columnName, tableName)
+
+	err := db.conn.QueryRowContext(ctx, query).Scan(
+		&stats.Count,
+		&stats.NullCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JSON stats for column '%s': %w", columnName, formatError(err))
+	}
+
+	return stats, nil
+}
+
+func (db *DB) initializeDB() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.conn != nil {
+		return nil
+	}
+
+	dsn, err := db.config.ToDBConnectionURI()
+	if err != nil {
+		return errors.Wrap(err, "failed to create DSN for Trino")
+	}
+
+	conn, err := sqlx.Open("trino", dsn)
+	if err != nil {
+		return errors.Errorf("failed to open database connection: %v", err)
+	}
+
+	db.conn = conn
+	return nil
+}
+
+// formatError flattens the multi-line errors returned by the coordinator so that they are readable in the logs.
+func formatError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.New(strings.ReplaceAll(err.Error(), "\n", "  -  "))
+}
@@ -0,0 +1,121 @@
+package trino
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/bruin-data/bruin/pkg/diff"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockDB(t *testing.T) (*DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mockDB.Close() })
+
+	db := NewDB(&Config{})
+	db.conn = sqlx.NewDb(mockDB, "sqlmock")
+
+	return db, mock
+}
+
+func TestDB_Select(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		mockConnection func(mock sqlmock.Sqlmock)
+		query          query.Query
+		want           [][]interface{}
+		wantErr        string
+	}{
+		{
+			name: "the trailing semicolon is removed",
+			mockConnection: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT 1, 2").
+					WillReturnRows(sqlmock.NewRows([]string{"one", "two"}).AddRow(1, 2))
+			},
+			query: query.Query{Query: "SELECT 1, 2;"},
+			want:  [][]interface{}{{int64(1), int64(2)}},
+		},
+		{
+			name: "multi-line errors are flattened",
+			mockConnection: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT broken").
+					WillReturnError(errors.New("line 1:8: Column 'broken' cannot be resolved\nquery failed"))
+			},
+			query:   query.Query{Query: "SELECT broken"},
+			wantErr: "line 1:8: Column 'broken' cannot be resolved  -  query failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock := newMockDB(t)
+			tt.mockConnection(mock)
+
+			got, err := db.Select(context.Background(), &tt.query)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDB_GetTableSummary(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+
+	mock.ExpectQuery("SELECT COUNT(*) FROM lakehouse.analytics.users").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+	mock.ExpectQuery("DESCRIBE lakehouse.analytics.users").
+		WillReturnRows(sqlmock.NewRows([]string{"Column", "Type", "Extra", "Comment"}).
+			AddRow("id", "bigint", "", "").
+			AddRow("tags", "array(varchar)", "", nil))
+	mock.ExpectQuery(`
+        SELECT
+            CAST(MIN(id) AS DOUBLE) as min_val,
+            CAST(MAX(id) AS DOUBLE) as max_val,
+            CAST(AVG(id) AS DOUBLE) as avg_val,
+            CAST(SUM(id) AS DOUBLE) as sum_val,
+            COUNT(id) as count_val,
+            COUNT(*) - COUNT(id) as null_count,
+            CAST(STDDEV(id) AS DOUBLE) as stddev_val
+        FROM lakehouse.analytics.users
+    `).WillReturnRows(sqlmock.NewRows([]string{"min_val", "max_val", "avg_val", "sum_val", "count_val", "null_count", "stddev_val"}).
+		AddRow(1.0, 2.0, 1.5, 3.0, int64(2), int64(0), 0.7))
+
+	got, err := db.GetTableSummary(context.Background(), "lakehouse.analytics.users")
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	assert.Equal(t, int64(2), got.RowCount)
+	require.Len(t, got.Table.Columns, 2)
+
+	id := got.Table.Columns[0]
+	assert.Equal(t, "id", id.Name)
+	assert.Equal(t, diff.CommonTypeNumeric, id.NormalizedType)
+	stats, ok := id.Stats.(*diff.NumericalStatistics)
+	require.True(t, ok)
+	assert.InDelta(t, 3.0, *stats.Sum, 0.001)
+	assert.Equal(t, int64(2), stats.Count)
+
+	tags := got.Table.Columns[1]
+	assert.Equal(t, diff.CommonTypeUnknown, tags.NormalizedType)
+	assert.IsType(t, &diff.UnknownStatistics{}, tags.Stats)
+}
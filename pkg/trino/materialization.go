@@ -0,0 +1,261 @@
+package trino
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+type (
+	MaterializerFunc        func(asset *pipeline.Asset, query, tableFormat string) ([]string, error)
+	AssetMaterializationMap map[pipeline.MaterializationType]map[pipeline.MaterializationStrategy]MaterializerFunc
+)
+
+var matMap = AssetMaterializationMap{
+	pipeline.MaterializationTypeView: {
+		pipeline.MaterializationStrategyNone:          viewMaterializer,
+		pipeline.MaterializationStrategyAppend:        errorMaterializer,
+		pipeline.MaterializationStrategyCreateReplace: errorMaterializer,
+		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
+	},
+	pipeline.MaterializationTypeTable: {
+		pipeline.MaterializationStrategyNone:          buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:        buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace: buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:  buildIncrementalQuery,
+		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
+		pipeline.MaterializationStrategyTimeInterval:  buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyMicrobatch:    buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyDDL:           buildDDLQuery,
+	},
+}
+
+func errorMaterializer(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	return nil, fmt.Errorf("materialization strategy %s is not supported for materialization type %s and asset type %s", asset.Materialization.Strategy, asset.Materialization.Type, asset.Type)
+}
+
+func viewMaterializer(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	return []string{fmt.Sprintf("CREATE OR REPLACE VIEW %s AS\n%s", asset.Name, query)}, nil
+}
+
+// tableProperties builds the `WITH` clause for the partitioning and sorting of the table, Iceberg and Hive tables use
+// different properties for them.
+func tableProperties(asset *pipeline.Asset, tableFormat string) (string, error) {
+	mat := asset.Materialization
+	properties := make([]string, 0, 2)
+
+	switch tableFormat {
+	case TableFormatHive:
+		if len(mat.ClusterBy) > 0 {
+			return "", errors.New("Trino assets with the Hive table format do not support `cluster_by`")
+		}
+		if mat.PartitionBy != "" {
+			properties = append(properties, fmt.Sprintf("partitioned_by = ARRAY['%s']", mat.PartitionBy))
+		}
+	default:
+		if mat.PartitionBy != "" {
+			properties = append(properties, fmt.Sprintf("partitioning = ARRAY['%s']", mat.PartitionBy))
+		}
+		if len(mat.ClusterBy) > 0 {
+			properties = append(properties, fmt.Sprintf("sorted_by = ARRAY['%s']", strings.Join(mat.ClusterBy, "', '")))
+		}
+	}
+
+	if len(properties) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("\nWITH (%s)", strings.Join(properties, ", ")), nil
+}
+
+// buildCreateReplaceQuery replaces Iceberg tables atomically, whereas Hive tables do not support `CREATE OR REPLACE`
+// and are dropped before being recreated.
+func buildCreateReplaceQuery(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	properties, err := tableProperties(asset, tableFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	if tableFormat == TableFormatHive {
+		return []string{
+			"DROP TABLE IF EXISTS " + asset.Name,
+			fmt.Sprintf("CREATE TABLE %s%s AS\n%s", asset.Name, properties, query),
+		}, nil
+	}
+
+	return []string{fmt.Sprintf("CREATE OR REPLACE TABLE %s%s AS\n%s", asset.Name, properties, query)}, nil
+}
+
+func buildAppendQuery(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	return []string{fmt.Sprintf("INSERT INTO %s %s", asset.Name, query)}, nil
+}
+
+// requireHivePartitionKey ensures that the rows are deleted through entire partitions, since Trino can only delete
+// rows from non-transactional Hive tables if the filter matches whole partitions.
+func requireHivePartitionKey(asset *pipeline.Asset, tableFormat string) error {
+	if tableFormat != TableFormatHive {
+		return nil
+	}
+
+	if asset.Materialization.PartitionBy == "" || asset.Materialization.PartitionBy != asset.Materialization.IncrementalKey {
+		return fmt.Errorf("materialization strategy %s requires the `partition_by` field to match the `incremental_key` for Trino assets with the Hive table format", asset.Materialization.Strategy)
+	}
+
+	return nil
+}
+
+// tempTablePrefix is the prefix of the tables that hold the query results during a materialization, the last query of
+// the materialization drops them.
+const tempTablePrefix = "__bruin_tmp_"
+
+// isTempTableCleanup returns true if the query drops a temporary table, these queries still need to be run when the
+// materialization fails before them.
+func isTempTableCleanup(query string) bool {
+	return strings.HasPrefix(query, "DROP TABLE IF EXISTS "+tempTablePrefix)
+}
+
+func buildIncrementalQuery(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	mat := asset.Materialization
+	strategy := pipeline.MaterializationStrategyDeleteInsert
+
+	if mat.IncrementalKey == "" {
+		return nil, fmt.Errorf("materialization strategy %s requires the `incremental_key` field to be set", strategy)
+	}
+
+	if tableFormat == TableFormatHive {
+		// the partitions to delete would come from a subquery, which Trino cannot match to whole Hive partitions
+		return nil, fmt.Errorf("materialization strategy %s is not supported for Trino assets with the Hive table format, use `time_interval` on the partition column instead", strategy)
+	}
+
+	tempTableName := tempTablePrefix + helpers.PrefixGenerator()
+
+	return []string{
+		fmt.Sprintf("CREATE TABLE %s AS %s", tempTableName, query),
+		fmt.Sprintf("DELETE FROM %s WHERE %s IN (SELECT DISTINCT %s FROM %s)", asset.Name, mat.IncrementalKey, mat.IncrementalKey, tempTableName),
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", asset.Name, tempTableName),
+		"DROP TABLE IF EXISTS " + tempTableName,
+	}, nil
+}
+
+func buildMergeQuery(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	if tableFormat == TableFormatHive {
+		return nil, fmt.Errorf("materialization strategy %s is not supported for Trino assets with the Hive table format", asset.Materialization.Strategy)
+	}
+
+	if len(asset.Columns) == 0 {
+		return nil, fmt.Errorf("materialization strategy %s requires the `columns` field to be set", asset.Materialization.Strategy)
+	}
+
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) == 0 {
+		return nil, fmt.Errorf("materialization strategy %s requires the `primary_key` field to be set on at least one column", asset.Materialization.Strategy)
+	}
+
+	on := make([]string, 0, len(primaryKeys))
+	for _, key := range primaryKeys {
+		on = append(on, fmt.Sprintf("target.%s = source.%s", key, key))
+	}
+
+	mergeLines := []string{
+		fmt.Sprintf("MERGE INTO %s target", asset.Name),
+		fmt.Sprintf("USING (%s) source ON %s", query, strings.Join(on, " AND ")),
+	}
+
+	updateColumns := asset.ColumnNamesWithUpdateOnMerge()
+	if len(updateColumns) > 0 {
+		updateStatements := make([]string, 0, len(updateColumns))
+		for _, col := range updateColumns {
+			updateStatements = append(updateStatements, fmt.Sprintf("%s = source.%s", col, col))
+		}
+		mergeLines = append(mergeLines, "WHEN MATCHED THEN UPDATE SET "+strings.Join(updateStatements, ", "))
+	}
+
+	columnNames := asset.ColumnNames()
+	sourceColumns := make([]string, 0, len(columnNames))
+	for _, col := range columnNames {
+		sourceColumns = append(sourceColumns, "source."+col)
+	}
+	mergeLines = append(mergeLines, fmt.Sprintf("WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)", strings.Join(columnNames, ", "), strings.Join(sourceColumns, ", ")))
+
+	return []string{strings.Join(mergeLines, "\n")}, nil
+}
+
+func buildTimeIntervalQuery(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	if asset.Materialization.IncrementalKey == "" {
+		return nil, errors.New("incremental_key is required for time_interval strategy")
+	}
+
+	if asset.Materialization.TimeGranularity == "" {
+		return nil, errors.New("time_granularity is required for time_interval strategy")
+	}
+
+	if !(asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityTimestamp || asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate) {
+		return nil, errors.New("time_granularity must be either 'date', or 'timestamp'")
+	}
+
+	if err := requireHivePartitionKey(asset, tableFormat); err != nil {
+		return nil, err
+	}
+
+	timePrefix := "TIMESTAMP"
+	startVar := "{{start_timestamp}}"
+	endVar := "{{end_timestamp}}"
+	if asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate {
+		timePrefix = "DATE"
+		startVar = "{{start_date}}"
+		endVar = "{{end_date}}"
+	}
+
+	return []string{
+		fmt.Sprintf("DELETE FROM %s WHERE %s BETWEEN %s '%s' AND %s '%s'",
+			asset.Name,
+			asset.Materialization.IncrementalKey,
+			timePrefix,
+			startVar,
+			timePrefix,
+			endVar),
+		fmt.Sprintf("INSERT INTO %s %s", asset.Name, query),
+	}, nil
+}
+
+// buildDDLQuery creates the table from the column definitions, Hive tables do not support `NOT NULL` columns. The
+// lakehouse connectors do not support default values, foreign keys or check constraints either, which are rejected
+// instead of being dropped silently.
+func buildDDLQuery(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	properties, err := tableProperties(asset, tableFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	columnDefs := make([]string, 0, len(asset.Columns))
+	for _, col := range asset.Columns {
+		if col.Default != "" {
+			return nil, fmt.Errorf("Trino assets do not support default values, remove the `default` field of the column '%s'", col.Name)
+		}
+		if col.ForeignKey != nil {
+			return nil, fmt.Errorf("Trino assets do not support foreign keys, remove the `foreign_key` field of the column '%s'", col.Name)
+		}
+		if col.CheckConstraint != "" {
+			return nil, fmt.Errorf("Trino assets do not support check constraints, remove the `check_constraint` field of the column '%s'", col.Name)
+		}
+
+		def := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if !col.Nullable.Bool() && tableFormat != TableFormatHive {
+			def += " NOT NULL"
+		}
+		if col.Description != "" {
+			def += fmt.Sprintf(" COMMENT '%s'", strings.ReplaceAll(col.Description, "'", "''"))
+		}
+		columnDefs = append(columnDefs, def)
+	}
+
+	ddl := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n%s\n)", asset.Name, strings.Join(columnDefs, ",\n"))
+	if asset.Description != "" {
+		ddl += fmt.Sprintf("\nCOMMENT '%s'", strings.ReplaceAll(asset.Description, "'", "''"))
+	}
+
+	return []string{ddl + properties}, nil
+}
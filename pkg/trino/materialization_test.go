@@ -0,0 +1,350 @@
+package trino
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tempTableRegex = regexp.MustCompile(`__bruin_tmp_[a-zA-Z0-9]+`)
+
+func TestMaterializer_Render(t *testing.T) {
+	t.Parallel()
+
+	mergeColumns := []pipeline.Column{
+		{Name: "id", PrimaryKey: true},
+		{Name: "name", UpdateOnMerge: true},
+		{Name: "created_at"},
+	}
+
+	tests := []struct {
+		name        string
+		task        *pipeline.Asset
+		query       string
+		tableFormat string
+		fullRefresh bool
+		want        []string
+		wantErr     string
+	}{
+		{
+			name:  "no materialization, return raw query",
+			task:  &pipeline.Asset{},
+			query: "SELECT 1",
+			want:  []string{"SELECT 1"},
+		},
+		{
+			name: "materialize to a view",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			query: "SELECT 1;",
+			want:  []string{"CREATE OR REPLACE VIEW my.asset AS\nSELECT 1"},
+		},
+		{
+			name: "iceberg tables are replaced atomically",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					PartitionBy: "day(created_at)",
+					ClusterBy:   []string{"id", "name"},
+				},
+			},
+			query: "SELECT 1",
+			want:  []string{"CREATE OR REPLACE TABLE my.asset\nWITH (partitioning = ARRAY['day(created_at)'], sorted_by = ARRAY['id', 'name']) AS\nSELECT 1"},
+		},
+		{
+			name: "hive tables are dropped and recreated",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					PartitionBy: "dt",
+				},
+			},
+			query:       "SELECT 1 AS id, '2024-01-01' AS dt",
+			tableFormat: TableFormatHive,
+			want: []string{
+				"DROP TABLE IF EXISTS my.asset",
+				"CREATE TABLE my.asset\nWITH (partitioned_by = ARRAY['dt']) AS\nSELECT 1 AS id, '2024-01-01' AS dt",
+			},
+		},
+		{
+			name: "hive tables do not support cluster_by",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:      pipeline.MaterializationTypeTable,
+					ClusterBy: []string{"id"},
+				},
+			},
+			query:       "SELECT 1",
+			tableFormat: TableFormatHive,
+			wantErr:     "Trino assets with the Hive table format do not support `cluster_by`",
+		},
+		{
+			name: "the table_format parameter of the asset overrides the connection",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Parameters:      map[string]string{"table_format": TableFormatHive},
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			},
+			query:       "SELECT 1",
+			tableFormat: TableFormatIceberg,
+			want: []string{
+				"DROP TABLE IF EXISTS my.asset",
+				"CREATE TABLE my.asset AS\nSELECT 1",
+			},
+		},
+		{
+			name: "unknown table formats are rejected",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			},
+			query:       "SELECT 1",
+			tableFormat: "delta",
+			wantErr:     "unsupported table format 'delta', must be either 'iceberg' or 'hive'",
+		},
+		{
+			name: "append",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			query: "SELECT 1",
+			want:  []string{"INSERT INTO my.asset SELECT 1"},
+		},
+		{
+			name: "full refresh replaces the table",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			query:       "SELECT 1",
+			fullRefresh: true,
+			want:        []string{"CREATE OR REPLACE TABLE my.asset AS\nSELECT 1"},
+		},
+		{
+			name: "delete+insert on iceberg tables",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:           pipeline.MaterializationTypeTable,
+					Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+					IncrementalKey: "dt",
+				},
+			},
+			query: "SELECT 1",
+			want: []string{
+				"CREATE TABLE __bruin_tmp AS SELECT 1",
+				"DELETE FROM my.asset WHERE dt IN (SELECT DISTINCT dt FROM __bruin_tmp)",
+				"INSERT INTO my.asset SELECT * FROM __bruin_tmp",
+				"DROP TABLE IF EXISTS __bruin_tmp",
+			},
+		},
+		{
+			name: "delete+insert is not supported on hive tables",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:           pipeline.MaterializationTypeTable,
+					Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+					IncrementalKey: "dt",
+					PartitionBy:    "dt",
+				},
+			},
+			query:       "SELECT 1",
+			tableFormat: TableFormatHive,
+			wantErr:     "materialization strategy delete+insert is not supported for Trino assets with the Hive table format, use `time_interval` on the partition column instead",
+		},
+		{
+			name: "merge on iceberg tables",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: mergeColumns,
+			},
+			query: "SELECT id, name, created_at FROM source",
+			want: []string{
+				"MERGE INTO my.asset target\n" +
+					"USING (SELECT id, name, created_at FROM source) source ON target.id = source.id\n" +
+					"WHEN MATCHED THEN UPDATE SET name = source.name\n" +
+					"WHEN NOT MATCHED THEN INSERT (id, name, created_at) VALUES (source.id, source.name, source.created_at)",
+			},
+		},
+		{
+			name: "merge is not supported on hive tables",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: mergeColumns,
+			},
+			query:       "SELECT 1",
+			tableFormat: TableFormatHive,
+			wantErr:     "materialization strategy merge is not supported for Trino assets with the Hive table format",
+		},
+		{
+			name: "time_interval with timestamp granularity",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyTimeInterval,
+					IncrementalKey:  "ts",
+					TimeGranularity: pipeline.MaterializationTimeGranularityTimestamp,
+				},
+			},
+			query: "SELECT ts FROM source",
+			want: []string{
+				"DELETE FROM my.asset WHERE ts BETWEEN TIMESTAMP '{{start_timestamp}}' AND TIMESTAMP '{{end_timestamp}}'",
+				"INSERT INTO my.asset SELECT ts FROM source",
+			},
+		},
+		{
+			name: "time_interval on hive tables deletes whole partitions",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyTimeInterval,
+					IncrementalKey:  "dt",
+					PartitionBy:     "dt",
+					TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+				},
+			},
+			query:       "SELECT dt FROM source",
+			tableFormat: TableFormatHive,
+			want: []string{
+				"DELETE FROM my.asset WHERE dt BETWEEN DATE '{{start_date}}' AND DATE '{{end_date}}'",
+				"INSERT INTO my.asset SELECT dt FROM source",
+			},
+		},
+		{
+			name: "time_interval on hive tables requires the partition column",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyTimeInterval,
+					IncrementalKey:  "dt",
+					TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+				},
+			},
+			query:       "SELECT dt FROM source",
+			tableFormat: TableFormatHive,
+			wantErr:     "materialization strategy time_interval requires the `partition_by` field to match the `incremental_key` for Trino assets with the Hive table format",
+		},
+		{
+			name: "ddl on iceberg tables",
+			task: &pipeline.Asset{
+				Name:        "my.asset",
+				Description: "the asset's table",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					Strategy:    pipeline.MaterializationStrategyDDL,
+					PartitionBy: "dt",
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "bigint", Nullable: pipeline.DefaultTrueBool{Value: new(bool)}},
+					{Name: "dt", Type: "date", Description: "the day"},
+				},
+			},
+			want: []string{
+				"CREATE TABLE IF NOT EXISTS my.asset (\n" +
+					"id bigint NOT NULL,\n" +
+					"dt date COMMENT 'the day'\n" +
+					")\n" +
+					"COMMENT 'the asset''s table'\n" +
+					"WITH (partitioning = ARRAY['dt'])",
+			},
+		},
+		{
+			name: "ddl on hive tables skips not null",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					Strategy:    pipeline.MaterializationStrategyDDL,
+					PartitionBy: "dt",
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "bigint", Nullable: pipeline.DefaultTrueBool{Value: new(bool)}},
+					{Name: "dt", Type: "date"},
+				},
+			},
+			tableFormat: TableFormatHive,
+			want: []string{
+				"CREATE TABLE IF NOT EXISTS my.asset (\n" +
+					"id bigint,\n" +
+					"dt date\n" +
+					")\n" +
+					"WITH (partitioned_by = ARRAY['dt'])",
+			},
+		},
+		{
+			name: "ddl rejects default values",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable, Strategy: pipeline.MaterializationStrategyDDL},
+				Columns:         []pipeline.Column{{Name: "status", Type: "varchar", Default: "'new'"}},
+			},
+			wantErr: "Trino assets do not support default values, remove the `default` field of the column 'status'",
+		},
+		{
+			name: "ddl rejects foreign keys",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable, Strategy: pipeline.MaterializationStrategyDDL},
+				Columns: []pipeline.Column{
+					{Name: "user_id", Type: "bigint", ForeignKey: &pipeline.ColumnForeignKey{Table: "users", Column: "id"}},
+				},
+			},
+			wantErr: "Trino assets do not support foreign keys, remove the `foreign_key` field of the column 'user_id'",
+		},
+		{
+			name: "ddl rejects check constraints",
+			task: &pipeline.Asset{
+				Name:            "my.asset",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable, Strategy: pipeline.MaterializationStrategyDDL},
+				Columns:         []pipeline.Column{{Name: "amount", Type: "bigint", CheckConstraint: "amount > 0"}},
+			},
+			wantErr: "Trino assets do not support check constraints, remove the `check_constraint` field of the column 'amount'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewMaterializer(tt.fullRefresh)
+			render, err := m.Render(tt.task, tt.query, tt.tableFormat)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			for i := range render {
+				render[i] = tempTableRegex.ReplaceAllString(render[i], "__bruin_tmp")
+			}
+			assert.Equal(t, tt.want, render)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package trino
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// Trino runs a single statement per request, therefore the materializer returns a slice of statements that are
+// executed in order, similar to Athena.
+type Materializer struct {
+	MaterializationMap AssetMaterializationMap
+	fullRefresh        bool
+}
+
+func NewMaterializer(fullRefresh bool) *Materializer {
+	return &Materializer{
+		MaterializationMap: matMap,
+		fullRefresh:        fullRefresh,
+	}
+}
+
+// Render materializes the query for the given table format, the `table_format` parameter of the asset takes precedence
+// over the format of the connection.
+func (m *Materializer) Render(asset *pipeline.Asset, query, tableFormat string) ([]string, error) {
+	mat := asset.Materialization
+	if mat.Type == pipeline.MaterializationTypeNone {
+		return []string{query}, nil
+	}
+
+	if format, ok := asset.Parameters["table_format"]; ok && format != "" {
+		tableFormat = format
+	}
+	if tableFormat == "" {
+		tableFormat = TableFormatIceberg
+	}
+	if tableFormat != TableFormatIceberg && tableFormat != TableFormatHive {
+		return nil, fmt.Errorf("unsupported table format '%s', must be either '%s' or '%s'", tableFormat, TableFormatIceberg, TableFormatHive)
+	}
+
+	strategy := mat.Strategy
+	if m.fullRefresh && mat.Type == pipeline.MaterializationTypeTable {
+		if mat.Strategy != pipeline.MaterializationStrategyDDL {
+			strategy = pipeline.MaterializationStrategyCreateReplace
+		}
+	}
+
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if matFunc, ok := m.MaterializationMap[mat.Type][strategy]; ok {
+		return matFunc(asset, query, tableFormat)
+	}
+
+	return nil, fmt.Errorf("unsupported materialization type - strategy combination: (`%s` - `%s`)", mat.Type, mat.Strategy)
+}
+
+func (m *Materializer) LogIfFullRefreshAndDDL(writer interface{}, asset *pipeline.Asset) error {
+	if !m.fullRefresh {
+		return nil
+	}
+
+	if asset.Materialization.Strategy != pipeline.MaterializationStrategyDDL {
+		return nil
+	}
+	if writer == nil {
+		return errors.New("no writer found in context, please create an issue for this: https://github.com/bruin-data/bruin/issues")
+	}
+	message := "Full refresh detected, but DDL strategy is in use — table will NOT be dropped or recreated.\n"
+	writerObj, ok := writer.(io.Writer)
+	if !ok {
+		return errors.New("writer is not an io.Writer")
+	}
+	_, _ = writerObj.Write([]byte(message))
+
+	return nil
+}
+
+type Renderer struct {
+	mat         *Materializer
+	tableFormat string
+}
+
+func NewRenderer(fullRefresh bool, tableFormat string) *Renderer {
+	return &Renderer{
+		mat:         NewMaterializer(fullRefresh),
+		tableFormat: tableFormat,
+	}
+}
+
+func (r *Renderer) Render(asset *pipeline.Asset, query string) (string, error) {
+	queries, err := r.mat.Render(asset, query, r.tableFormat)
+	if err != nil {
+		return "", err
+	}
+
+	if len(queries) == 1 && asset.Materialization.Type == pipeline.MaterializationTypeNone {
+		return queries[0], nil
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
@@ -0,0 +1,126 @@
+package trino
+
+import (
+	"context"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+)
+
+type materializer interface {
+	Render(task *pipeline.Asset, query, tableFormat string) ([]string, error)
+	LogIfFullRefreshAndDDL(writer interface{}, asset *pipeline.Asset) error
+}
+
+type Client interface {
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+	Select(ctx context.Context, query *query.Query) ([][]interface{}, error)
+	SelectWithSchema(ctx context.Context, queryObject *query.Query) (*query.QueryResult, error)
+	GetTableFormat() string
+	Ping(ctx context.Context) error
+}
+
+type connectionFetcher interface {
+	GetTrinoConnectionWithoutDefault(name string) (Client, error)
+	GetConnection(name string) (interface{}, error)
+}
+
+type BasicOperator struct {
+	connection   connectionFetcher
+	extractor    query.QueryExtractor
+	materializer materializer
+}
+
+func NewBasicOperator(conn connectionFetcher, extractor query.QueryExtractor, materializer materializer) *BasicOperator {
+	return &BasicOperator{
+		connection:   conn,
+		extractor:    extractor,
+		materializer: materializer,
+	}
+}
+
+func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	return o.RunTask(ctx, ti.GetPipeline(), ti.GetAsset())
+}
+
+func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
+	extractor := o.extractor.CloneForAsset(ctx, p, t)
+	queries, err := extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
+	if err != nil {
+		return errors.Wrap(err, "cannot extract queries from the task file")
+	}
+
+	if len(queries) == 0 {
+		return nil
+	}
+
+	if len(queries) > 1 && t.Materialization.Type != pipeline.MaterializationTypeNone {
+		return errors.New("cannot enable materialization for tasks with multiple queries")
+	}
+
+	writer := ctx.Value(executor.KeyPrinter)
+	err = o.materializer.LogIfFullRefreshAndDDL(writer, t)
+	if err != nil {
+		return err
+	}
+
+	connName, err := p.GetConnectionNameForAsset(t)
+	if err != nil {
+		return err
+	}
+
+	conn, err := o.connection.GetTrinoConnectionWithoutDefault(connName)
+	if err != nil {
+		return err
+	}
+
+	q := queries[0]
+	materializedQueries, err := o.materializer.Render(t, q.String(), conn.GetTableFormat())
+	if err != nil {
+		return err
+	}
+
+	if t.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval || t.Materialization.Strategy == pipeline.MaterializationStrategyMicrobatch {
+		materializedQueries, err = extractor.ReextractQueriesFromSlice(materializedQueries)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, queryString := range materializedQueries {
+		err = conn.RunQueryWithoutResult(ctx, &query.Query{Query: queryString})
+		if err != nil {
+			dropTempTables(ctx, conn, materializedQueries[i+1:])
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dropTempTables runs the cleanup queries among the ones that were not run due to a failure, so that the temporary
+// tables are not left behind. The tables are dropped even if the run is cancelled, their errors are ignored since the
+// original failure is the one to report.
+func dropTempTables(ctx context.Context, conn Client, remainingQueries []string) {
+	for _, queryString := range remainingQueries {
+		if isTempTableCleanup(queryString) {
+			_ = conn.RunQueryWithoutResult(context.WithoutCancel(ctx), &query.Query{Query: queryString})
+		}
+	}
+}
+
+func NewColumnCheckOperator(manager connectionFetcher) *ansisql.ColumnCheckOperator {
+	return ansisql.NewColumnCheckOperator(map[string]ansisql.CheckRunner{
+		"not_null":        ansisql.NewNotNullCheck(manager),
+		"unique":          ansisql.NewUniqueCheck(manager),
+		"positive":        ansisql.NewPositiveCheck(manager),
+		"non_negative":    ansisql.NewNonNegativeCheck(manager),
+		"negative":        ansisql.NewNegativeCheck(manager),
+		"accepted_values": &AcceptedValuesCheck{conn: manager},
+		"pattern":         &PatternCheck{conn: manager},
+	})
+}
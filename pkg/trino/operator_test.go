@@ -0,0 +1,140 @@
+package trino
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockExtractor struct {
+	mock.Mock
+}
+
+func (m *mockExtractor) ExtractQueriesFromString(content string) ([]*query.Query, error) {
+	res := m.Called(content)
+	return res.Get(0).([]*query.Query), res.Error(1)
+}
+
+func (m *mockExtractor) CloneForAsset(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) query.QueryExtractor {
+	return m
+}
+
+func (m *mockExtractor) ReextractQueriesFromSlice(content []string) ([]string, error) {
+	res := m.Called(content)
+	return res.Get(0).([]string), res.Error(1)
+}
+
+type mockClient struct {
+	mock.Mock
+}
+
+func (m *mockClient) RunQueryWithoutResult(ctx context.Context, q *query.Query) error {
+	return m.Called(q.Query).Error(0)
+}
+
+func (m *mockClient) Select(ctx context.Context, q *query.Query) ([][]interface{}, error) {
+	res := m.Called(q.Query)
+	return res.Get(0).([][]interface{}), res.Error(1)
+}
+
+func (m *mockClient) SelectWithSchema(ctx context.Context, q *query.Query) (*query.QueryResult, error) {
+	res := m.Called(q.Query)
+	return res.Get(0).(*query.QueryResult), res.Error(1)
+}
+
+func (m *mockClient) GetTableFormat() string {
+	return m.Called().String(0)
+}
+
+func (m *mockClient) Ping(ctx context.Context) error {
+	return m.Called().Error(0)
+}
+
+type mockConnectionFetcher struct {
+	client *mockClient
+}
+
+func (m *mockConnectionFetcher) GetTrinoConnectionWithoutDefault(name string) (Client, error) {
+	return m.client, nil
+}
+
+func (m *mockConnectionFetcher) GetConnection(name string) (interface{}, error) {
+	return m.client, nil
+}
+
+func TestBasicOperator_RunTask(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "my.asset",
+		Type: pipeline.AssetTypeTrinoQuery,
+		Materialization: pipeline.Materialization{
+			Type:        pipeline.MaterializationTypeTable,
+			PartitionBy: "dt",
+		},
+		ExecutableFile: pipeline.ExecutableFile{Content: "SELECT 1 AS id, DATE '2024-01-01' AS dt"},
+	}
+
+	extractor := new(mockExtractor)
+	extractor.On("ExtractQueriesFromString", asset.ExecutableFile.Content).
+		Return([]*query.Query{{Query: asset.ExecutableFile.Content}}, nil)
+
+	client := new(mockClient)
+	client.On("GetTableFormat").Return(TableFormatHive)
+	client.On("RunQueryWithoutResult", "DROP TABLE IF EXISTS my.asset").Return(nil).Once()
+	client.On("RunQueryWithoutResult", "CREATE TABLE my.asset\nWITH (partitioned_by = ARRAY['dt']) AS\nSELECT 1 AS id, DATE '2024-01-01' AS dt").Return(nil).Once()
+
+	op := NewBasicOperator(&mockConnectionFetcher{client: client}, extractor, NewMaterializer(false))
+	err := op.RunTask(context.Background(), &pipeline.Pipeline{}, asset)
+	require.NoError(t, err)
+
+	client.AssertExpectations(t)
+	assert.Len(t, client.Calls, 3)
+}
+
+func TestBasicOperator_RunTask_DropsTempTableOnFailure(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "my.asset",
+		Type: pipeline.AssetTypeTrinoQuery,
+		Materialization: pipeline.Materialization{
+			Type:           pipeline.MaterializationTypeTable,
+			Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+			IncrementalKey: "dt",
+		},
+		ExecutableFile: pipeline.ExecutableFile{Content: "SELECT 1 AS id, DATE '2024-01-01' AS dt"},
+	}
+
+	extractor := new(mockExtractor)
+	extractor.On("ExtractQueriesFromString", asset.ExecutableFile.Content).
+		Return([]*query.Query{{Query: asset.ExecutableFile.Content}}, nil)
+
+	client := new(mockClient)
+	client.On("GetTableFormat").Return(TableFormatIceberg)
+	client.On("RunQueryWithoutResult", mock.MatchedBy(func(q string) bool {
+		return strings.HasPrefix(q, "CREATE TABLE "+tempTablePrefix)
+	})).Return(nil).Once()
+	client.On("RunQueryWithoutResult", mock.MatchedBy(func(q string) bool {
+		return strings.HasPrefix(q, "DELETE FROM my.asset")
+	})).Return(errors.New("delete failed")).Once()
+	client.On("RunQueryWithoutResult", mock.MatchedBy(func(q string) bool {
+		return strings.HasPrefix(q, "DROP TABLE IF EXISTS "+tempTablePrefix)
+	})).Return(nil).Once()
+
+	op := NewBasicOperator(&mockConnectionFetcher{client: client}, extractor, NewMaterializer(false))
+	err := op.RunTask(context.Background(), &pipeline.Pipeline{}, asset)
+	require.EqualError(t, err, "delete failed")
+
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "RunQueryWithoutResult", mock.MatchedBy(func(q string) bool {
+		return strings.HasPrefix(q, "INSERT INTO")
+	}))
+}
@@ -54,6 +54,18 @@ def main():
                 logging.info("got add-limit command")
                 c = cmd["contents"]
                 result = add_limit(c["query"], c["limit"], c["dialect"])
+            elif cmd["command"] == "format-query":
+                from parser.format import format_query
+
+                logging.info("got format-query command")
+                c = cmd["contents"]
+                result = format_query(c["query"], c["dialect"], c.get("options"))
+            elif cmd["command"] == "analyze-query":
+                from parser.analyze import analyze_query
+
+                logging.info("got analyze-query command")
+                c = cmd["contents"]
+                result = analyze_query(c["query"], c["dialect"])
             elif cmd["command"] == "exit":
                 logging.info("got exit command amx")
                 break
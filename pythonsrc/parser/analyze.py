@@ -0,0 +1,150 @@
+from sqlglot import exp, parse
+
+from .format import ANSI_ESCAPE_PATTERN
+from .main import extract_tables, get_table_name
+
+NONDETERMINISTIC_EXPRESSIONS = {
+    exp.CurrentDate: "current_date",
+    exp.CurrentDatetime: "current_datetime",
+    exp.CurrentTime: "current_time",
+    exp.CurrentTimestamp: "current_timestamp",
+    exp.Rand: "rand",
+    exp.Uuid: "uuid",
+}
+
+# the functions that sqlglot does not have a dedicated expression for, they are parsed as anonymous functions
+NONDETERMINISTIC_FUNCTIONS = {
+    "gen_random_uuid",
+    "generate_uuid",
+    "getdate",
+    "localtimestamp",
+    "newid",
+    "now",
+    "random",
+    "sysdate",
+    "sysdatetime",
+    "systimestamp",
+    "uuid_string",
+}
+
+
+def final_selects(statement: exp.Expression) -> list[exp.Select]:
+    """
+    Returns the selects whose projections end up in the result of the statement, e.g. both sides of a union, or the
+    query of a `CREATE TABLE ... AS SELECT`.
+    """
+    if isinstance(statement, (exp.Create, exp.Insert)):
+        statement = statement.expression
+    if isinstance(statement, exp.Subquery):
+        return final_selects(statement.this)
+    if isinstance(statement, exp.SetOperation):
+        return final_selects(statement.left) + final_selects(statement.right)
+    if isinstance(statement, exp.Select):
+        return [statement]
+    return []
+
+
+def has_select_star(statement: exp.Expression) -> bool:
+    for select in final_selects(statement):
+        for projection in select.expressions:
+            if isinstance(projection, exp.Star):
+                return True
+            if isinstance(projection, exp.Column) and isinstance(
+                projection.this, exp.Star
+            ):
+                return True
+    return False
+
+
+def cartesian_joins(statement: exp.Expression) -> list[str]:
+    """
+    Returns the tables that are joined without any condition. The explicit `CROSS JOIN`s are considered intentional,
+    and the comma joins are only reported if the `WHERE` clause does not refer to the joined table.
+    """
+    tables = []
+    for select in statement.find_all(exp.Select):
+        where = select.args.get("where")
+        referenced = set()
+        if where is not None:
+            referenced = {column.table for column in where.find_all(exp.Column)}
+
+        for join in select.args.get("joins") or []:
+            if join.args.get("on") or join.args.get("using"):
+                continue
+            if (join.kind or "").upper() == "CROSS":
+                continue
+
+            table = join.this
+            if not isinstance(table, exp.Table):
+                continue
+
+            if not join.side and not join.kind and table.alias_or_name in referenced:
+                continue
+
+            tables.append(get_table_name(table))
+    return sorted(set(tables))
+
+
+def nondeterministic_functions(statement: exp.Expression) -> list[str]:
+    functions = set()
+    for func in statement.find_all(exp.Func):
+        name = NONDETERMINISTIC_EXPRESSIONS.get(type(func))
+        if name is None and isinstance(func, exp.Anonymous):
+            anonymous_name = func.name.lower()
+            if anonymous_name in NONDETERMINISTIC_FUNCTIONS:
+                name = anonymous_name
+        if name is not None:
+            functions.add(name)
+    return sorted(functions)
+
+
+def has_order_by_without_limit(statement: exp.Expression) -> bool:
+    if isinstance(statement, (exp.Create, exp.Insert)):
+        statement = statement.expression
+    if isinstance(statement, exp.Subquery):
+        statement = statement.this
+    if not isinstance(statement, exp.Query):
+        return False
+
+    return (
+        statement.args.get("order") is not None
+        and statement.args.get("limit") is None
+        and statement.args.get("fetch") is None
+    )
+
+
+def analyze_query(query: str, dialect: str) -> dict:
+    """
+    Finds the common anti-patterns in the given query, the caller decides which of them are actual issues based on
+    the asset the query belongs to.
+    """
+    try:
+        statements = [s for s in parse(query, dialect=dialect) if s is not None]
+    except Exception as e:
+        return {"error": ANSI_ESCAPE_PATTERN.sub("", str(e))}
+
+    result = {
+        "select_star": False,
+        "cartesian_joins": [],
+        "nondeterministic_functions": [],
+        "order_by_without_limit": False,
+        "tables": [],
+    }
+
+    cartesian = set()
+    functions = set()
+    tables = set()
+    for statement in statements:
+        result["select_star"] = result["select_star"] or has_select_star(statement)
+        result["order_by_without_limit"] = result[
+            "order_by_without_limit"
+        ] or has_order_by_without_limit(statement)
+        cartesian.update(cartesian_joins(statement))
+        functions.update(nondeterministic_functions(statement))
+        tables.update(get_table_name(table) for table in extract_tables(statement))
+
+    result["cartesian_joins"] = sorted(cartesian)
+    result["nondeterministic_functions"] = sorted(functions)
+    result["tables"] = sorted(tables)
+
+    return result
@@ -0,0 +1,71 @@
+import pytest
+
+from .analyze import analyze_query
+
+test_cases_analyze = [
+    {
+        "name": "select star in the final projection",
+        "query": "select * from raw.items",
+        "expected": {"select_star": True, "tables": ["raw.items"]},
+    },
+    {
+        "name": "qualified select star in a union",
+        "query": "select id from raw.items union all select i.* from raw.other_items i",
+        "expected": {"select_star": True, "tables": ["raw.items", "raw.other_items"]},
+    },
+    {
+        "name": "select star in subqueries and count star are fine",
+        "query": "with c as (select * from raw.items) select count(*) from (select * from c) s",
+        "expected": {"tables": ["raw.items"]},
+    },
+    {
+        "name": "comma join without a condition",
+        "query": "select a.id from raw.a a, raw.b b",
+        "expected": {"cartesian_joins": ["raw.b"], "tables": ["raw.a", "raw.b"]},
+    },
+    {
+        "name": "comma join with a condition in where and an explicit cross join",
+        "query": "select a.id from raw.a a, raw.b b cross join raw.c where a.id = b.id",
+        "expected": {"tables": ["raw.a", "raw.b", "raw.c"]},
+    },
+    {
+        "name": "non-deterministic functions",
+        "query": "select id, current_timestamp() as loaded_at, rand() as r, now() as n from raw.a",
+        "expected": {
+            "nondeterministic_functions": ["current_timestamp", "now", "rand"],
+            "tables": ["raw.a"],
+        },
+    },
+    {
+        "name": "order by without limit",
+        "query": "select id from raw.a order by id",
+        "expected": {"order_by_without_limit": True, "tables": ["raw.a"]},
+    },
+    {
+        "name": "order by with limit",
+        "query": "select id from raw.a order by id limit 10",
+        "expected": {"tables": ["raw.a"]},
+    },
+]
+
+
+@pytest.mark.parametrize(
+    "query,expected",
+    [(tc["query"], tc["expected"]) for tc in test_cases_analyze],
+    ids=[tc["name"] for tc in test_cases_analyze],
+)
+def test_analyze_query(query, expected):
+    result = analyze_query(query, "bigquery")
+    assert result == {
+        "select_star": False,
+        "cartesian_joins": [],
+        "nondeterministic_functions": [],
+        "order_by_without_limit": False,
+        "tables": [],
+        **expected,
+    }
+
+
+def test_analyze_query_invalid():
+    result = analyze_query("select from where", "bigquery")
+    assert result["error"]
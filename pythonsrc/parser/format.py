@@ -0,0 +1,183 @@
+import re
+
+from sqlglot import parse
+from sqlglot.dialects.dialect import Dialect
+from sqlglot.tokens import TokenType
+
+JINJA_EXPRESSION_PATTERN = re.compile(r"{{.*?}}", re.DOTALL)
+# the statement and comment blocks, the tempered dot makes sure a single block never spans over the next one
+JINJA_BLOCK = r"(?:{%(?:(?!%}).)*%}|{#(?:(?!#}).)*#})"
+LEADING_JINJA_BLOCKS_PATTERN = re.compile(
+    r"\A(?:\s*" + JINJA_BLOCK + r")+[ \t]*(?:\n|\Z)", re.DOTALL
+)
+TRAILING_JINJA_BLOCKS_PATTERN = re.compile(
+    r"(?:\A|\n)(?:[ \t]*" + JINJA_BLOCK + r"\s*)+\Z", re.DOTALL
+)
+PLACEHOLDER_PATTERN = re.compile(r"__bruin_jinja_\d+__")
+LINE_COMMENT_PATTERN = re.compile(r"--([^\n]*)")
+ANSI_ESCAPE_PATTERN = re.compile(r"\x1b\[[0-9;]*m")
+
+# the token types that hold user defined text, their casing must never be changed
+TEXT_TOKEN_TYPES = {
+    TokenType.STRING,
+    TokenType.IDENTIFIER,
+    TokenType.VAR,
+    TokenType.NUMBER,
+    TokenType.NATIONAL_STRING,
+    TokenType.RAW_STRING,
+    TokenType.HEX_STRING,
+    TokenType.BIT_STRING,
+    TokenType.BYTE_STRING,
+    TokenType.HEREDOC_STRING,
+    TokenType.UNICODE_STRING,
+}
+
+
+def split_jinja_blocks(query: str) -> tuple[str, str, str]:
+    """
+    Splits the Jinja statements and comments at the beginning and at the end of the query, e.g. `{% set ... %}`, so
+    that they are kept as they are while the query between them is formatted.
+    """
+    header = ""
+    match = LEADING_JINJA_BLOCKS_PATTERN.match(query)
+    if match:
+        header = match.group(0)
+        query = query[match.end() :]
+
+    footer = ""
+    match = TRAILING_JINJA_BLOCKS_PATTERN.search(query)
+    if match:
+        footer = match.group(0)
+        query = query[: match.start()]
+
+    return header, query, footer
+
+
+def replace_jinja_expressions(query: str) -> tuple[str, dict[str, str]]:
+    """
+    Replaces the Jinja expressions with identifiers so that sqlglot can parse them wherever a value or a table name is
+    expected.
+    """
+    expressions = {}
+
+    def replace(match: re.Match) -> str:
+        placeholder = f"__bruin_jinja_{len(expressions)}__"
+        expressions[placeholder] = match.group(0)
+        return placeholder
+
+    return JINJA_EXPRESSION_PATTERN.sub(replace, query), expressions
+
+
+def placeholder_neighbours(query: str, dialect: str) -> list[tuple[str, str]]:
+    """
+    Returns the placeholders along with the token that precedes them, which is used to make sure the formatting did
+    not change how the Jinja expressions are used, e.g. `from table {{ filter }}` must not become an alias.
+    """
+    tokens = Dialect.get_or_raise(dialect).tokenize(query)
+    neighbours = []
+    for i, token in enumerate(tokens):
+        for placeholder in PLACEHOLDER_PATTERN.findall(token.text):
+            previous = tokens[i - 1].text.upper() if i > 0 else ""
+            neighbours.append((placeholder, previous))
+    return neighbours
+
+
+def restore_line_comments(original: str, formatted: str) -> str:
+    """
+    sqlglot turns every comment into a block comment, the ones that were line comments are turned back into line
+    comments if they are still at the end of a line.
+    """
+    for match in LINE_COMMENT_PATTERN.finditer(original):
+        text = match.group(1).strip()
+        if not text:
+            continue
+        formatted = re.sub(
+            r"/\* " + re.escape(text) + r" \*/[ \t]*$",
+            lambda _: "-- " + text,
+            formatted,
+            count=1,
+            flags=re.MULTILINE,
+        )
+    return formatted
+
+
+def lowercase_keywords(query: str, dialect: str) -> str:
+    result = []
+    last = 0
+    for token in Dialect.get_or_raise(dialect).tokenize(query):
+        if token.token_type in TEXT_TOKEN_TYPES or not token.text.isupper():
+            continue
+        start = token.end - len(token.text) + 1
+        if query[start : token.end + 1] != token.text:
+            continue
+        result.append(query[last:start])
+        result.append(token.text.lower())
+        last = token.end + 1
+    result.append(query[last:])
+    return "".join(result)
+
+
+def format_query(query: str, dialect: str, options: dict = None) -> dict:
+    options = options or {}
+    indent = options.get("indent") or 2
+    line_width = options.get("line_width") or 80
+    keyword_case = (options.get("keyword_case") or "upper").lower()
+    if keyword_case not in ("upper", "lower"):
+        return {
+            "error": f"invalid keyword case '{keyword_case}', it must be either 'upper' or 'lower'"
+        }
+
+    header, body, footer = split_jinja_blocks(query)
+    if not body.strip():
+        return {"query": query, "error": None}
+
+    if "{%" in body or "{#" in body:
+        return {
+            "error": "Jinja statements are only supported at the beginning or at the end of the query"
+        }
+
+    replaced, expressions = replace_jinja_expressions(body)
+    try:
+        statements = [s for s in parse(replaced, dialect=dialect) if s is not None]
+    except Exception as e:
+        return {
+            "error": f"failed to parse the query: {ANSI_ESCAPE_PATTERN.sub('', str(e))}"
+        }
+
+    if not statements:
+        return {"query": query, "error": None}
+
+    formatted = ";\n\n".join(
+        statement.sql(
+            dialect=dialect,
+            pretty=True,
+            pad=indent,
+            indent=indent,
+            max_text_width=line_width,
+            normalize_functions=keyword_case,
+            comments=True,
+        )
+        for statement in statements
+    )
+    if body.rstrip().endswith(";"):
+        formatted += ";"
+
+    if keyword_case == "lower":
+        formatted = lowercase_keywords(formatted, dialect)
+
+    if placeholder_neighbours(formatted, dialect) != placeholder_neighbours(
+        replaced, dialect
+    ):
+        return {
+            "error": "the query cannot be formatted without changing how its Jinja expressions are used"
+        }
+
+    formatted = restore_line_comments(body, formatted)
+    formatted = PLACEHOLDER_PATTERN.sub(lambda m: expressions[m.group(0)], formatted)
+
+    if footer:
+        formatted += "\n" + footer.lstrip("\n")
+    else:
+        formatted += "\n"
+
+    return {"query": header + formatted, "error": None}
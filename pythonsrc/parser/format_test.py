@@ -0,0 +1,132 @@
+import pytest
+
+from .format import format_query
+
+test_cases_format = [
+    {
+        "name": "simple select",
+        "query": "select id, name from raw.items where id > 1",
+        "options": {},
+        "expected": """SELECT
+  id,
+  name
+FROM raw.items
+WHERE
+  id > 1
+""",
+    },
+    {
+        "name": "lowercase keywords with a custom indentation",
+        "query": "SELECT id, COUNT(*) AS cnt FROM raw.items GROUP BY id",
+        "options": {"keyword_case": "lower", "indent": 4},
+        "expected": """select
+    id,
+    count(*) as cnt
+from raw.items
+group by
+    id
+""",
+    },
+    {
+        "name": "multiple statements keep the trailing semicolon",
+        "query": "delete from raw.items where id = 1; select 1;",
+        "options": {},
+        "expected": """DELETE FROM raw.items
+WHERE
+  id = 1;
+
+SELECT
+  1;
+""",
+    },
+    {
+        "name": "jinja expressions are preserved",
+        "query": "select id from {{ this }} where dt between '{{ start_date }}' and '{{ end_date }}'",
+        "options": {},
+        "expected": """SELECT
+  id
+FROM {{ this }}
+WHERE
+  dt BETWEEN '{{ start_date }}' AND '{{ end_date }}'
+""",
+    },
+    {
+        "name": "leading and trailing jinja blocks are kept as they are",
+        "query": """{% set columns = ['id', 'name'] %}
+{# the main query #}
+select id from items
+{# end of the query #}
+""",
+        "options": {},
+        "expected": """{% set columns = ['id', 'name'] %}
+{# the main query #}
+SELECT
+  id
+FROM items
+{# end of the query #}
+""",
+    },
+    {
+        "name": "line comments stay as line comments",
+        "query": """select
+    id, -- the identifier
+    name
+from items""",
+        "options": {},
+        "expected": """SELECT
+  id, -- the identifier
+  name
+FROM items
+""",
+    },
+]
+
+
+@pytest.mark.parametrize(
+    "query,options,expected",
+    [(tc["query"], tc["options"], tc["expected"]) for tc in test_cases_format],
+    ids=[tc["name"] for tc in test_cases_format],
+)
+def test_format_query(query, options, expected):
+    result = format_query(query, "bigquery", options)
+    assert result["error"] is None
+    assert result["query"] == expected
+
+    # formatting an already formatted query must not change it
+    again = format_query(result["query"], "bigquery", options)
+    assert again["query"] == expected
+
+
+test_cases_format_errors = [
+    {
+        "name": "jinja statements in the middle of the query",
+        "query": "select id from items {% if is_dev %} limit 10 {% endif %}",
+        "options": {},
+    },
+    {
+        "name": "jinja expression that would turn into an alias",
+        "query": "select id from items {{ filter }}",
+        "options": {},
+    },
+    {
+        "name": "invalid query",
+        "query": "select from where",
+        "options": {},
+    },
+    {
+        "name": "invalid keyword case",
+        "query": "select 1",
+        "options": {"keyword_case": "title"},
+    },
+]
+
+
+@pytest.mark.parametrize(
+    "query,options",
+    [(tc["query"], tc["options"]) for tc in test_cases_format_errors],
+    ids=[tc["name"] for tc in test_cases_format_errors],
+)
+def test_format_query_errors(query, options):
+    result = format_query(query, "bigquery", options)
+    assert result["error"]
+    assert "query" not in result